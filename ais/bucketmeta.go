@@ -83,10 +83,11 @@ type (
 		setProps      *cmn.BucketProps         // new props to set
 		cloudProps    http.Header
 
-		wait         bool
-		needReMirror bool
-		needReEC     bool
-		terminate    bool
+		wait           bool
+		needReMirror   bool
+		needReEC       bool
+		needReLayoutEC bool
+		terminate      bool
 	}
 )
 
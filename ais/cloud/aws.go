@@ -12,6 +12,7 @@ import (
 	"io"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/NVIDIA/aistore/3rdparty/glog"
 	"github.com/NVIDIA/aistore/cluster"
@@ -47,37 +48,75 @@ var _ cluster.CloudProvider = (*awsProvider)(nil)
 
 func NewAWS(t cluster.Target) (cluster.CloudProvider, error) { return &awsProvider{t: t}, nil }
 
-// A session is created using default credentials from
-// configuration file in ~/.aws/credentials and environment variables
-func createSession() *session.Session {
+// A session is created using default credentials from configuration file in
+// ~/.aws/credentials and environment variables - or, if specified, the named
+// profile of the S3 endpoint (see cmn.CloudConfAWS)
+func createSession(ep *cmn.AWSEndpointConf) *session.Session {
 	// TODO: avoid creating sessions for each request
-	return session.Must(session.NewSessionWithOptions(session.Options{
-		SharedConfigState: session.SharedConfigEnable,
-		Config:            aws.Config{HTTPClient: cmn.NewClient(cmn.TransportArgs{})},
-	}))
+	var (
+		opts = session.Options{SharedConfigState: session.SharedConfigEnable, Profile: ep.Profile}
+		args = cmn.TransportArgs{}
+	)
+	if ep.Endpoint != "" {
+		args.UseHTTPS = strings.HasPrefix(ep.Endpoint, "https://")
+		args.SkipVerify = ep.SkipVerify
+		opts.Config.Endpoint = aws.String(ep.Endpoint)
+		opts.Config.S3ForcePathStyle = aws.Bool(ep.ForcePathStyle)
+	}
+	opts.Config.HTTPClient = cmn.NewClient(args)
+	return session.Must(session.NewSessionWithOptions(opts))
+}
+
+// S3 endpoint of the bucket: bucket's own `extra.aws` props take precedence
+// over the (namespace's or default) endpoint from the cluster config.
+func endpointConf(bck *cmn.Bck) (ep cmn.AWSEndpointConf) {
+	var ns cmn.Ns
+	if bck != nil {
+		ns = bck.Ns
+	}
+	if v, ok := cmn.GCO.Get().Cloud.ProviderConf(cmn.ProviderAmazon); ok {
+		if awsConf, ok := v.(cmn.CloudConfAWS); ok {
+			ep = awsConf.Endpoint(ns)
+		}
+	}
+	if bck == nil || bck.Props == nil {
+		return
+	}
+	if bckEp := bck.Props.Extra.AWS; bckEp.Endpoint != "" {
+		ep.ExtraPropsAWS = bckEp
+	} else if bckEp.Profile != "" {
+		ep.Profile = bckEp.Profile
+	}
+	return
 }
 
 // newS3Client creates new S3 client that can be used to make requests. It is
 // guaranteed that the client is initialized even in case of errors.
 func (awsp *awsProvider) newS3Client(conf sessConf, tag string) (svc *s3.S3, regIsSet bool, err error) {
 	var (
-		sess    = createSession()
+		ep      = endpointConf(conf.bck)
+		sess    = createSession(&ep)
 		awsConf = &aws.Config{}
 	)
 
-	if conf.region != "" {
+	switch {
+	case conf.region != "":
 		awsConf.Region = aws.String(conf.region)
 		regIsSet = true
-	} else if conf.bck != nil {
-		if conf.bck.Props == nil || conf.bck.Props.Extra.CloudRegion == "" {
-			if tag != "" {
-				err = fmt.Errorf("%s: unknown region for bucket %s -- proceeding with default", tag, conf.bck)
-			}
-			svc = s3.New(sess)
-			return
-		}
-		regIsSet = true
+	case conf.bck != nil && conf.bck.Props != nil && conf.bck.Props.Extra.CloudRegion != "":
 		awsConf.Region = aws.String(conf.bck.Props.Extra.CloudRegion)
+		regIsSet = true
+	case ep.Region != "":
+		awsConf.Region = aws.String(ep.Region)
+		regIsSet = true
+	default:
+		if conf.bck != nil && tag != "" {
+			err = fmt.Errorf("%s: unknown region for bucket %s -- proceeding with default", tag, conf.bck)
+		}
+		// S3-compatible storage (e.g., MinIO) is not required to be region-aware
+		if ep.Endpoint != "" && aws.StringValue(sess.Config.Region) == "" {
+			awsConf.Region = aws.String(endpoints.UsEast1RegionID)
+		}
 	}
 	svc = s3.New(sess, awsConf)
 	return
@@ -254,7 +293,7 @@ func (awsp *awsProvider) HeadBucket(ctx context.Context, bck *cluster.Bck) (bckP
 		}
 
 		// Create new svc with the region details.
-		svc, _, _ = awsp.newS3Client(sessConf{region: region}, "")
+		svc, _, _ = awsp.newS3Client(sessConf{bck: cloudBck, region: region}, "")
 	}
 
 	region = *svc.Config.Region
@@ -281,7 +320,7 @@ func (awsp *awsProvider) HeadBucket(ctx context.Context, bck *cluster.Bck) (bckP
 //////////////////
 
 func (awsp *awsProvider) ListBuckets(ctx context.Context, query cmn.QueryBcks) (buckets cmn.BucketNames, errCode int, err error) {
-	svc, _, _ := awsp.newS3Client(sessConf{}, "")
+	svc, _, _ := awsp.newS3Client(sessConf{bck: &cmn.Bck{Provider: cmn.ProviderAmazon, Ns: query.Ns}}, "")
 	result, err := svc.ListBuckets(&s3.ListBucketsInput{})
 	if err != nil {
 		errCode, err = awsp.awsErrorToAISError(err, &cmn.Bck{Provider: cmn.ProviderAmazon})
@@ -296,6 +335,7 @@ func (awsp *awsProvider) ListBuckets(ctx context.Context, query cmn.QueryBcks) (
 		buckets[idx] = cmn.Bck{
 			Name:     aws.StringValue(bck.Name),
 			Provider: cmn.ProviderAmazon,
+			Ns:       query.Ns,
 		}
 	}
 	return
@@ -0,0 +1,424 @@
+// Package cloud contains implementation of various cloud providers.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package cloud
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/fs"
+)
+
+// Providers that store objects as files in a directory tree (e.g., HDFS, local
+// filesystem): a bucket is a directory under the configured root, and an
+// object is a file in that directory or any of its subdirectories. The
+// providers differ only in the filesystem calls (see `dirFS`).
+//
+// Files are listed in the lexicographical order of their names (relative to
+// the bucket's directory) - the order that is required to page through the
+// results with the last listed name as continuation token.
+
+const (
+	dirPerm = 0o755
+
+	// PUT writes to a hidden temporary file that then gets renamed - listings skip those
+	tmpFileSuffix = ".ais-tmp"
+)
+
+type (
+	// returns directory entries in any order
+	readDirFunc func(dir string) ([]os.FileInfo, error)
+
+	// filesystem of the provider
+	dirFS interface {
+		ReadDir(dir string) ([]os.FileInfo, error)
+		Stat(fpath string) (os.FileInfo, error)
+		Open(fpath string) (io.ReadCloser, os.FileInfo, error)
+		Create(fpath string) (io.WriteCloser, error) // fails if exists
+		MkdirAll(dir string, perm os.FileMode) error
+		Rename(oldpath, newpath string) error
+		Remove(fpath string) error
+	}
+
+	dirProvider struct {
+		t        cluster.Target
+		fs       dirFS
+		root     string
+		provider string // cmn.ProviderFS, ...
+		source   string // cluster.SourceFSObjMD, ...
+		maxPage  uint
+	}
+
+	dirLister struct {
+		readDir readDirFunc
+		root    string
+		msg     *cmn.SelectMsg
+		list    *cmn.BucketList
+	}
+)
+
+func listDir(readDir readDirFunc, root string, msg *cmn.SelectMsg) (*cmn.BucketList, error) {
+	dl := &dirLister{
+		readDir: readDir,
+		root:    root,
+		msg:     msg,
+		list:    &cmn.BucketList{Entries: make([]*cmn.BucketEntry, 0, 64)},
+	}
+	if _, err := dl.walk(""); err != nil {
+		return nil, err
+	}
+	return dl.list, nil
+}
+
+// Sorting directory `a` as "a/" places all its files right where their full
+// names belong, e.g.: "a-b" < "a/c" < "ab".
+func dirEntryKey(finfo os.FileInfo) string {
+	if finfo.IsDir() {
+		return finfo.Name() + "/"
+	}
+	return finfo.Name()
+}
+
+func (dl *dirLister) walk(dir string) (stop bool, err error) {
+	finfos, err := dl.readDir(path.Join(dl.root, dir))
+	if err != nil {
+		return
+	}
+	sort.Slice(finfos, func(i, j int) bool { return dirEntryKey(finfos[i]) < dirEntryKey(finfos[j]) })
+	var (
+		prefix = dl.msg.Prefix
+		token  = dl.msg.ContinuationToken
+	)
+	for _, finfo := range finfos {
+		name := path.Join(dir, finfo.Name())
+		if finfo.IsDir() {
+			dirPrefix := name + "/"
+			if !strings.HasPrefix(dirPrefix, prefix) && !strings.HasPrefix(prefix, dirPrefix) {
+				continue
+			}
+			// skip the directory if all its files precede the continuation token
+			if token != "" && dirPrefix < token && !strings.HasPrefix(token, dirPrefix) {
+				continue
+			}
+			if stop, err = dl.walk(name); stop || err != nil {
+				return
+			}
+			continue
+		}
+		if !finfo.Mode().IsRegular() || strings.HasSuffix(name, tmpFileSuffix) {
+			continue
+		}
+		if !strings.HasPrefix(name, prefix) || (token != "" && name <= token) {
+			continue
+		}
+		if uint(len(dl.list.Entries)) >= dl.msg.PageSize {
+			dl.list.ContinuationToken = dl.list.Entries[len(dl.list.Entries)-1].Name
+			return true, nil
+		}
+		dl.list.Entries = append(dl.list.Entries, dl.newEntry(name, finfo))
+	}
+	return
+}
+
+func (dl *dirLister) newEntry(name string, finfo os.FileInfo) *cmn.BucketEntry {
+	entry := &cmn.BucketEntry{Name: name}
+	if dl.msg.WantProp(cmn.GetPropsSize) {
+		entry.Size = finfo.Size()
+	}
+	if dl.msg.WantProp(cmn.GetPropsVersion) {
+		entry.Version = fileVersion(finfo)
+	}
+	return entry
+}
+
+// Files have no version of their own - modification time (and, when available,
+// inode number) is used instead, so that a modified or replaced file can be
+// detected (see `versioning.validate_warm_get`).
+func fileVersion(finfo os.FileInfo) string {
+	version := strconv.FormatInt(finfo.ModTime().UnixNano(), 10)
+	if stat, ok := finfo.Sys().(*syscall.Stat_t); ok {
+		version += "-" + strconv.FormatUint(stat.Ino, 10)
+	}
+	return version
+}
+
+// object name => path of the file in the bucket's directory; names that would
+// resolve outside of the directory (e.g., "../a") are rejected
+func objFilePath(bckDir, objName string) (string, error) {
+	fpath := path.Join(bckDir, objName)
+	if !strings.HasPrefix(fpath, bckDir+"/") {
+		return "", fmt.Errorf("invalid object name %q", objName)
+	}
+	return fpath, nil
+}
+
+// hidden temporary file next to the destination (see `tmpFileSuffix`)
+func tmpFilePath(fpath string) string {
+	return path.Join(path.Dir(fpath), "."+path.Base(fpath)+"."+cmn.GenTie()+tmpFileSuffix)
+}
+
+/////////////////
+// dirProvider //
+/////////////////
+
+// interface guard
+var _ cluster.CloudProvider = (*dirProvider)(nil)
+
+func (dp *dirProvider) bckDir(bck *cmn.Bck) string { return path.Join(dp.root, bck.Name) }
+
+func (dp *dirProvider) objPath(lom *cluster.LOM) (string, error) {
+	return objFilePath(dp.bckDir(lom.Bck().RemoteBck()), lom.ObjName)
+}
+
+func (dp *dirProvider) fsErrorToAISError(fsError error, bck *cmn.Bck) (int, error) {
+	switch {
+	case os.IsNotExist(fsError):
+		if bck != nil {
+			if _, err := dp.fs.Stat(dp.bckDir(bck)); os.IsNotExist(err) {
+				return http.StatusNotFound, cmn.NewErrorRemoteBucketDoesNotExist(*bck, dp.t.Snode().Name())
+			}
+		}
+		return http.StatusNotFound, cmn.NewNotFoundError("%v", fsError)
+	case os.IsPermission(fsError):
+		return http.StatusForbidden, fsError
+	default:
+		return http.StatusInternalServerError, fsError
+	}
+}
+
+func (dp *dirProvider) Provider() string { return dp.provider }
+
+func (dp *dirProvider) MaxPageSize() uint { return dp.maxPage }
+
+//////////////////
+// LIST OBJECTS //
+//////////////////
+
+func (dp *dirProvider) ListObjects(ctx context.Context, bck *cluster.Bck, msg *cmn.SelectMsg) (bckList *cmn.BucketList, errCode int, err error) {
+	msg.PageSize = calcPageSize(msg.PageSize, dp.MaxPageSize())
+	cloudBck := bck.RemoteBck()
+	if glog.FastV(4, glog.SmoduleAIS) {
+		glog.Infof("list_objects %s", cloudBck.Name)
+	}
+	if bckList, err = listDir(dp.fs.ReadDir, dp.bckDir(cloudBck), msg); err != nil {
+		errCode, err = dp.fsErrorToAISError(err, cloudBck)
+		return
+	}
+	if glog.FastV(4, glog.SmoduleAIS) {
+		glog.Infof("[list_bucket] count %d", len(bckList.Entries))
+	}
+	return
+}
+
+/////////////////
+// HEAD BUCKET //
+/////////////////
+
+func (dp *dirProvider) HeadBucket(ctx context.Context, bck *cluster.Bck) (bckProps cmn.SimpleKVs, errCode int, err error) {
+	cloudBck := bck.RemoteBck()
+	if glog.FastV(4, glog.SmoduleAIS) {
+		glog.Infof("[head_bucket] %s", cloudBck.Name)
+	}
+	finfo, err := dp.fs.Stat(dp.bckDir(cloudBck))
+	if err != nil {
+		errCode, err = dp.fsErrorToAISError(err, cloudBck)
+		return
+	}
+	if !finfo.IsDir() {
+		return nil, http.StatusNotFound, cmn.NewErrorRemoteBucketDoesNotExist(*cloudBck, dp.t.Snode().Name())
+	}
+	bckProps = make(cmn.SimpleKVs, 2)
+	bckProps[cmn.HeaderCloudProvider] = dp.provider
+	// modification time (and inode, if available) serves as version of the file
+	bckProps[cmn.HeaderBucketVerEnabled] = "true"
+	return
+}
+
+//////////////////
+// BUCKET NAMES //
+//////////////////
+
+func (dp *dirProvider) ListBuckets(ctx context.Context, query cmn.QueryBcks) (buckets cmn.BucketNames, errCode int, err error) {
+	finfos, err := dp.fs.ReadDir(dp.root)
+	if err != nil {
+		errCode, err = dp.fsErrorToAISError(err, nil)
+		return
+	}
+	buckets = make(cmn.BucketNames, 0, len(finfos))
+	for _, finfo := range finfos {
+		if !finfo.IsDir() || cmn.ValidateBckName(finfo.Name()) != nil {
+			continue
+		}
+		buckets = append(buckets, cmn.Bck{Name: finfo.Name(), Provider: dp.provider})
+	}
+	return
+}
+
+/////////////////
+// HEAD OBJECT //
+/////////////////
+
+func (dp *dirProvider) HeadObj(ctx context.Context, lom *cluster.LOM) (objMeta cmn.SimpleKVs, errCode int, err error) {
+	cloudBck := lom.Bck().RemoteBck()
+	objPath, err := dp.objPath(lom)
+	if err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+	finfo, err := dp.fs.Stat(objPath)
+	if err != nil {
+		errCode, err = dp.fsErrorToAISError(err, cloudBck)
+		return
+	}
+	if !finfo.Mode().IsRegular() {
+		return nil, http.StatusNotFound, cmn.NewNotFoundError("%s/%s is not a file", cloudBck, lom.ObjName)
+	}
+	objMeta = make(cmn.SimpleKVs, 3)
+	objMeta[cmn.HeaderCloudProvider] = dp.provider
+	objMeta[cmn.HeaderObjSize] = strconv.FormatInt(finfo.Size(), 10)
+	objMeta[cmn.HeaderObjVersion] = fileVersion(finfo)
+	if glog.FastV(4, glog.SmoduleAIS) {
+		glog.Infof("[head_object] %s/%s", cloudBck, lom.ObjName)
+	}
+	return
+}
+
+////////////////
+// GET OBJECT //
+////////////////
+
+func (dp *dirProvider) GetObjReader(ctx context.Context, lom *cluster.LOM) (r io.ReadCloser, expectedCksm *cmn.Cksum, errCode int, err error) {
+	var (
+		cloudBck = lom.Bck().RemoteBck()
+		objPath  string
+		fr       io.ReadCloser
+		finfo    os.FileInfo
+	)
+	if objPath, err = dp.objPath(lom); err != nil {
+		return nil, nil, http.StatusBadRequest, err
+	}
+	if fr, finfo, err = dp.fs.Open(objPath); err != nil {
+		errCode, err = dp.fsErrorToAISError(err, cloudBck)
+		return
+	}
+	if !finfo.Mode().IsRegular() {
+		fr.Close()
+		return nil, nil, http.StatusNotFound, cmn.NewNotFoundError("%s/%s is not a file", cloudBck, lom.ObjName)
+	}
+	version := fileVersion(finfo)
+	lom.SetVersion(version)
+	lom.SetCustomMD(cmn.SimpleKVs{
+		cluster.SourceObjMD:  dp.source,
+		cluster.VersionObjMD: version,
+	})
+	setSize(ctx, finfo.Size())
+	r = wrapReader(ctx, fr)
+	return
+}
+
+func (dp *dirProvider) GetObj(ctx context.Context, lom *cluster.LOM) (workFQN string, errCode int, err error) {
+	reader, _, errCode, err := dp.GetObjReader(ctx, lom)
+	if err != nil {
+		return "", errCode, err
+	}
+	params := cluster.PutObjectParams{
+		Tag:          fs.WorkfileColdget,
+		Reader:       reader,
+		RecvType:     cluster.ColdGet,
+		WithFinalize: false,
+	}
+	workFQN, err = dp.t.PutObject(lom, params)
+	if err != nil {
+		return
+	}
+	if glog.FastV(4, glog.SmoduleAIS) {
+		glog.Infof("[get_object] %s", lom)
+	}
+	return
+}
+
+////////////////
+// PUT OBJECT //
+////////////////
+
+func (dp *dirProvider) PutObj(ctx context.Context, r io.Reader, lom *cluster.LOM) (version string, errCode int, err error) {
+	var (
+		cloudBck = lom.Bck().RemoteBck()
+		objPath  string
+		fw       io.WriteCloser
+		written  int64
+	)
+	if objPath, err = dp.objPath(lom); err != nil {
+		return "", http.StatusBadRequest, err
+	}
+	tmpPath := tmpFilePath(objPath)
+	if _, err = dp.fs.Stat(dp.bckDir(cloudBck)); err != nil {
+		errCode, err = dp.fsErrorToAISError(err, cloudBck)
+		return
+	}
+	if err = dp.fs.MkdirAll(path.Dir(objPath), dirPerm); err != nil {
+		errCode, err = dp.fsErrorToAISError(err, cloudBck)
+		return
+	}
+	if fw, err = dp.fs.Create(tmpPath); err != nil {
+		errCode, err = dp.fsErrorToAISError(err, cloudBck)
+		return
+	}
+	buf, slab := dp.t.MMSA().Alloc()
+	written, err = io.CopyBuffer(fw, r, buf)
+	slab.Free(buf)
+	if errC := fw.Close(); err == nil {
+		err = errC
+	}
+	if err == nil {
+		err = dp.fs.Rename(tmpPath, objPath)
+	}
+	if err != nil {
+		if errRm := dp.fs.Remove(tmpPath); errRm != nil && !os.IsNotExist(errRm) {
+			glog.Errorf("nested err: %v", errRm)
+		}
+		errCode, err = dp.fsErrorToAISError(err, cloudBck)
+		return
+	}
+	finfo, err := dp.fs.Stat(objPath)
+	if err != nil {
+		errCode, err = dp.fsErrorToAISError(err, cloudBck)
+		return
+	}
+	version = fileVersion(finfo)
+	if glog.FastV(4, glog.SmoduleAIS) {
+		glog.Infof("[put_object] %s, size %d, version %s", lom, written, version)
+	}
+	return
+}
+
+///////////////////
+// DELETE OBJECT //
+///////////////////
+
+func (dp *dirProvider) DeleteObj(ctx context.Context, lom *cluster.LOM) (errCode int, err error) {
+	objPath, err := dp.objPath(lom)
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
+	if err = dp.fs.Remove(objPath); err != nil {
+		errCode, err = dp.fsErrorToAISError(err, lom.Bck().RemoteBck())
+		return
+	}
+	if glog.FastV(4, glog.SmoduleAIS) {
+		glog.Infof("[delete_object] %s", lom)
+	}
+	return
+}
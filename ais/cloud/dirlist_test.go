@@ -0,0 +1,71 @@
+// Package cloud contains implementation of various cloud providers.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package cloud
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/devtools/tutils/tassert"
+)
+
+func TestListDir(t *testing.T) {
+	root, err := ioutil.TempDir("", "dirlist")
+	tassert.CheckFatal(t, err)
+	defer os.RemoveAll(root)
+
+	// lexicographical order
+	names := []string{"a-b", "a/c", "a/d/e", "a/f", "ab", "b/c/d", "c"}
+	for _, name := range names {
+		fqn := filepath.Join(root, name)
+		tassert.CheckFatal(t, os.MkdirAll(filepath.Dir(fqn), 0o755))
+		tassert.CheckFatal(t, ioutil.WriteFile(fqn, []byte(name), 0o644))
+	}
+	tassert.CheckFatal(t, os.MkdirAll(filepath.Join(root, "empty"), 0o755))
+	// PUT in progress
+	tassert.CheckFatal(t, ioutil.WriteFile(tmpFilePath(filepath.Join(root, "a/g")), []byte("tmp"), 0o644))
+
+	tests := []struct {
+		prefix   string
+		pageSize uint
+		expected []string
+	}{
+		{prefix: "", pageSize: 100, expected: names},
+		{prefix: "", pageSize: 2, expected: names},
+		{prefix: "", pageSize: 1, expected: names},
+		{prefix: "a/", pageSize: 2, expected: []string{"a/c", "a/d/e", "a/f"}},
+		{prefix: "a", pageSize: 3, expected: []string{"a-b", "a/c", "a/d/e", "a/f", "ab"}},
+		{prefix: "b/c", pageSize: 10, expected: []string{"b/c/d"}},
+		{prefix: "x", pageSize: 10, expected: []string{}},
+	}
+	for _, test := range tests {
+		var (
+			listed = make([]string, 0, len(test.expected))
+			msg    = &cmn.SelectMsg{Prefix: test.prefix, PageSize: test.pageSize, Props: cmn.GetPropsSize}
+		)
+		for {
+			list, err := listDir(ioutil.ReadDir, root, msg)
+			tassert.CheckFatal(t, err)
+			tassert.Fatalf(t, uint(len(list.Entries)) <= test.pageSize, "page size exceeded: %d", len(list.Entries))
+			for _, entry := range list.Entries {
+				tassert.Errorf(t, entry.Size == int64(len(entry.Name)), "%s: invalid size %d", entry.Name, entry.Size)
+				listed = append(listed, entry.Name)
+			}
+			if list.ContinuationToken == "" {
+				break
+			}
+			msg.ContinuationToken = list.ContinuationToken
+		}
+		tassert.Errorf(t, len(listed) == len(test.expected), "prefix %q, page %d: expected %v, got %v",
+			test.prefix, test.pageSize, test.expected, listed)
+		for i := 0; i < len(listed) && i < len(test.expected); i++ {
+			tassert.Errorf(t, listed[i] == test.expected[i], "prefix %q, page %d: expected %v, got %v",
+				test.prefix, test.pageSize, test.expected, listed)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+// Package cloud contains implementation of various cloud providers.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package cloud
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+)
+
+// Directory tree of a (shared) filesystem - typically, NFS or Lustre mounted
+// at the same path on all targets (see cmn.CloudConfFS and `dirProvider`).
+// Requires no SDK and is, therefore, always built in.
+
+const fsFilePerm = 0o644
+
+type localFS struct{}
+
+// interface guard
+var _ dirFS = localFS{}
+
+func NewFS(t cluster.Target) (cluster.CloudProvider, error) {
+	v, ok := cmn.GCO.Get().Cloud.ProviderConf(cmn.ProviderFS)
+	if !ok {
+		return nil, fmt.Errorf("%q cloud is not configured", cmn.ProviderFS)
+	}
+	conf := v.(cmn.CloudConfFS)
+	finfo, err := os.Stat(conf.Root)
+	if err != nil {
+		return nil, fmt.Errorf("%q cloud: %v", cmn.ProviderFS, err)
+	}
+	if !finfo.IsDir() {
+		return nil, fmt.Errorf("%q cloud: root %q is not a directory", cmn.ProviderFS, conf.Root)
+	}
+	glog.Infof("[cloud_fs] root %q", conf.Root)
+	return &dirProvider{
+		t:        t,
+		fs:       localFS{},
+		root:     conf.Root,
+		provider: cmn.ProviderFS,
+		source:   cluster.SourceFSObjMD,
+		maxPage:  10000,
+	}, nil
+}
+
+func (localFS) ReadDir(dir string) ([]os.FileInfo, error)   { return ioutil.ReadDir(dir) }
+func (localFS) Stat(fpath string) (os.FileInfo, error)      { return os.Stat(fpath) }
+func (localFS) MkdirAll(dir string, perm os.FileMode) error { return os.MkdirAll(dir, perm) }
+func (localFS) Rename(oldpath, newpath string) error        { return os.Rename(oldpath, newpath) }
+func (localFS) Remove(fpath string) error                   { return os.Remove(fpath) }
+
+func (localFS) Open(fpath string) (io.ReadCloser, os.FileInfo, error) {
+	fh, err := os.Open(fpath)
+	if err != nil {
+		return nil, nil, err
+	}
+	finfo, err := fh.Stat()
+	if err != nil {
+		fh.Close()
+		return nil, nil, err
+	}
+	return fh, finfo, nil
+}
+
+func (localFS) Create(fpath string) (io.WriteCloser, error) {
+	return os.OpenFile(fpath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, fsFilePerm)
+}
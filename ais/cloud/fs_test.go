@@ -0,0 +1,55 @@
+// Package cloud contains implementation of various cloud providers.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package cloud
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/devtools/tutils/tassert"
+)
+
+func TestFSProvider(t *testing.T) {
+	root, err := ioutil.TempDir("", "cloud_fs")
+	tassert.CheckFatal(t, err)
+	defer os.RemoveAll(root)
+
+	for _, name := range []string{"bck1/a", "bck1/b/c", "bck2/d"} {
+		fqn := filepath.Join(root, name)
+		tassert.CheckFatal(t, os.MkdirAll(filepath.Dir(fqn), 0o755))
+		tassert.CheckFatal(t, ioutil.WriteFile(fqn, []byte(name), 0o644))
+	}
+	tassert.CheckFatal(t, ioutil.WriteFile(filepath.Join(root, "not-a-bucket"), nil, 0o644))
+
+	config := cmn.GCO.BeginUpdate()
+	config.Cloud.Conf = map[string]interface{}{cmn.ProviderFS: cmn.CloudConfFS{Root: root}}
+	cmn.GCO.CommitUpdate(config)
+
+	var (
+		ctx   = context.Background()
+		bck   = cluster.NewBck("bck1", cmn.ProviderFS, cmn.NsGlobal)
+		tMock = cluster.NewTargetMock(cluster.NewBaseBownerMock(bck))
+	)
+	fp, err := NewFS(tMock)
+	tassert.CheckFatal(t, err)
+
+	buckets, _, err := fp.ListBuckets(ctx, cmn.QueryBcks{Provider: cmn.ProviderFS})
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, len(buckets) == 2, "expected 2 buckets, got %v", buckets)
+
+	_, _, err = fp.HeadBucket(ctx, bck)
+	tassert.CheckFatal(t, err)
+
+	list, _, err := fp.ListObjects(ctx, bck, &cmn.SelectMsg{Props: cmn.GetPropsVersion})
+	tassert.CheckFatal(t, err)
+	tassert.Fatalf(t, len(list.Entries) == 2, "expected 2 objects, got %d", len(list.Entries))
+	tassert.Errorf(t, list.Entries[0].Name == "a" && list.Entries[1].Name == "b/c", "invalid listing: %v", list.Entries)
+	tassert.Errorf(t, list.Entries[0].Version != "", "expected version of %q", list.Entries[0].Name)
+}
@@ -0,0 +1,64 @@
+// +build hdfs
+
+// Package cloud contains implementation of various cloud providers.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package cloud
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/colinmarc/hdfs/v2"
+)
+
+// HDFS has no notion of buckets: a bucket is a directory under the configured
+// root (see cmn.CloudConfHDFS and `dirProvider`).
+
+// ReadDir, Stat, MkdirAll, Rename, and Remove come with the client
+type hdfsFS struct {
+	*hdfs.Client
+}
+
+// interface guard
+var _ dirFS = hdfsFS{}
+
+func NewHDFS(t cluster.Target) (cluster.CloudProvider, error) {
+	v, ok := cmn.GCO.Get().Cloud.ProviderConf(cmn.ProviderHDFS)
+	if !ok {
+		return nil, fmt.Errorf("%q cloud is not configured", cmn.ProviderHDFS)
+	}
+	conf := v.(cmn.CloudConfHDFS)
+	client, err := hdfs.NewClient(hdfs.ClientOptions{
+		Addresses:           conf.Addresses,
+		User:                conf.User,
+		UseDatanodeHostname: conf.UseDatanodeHostname,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to HDFS namenode(s) %v: %v", conf.Addresses, err)
+	}
+	glog.Infof("[cloud_hdfs] namenode(s) %v, root %q", conf.Addresses, conf.Root)
+	return &dirProvider{
+		t:        t,
+		fs:       hdfsFS{client},
+		root:     conf.Root,
+		provider: cmn.ProviderHDFS,
+		source:   cluster.SourceHDFSObjMD,
+		maxPage:  1000,
+	}, nil
+}
+
+func (hfs hdfsFS) Open(fpath string) (io.ReadCloser, os.FileInfo, error) {
+	fr, err := hfs.Client.Open(fpath)
+	if err != nil {
+		return nil, nil, err
+	}
+	return fr, fr.Stat(), nil
+}
+
+func (hfs hdfsFS) Create(fpath string) (io.WriteCloser, error) { return hfs.Client.Create(fpath) }
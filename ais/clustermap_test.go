@@ -0,0 +1,48 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"testing"
+
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+)
+
+func TestRelabeled(t *testing.T) {
+	newTarget := func(url string, dom cluster.Domain) *cluster.Snode {
+		return &cluster.Snode{
+			DaemonID:   "t1",
+			DaemonType: cmn.Target,
+			PublicNet:  cluster.NetInfo{DirectURL: url},
+			Domain:     dom,
+		}
+	}
+	smap := newSmap()
+	smap.addTarget(newTarget("http://host1:8081", cluster.Domain{Zone: "z1"}))
+
+	// The relabeled target is not equal (and so it re-registers updating
+	// Smap) but it is not a new target either - see `requiresRebalance`.
+	tests := []struct {
+		name      string
+		nsi       *cluster.Snode
+		equal     bool
+		relabeled bool
+	}{
+		{"same", newTarget("http://host1:8081", cluster.Domain{Zone: "z1"}), true, false},
+		{"domain", newTarget("http://host1:8081", cluster.Domain{Zone: "z2"}), false, true},
+		{"domain and url", newTarget("http://host2:8081", cluster.Domain{Zone: "z2"}), false, false},
+		{"url", newTarget("http://host2:8081", cluster.Domain{Zone: "z1"}), false, false},
+	}
+	osi := smap.GetTarget("t1")
+	for _, test := range tests {
+		if osi.Equals(test.nsi) != test.equal {
+			t.Errorf("%s: expected equal=%t", test.name, test.equal)
+		}
+		if relabeled(smap, test.nsi) != test.relabeled {
+			t.Errorf("%s: expected relabeled=%t", test.name, test.relabeled)
+		}
+	}
+}
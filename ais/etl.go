@@ -22,8 +22,11 @@ import (
 /////////////////
 
 // [METHOD] /v1/etl
+//
+// NOTE: ETL running in K8s pods requires K8s deployment, while process-based
+// ETL can run on any deployment but only when allowed (see `etl.ProcessSpec`).
 func (t *targetrunner) etlHandler(w http.ResponseWriter, r *http.Request) {
-	if err := k8s.Detect(); err != nil {
+	if err := k8s.Detect(); err != nil && !cmn.GCO.Get().ETL.AllowProcess {
 		t.invalmsghdlr(w, r, err.Error())
 		return
 	}
@@ -39,6 +42,8 @@ func (t *targetrunner) etlHandler(w http.ResponseWriter, r *http.Request) {
 			t.initETL(w, r)
 		case cmn.ETLBuild:
 			t.buildETL(w, r)
+		case cmn.ETLPipe:
+			t.pipelineETL(w, r)
 		default:
 			t.invalmsghdlrf(w, r, "invalid POST path: %s", apiItems[0])
 		}
@@ -91,6 +96,19 @@ func (t *targetrunner) buildETL(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+func (t *targetrunner) pipelineETL(w http.ResponseWriter, r *http.Request) {
+	var msg etl.PipelineMsg
+	if _, err := t.checkRESTItems(w, r, 0, false, cmn.Version, cmn.ETL, cmn.ETLPipe); err != nil {
+		return
+	}
+	if err := cmn.ReadJSON(w, r, &msg); err != nil {
+		return
+	}
+	if err := etl.StartPipeline(t, msg); err != nil {
+		t.invalmsghdlr(w, r, err.Error())
+	}
+}
+
 func (t *targetrunner) stopETL(w http.ResponseWriter, r *http.Request) {
 	apiItems, err := t.checkRESTItems(w, r, 1, false, cmn.Version, cmn.ETL, cmn.ETLStop)
 	if err != nil {
@@ -125,6 +143,11 @@ func (t *targetrunner) doETL(w http.ResponseWriter, r *http.Request, uuid string
 		return
 	}
 	if err := comm.Do(w, r, bck, objName); err != nil {
+		if _, ok := err.(*cmn.ETLError); ok {
+			// Already attributed, eg. to the stage of the pipeline.
+			t.invalmsghdlr(w, r, err.Error())
+			return
+		}
 		t.invalmsghdlr(w, r, cmn.NewETLError(&cmn.ETLErrorContext{
 			UUID:    uuid,
 			PodName: comm.PodName(),
@@ -224,6 +247,8 @@ func (p *proxyrunner) etlHandler(w http.ResponseWriter, r *http.Request) {
 			p.initETL(w, r)
 		case cmn.ETLBuild:
 			p.buildETL(w, r)
+		case cmn.ETLPipe:
+			p.pipelineETL(w, r)
 		default:
 			p.invalmsghdlrf(w, r, "invalid POST path: %s", apiItems[0])
 		}
@@ -352,6 +377,53 @@ func (p *proxyrunner) buildETL(w http.ResponseWriter, r *http.Request) {
 	p.invalmsghdlr(w, r, err.Error())
 }
 
+// POST /v1/etl/pipeline
+//
+// pipelineETL defines the pipeline of already initialized ETLs which can be
+// used in place of any ETL (see `etl.StartPipeline`).
+func (p *proxyrunner) pipelineETL(w http.ResponseWriter, r *http.Request) {
+	_, err := p.checkRESTItems(w, r, 0, false, cmn.Version, cmn.ETL, cmn.ETLPipe)
+	if err != nil {
+		return
+	}
+
+	var msg etl.PipelineMsg
+	if err := cmn.ReadJSON(w, r, &msg); err != nil {
+		return
+	}
+
+	msg.ID = cmn.GenUUID()
+	if err := msg.Validate(); err != nil {
+		p.invalmsghdlr(w, r, err.Error())
+		return
+	}
+
+	results := p.bcastToGroup(bcastArgs{
+		req:     cmn.ReqArgs{Method: http.MethodPost, Path: r.URL.Path, Body: cmn.MustMarshal(msg)},
+		timeout: cmn.DefaultTimeout,
+	})
+	for res := range results {
+		if res.err != nil {
+			err = res.err
+			glog.Error(err)
+		}
+	}
+	if err == nil {
+		w.Write([]byte(msg.ID))
+		return
+	}
+
+	// At least one target has failed to define the pipeline - remove it from all.
+	p.bcastToGroup(bcastArgs{
+		req: cmn.ReqArgs{
+			Method: http.MethodDelete,
+			Path:   cmn.JoinWords(cmn.Version, cmn.ETL, cmn.ETLStop, msg.ID),
+		},
+		timeout: cmn.DefaultTimeout,
+	})
+	p.invalmsghdlr(w, r, err.Error())
+}
+
 // GET /v1/etl/list
 func (p *proxyrunner) listETL(w http.ResponseWriter, r *http.Request) {
 	if _, err := p.checkRESTItems(w, r, 0, false, cmn.Version, cmn.ETL, cmn.ETLList); err != nil {
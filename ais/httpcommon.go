@@ -15,6 +15,7 @@ import (
 	"net"
 	"net/http"
 	"net/url"
+	"os"
 	"path/filepath"
 	"runtime"
 	rdebug "runtime/debug"
@@ -575,9 +576,29 @@ func (h *httprunner) initSI(daemonType string) {
 		intraControlAddr,
 		intraDataAddr,
 	)
+	h.si.Domain = initDomain(config)
 	cmn.InitShortID(h.si.Digest())
 }
 
+// initDomain returns the failure domain of the node: configured labels
+// can be overridden via environment (e.g., by the orchestrator).
+func initDomain(config *cmn.Config) cluster.Domain {
+	dom := cluster.Domain{Zone: config.Domain.Zone, Rack: config.Domain.Rack, Host: config.Domain.Host}
+	if zone := os.Getenv(cmn.EnvVars.Zone); zone != "" {
+		dom.Zone = zone
+	}
+	if rack := os.Getenv(cmn.EnvVars.Rack); rack != "" {
+		dom.Rack = rack
+	}
+	if host := os.Getenv(cmn.EnvVars.Host); host != "" {
+		dom.Host = host
+	}
+	if !dom.IsEmpty() {
+		glog.Infof("failure domain: %s", dom)
+	}
+	return dom
+}
+
 func mustDiffer(ip1 net.IP, port1 int, use1 bool, ip2 net.IP, port2 int, use2 bool, tag string) {
 	if !use1 || !use2 {
 		return
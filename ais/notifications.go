@@ -698,7 +698,8 @@ func isDLType(t string) bool {
 	return t == string(downloader.DlTypeMulti) ||
 		t == string(downloader.DlTypeCloud) ||
 		t == string(downloader.DlTypeSingle) ||
-		t == string(downloader.DlTypeRange)
+		t == string(downloader.DlTypeRange) ||
+		t == string(downloader.DlTypeManifest)
 }
 
 //
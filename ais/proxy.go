@@ -1693,10 +1693,15 @@ func (p *proxyrunner) listObjectsAIS(bck *cluster.Bck, smsg cmn.SelectMsg) (allE
 
 		hasEnough bool
 		entries   []*cmn.BucketEntry
-		cacheID   = cacheReqID{bck: bck.Bck, prefix: smsg.Prefix}
-		token     = smsg.ContinuationToken
-		pageSize  = smsg.PageSize
-		props     = smsg.PropsSet()
+		cacheID   = cacheReqID{
+			bck:       bck.Bck,
+			prefix:    smsg.Prefix,
+			delimiter: smsg.Delimiter,
+			versions:  smsg.IsFlagSet(cmn.SelectVersions),
+		}
+		token    = smsg.ContinuationToken
+		pageSize = smsg.PageSize
+		props    = smsg.PropsSet()
 	)
 
 	// TODO: Before checking cache and buffer we should check if there is another
@@ -1780,6 +1785,9 @@ func (p *proxyrunner) listObjectsRemote(bck *cluster.Bck, smsg cmn.SelectMsg) (a
 	if smsg.StartAfter != "" {
 		return nil, fmt.Errorf("start after for cloud buckets is not yet supported")
 	}
+	if smsg.Delimiter != "" && !smsg.IsFlagSet(cmn.SelectCached) {
+		return nil, fmt.Errorf("delimiter for cloud buckets is not yet supported")
+	}
 
 	var (
 		smap       = p.owner.smap.get()
@@ -3015,8 +3023,9 @@ func (p *proxyrunner) _updPost(ctx *smapModifier, clone *smapX) {
 	if ctx.nsi.IsTarget() {
 		// Trigger rebalance (in case target with given ID already exists
 		// we must trigger the rebalance and assume it is a new target
-		// with the same ID).
-		if ctx.exists || p.requiresRebalance(ctx.smap, clone) {
+		// with the same ID). The target that only changed its failure
+		// domain is not new - see `requiresRebalance`.
+		if (ctx.exists && !relabeled(ctx.smap, ctx.nsi)) || p.requiresRebalance(ctx.smap, clone) {
 			rmdCtx := &rmdModifier{
 				pre: func(_ *rmdModifier, clone *rebMD) {
 					clone.TargetIDs = []string{ctx.nsi.ID()}
@@ -3834,6 +3843,17 @@ func (p *proxyrunner) canStartRebalance(skipConfigCheck ...bool) error {
 	return nil
 }
 
+// relabeled returns true if the target is registered with nothing but its
+// failure domain changed.
+func relabeled(smap *smapX, nsi *cluster.Snode) bool {
+	osi := smap.GetTarget(nsi.ID())
+	return osi != nil && osi.Domain != nsi.Domain && osi.EqualsIgnoreDomain(nsi)
+}
+
+// requiresRebalance returns true if the objects must be moved between the
+// targets. Changed failure domain of a target (relabeling) requires rebalance
+// only when EC is used: the objects are placed regardless of domains while EC
+// slices are spread across them.
 func (p *proxyrunner) requiresRebalance(prev, cur *smapX) bool {
 	if err := p.canStartRebalance(); err != nil {
 		return false
@@ -3864,6 +3884,13 @@ func (p *proxyrunner) requiresRebalance(prev, cur *smapX) bool {
 				return true
 			}
 		}
+		// Failure domain of a target has changed - EC slices must be
+		// re-spread across the domains (see `cluster.HrwTargetList`).
+		for _, si := range cur.Tmap {
+			if psi := prev.GetTarget(si.ID()); psi != nil && psi.Domain != si.Domain {
+				return true
+			}
+		}
 	}
 
 	return false
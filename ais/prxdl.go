@@ -83,6 +83,9 @@ func (p *proxyrunner) broadcastDownloadAdminRequest(method, path string, msg *do
 
 	switch method {
 	case http.MethodGet:
+		if msg.ID == "" && msg.Schedules {
+			return p.aggregateDownloadSchedules(validResponses)
+		}
 		if msg.ID == "" {
 			// If ID is empty, return the list of downloads
 			aggregate := make(map[string]*downloader.DlJobInfo)
@@ -126,6 +129,28 @@ func (p *proxyrunner) broadcastDownloadAdminRequest(method, path string, msg *do
 	}
 }
 
+func (p *proxyrunner) aggregateDownloadSchedules(responses []callResult) ([]byte, int, error) {
+	aggregate := make(map[string]*downloader.DlScheduleInfo)
+	for _, resp := range responses {
+		var parsedResp map[string]*downloader.DlScheduleInfo
+		if err := jsoniter.Unmarshal(resp.bytes, &parsedResp); err != nil {
+			return nil, http.StatusInternalServerError, err
+		}
+		for k, v := range parsedResp {
+			if info, ok := aggregate[k]; ok {
+				info.Aggregate(v)
+				continue
+			}
+			aggregate[k] = v
+		}
+	}
+	schedules := make(downloader.DlScheduleInfos, 0, len(aggregate))
+	for _, v := range aggregate {
+		schedules = append(schedules, v)
+	}
+	return cmn.MustMarshal(schedules), http.StatusOK, nil
+}
+
 func (p *proxyrunner) broadcastStartDownloadRequest(r *http.Request, id string, body []byte) (errCode int, err error) {
 	query := r.URL.Query()
 	query.Set(cmn.URLParamUUID, id)
@@ -250,13 +275,31 @@ func (p *proxyrunner) httpDownloadPost(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	if dlBase.Schedule != "" {
+		if err := downloader.ValidateSchedule(dlb.Type, dlBase.Schedule); err != nil {
+			p.invalmsghdlr(w, r, err.Error())
+			return
+		}
+	}
+
 	id := cmn.GenUUID()
 	smap := p.owner.smap.get()
 
 	if errCode, err := p.broadcastStartDownloadRequest(r, id, body); err != nil {
+		if dlBase.Schedule != "" {
+			// Do not leave the schedule behind on the targets that succeeded.
+			path := cmn.JoinWords(cmn.Version, cmn.Download, cmn.Remove)
+			for range p.broadcastDownloadRequest(http.MethodDelete, path, cmn.MustMarshal(&downloader.DlAdminBody{ID: id}), url.Values{}) {
+			}
+		}
 		p.invalmsghdlrstatusf(w, r, errCode, "Error starting download: %v.", err.Error())
 		return
 	}
+	if dlBase.Schedule != "" {
+		// Scheduled runs are not tracked by IC - their status is queried from the targets.
+		p.respondWithID(w, id)
+		return
+	}
 	nl := downloader.NewDownloadNL(id, string(dlb.Type), &smap.Smap, progressInterval)
 	nl.SetOwner(equalIC)
 	p.ic.registerEqual(regIC{nl: nl, smap: smap})
@@ -86,8 +86,10 @@ type (
 	// Cache request ID. This identifies and splits requests into
 	// multiple caches that these requests can use.
 	cacheReqID struct {
-		bck    cmn.Bck
-		prefix string
+		bck       cmn.Bck
+		prefix    string
+		delimiter string
+		versions  bool // listing includes prior versions (cmn.SelectVersions)
 	}
 
 	// Single (contiguous) interval of entries.
@@ -170,6 +172,7 @@ func (b *queryBuffer) mergeTargetBuffers() (filled bool) {
 	}
 
 	cmn.SortBckEntries(entries)
+	entries = dedupDirEntries(entries)
 
 	if minObj != "" {
 		idx := sort.Search(len(entries), func(i int) bool {
@@ -184,6 +187,23 @@ func (b *queryBuffer) mergeTargetBuffers() (filled bool) {
 	return true
 }
 
+// dedupDirEntries removes duplicated directory entries (listing with
+// delimiter) - the same directory may be returned by multiple targets.
+func dedupDirEntries(entries []*cmn.BucketEntry) []*cmn.BucketEntry {
+	j := 0
+	for _, entry := range entries {
+		if j > 0 && entry.IsDir() && entries[j-1].Name == entry.Name {
+			continue
+		}
+		entries[j] = entry
+		j++
+	}
+	for i := j; i < len(entries); i++ {
+		entries[i] = nil
+	}
+	return entries[:j]
+}
+
 func (b *queryBuffer) get(token string, size uint) (entries []*cmn.BucketEntry, hasEnough bool) {
 	b.lastAccess.Store(mono.NanoTime())
 
@@ -212,6 +232,7 @@ func (b *queryBuffer) get(token string, size uint) (entries []*cmn.BucketEntry,
 		}
 		size = uint(len(entries))
 	}
+	size = versionsEnd(entries, size)
 
 	// Move buffer after returned entries.
 	b.currentBuff = entries[size:]
@@ -223,6 +244,16 @@ func (b *queryBuffer) get(token string, size uint) (entries []*cmn.BucketEntry,
 	return entries, true
 }
 
+// Extends `end` so that prior versions of the object are never separated from
+// the object itself (see cmn.SelectVersions).
+func versionsEnd(entries []*cmn.BucketEntry, end uint) uint {
+	for end > 0 && end < uint(len(entries)) && entries[end].IsPrevVersion() &&
+		entries[end].Name == entries[end-1].Name {
+		end++
+	}
+	return end
+}
+
 func (b *queryBuffer) set(id string, entries []*cmn.BucketEntry, size uint) {
 	if b.leftovers == nil {
 		b.leftovers = make(map[string]*queryBufferTarget, 5)
@@ -315,7 +346,7 @@ func (ci *cacheInterval) get(token string, objCnt uint, params reqParams) (entri
 	}
 	entries = entries[start:]
 
-	end := cmn.MinUint(uint(len(entries)), objCnt)
+	end := versionsEnd(entries, cmn.MinUint(uint(len(entries)), objCnt))
 	if params.prefix != "" {
 		// Move `end-1` to last entry that starts with `params.prefix`.
 		for ; end > 0; end-- {
@@ -461,8 +492,9 @@ func (c *queryCaches) get(reqID cacheReqID, token string, objCnt uint) (entries
 	}
 
 	// When `prefix` is requested we must also check if there is enough entries
-	// in the "main" (whole bucket) cache with given prefix.
-	if reqID.prefix != "" {
+	// in the "main" (whole bucket) cache with given prefix. Not applicable
+	// to the listings with delimiter - the names are rolled up differently.
+	if reqID.prefix != "" && reqID.delimiter == "" {
 		// We must adjust parameters and cache id.
 		params := reqParams{prefix: reqID.prefix}
 		reqID = cacheReqID{bck: reqID.bck}
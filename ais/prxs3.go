@@ -10,6 +10,7 @@ import (
 	"net/http"
 	"net/url"
 	"path"
+	"strconv"
 	"strings"
 	"time"
 
@@ -57,10 +58,22 @@ func (p *proxyrunner) s3Handler(w http.ResponseWriter, r *http.Request) {
 				p.getBckVersioningS3(w, r, apiItems[0])
 				return
 			}
+			if _, uploads := q[s3compat.URLParamMptUploads]; uploads {
+				p.listMptUploads(w, r, apiItems[0], q)
+				return
+			}
+			if _, versions := q[s3compat.URLParamVersions]; versions {
+				p.bckListVersionsS3(w, r, apiItems[0])
+				return
+			}
 			// only bucket name - list objects in the bucket
 			p.bckListS3(w, r, apiItems[0])
 			return
 		}
+		if _, tagging := r.URL.Query()[s3compat.URLParamTagging]; tagging {
+			p.objTaggingS3(w, r, apiItems)
+			return
+		}
 		// object data otherwise
 		p.getObjS3(w, r, apiItems)
 	case http.MethodPut:
@@ -78,13 +91,21 @@ func (p *proxyrunner) s3Handler(w http.ResponseWriter, r *http.Request) {
 			p.putBckS3(w, r, apiItems[0])
 			return
 		}
+		if _, tagging := r.URL.Query()[s3compat.URLParamTagging]; tagging {
+			p.objTaggingS3(w, r, apiItems)
+			return
+		}
 		p.putObjS3(w, r, apiItems)
 	case http.MethodPost:
+		q := r.URL.Query()
+		if _, uploads := q[s3compat.URLParamMptUploads]; uploads || q.Get(s3compat.URLParamUploadID) != "" {
+			p.handleMptUpload(w, r, apiItems)
+			return
+		}
 		if len(apiItems) != 1 {
 			p.invalmsghdlr(w, r, "bucket name expected")
 			return
 		}
-		q := r.URL.Query()
 		if _, multiple := q[s3compat.URLParamMultiDelete]; !multiple {
 			p.invalmsghdlr(w, r, "invalid request")
 			return
@@ -105,6 +126,10 @@ func (p *proxyrunner) s3Handler(w http.ResponseWriter, r *http.Request) {
 			p.delBckS3(w, r, apiItems[0])
 			return
 		}
+		if _, tagging := r.URL.Query()[s3compat.URLParamTagging]; tagging {
+			p.objTaggingS3(w, r, apiItems)
+			return
+		}
 		p.delObjS3(w, r, apiItems)
 	default:
 		p.invalmsghdlrf(w, r, "Invalid HTTP Method: %v %s", r.Method, r.URL.Path)
@@ -249,7 +274,8 @@ func (p *proxyrunner) bckListS3(w http.ResponseWriter, r *http.Request, bucket s
 	}
 	smsg := cmn.SelectMsg{UUID: cmn.GenUUID(), TimeFormat: time.RFC3339}
 	smsg.AddProps(cmn.GetPropsSize, cmn.GetPropsChecksum, cmn.GetPropsAtime, cmn.GetPropsVersion)
-	s3compat.FillMsgFromS3Query(r.URL.Query(), &smsg)
+	q := r.URL.Query()
+	s3compat.FillMsgFromS3Query(q, &smsg)
 
 	locationIsAIS := bck.IsAIS() || smsg.IsFlagSet(cmn.SelectCached)
 	var (
@@ -266,8 +292,30 @@ func (p *proxyrunner) bckListS3(w http.ResponseWriter, r *http.Request, bucket s
 		return
 	}
 
-	resp := s3compat.NewListObjectResult()
-	resp.ContinuationToken = smsg.ContinuationToken
+	resp := s3compat.NewListObjectResult(q)
+	resp.FillFromAisBckList(objList, &smsg)
+	b := resp.MustMarshal()
+	w.Header().Set(cmn.HeaderContentType, cmn.ContentXML)
+	w.Write(b)
+}
+
+// GET s3/bckName?versions
+func (p *proxyrunner) bckListVersionsS3(w http.ResponseWriter, r *http.Request, bucket string) {
+	bck := cluster.NewBck(bucket, cmn.ProviderAIS, cmn.NsGlobal)
+	if err := bck.Init(p.owner.bmd, nil); err != nil {
+		p.invalmsghdlr(w, r, err.Error())
+		return
+	}
+	smsg := cmn.SelectMsg{UUID: cmn.GenUUID(), TimeFormat: time.RFC3339}
+	smsg.AddProps(cmn.GetPropsSize, cmn.GetPropsChecksum, cmn.GetPropsAtime, cmn.GetPropsVersion)
+	q := r.URL.Query()
+	s3compat.FillMsgFromS3VersionsQuery(q, &smsg)
+	objList, err := p.listObjectsAIS(bck, smsg)
+	if err != nil {
+		p.invalmsghdlr(w, r, err.Error())
+		return
+	}
+	resp := s3compat.NewListVersionsResult(bck.Name, q)
 	resp.FillFromAisBckList(objList, &smsg)
 	b := resp.MustMarshal()
 	w.Header().Set(cmn.HeaderContentType, cmn.ContentXML)
@@ -373,6 +421,10 @@ func (p *proxyrunner) putObjS3(w http.ResponseWriter, r *http.Request, items []s
 		p.directPutObjS3(w, r, items)
 		return
 	}
+	if r.URL.Query().Get(s3compat.URLParamUploadID) != "" {
+		p.invalmsghdlr(w, r, "copying a part of multipart upload is not supported", http.StatusNotImplemented)
+		return
+	}
 	p.copyObjS3(w, r, items)
 }
 
@@ -474,6 +526,38 @@ func (p *proxyrunner) delObjS3(w http.ResponseWriter, r *http.Request, items []s
 	s3Redirect(w, redirectURL, bck.Name)
 }
 
+// [GET|PUT|DELETE] s3/bckName/objName?tagging
+func (p *proxyrunner) objTaggingS3(w http.ResponseWriter, r *http.Request, items []string) {
+	started := time.Now()
+	bck := cluster.NewBck(items[0], cmn.ProviderAIS, cmn.NsGlobal)
+	if err := bck.Init(p.owner.bmd, nil); err != nil {
+		p.invalmsghdlr(w, r, err.Error())
+		return
+	}
+	access := cmn.AccessPUT
+	if r.Method == http.MethodGet {
+		access = cmn.AccessObjHEAD
+	}
+	if err := bck.Allow(access); err != nil {
+		p.invalmsghdlr(w, r, err.Error(), http.StatusForbidden)
+		return
+	}
+	var (
+		smap    = p.owner.smap.get()
+		objName = path.Join(items[1:]...)
+	)
+	si, err := cluster.HrwTarget(bck.MakeUname(objName), &smap.Smap)
+	if err != nil {
+		p.invalmsghdlr(w, r, err.Error())
+		return
+	}
+	if glog.FastV(4, glog.SmoduleAIS) {
+		glog.Infof("AISS3 tagging: %s %s/%s => %s", r.Method, bck, objName, si)
+	}
+	redirectURL := p.redirectURL(r, si, started, cmn.NetworkIntraData)
+	s3Redirect(w, redirectURL, bck.Name)
+}
+
 // GET s3/bk-name?versioning
 func (p *proxyrunner) getBckVersioningS3(w http.ResponseWriter, r *http.Request, bucket string) {
 	bck := cluster.NewBck(bucket, cmn.ProviderAIS, cmn.NsGlobal)
@@ -518,3 +602,76 @@ func (p *proxyrunner) putBckVersioningS3(w http.ResponseWriter, r *http.Request,
 		p.invalmsghdlr(w, r, err.Error())
 	}
 }
+
+// POST s3/bckName/objName?uploads - initiate multipart upload
+// POST s3/bckName/objName?uploadId=<id> - complete multipart upload
+// All the requests of an upload are redirected to the target that owns the object.
+func (p *proxyrunner) handleMptUpload(w http.ResponseWriter, r *http.Request, items []string) {
+	started := time.Now()
+	if len(items) < 2 {
+		p.invalmsghdlr(w, r, "object name is undefined")
+		return
+	}
+	bck := cluster.NewBck(items[0], cmn.ProviderAIS, cmn.NsGlobal)
+	if err := bck.Init(p.owner.bmd, nil); err != nil {
+		p.invalmsghdlr(w, r, err.Error())
+		return
+	}
+	if err := bck.Allow(cmn.AccessPUT); err != nil {
+		p.invalmsghdlr(w, r, err.Error(), http.StatusForbidden)
+		return
+	}
+	var (
+		smap    = p.owner.smap.get()
+		objName = path.Join(items[1:]...)
+	)
+	si, err := cluster.HrwTarget(bck.MakeUname(objName), &smap.Smap)
+	if err != nil {
+		p.invalmsghdlr(w, r, err.Error())
+		return
+	}
+	if glog.FastV(4, glog.SmoduleAIS) {
+		glog.Infof("AISS3 MPT: %s %s/%s => %s", r.Method, bck, objName, si)
+	}
+	redirectURL := p.redirectURL(r, si, started, cmn.NetworkIntraData)
+	s3Redirect(w, redirectURL, bck.Name)
+}
+
+// GET s3/bckName?uploads
+// In-progress uploads are spread across targets - collect and merge.
+func (p *proxyrunner) listMptUploads(w http.ResponseWriter, r *http.Request, bucket string, q url.Values) {
+	bck := cluster.NewBck(bucket, cmn.ProviderAIS, cmn.NsGlobal)
+	if err := bck.Init(p.owner.bmd, nil); err != nil {
+		p.invalmsghdlr(w, r, err.Error())
+		return
+	}
+	if err := bck.Allow(cmn.AccessObjLIST); err != nil {
+		p.invalmsghdlr(w, r, err.Error(), http.StatusForbidden)
+		return
+	}
+	var (
+		maxUploads int
+		results    = p.bcastToGroup(bcastArgs{
+			req:     cmn.ReqArgs{Method: http.MethodGet, Path: r.URL.Path, Query: q},
+			network: cmn.NetworkIntraData,
+			timeout: cmn.DefaultTimeout,
+			fv:      func() interface{} { return &s3compat.ListMptUploadsResult{} },
+		})
+		resp = &s3compat.ListMptUploadsResult{Bucket: bck.Name}
+	)
+	for res := range results {
+		if res.err != nil {
+			p.invalmsghdlr(w, r, res.err.Error())
+			return
+		}
+		resp.Uploads = append(resp.Uploads, res.v.(*s3compat.ListMptUploadsResult).Uploads...)
+	}
+	if s := q.Get(s3compat.URLParamMaxUploads); s != "" {
+		if v, err := strconv.Atoi(s); err == nil {
+			maxUploads = v
+		}
+	}
+	resp.Truncate(q.Get(s3compat.URLParamKeyMarker), maxUploads)
+	w.Header().Set(cmn.HeaderContentType, cmn.ContentXML)
+	w.Write(resp.MustMarshal())
+}
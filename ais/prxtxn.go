@@ -311,11 +311,13 @@ func (p *proxyrunner) setBucketProps(w http.ResponseWriter, r *http.Request, msg
 	}
 	c.msg.BMDVersion = bmd.version()
 
-	// 4. if remirror|re-EC|TBD-storage-svc
-	if ctx.needReMirror || ctx.needReEC {
+	// 4. if remirror|re-EC|re-layout-EC|TBD-storage-svc
+	if ctx.needReMirror || ctx.needReEC || ctx.needReLayoutEC {
 		action := cmn.ActMakeNCopies
 		if ctx.needReEC {
 			action = cmn.ActECEncode
+		} else if ctx.needReLayoutEC {
+			action = cmn.ActECRelayout
 		}
 		nl := xaction.NewXactNL(c.uuid, action, &c.smap.Smap, nil, bck.Bck)
 		nl.SetOwner(equalIC)
@@ -350,6 +352,7 @@ func (p *proxyrunner) _setPropsPre(ctx *bmdModifier, clone *bucketMD) (err error
 
 	ctx.needReMirror = reMirror(bprops, ctx.setProps)
 	ctx.needReEC = reEC(bprops, ctx.setProps, bck)
+	ctx.needReLayoutEC = reLayoutEC(bprops, ctx.setProps)
 	clone.set(bck, ctx.setProps)
 	return nil
 }
@@ -851,8 +854,12 @@ func (p *proxyrunner) makeNprops(bck *cluster.Bck, propsToUpdate cmn.BucketProps
 		}
 	}
 	if bprops.EC.Enabled && nprops.EC.Enabled {
-		if !reflect.DeepEqual(bprops.EC, nprops.EC) {
-			err = fmt.Errorf("%s: once enabled, EC configuration can be only disabled but cannot change", p.si)
+		// slice counts and size limit can change - the bucket gets re-encoded
+		bconf, nconf := bprops.EC, nprops.EC
+		bconf.DataSlices, bconf.ParitySlices, bconf.ObjSizeLimit = nconf.DataSlices, nconf.ParitySlices, nconf.ObjSizeLimit
+		if !reflect.DeepEqual(bconf, nconf) {
+			err = fmt.Errorf("%s: once enabled, EC configuration can be only disabled or re-laid out "+
+				"(data and parity slices, object size limit)", p.si)
 			return
 		}
 	} else if nprops.EC.Enabled {
@@ -873,7 +880,7 @@ func (p *proxyrunner) makeNprops(bck *cluster.Bck, propsToUpdate cmn.BucketProps
 
 	// cannot run make-n-copies and EC on the same bucket at the same time
 	remirror := reMirror(bprops, nprops)
-	reec := reEC(bprops, nprops, bck)
+	reec := reEC(bprops, nprops, bck) || reLayoutEC(bprops, nprops)
 	if len(creating) == 0 && remirror && reec {
 		err = cmn.NewErrorBucketIsBusy(bck.Bck, p.si.String())
 		return
@@ -16,6 +16,9 @@ import (
 // for buckets that do not have creation date(created with older AIS)
 var defaultDate = time.Unix(0, 1546300800000000000)
 
+// TODO: AIS buckets and objects do not have owners
+var aisOwner = BckOwner{ID: "1", Name: "ais"}
+
 type (
 	// List bucket response
 	ListBucketResult struct {
@@ -50,11 +53,8 @@ type (
 
 func NewListBucketResult() *ListBucketResult {
 	return &ListBucketResult{
-		Ns: s3Namespace,
-		Owner: BckOwner{ // TODO:
-			ID:   "1",
-			Name: "ais",
-		},
+		Ns:      s3Namespace,
+		Owner:   aisOwner,
 		Buckets: make([]*Bucket, 0),
 	}
 }
@@ -5,6 +5,7 @@
 package s3compat
 
 import (
+	"net/http"
 	"strings"
 
 	"github.com/NVIDIA/aistore/cmn"
@@ -20,6 +21,13 @@ const (
 	versioningEnabled   = "Enabled"
 	versioningDisabled  = "Suspended"
 
+	// multipart upload
+	URLParamMptUploads = "uploads"
+	URLParamUploadID   = "uploadId"
+	URLParamPartNum    = "partNumber"
+	URLParamKeyMarker  = "key-marker"
+	URLParamMaxUploads = "max-uploads"
+
 	s3Namespace = "http://s3.amazonaws.com/doc/2006-03-01"
 	// TODO: can it be omitted? // storageClass = "STANDARD"
 
@@ -28,6 +36,11 @@ const (
 	headerVersion = "x-amz-version-id"
 	HeaderObjSrc  = "x-amz-copy-source"
 
+	HeaderContentMD5 = "Content-MD5"
+
+	// user-defined metadata: x-amz-meta-<key>: <value>
+	headerUserMDPrefix = "x-amz-meta-"
+
 	headerAtime = "Last-Modified"
 )
 
@@ -45,6 +58,29 @@ func ExtractEndpoint(path string) string {
 	return ep
 }
 
+// UserMDFromHeader converts S3 user-defined metadata headers (x-amz-meta-*)
+// into native AIS user metadata headers, so that the request can be handled
+// as a regular PUT. As per S3, metadata keys are case-insensitive and stored
+// in lowercase.
+func UserMDFromHeader(header http.Header) {
+	prefix := http.CanonicalHeaderKey(headerUserMDPrefix)
+	for key, values := range header {
+		if !strings.HasPrefix(key, prefix) || len(key) == len(prefix) {
+			continue
+		}
+		mdKey := strings.ToLower(key[len(prefix):])
+		header.Add(cmn.HeaderObjUserMD, mdKey+"="+strings.Join(values, ","))
+	}
+}
+
+// SetUserMDHeader sets x-amz-meta-* response headers from the object's
+// user-defined metadata.
+func SetUserMDHeader(header http.Header, md cmn.SimpleKVs) {
+	for k, v := range md {
+		header.Set(headerUserMDPrefix+k, v)
+	}
+}
+
 func MakeRedirectBody(newPath, bucket string) string {
 	ep := ExtractEndpoint(newPath)
 	body := "<?xml version=\"1.0\" encoding=\"UTF-8\"?>" +
@@ -0,0 +1,203 @@
+// Package s3compat provides Amazon S3 compatibility layer
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package s3compat
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+
+	"github.com/NVIDIA/aistore/cmn"
+)
+
+const (
+	MaxPartNum        = 10000 // as per AWS S3 limits
+	defaultMaxUploads = 1000
+
+	multipartETagSep = "-"
+)
+
+type (
+	// Uploaded part (internal)
+	MptPart struct {
+		MD5  string // MD5 of the part as hex string (aka ETag)
+		FQN  string // FQN of the file that contains the part's data
+		Size int64  // part's size
+		Num  int64  // part's number, starts from 1
+	}
+
+	// Response to CreateMultipartUpload
+	InitiateMptUploadResult struct {
+		Ns       string `xml:"xmlns,attr"`
+		Bucket   string `xml:"Bucket"`
+		Key      string `xml:"Key"`
+		UploadID string `xml:"UploadId"`
+	}
+	// CompleteMultipartUpload request body
+	CompleteMptUpload struct {
+		Parts []*PartInfo `xml:"Part"`
+	}
+	PartInfo struct {
+		ETag         string `xml:"ETag"`
+		PartNumber   int64  `xml:"PartNumber"`
+		Size         int64  `xml:"Size,omitempty"`
+		LastModified string `xml:"LastModified,omitempty"`
+	}
+	// Response to CompleteMultipartUpload
+	CompleteMptUploadResult struct {
+		Ns     string `xml:"xmlns,attr"`
+		Bucket string `xml:"Bucket"`
+		Key    string `xml:"Key"`
+		ETag   string `xml:"ETag"`
+	}
+	// Response to ListParts
+	ListPartsResult struct {
+		Ns       string      `xml:"xmlns,attr"`
+		Bucket   string      `xml:"Bucket"`
+		Key      string      `xml:"Key"`
+		UploadID string      `xml:"UploadId"`
+		Parts    []*PartInfo `xml:"Part"`
+	}
+	// Response to ListMultipartUploads
+	// NOTE: targets return the structure as JSON, proxy merges the results
+	// and sends XML to the client.
+	ListMptUploadsResult struct {
+		Ns          string        `xml:"xmlns,attr" json:"-"`
+		Bucket      string        `xml:"Bucket" json:"bucket"`
+		KeyMarker   string        `xml:"KeyMarker" json:"-"`
+		MaxUploads  int           `xml:"MaxUploads" json:"-"`
+		IsTruncated bool          `xml:"IsTruncated" json:"-"`
+		Uploads     []*UploadInfo `xml:"Upload" json:"uploads"`
+	}
+	UploadInfo struct {
+		Key       string `xml:"Key" json:"key"`
+		UploadID  string `xml:"UploadId" json:"upload_id"`
+		Initiated string `xml:"Initiated" json:"initiated"`
+	}
+)
+
+// ParsePartNum parses and validates part number.
+func ParsePartNum(s string) (int64, error) {
+	partNum, err := strconv.ParseInt(s, 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid part number %q: %v", s, err)
+	}
+	if partNum < 1 || partNum > MaxPartNum {
+		return 0, fmt.Errorf("invalid part number %d, must be between 1 and %d", partNum, MaxPartNum)
+	}
+	return partNum, nil
+}
+
+// MptETag computes S3 ETag of the object assembled from the given parts:
+// MD5 of concatenated binary MD5s of all the parts followed by the number
+// of parts.
+func MptETag(parts []*MptPart) string {
+	h := md5.New()
+	for _, part := range parts {
+		b, err := hex.DecodeString(part.MD5)
+		cmn.AssertNoErr(err)
+		h.Write(b)
+	}
+	return hex.EncodeToString(h.Sum(nil)) + multipartETagSep + strconv.Itoa(len(parts))
+}
+
+// PartsSize returns the total size of the given parts.
+func PartsSize(parts []*MptPart) (size int64) {
+	for _, part := range parts {
+		size += part.Size
+	}
+	return
+}
+
+// OpenParts opens all the parts for reading (in order) - the caller must close
+// the returned files.
+func OpenParts(parts []*MptPart) (files []*os.File, err error) {
+	files = make([]*os.File, 0, len(parts))
+	for _, part := range parts {
+		var fh *os.File
+		if fh, err = os.Open(part.FQN); err != nil {
+			for _, f := range files {
+				cmn.Close(f)
+			}
+			return nil, err
+		}
+		files = append(files, fh)
+	}
+	return
+}
+
+func NewErrNoSuchUpload(id string) error {
+	return cmn.NewNotFoundError("upload %q", id)
+}
+
+func IsErrNoSuchUpload(err error) bool {
+	_, ok := err.(*cmn.NotFoundError)
+	return ok
+}
+
+func SetPartETag(header http.Header, md5 string) {
+	header.Set(headerETag, md5)
+}
+
+///////////////////////
+// XML serialization //
+///////////////////////
+
+func (r *InitiateMptUploadResult) MustMarshal() []byte {
+	r.Ns = s3Namespace
+	b, err := xml.Marshal(r)
+	cmn.AssertNoErr(err)
+	return []byte(xml.Header + string(b))
+}
+
+func (r *CompleteMptUploadResult) MustMarshal() []byte {
+	r.Ns = s3Namespace
+	b, err := xml.Marshal(r)
+	cmn.AssertNoErr(err)
+	return []byte(xml.Header + string(b))
+}
+
+func (r *ListPartsResult) MustMarshal() []byte {
+	r.Ns = s3Namespace
+	b, err := xml.Marshal(r)
+	cmn.AssertNoErr(err)
+	return []byte(xml.Header + string(b))
+}
+
+func (r *ListMptUploadsResult) MustMarshal() []byte {
+	r.Ns = s3Namespace
+	b, err := xml.Marshal(r)
+	cmn.AssertNoErr(err)
+	return []byte(xml.Header + string(b))
+}
+
+// Truncate sorts the uploads by (key, upload ID), skips all the keys up to
+// and including `keyMarker` (if defined), and keeps at most `maxUploads`.
+func (r *ListMptUploadsResult) Truncate(keyMarker string, maxUploads int) {
+	sort.Slice(r.Uploads, func(i, j int) bool {
+		if r.Uploads[i].Key != r.Uploads[j].Key {
+			return r.Uploads[i].Key < r.Uploads[j].Key
+		}
+		return r.Uploads[i].UploadID < r.Uploads[j].UploadID
+	})
+	if keyMarker != "" {
+		idx := sort.Search(len(r.Uploads), func(i int) bool { return r.Uploads[i].Key > keyMarker })
+		r.Uploads = r.Uploads[idx:]
+	}
+	if maxUploads <= 0 {
+		maxUploads = defaultMaxUploads
+	}
+	r.KeyMarker = keyMarker
+	r.MaxUploads = maxUploads
+	if len(r.Uploads) > maxUploads {
+		r.Uploads = r.Uploads[:maxUploads]
+		r.IsTruncated = true
+	}
+}
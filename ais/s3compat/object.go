@@ -18,24 +18,53 @@ import (
 
 const defaultLastModified = 0 // When an object was not accessed yet
 
+// ListObjects(V2) query parameters
+const (
+	QparamListType          = "list-type"
+	QparamMaxKeys           = "max-keys"
+	QparamPrefix            = "prefix"
+	QparamDelimiter         = "delimiter"
+	QparamContinuationToken = "continuation-token"
+	QparamStartAfter        = "start-after"
+	QparamMarker            = "marker" // ListObjects (V1) only
+	QparamFetchOwner        = "fetch-owner"
+	QparamEncodingType      = "encoding-type"
+
+	listTypeV2      = "2"
+	encodingTypeURL = "url"
+)
+
 type (
 	// List objects response
 	ListObjectResult struct {
-		Ns                    string     `xml:"xmlns,attr"`
-		Prefix                string     `xml:"Prefix"`
-		KeyCount              int        `xml:"KeyCount"` // number of objects in the response
-		MaxKeys               int        `xml:"MaxKeys"`
-		IsTruncated           bool       `xml:"IsTruncated"`           // true if there are more pages to read
-		ContinuationToken     string     `xml:"ContinuationToken"`     // original ContinuationToken
-		NextContinuationToken string     `xml:"NextContinuationToken"` // NextContinuationToken to read the next page
-		Contents              []*ObjInfo `xml:"Contents"`              // list of objects
+		Ns                    string          `xml:"xmlns,attr"`
+		Prefix                string          `xml:"Prefix"`
+		Delimiter             string          `xml:"Delimiter,omitempty"`
+		EncodingType          string          `xml:"EncodingType,omitempty"`
+		KeyCount              int             `xml:"KeyCount"` // number of objects and common prefixes in the response
+		MaxKeys               int             `xml:"MaxKeys"`
+		IsTruncated           bool            `xml:"IsTruncated"`                     // true if there are more pages to read
+		Marker                string          `xml:"Marker,omitempty"`                // original Marker (ListObjects V1)
+		NextMarker            string          `xml:"NextMarker,omitempty"`            // NextMarker to read the next page (ListObjects V1)
+		StartAfter            string          `xml:"StartAfter,omitempty"`            // original StartAfter
+		ContinuationToken     string          `xml:"ContinuationToken,omitempty"`     // original ContinuationToken
+		NextContinuationToken string          `xml:"NextContinuationToken,omitempty"` // NextContinuationToken to read the next page
+		Contents              []*ObjInfo      `xml:"Contents"`                        // list of objects
+		CommonPrefixes        []*CommonPrefix `xml:"CommonPrefixes"`                  // list of names rolled up by delimiter
+
+		listV2     bool
+		fetchOwner bool
 	}
 	ObjInfo struct {
-		Key          string `xml:"Key"`
-		LastModified string `xml:"LastModified"`
-		ETag         string `xml:"ETag"`
-		Size         int64  `xml:"Size"`
-		Class        string `xml:"StorageClass"`
+		Key          string    `xml:"Key"`
+		LastModified string    `xml:"LastModified"`
+		ETag         string    `xml:"ETag"`
+		Size         int64     `xml:"Size"`
+		Class        string    `xml:"StorageClass"`
+		Owner        *BckOwner `xml:"Owner,omitempty"`
+	}
+	CommonPrefix struct {
+		Prefix string `xml:"Prefix"`
 	}
 
 	// Response for object copy request
@@ -46,40 +75,86 @@ type (
 )
 
 func FillMsgFromS3Query(query url.Values, msg *cmn.SelectMsg) {
-	mxStr := query.Get("max-keys")
+	mxStr := query.Get(QparamMaxKeys)
 	if pageSize, err := strconv.Atoi(mxStr); err == nil && pageSize > 0 {
 		msg.PageSize = uint(pageSize)
 	}
-	if prefix := query.Get("prefix"); prefix != "" {
+	if prefix := query.Get(QparamPrefix); prefix != "" {
 		msg.Prefix = prefix
 	}
+	if delimiter := query.Get(QparamDelimiter); delimiter != "" {
+		msg.Delimiter = delimiter
+	}
+	if query.Get(QparamListType) != listTypeV2 {
+		// ListObjects (V1): marker is the last key of the previous page,
+		// exactly what AIS continuation token is
+		msg.ContinuationToken = query.Get(QparamMarker)
+		return
+	}
 	var token string
-	if token = query.Get("continuation-token"); token != "" {
+	if token = query.Get(QparamContinuationToken); token != "" {
 		msg.ContinuationToken = token
 	}
 	// start-after makes sense only on first call. For the next call,
 	// when continuation-token is set, start-after is ignored
-	if after := query.Get("start-after"); after != "" && token == "" {
+	if after := query.Get(QparamStartAfter); after != "" && token == "" {
 		msg.StartAfter = after
 	}
 }
 
-func NewListObjectResult() *ListObjectResult {
-	return &ListObjectResult{
-		Ns:       s3Namespace,
-		MaxKeys:  1000,
-		Contents: make([]*ObjInfo, 0),
+func NewListObjectResult(query url.Values) *ListObjectResult {
+	r := &ListObjectResult{
+		Ns:             s3Namespace,
+		MaxKeys:        1000,
+		Prefix:         query.Get(QparamPrefix),
+		Delimiter:      query.Get(QparamDelimiter),
+		Contents:       make([]*ObjInfo, 0),
+		CommonPrefixes: make([]*CommonPrefix, 0),
+		listV2:         query.Get(QparamListType) == listTypeV2,
+	}
+	if query.Get(QparamEncodingType) == encodingTypeURL {
+		r.EncodingType = encodingTypeURL
 	}
+	if r.listV2 {
+		r.ContinuationToken = query.Get(QparamContinuationToken)
+		r.StartAfter = query.Get(QparamStartAfter)
+		r.fetchOwner = cmn.IsParseBool(query.Get(QparamFetchOwner))
+	} else {
+		r.Marker = query.Get(QparamMarker)
+		r.fetchOwner = true // ListObjects (V1) always returns the owner
+	}
+	return r
 }
 
 func (r *ListObjectResult) MustMarshal() []byte {
+	if r.EncodingType == encodingTypeURL {
+		r.Prefix = url.QueryEscape(r.Prefix)
+		r.Delimiter = url.QueryEscape(r.Delimiter)
+		r.StartAfter = url.QueryEscape(r.StartAfter)
+		r.Marker = url.QueryEscape(r.Marker)
+		r.NextMarker = url.QueryEscape(r.NextMarker)
+		for _, obj := range r.Contents {
+			obj.Key = url.QueryEscape(obj.Key)
+		}
+		for _, cp := range r.CommonPrefixes {
+			cp.Prefix = url.QueryEscape(cp.Prefix)
+		}
+	}
 	b, err := xml.Marshal(r)
 	cmn.AssertNoErr(err)
 	return []byte(xml.Header + string(b))
 }
 
 func (r *ListObjectResult) Add(entry *cmn.BucketEntry, smsg *cmn.SelectMsg) {
-	r.Contents = append(r.Contents, entryToS3(entry, smsg))
+	if entry.IsDir() {
+		r.CommonPrefixes = append(r.CommonPrefixes, &CommonPrefix{Prefix: entry.Name})
+		return
+	}
+	objInfo := entryToS3(entry, smsg)
+	if r.fetchOwner {
+		objInfo.Owner = &aisOwner
+	}
+	r.Contents = append(r.Contents, objInfo)
 }
 
 func entryToS3(entry *cmn.BucketEntry, smsg *cmn.SelectMsg) *ObjInfo {
@@ -100,7 +175,11 @@ func entryToS3(entry *cmn.BucketEntry, smsg *cmn.SelectMsg) *ObjInfo {
 func (r *ListObjectResult) FillFromAisBckList(bckList *cmn.BucketList, smsg *cmn.SelectMsg) {
 	r.KeyCount = len(bckList.Entries)
 	r.IsTruncated = bckList.ContinuationToken != ""
-	r.ContinuationToken = bckList.ContinuationToken
+	if r.listV2 {
+		r.NextContinuationToken = bckList.ContinuationToken
+	} else {
+		r.NextMarker = bckList.ContinuationToken
+	}
 	for _, e := range bckList.Entries {
 		r.Add(e, smsg)
 	}
@@ -131,6 +210,8 @@ func SetHeaderFromLOM(header http.Header, lom *cluster.LOM, size int64) {
 	header.Set(cmn.HeaderContentLength, strconv.FormatInt(size, 10))
 	header.Set(cmn.HeaderContentType, cmn.ContentBinary)
 	header.Set(headerVersion, lom.Version())
+	SetUserMDHeader(header, lom.UserMD())
+	SetTaggingCountHeader(header, lom.Tags())
 }
 
 func SetETLHeader(header http.Header, lom *cluster.LOM) {
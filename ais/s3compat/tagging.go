@@ -0,0 +1,101 @@
+// Package s3compat provides Amazon S3 compatibility layer
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package s3compat
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+
+	"github.com/NVIDIA/aistore/cmn"
+)
+
+const (
+	URLParamTagging = "tagging"
+
+	// PUT: URL-encoded tags, e.g. "key1=value1&key2=value2"
+	headerTagging = "x-amz-tagging"
+	// GET/HEAD: the number of tags of the object
+	headerTaggingCount = "x-amz-tagging-count"
+)
+
+type (
+	// PutObjectTagging request body and GetObjectTagging response
+	Tagging struct {
+		XMLName xml.Name `xml:"Tagging"`
+		Ns      string   `xml:"xmlns,attr,omitempty"`
+		TagSet  TagSet   `xml:"TagSet"`
+	}
+	TagSet struct {
+		Tags []Tag `xml:"Tag"`
+	}
+	Tag struct {
+		Key   string `xml:"Key"`
+		Value string `xml:"Value"`
+	}
+)
+
+// NewTagging returns object tags sorted by key.
+func NewTagging(tags cmn.SimpleKVs) *Tagging {
+	tagging := &Tagging{TagSet: TagSet{Tags: make([]Tag, 0, len(tags))}}
+	for k, v := range tags {
+		tagging.TagSet.Tags = append(tagging.TagSet.Tags, Tag{Key: k, Value: v})
+	}
+	sort.Slice(tagging.TagSet.Tags, func(i, j int) bool {
+		return tagging.TagSet.Tags[i].Key < tagging.TagSet.Tags[j].Key
+	})
+	return tagging
+}
+
+func (t *Tagging) MustMarshal() []byte {
+	t.Ns = s3Namespace
+	b, err := xml.Marshal(t)
+	cmn.AssertNoErr(err)
+	return []byte(xml.Header + string(b))
+}
+
+// Tags validates and returns the tag set.
+func (t *Tagging) Tags() (cmn.SimpleKVs, error) {
+	tags := make(cmn.SimpleKVs, len(t.TagSet.Tags))
+	for _, tag := range t.TagSet.Tags {
+		if _, ok := tags[tag.Key]; ok {
+			return nil, fmt.Errorf("duplicate tag key %q", tag.Key)
+		}
+		tags[tag.Key] = tag.Value
+	}
+	if err := cmn.ValidateObjTags(tags); err != nil {
+		return nil, err
+	}
+	return tags, nil
+}
+
+// TagsFromHeader converts S3 tagging header (x-amz-tagging) into native AIS
+// tags headers, so that the request can be handled as a regular PUT.
+func TagsFromHeader(header http.Header) error {
+	s := header.Get(headerTagging)
+	if s == "" {
+		return nil
+	}
+	query, err := url.ParseQuery(s)
+	if err != nil {
+		return fmt.Errorf("invalid %s header %q: %v", headerTagging, s, err)
+	}
+	for k, values := range query {
+		if len(values) != 1 {
+			return fmt.Errorf("invalid %s header %q: duplicate tag key %q", headerTagging, s, k)
+		}
+		header.Add(cmn.HeaderObjTags, k+"="+values[0])
+	}
+	return nil
+}
+
+func SetTaggingCountHeader(header http.Header, tags cmn.SimpleKVs) {
+	if len(tags) > 0 {
+		header.Set(headerTaggingCount, strconv.Itoa(len(tags)))
+	}
+}
@@ -0,0 +1,125 @@
+// Package s3compat provides Amazon S3 compatibility layer
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package s3compat
+
+import (
+	"encoding/xml"
+	"net/url"
+	"strconv"
+
+	"github.com/NVIDIA/aistore/cmn"
+)
+
+// Object versions (ais buckets with versioning.max_versions > 0)
+const (
+	URLParamVersions  = "versions"  // GET s3/bckName?versions - ListObjectVersions
+	URLParamVersionID = "versionId" // GET|HEAD|DELETE s3/bckName/objName?versionId=<id>
+
+	// ListObjectVersions query parameters
+	QparamKeyMarker       = "key-marker"
+	QparamVersionIDMarker = "version-id-marker" // ignored: versions of an object are never split between pages
+)
+
+type (
+	// ListObjectVersions response
+	ListVersionsResult struct {
+		Ns              string          `xml:"xmlns,attr"`
+		Name            string          `xml:"Name"`
+		Prefix          string          `xml:"Prefix"`
+		Delimiter       string          `xml:"Delimiter,omitempty"`
+		KeyMarker       string          `xml:"KeyMarker"`
+		VersionIDMarker string          `xml:"VersionIdMarker,omitempty"`
+		NextKeyMarker   string          `xml:"NextKeyMarker,omitempty"`
+		MaxKeys         int             `xml:"MaxKeys"`
+		IsTruncated     bool            `xml:"IsTruncated"`
+		Versions        []*VersionInfo  `xml:"Version"`
+		DeleteMarkers   []*DelMarker    `xml:"DeleteMarker"`
+		CommonPrefixes  []*CommonPrefix `xml:"CommonPrefixes"`
+	}
+	VersionInfo struct {
+		Key          string    `xml:"Key"`
+		VersionID    string    `xml:"VersionId"`
+		IsLatest     bool      `xml:"IsLatest"`
+		LastModified string    `xml:"LastModified"`
+		ETag         string    `xml:"ETag"`
+		Size         int64     `xml:"Size"`
+		Owner        *BckOwner `xml:"Owner,omitempty"`
+	}
+	DelMarker struct {
+		Key          string    `xml:"Key"`
+		VersionID    string    `xml:"VersionId"`
+		IsLatest     bool      `xml:"IsLatest"`
+		LastModified string    `xml:"LastModified"`
+		Owner        *BckOwner `xml:"Owner,omitempty"`
+	}
+)
+
+func FillMsgFromS3VersionsQuery(query url.Values, msg *cmn.SelectMsg) {
+	if pageSize, err := strconv.Atoi(query.Get(QparamMaxKeys)); err == nil && pageSize > 0 {
+		msg.PageSize = uint(pageSize)
+	}
+	msg.Prefix = query.Get(QparamPrefix)
+	msg.Delimiter = query.Get(QparamDelimiter)
+	msg.ContinuationToken = query.Get(QparamKeyMarker)
+	msg.SetFlag(cmn.SelectVersions)
+}
+
+func NewListVersionsResult(bckName string, query url.Values) *ListVersionsResult {
+	return &ListVersionsResult{
+		Ns:              s3Namespace,
+		Name:            bckName,
+		MaxKeys:         1000,
+		Prefix:          query.Get(QparamPrefix),
+		Delimiter:       query.Get(QparamDelimiter),
+		KeyMarker:       query.Get(QparamKeyMarker),
+		VersionIDMarker: query.Get(QparamVersionIDMarker),
+		Versions:        make([]*VersionInfo, 0),
+		DeleteMarkers:   make([]*DelMarker, 0),
+		CommonPrefixes:  make([]*CommonPrefix, 0),
+	}
+}
+
+// FillFromAisBckList converts the list of objects that includes prior versions
+// and delete markers (see cmn.SelectVersions). The entries are expected to be
+// sorted by name with the latest version of an object coming first.
+func (r *ListVersionsResult) FillFromAisBckList(bckList *cmn.BucketList, smsg *cmn.SelectMsg) {
+	r.IsTruncated = bckList.ContinuationToken != ""
+	r.NextKeyMarker = bckList.ContinuationToken
+	for i, e := range bckList.Entries {
+		if e.IsDir() {
+			r.CommonPrefixes = append(r.CommonPrefixes, &CommonPrefix{Prefix: e.Name})
+			continue
+		}
+		var (
+			latest = i == 0 || bckList.Entries[i-1].Name != e.Name
+			mtime  = cmn.Either(e.Atime, cmn.FormatUnixNano(defaultLastModified, smsg.TimeFormat))
+		)
+		if e.IsDelMarker() {
+			r.DeleteMarkers = append(r.DeleteMarkers, &DelMarker{
+				Key:          e.Name,
+				VersionID:    e.Version,
+				IsLatest:     latest,
+				LastModified: mtime,
+				Owner:        &aisOwner,
+			})
+			continue
+		}
+		r.Versions = append(r.Versions, &VersionInfo{
+			Key:          e.Name,
+			VersionID:    e.Version,
+			IsLatest:     latest,
+			LastModified: mtime,
+			ETag:         e.Checksum,
+			Size:         e.Size,
+			Owner:        &aisOwner,
+		})
+	}
+}
+
+func (r *ListVersionsResult) MustMarshal() []byte {
+	b, err := xml.Marshal(r)
+	cmn.AssertNoErr(err)
+	return []byte(xml.Header + string(b))
+}
@@ -6,6 +6,7 @@ package ais
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -25,18 +26,22 @@ import (
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/mono"
 	"github.com/NVIDIA/aistore/dbdriver"
+	"github.com/NVIDIA/aistore/downloader"
 	"github.com/NVIDIA/aistore/dsort"
 	"github.com/NVIDIA/aistore/ec"
 	"github.com/NVIDIA/aistore/etl"
 	"github.com/NVIDIA/aistore/fs"
 	"github.com/NVIDIA/aistore/health"
+	"github.com/NVIDIA/aistore/hk"
 	"github.com/NVIDIA/aistore/mirror"
 	"github.com/NVIDIA/aistore/nl"
 	"github.com/NVIDIA/aistore/reb"
+	"github.com/NVIDIA/aistore/scrub"
 	"github.com/NVIDIA/aistore/stats"
 	"github.com/NVIDIA/aistore/transport"
 	"github.com/NVIDIA/aistore/xaction"
 	"github.com/NVIDIA/aistore/xaction/xreg"
+	"github.com/NVIDIA/aistore/xaction/xrun"
 	jsoniter "github.com/json-iterator/go"
 )
 
@@ -48,7 +53,8 @@ const (
 )
 
 const (
-	clusterClockDrift = 5 * time.Millisecond // is expected to be bounded by
+	clusterClockDrift  = 5 * time.Millisecond // is expected to be bounded by
+	scrubCheckInterval = 10 * time.Minute     // how often to check whether periodic scrub is enabled
 )
 
 type (
@@ -87,7 +93,8 @@ type (
 			local  localGFN
 			global globalGFN
 		}
-		regstate regstate // the state of being registered with the primary, can be (en/dis)abled via API
+		regstate regstate   // the state of being registered with the primary, can be (en/dis)abled via API
+		mpt      mptUploads // S3 multipart uploads in progress
 	}
 )
 
@@ -201,13 +208,22 @@ func (t *targetrunner) Run() error {
 
 	t.checkRestarted()
 
-	// register object type and workfile type
+	// register object, workfile, multipart upload, object version, and ETL cache types
 	if err := fs.CSM.RegisterContentType(fs.ObjectType, &fs.ObjectContentResolver{}); err != nil {
 		cmn.ExitLogf("%v", err)
 	}
 	if err := fs.CSM.RegisterContentType(fs.WorkfileType, &fs.WorkfileContentResolver{}); err != nil {
 		cmn.ExitLogf("%v", err)
 	}
+	if err := fs.CSM.RegisterContentType(fs.MultipartType, &fs.MultipartContentResolver{}); err != nil {
+		cmn.ExitLogf("%v", err)
+	}
+	if err := fs.CSM.RegisterContentType(fs.ObjVersionType, &fs.ObjVersionContentResolver{}); err != nil {
+		cmn.ExitLogf("%v", err)
+	}
+	if err := fs.CSM.RegisterContentType(fs.ETLCacheType, &fs.ETLCacheContentResolver{}); err != nil {
+		cmn.ExitLogf("%v", err)
+	}
 
 	dryRunInit()
 
@@ -264,6 +280,10 @@ func (t *targetrunner) Run() error {
 
 	ec.Init(t)
 
+	go t.resumeWriteBack(config)
+	go t.resumeECRelayout(config)
+	hk.Reg(cmn.ActScrub, t.scrubHK, scrubCheckInterval)
+
 	marked := xreg.GetResilverMarked()
 	if marked.Interrupted {
 		go func() {
@@ -274,6 +294,7 @@ func (t *targetrunner) Run() error {
 
 	dsort.InitManagers(driver)
 	dsort.RegisterNode(t.owner.smap, t.owner.bmd, t.si, t, t.statsT)
+	downloader.InitSchedules(t, t.statsT)
 
 	defer etl.StopAll(t) // Always try to stop running ETLs.
 
@@ -310,6 +331,10 @@ func (c clouds) initExt(t *targetrunner) (err error) {
 			c[provider], err = cloud.NewGCP(t)
 		case cmn.ProviderAzure:
 			c[provider], err = cloud.NewAzure(t)
+		case cmn.ProviderHDFS:
+			c[provider], err = cloud.NewHDFS(t)
+		case cmn.ProviderFS:
+			c[provider], err = cloud.NewFS(t)
 		default:
 			err = fmt.Errorf("unknown cloud provider: %q", provider)
 		}
@@ -794,6 +819,15 @@ func (t *targetrunner) getObject(w http.ResponseWriter, r *http.Request, query u
 		t.doETL(w, r, query.Get(cmn.URLParamUUID), bck, objName)
 		return
 	}
+	if version := query.Get(cmn.URLParamObjVersion); version != "" {
+		t.getObjVersion(w, r, lom, version, false /*head*/)
+		return
+	}
+	cond, err := cmn.ParseCondHdr(r.Header)
+	if err != nil {
+		t.invalmsghdlr(w, r, err.Error())
+		return
+	}
 	goi := &getObjInfo{
 		started: started,
 		t:       t,
@@ -803,6 +837,7 @@ func (t *targetrunner) getObject(w http.ResponseWriter, r *http.Request, query u
 		ranges:  cmn.RangesQuery{Range: r.Header.Get(cmn.HeaderRange), Size: 0},
 		isGFN:   isGFNRequest,
 		chunked: config.Net.HTTP.Chunked,
+		cond:    cond,
 	}
 	if bck.IsHTTP() {
 		originalURL := query.Get(cmn.URLParamOrigURL)
@@ -926,6 +961,10 @@ func (t *targetrunner) httpobjdelete(w http.ResponseWriter, r *http.Request) {
 		t.invalmsghdlr(w, r, err.Error())
 		return
 	}
+	if version := query.Get(cmn.URLParamObjVersion); version != "" && !evict {
+		t.delObjVersion(w, r, lom, version)
+		return
+	}
 	errCode, err := t.DeleteObject(context.Background(), lom, evict)
 	if err != nil {
 		if errCode == http.StatusNotFound {
@@ -1021,6 +1060,15 @@ func (t *targetrunner) headObject(w http.ResponseWriter, r *http.Request, query
 		invalidHandler(w, r, err.Error())
 		return
 	}
+	if version := query.Get(cmn.URLParamObjVersion); version != "" {
+		t.getObjVersion(w, r, lom, version, true /*head*/)
+		return
+	}
+	cond, err := cmn.ParseCondHdr(r.Header)
+	if err != nil {
+		invalidHandler(w, r, err.Error())
+		return
+	}
 
 	lom.Lock(false)
 	if err = lom.Load(true); err != nil && !cmn.IsObjNotExist(err) { // (doesnotexist -> ok, other)
@@ -1060,6 +1108,9 @@ func (t *targetrunner) headObject(w http.ResponseWriter, r *http.Request, query
 				http.StatusNotFound)
 			return
 		}
+		if cond != nil && !t.headObjCond(w, r, lom, cond) {
+			return
+		}
 		lom.ToHTTPHdr(hdr)
 	} else {
 		objMeta, errCode, err := t.Cloud(lom.Bck()).HeadObj(context.Background(), lom)
@@ -1068,6 +1119,9 @@ func (t *targetrunner) headObject(w http.ResponseWriter, r *http.Request, query
 			invalidHandler(w, r, errMsg, errCode)
 			return
 		}
+		if cond != nil && !t.headCloudObjCond(w, r, lom, cond, objMeta) {
+			return
+		}
 		for k, v := range objMeta {
 			hdr.Set(k, v)
 		}
@@ -1146,6 +1200,19 @@ func (t *targetrunner) sendECMetafile(w http.ResponseWriter, r *http.Request, ap
 		}
 		return
 	}
+	// EC scrub: missing or damaged slice (replica) is reported as not found,
+	// and the one that takes too long to verify - as unavailable
+	if cmn.IsParseBool(r.URL.Query().Get(cmn.URLParamECVerify)) {
+		if err := ec.VerifyCT(bck, objName, md); err != nil {
+			glog.Errorf("%s: %s/%s: %v", t.si, bck, objName, err)
+			status := http.StatusNotFound
+			if errors.Is(err, ec.ErrorVerifyTimeout) {
+				status = http.StatusServiceUnavailable
+			}
+			t.invalmsghdlrsilent(w, r, err.Error(), status)
+			return
+		}
+	}
 	w.Write(md.Marshal())
 }
 
@@ -1298,7 +1365,25 @@ func (t *targetrunner) doPut(r *http.Request, lom *cluster.LOM, started time.Tim
 		cksumValue = header.Get(cmn.HeaderObjCksumVal)
 		recvType   = r.URL.Query().Get(cmn.URLParamRecvType)
 	)
+	userMD, err := cmn.UserMDFromHTTPHdr(header)
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
+	tags, err := cmn.TagsFromHTTPHdr(header)
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
 	lom.FromHTTPHdr(header) // TODO: check that values parsed here are not coming from the user
+	lom.SetUserMD(userMD)   // PUT always replaces user-defined metadata and tags
+	lom.SetTags(tags)
+	cond, err := cmn.ParseCondHdr(header)
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
+	if cond != nil && !cond.CreateOnly() {
+		return http.StatusNotImplemented, fmt.Errorf("%s: conditional PUT supports only %s: %s",
+			lom, cmn.HeaderIfNoneMatch, cmn.ETagAny)
+	}
 	parsedFQN := lom.ParsedFQN()
 	poi := &putObjInfo{
 		started:    started,
@@ -1308,6 +1393,7 @@ func (t *targetrunner) doPut(r *http.Request, lom *cluster.LOM, started time.Tim
 		cksumToUse: cmn.NewCksum(cksumType, cksumValue),
 		ctx:        context.Background(),
 		workFQN:    fs.CSM.GenContentParsedFQN(parsedFQN, fs.WorkfileType, fs.WorkfilePut),
+		createOnly: cond.CreateOnly(),
 	}
 	if recvType != "" {
 		n, err := strconv.Atoi(recvType)
@@ -1355,6 +1441,90 @@ func (t *targetrunner) putMirror(lom *cluster.LOM) {
 	}
 }
 
+func (t *targetrunner) writeBack(lom *cluster.LOM) {
+	const retries = 2
+	var err error
+	for i := 0; i < retries; i++ {
+		xwb := xreg.RenewWriteBack(t, lom.Bck(), "", false /*scan*/)
+		if err = xwb.(*xrun.XactWriteBack).Flush(lom); !xaction.IsErrXactExpired(err) {
+			break
+		}
+		// retry upon race vs (just finished/timed_out)
+	}
+	if err != nil {
+		glog.Errorf("%s: failed to initiate write-back (to be flushed upon next scan), err: %v", lom, err)
+	}
+}
+
+// flush the objects that were not written to the Cloud prior to restart
+func (t *targetrunner) resumeWriteBack(config *cmn.Config) {
+	for !t.ClusterStarted() {
+		time.Sleep(config.Timeout.CplaneOperation)
+	}
+	t.owner.bmd.Get().Range(nil, nil, func(bck *cluster.Bck) bool {
+		if bck.Props.WritePolicy == cmn.WritePolicyBack {
+			xreg.RenewWriteBack(t, bck, "", true /*scan*/)
+		}
+		return false
+	})
+}
+
+// resume EC re-layout interrupted by the restart (or failed to re-encode some objects)
+func (t *targetrunner) resumeECRelayout(config *cmn.Config) {
+	for !t.ClusterStarted() {
+		time.Sleep(config.Timeout.CplaneOperation)
+	}
+	t.owner.bmd.Get().Range(nil, nil, func(bck *cluster.Bck) bool {
+		if !ec.RelayoutInterrupted(bck.Bck) {
+			return false
+		}
+		if !bck.Props.EC.Enabled {
+			ec.RemoveRelayoutMarker(bck.Bck)
+			return false
+		}
+		glog.Infof("resuming EC re-layout of %s...", bck)
+		xact, err := xreg.RenewECRelayout(t, bck, cmn.GenUUID())
+		if err != nil {
+			glog.Error(err)
+			return false
+		}
+		go xact.Run()
+		return false
+	})
+}
+
+// runs scrub upon user request or periodically (see `scrubHK`)
+func (t *targetrunner) runScrub(id string) {
+	regToIC := id == ""
+	if regToIC {
+		id = cmn.GenUUID()
+	}
+	xact := xreg.RenewScrub(t, t.statsT, id)
+	if xact == nil {
+		return // already running
+	}
+	if regToIC {
+		regMsg := xactRegMsg{UUID: id, Kind: cmn.ActScrub, Srcs: []string{t.si.ID()}}
+		msg := t.newAisMsg(&cmn.ActionMsg{Action: cmn.ActRegGlobalXaction, Value: regMsg}, nil, nil)
+		t.bcastToIC(msg, false /*wait*/)
+	}
+	xact.AddNotif(&xaction.NotifXact{
+		NotifBase: nl.NotifBase{When: cluster.UponTerm, Dsts: []string{equalIC}, F: t.callerNotifyFin},
+		Xact:      xact,
+	})
+	xact.(*scrub.Xaction).Run()
+}
+
+// periodic scrub (config.Scrub.Interval) - housekeeping callback
+func (t *targetrunner) scrubHK() time.Duration {
+	config := cmn.GCO.Get()
+	if !config.Scrub.Enabled || !t.ClusterStarted() {
+		return scrubCheckInterval
+	}
+	go t.runScrub("")
+	return config.Scrub.Interval
+}
+
 func (t *targetrunner) DeleteObject(ctx context.Context, lom *cluster.LOM, evict bool) (int, error) {
 	var (
 		cloudErr     error
@@ -1365,7 +1535,8 @@ func (t *targetrunner) DeleteObject(ctx context.Context, lom *cluster.LOM, evict
 	lom.Lock(true)
 	defer lom.Unlock(true)
 
-	delFromCloud := lom.Bck().IsRemote() && !evict
+	// write-never: the Cloud object (if any) was never written by AIS
+	delFromCloud := lom.Bck().IsRemote() && !evict && lom.WritePolicy() != cmn.WritePolicyNever
 	if err := lom.Load(false); err == nil {
 		delFromAIS = true
 	} else if !cmn.IsObjNotExist(err) {
@@ -1373,16 +1544,25 @@ func (t *targetrunner) DeleteObject(ctx context.Context, lom *cluster.LOM, evict
 	} else if !delFromCloud && cmn.IsObjNotExist(err) {
 		return http.StatusNotFound, err
 	}
+	if evict && delFromAIS && lom.IsDirty() {
+		return http.StatusConflict, fmt.Errorf("%s: cannot evict, the object is yet to be written to the Cloud", lom)
+	}
 
 	if delFromCloud {
-		if errCode, err := t.Cloud(lom.Bck()).DeleteObj(ctx, lom); err != nil {
+		// a dirty object may not be in the Cloud yet
+		if errCode, err := t.Cloud(lom.Bck()).DeleteObj(ctx, lom); err != nil &&
+			!(delFromAIS && lom.IsDirty() && errCode == http.StatusNotFound) {
 			cloudErr = err
 			cloudErrCode = errCode
 			t.statsT.Add(stats.DeleteCount, 1)
 		}
 	}
 	if delFromAIS {
-		errRet = lom.Remove()
+		if lom.Bck().IsAIS() && lom.VersionConf().KeepVersions() {
+			errRet = t.delObjKeepVersions(lom)
+		} else {
+			errRet = lom.Remove()
+		}
 		if errRet != nil {
 			if !os.IsNotExist(errRet) {
 				if cloudErr != nil {
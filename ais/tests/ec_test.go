@@ -5,7 +5,9 @@
 package integration
 
 import (
+	"bytes"
 	"fmt"
+	"io/ioutil"
 	"math/rand"
 	"os"
 	"path"
@@ -536,8 +538,8 @@ func damageMetadataCksum(t *testing.T, slicePath string) {
 	tassert.CheckFatal(t, err)
 }
 
-// Short test to make sure that EC options cannot be changed after
-// EC is enabled
+// Short test to make sure that EC options, except the slice counts and the
+// object size limit, cannot be changed after EC is enabled
 func TestECChange(t *testing.T) {
 	var (
 		proxyURL = tutils.RandomProxyURL()
@@ -590,10 +592,15 @@ func TestECChange(t *testing.T) {
 	_, err = api.SetBucketProps(baseParams, bck, bucketProps)
 	tassert.Errorf(t, err == nil, "Enabling EC failed: %v", err)
 
-	tutils.Logln("Trying to modify EC options when EC is enabled")
+	tutils.Logln("Trying to modify EC layout when EC is enabled")
 	bucketProps.EC.Enabled = api.Bool(true)
 	bucketProps.EC.ObjSizeLimit = api.Int64(300000)
 	_, err = api.SetBucketProps(baseParams, bck, bucketProps)
+	tassert.Errorf(t, err == nil, "Modifying EC layout failed: %v", err)
+
+	tutils.Logln("Trying to modify other EC options when EC is enabled")
+	bucketProps.EC.Compression = api.String(cmn.CompressAlways)
+	_, err = api.SetBucketProps(baseParams, bck, bucketProps)
 	tassert.Errorf(t, err != nil, "Modifiying EC properties must fail")
 
 	tutils.Logln("Resetting bucket properties")
@@ -816,6 +823,76 @@ func TestECRestoreObjAndSliceCloud(t *testing.T) {
 	}
 }
 
+// Damages a slice and then the main replica of the same object, and checks
+// that EC scrub repairs both in place.
+func TestECScrub(t *testing.T) {
+	var (
+		bck = cmn.Bck{
+			Name:     testBucketName + "-ec-scrub",
+			Provider: cmn.ProviderAIS,
+		}
+		proxyURL   = tutils.RandomProxyURL()
+		baseParams = tutils.BaseAPIParams(proxyURL)
+	)
+
+	o := ecOptions{
+		minTargets: 4,
+		dataCnt:    2,
+		parityCnt:  1,
+		objCount:   1,
+		pattern:    "obj-scrub-%04d",
+		silent:     true,
+	}.init(t, proxyURL)
+	objName := fmt.Sprintf(o.pattern, 0)
+
+	newLocalBckWithProps(t, baseParams, bck, defaultECBckProps(o), o)
+	defer tutils.DestroyBucket(t, proxyURL, bck)
+
+	foundParts, mainObjPath := createECFile(t, baseParams, bck, objName, o)
+	var slicePath string
+	for fqn := range foundParts {
+		if ct, err := cluster.NewCTFromFQN(fqn, nil); err == nil && ct.ContentType() == ec.SliceType {
+			slicePath = fqn
+			break
+		}
+	}
+	tassert.Fatalf(t, slicePath != "", "no slices of %s found", objName)
+
+	scrubAndCheck := func(fqn string) {
+		orig, err := ioutil.ReadFile(fqn)
+		tassert.CheckFatal(t, err)
+		damaged := append([]byte(nil), orig...)
+		damaged[len(damaged)/2] ^= 0xff
+		err = ioutil.WriteFile(fqn, damaged, 0o644)
+		tassert.CheckFatal(t, err)
+
+		tutils.Logf("Damaged %s, starting EC scrub\n", fqn)
+		xactArgs := api.XactReqArgs{Kind: cmn.ActECScrub, Bck: bck, Timeout: rebalanceTimeout}
+		xactArgs.ID, err = api.StartXaction(baseParams, xactArgs)
+		tassert.CheckFatal(t, err)
+		_, err = api.WaitForXaction(baseParams, xactArgs)
+		tassert.CheckFatal(t, err)
+
+		// the repair of the slice is asynchronous (re-encoding)
+		totalCnt := 2 + o.sliceTotal()*2
+		objSize := int64(ecMinBigSize * 2)
+		sliceSize := ec.SliceSize(objSize, o.dataCnt)
+		foundParts, _ := waitForECFinishes(t, totalCnt, objSize, sliceSize, true, bck, objName)
+		ecCheckSlices(t, foundParts, bck, ecTestDir+objName, objSize, sliceSize, totalCnt)
+
+		repaired, err := ioutil.ReadFile(fqn)
+		tassert.CheckFatal(t, err)
+		tassert.Errorf(t, bytes.Equal(repaired, orig), "%s was not repaired", fqn)
+	}
+
+	tutils.Logln("Damaging a slice")
+	scrubAndCheck(slicePath)
+
+	tutils.Logln("Damaging the main replica")
+	scrubAndCheck(mainObjPath)
+	objectsExist(t, baseParams, bck, o.pattern, o.objCount)
+}
+
 // Quick check that EC can restore a damaged object and a missing slice
 //  - PUTs an object to the bucket
 //  - filepath.Walk checks that the number of metafiles and slices are correct
@@ -2142,6 +2219,77 @@ func TestECBucketEncode(t *testing.T) {
 	//
 }
 
+func TestECRelayout(t *testing.T) {
+	var (
+		bck = cmn.Bck{
+			Name:     testBucketName + "-ec-relayout",
+			Provider: cmn.ProviderAIS,
+		}
+		proxyURL   = tutils.RandomProxyURL()
+		baseParams = tutils.BaseAPIParams(proxyURL)
+		objSize    = int64(ecMinBigSize * 2)
+	)
+
+	o := ecOptions{
+		minTargets:  4,
+		dataCnt:     1,
+		parityCnt:   1,
+		objCount:    20,
+		concurrency: 8,
+		pattern:     "obj-relayout-%04d",
+		silent:      true,
+	}.init(t, proxyURL)
+
+	newLocalBckWithProps(t, baseParams, bck, defaultECBckProps(o), o)
+	defer tutils.DestroyBucket(t, proxyURL, bck)
+
+	wg := &sync.WaitGroup{}
+	wg.Add(o.objCount)
+	for i := 0; i < o.objCount; i++ {
+		go func(objName string) {
+			defer wg.Done()
+			createECFile(t, baseParams, bck, objName, o)
+		}(fmt.Sprintf(o.pattern, i))
+	}
+	wg.Wait()
+	if t.Failed() {
+		t.FailNow()
+	}
+
+	relayout := func(dataCnt, parityCnt int) {
+		o.dataCnt, o.parityCnt = dataCnt, parityCnt
+		bckProps := defaultECBckProps(o)
+		setBucketECProps(t, baseParams, bck, bckProps)
+
+		tutils.Logf("EC re-layout must start automatically for bucket %s\n", bck)
+		xactArgs := api.XactReqArgs{Kind: cmn.ActECRelayout, Bck: bck, Timeout: rebalanceTimeout}
+		_, err := api.WaitForXaction(baseParams, xactArgs)
+		tassert.CheckFatal(t, err)
+
+		totalCnt := 2 + o.sliceTotal()*2
+		sliceSize := ec.SliceSize(objSize, o.dataCnt)
+		for i := 0; i < o.objCount; i++ {
+			objName := fmt.Sprintf(o.pattern, i)
+			foundParts, _ := waitForECFinishes(t, totalCnt, objSize, sliceSize, true, bck, objName)
+			ecCheckSlices(t, foundParts, bck, ecTestDir+objName, objSize, sliceSize, totalCnt)
+		}
+	}
+
+	if o.smap.CountActiveTargets() > 4 {
+		tutils.Logln("Increasing the number of data and parity slices")
+		relayout(2, 2)
+	} else {
+		tutils.Logln("Increasing the number of parity slices")
+		relayout(1, 2)
+	}
+	objectsExist(t, baseParams, bck, o.pattern, o.objCount)
+
+	tutils.Logln("Decreasing the number of slices")
+	relayout(1, 1)
+	objectsExist(t, baseParams, bck, o.pattern, o.objCount)
+	assertBucketSize(t, baseParams, bck, o.objCount)
+}
+
 func init() {
 	proxyURL := tutils.GetPrimaryURL()
 	primary, err := tutils.GetPrimaryProxy(proxyURL)
@@ -6,10 +6,13 @@ package integration
 
 import (
 	"fmt"
+	"net/http"
+	"net/url"
 	"path"
 	"strconv"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/NVIDIA/aistore/api"
 	"github.com/NVIDIA/aistore/cluster"
@@ -614,3 +617,148 @@ func TestObjProps(t *testing.T) {
 		})
 	}
 }
+
+func TestObjPriorVersions(t *testing.T) {
+	const (
+		objName     = "versioned-obj"
+		numPuts     = 5
+		maxVersions = 3
+	)
+	var (
+		proxyURL   = tutils.RandomProxyURL()
+		baseParams = tutils.BaseAPIParams(proxyURL)
+		bck        = cmn.Bck{Name: cmn.RandString(10), Provider: cmn.ProviderAIS}
+		msg        = &cmn.SelectMsg{Props: cmn.GetPropsVersion + "," + cmn.GetPropsSize}
+	)
+	tutils.CreateFreshBucket(t, proxyURL, bck)
+	defer tutils.DestroyBucket(t, proxyURL, bck)
+
+	_, err := api.SetBucketProps(baseParams, bck, cmn.BucketPropsToUpdate{
+		Versioning: &cmn.VersionConfToUpdate{
+			Enabled:     api.Bool(true),
+			MaxVersions: api.Int(maxVersions),
+		},
+	})
+	tassert.CheckFatal(t, err)
+
+	for i := 1; i <= numPuts; i++ {
+		r, err := readers.NewRandReader(int64(i*cmn.KiB), cmn.ChecksumNone)
+		tassert.CheckFatal(t, err)
+		err = api.PutObject(api.PutObjectArgs{BaseParams: baseParams, Bck: bck, Object: objName, Reader: r})
+		tassert.CheckFatal(t, err)
+	}
+
+	tutils.Logln("listing object versions...")
+	msg.SetFlag(cmn.SelectVersions)
+	objList, err := api.ListObjects(baseParams, bck, msg, 0)
+	tassert.CheckFatal(t, err)
+	tassert.Fatalf(t, len(objList.Entries) == maxVersions+1,
+		"expected %d entries, got %d", maxVersions+1, len(objList.Entries))
+	for i, entry := range objList.Entries {
+		version := strconv.Itoa(numPuts - i)
+		tassert.Errorf(t, entry.Version == version, "entry %d: expected version %s, got %s", i, version, entry.Version)
+		tassert.Errorf(t, entry.IsPrevVersion() == (i > 0), "entry %d: unexpected flags %x", i, entry.Flags)
+	}
+
+	tutils.Logln("reading prior version...")
+	query := url.Values{cmn.URLParamObjVersion: []string{"3"}}
+	n, err := api.GetObject(baseParams, bck, objName, api.GetObjectInput{Query: query})
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, n == 3*cmn.KiB, "expected size %d, got %d", 3*cmn.KiB, n)
+
+	tutils.Logln("deleting object...")
+	tassert.CheckFatal(t, api.DeleteObject(baseParams, bck, objName))
+	_, err = api.HeadObject(baseParams, bck, objName)
+	tassert.Fatalf(t, err != nil, "expected object %s to be deleted", objName)
+
+	objList, err = api.ListObjects(baseParams, bck, msg, 0)
+	tassert.CheckFatal(t, err)
+	tassert.Fatalf(t, len(objList.Entries) == maxVersions, "expected %d entries, got %d", maxVersions, len(objList.Entries))
+	tassert.Errorf(t, objList.Entries[0].IsDelMarker(), "expected delete marker, got %+v", objList.Entries[0])
+
+	tutils.Logln("deleting prior versions...")
+	for _, entry := range objList.Entries {
+		tassert.CheckFatal(t, api.DeleteObjectVersion(baseParams, bck, objName, entry.Version))
+	}
+	objList, err = api.ListObjects(baseParams, bck, msg, 0)
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, len(objList.Entries) == 0, "expected no entries, got %d", len(objList.Entries))
+}
+
+func TestObjConditionalRequests(t *testing.T) {
+	const objName = "conditional-obj"
+	var (
+		proxyURL   = tutils.RandomProxyURL()
+		baseParams = tutils.BaseAPIParams(proxyURL)
+		bck        = cmn.Bck{Name: cmn.RandString(10), Provider: cmn.ProviderAIS}
+	)
+	tutils.CreateFreshBucket(t, proxyURL, bck)
+	defer tutils.DestroyBucket(t, proxyURL, bck)
+
+	r, err := readers.NewRandReader(cmn.KiB, cmn.ChecksumNone)
+	tassert.CheckFatal(t, err)
+	putArgs := api.PutObjectArgs{BaseParams: baseParams, Bck: bck, Object: objName, Reader: r, CreateOnly: true}
+	tassert.CheckFatal(t, api.PutObject(putArgs))
+
+	tutils.Logln("create-only PUT of the existing object...")
+	putArgs.Reader, err = readers.NewRandReader(cmn.KiB, cmn.ChecksumNone)
+	tassert.CheckFatal(t, err)
+	err = api.PutObject(putArgs)
+	checkHTTPStatus(t, err, http.StatusPreconditionFailed)
+
+	props, err := api.HeadObject(baseParams, bck, objName)
+	tassert.CheckFatal(t, err)
+	etag := strconv.Quote(props.Checksum.Value)
+
+	tutils.Logln("conditional GET...")
+	hdr := http.Header{cmn.HeaderIfNoneMatch: []string{etag}}
+	n, err := api.GetObject(baseParams, bck, objName, api.GetObjectInput{Header: hdr})
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, n == 0, "expected 304 Not Modified with no content, got %d bytes", n)
+
+	hdr = http.Header{cmn.HeaderIfMatch: []string{etag}}
+	n, err = api.GetObject(baseParams, bck, objName, api.GetObjectInput{Header: hdr})
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, n == cmn.KiB, "expected size %d, got %d", cmn.KiB, n)
+
+	hdr = http.Header{cmn.HeaderIfMatch: []string{`"mismatch"`}}
+	_, err = api.GetObject(baseParams, bck, objName, api.GetObjectInput{Header: hdr})
+	checkHTTPStatus(t, err, http.StatusPreconditionFailed)
+
+	hdr = http.Header{cmn.HeaderIfUnmodifiedSince: []string{time.Now().Add(-time.Hour).UTC().Format(http.TimeFormat)}}
+	_, err = api.GetObject(baseParams, bck, objName, api.GetObjectInput{Header: hdr})
+	checkHTTPStatus(t, err, http.StatusPreconditionFailed)
+}
+
+func TestObjConditionalHeadCloud(t *testing.T) {
+	const objName = "conditional-head-obj"
+	var (
+		proxyURL   = tutils.RandomProxyURL()
+		baseParams = tutils.BaseAPIParams(proxyURL)
+		bck        = cliBck
+	)
+	tutils.CheckSkip(t, tutils.SkipTestArgs{Cloud: true, Bck: bck})
+
+	r, err := readers.NewRandReader(cmn.KiB, cmn.ChecksumNone)
+	tassert.CheckFatal(t, err)
+	putArgs := api.PutObjectArgs{BaseParams: baseParams, Bck: bck, Object: objName, Reader: r}
+	tassert.CheckFatal(t, api.PutObject(putArgs))
+	defer api.DeleteObject(baseParams, bck, objName)
+	tassert.CheckFatal(t, api.EvictObject(baseParams, bck, objName))
+
+	tutils.Logln("conditional HEAD of the object that is not cached...")
+	baseParams.Method = http.MethodHead
+	err = api.DoHTTPRequest(api.ReqParams{
+		BaseParams: baseParams,
+		Path:       cmn.JoinWords(cmn.Version, cmn.Objects, bck.Name, objName),
+		Query:      cmn.AddBckToQuery(nil, bck),
+		Header:     http.Header{cmn.HeaderIfMatch: []string{`"mismatch"`}},
+	})
+	checkHTTPStatus(t, err, http.StatusPreconditionFailed)
+}
+
+func checkHTTPStatus(t *testing.T, err error, status int) {
+	tassert.Fatalf(t, err != nil, "expected status %d, got no error", status)
+	httpErr, ok := err.(*cmn.HTTPError)
+	tassert.Fatalf(t, ok && httpErr.Status == status, "expected status %d, got %v", status, err)
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/NVIDIA/aistore/api"
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/devtools/tutils"
+	"github.com/NVIDIA/aistore/devtools/tutils/readers"
 	"github.com/NVIDIA/aistore/devtools/tutils/tassert"
 	"github.com/NVIDIA/aistore/query"
 	jsoniter "github.com/json-iterator/go"
@@ -113,6 +114,49 @@ func TestQueryVersionFilter(t *testing.T) {
 	checkQueryDone(t, handle)
 }
 
+func TestQueryTagFilter(t *testing.T) {
+	var (
+		proxyURL   = tutils.RandomProxyURL()
+		baseParams = tutils.BaseAPIParams(proxyURL)
+		bck        = cmn.Bck{
+			Name:     "TESTQUERYBUCKET",
+			Provider: cmn.ProviderAIS,
+		}
+		numObjects       = 10
+		queryObjectNames = make(cmn.StringSet, numObjects/2)
+	)
+
+	tutils.CreateFreshBucket(t, proxyURL, bck)
+	defer tutils.DestroyBucket(t, proxyURL, bck)
+
+	for i := 0; i < numObjects; i++ {
+		objName := fmt.Sprintf("object-%d.txt", i)
+		tags := cmn.SimpleKVs{"split": "validation"}
+		if i%2 == 0 {
+			tags["split"] = "train"
+			queryObjectNames.Add(objName)
+		}
+		r, err := readers.NewRandReader(cmn.KiB, cmn.ChecksumNone)
+		tassert.CheckFatal(t, err)
+		err = api.PutObject(api.PutObjectArgs{BaseParams: baseParams, Bck: bck, Object: objName, Reader: r, Tags: tags})
+		tassert.CheckFatal(t, err)
+	}
+
+	filter := query.TagFilterMsg("split", "train")
+	handle, err := api.InitQuery(baseParams, "object-{0..100}.txt", bck, filter)
+	tassert.CheckFatal(t, err)
+
+	objectsNames, err := api.NextQueryResults(baseParams, handle, uint(numObjects))
+	tassert.CheckFatal(t, err)
+	tassert.Fatalf(t, len(objectsNames) == numObjects/2, "expected %d to be returned, got %d", numObjects/2, len(objectsNames))
+	for _, object := range objectsNames {
+		tassert.Errorf(t, queryObjectNames.Contains(object.Name), "unexpected object %s", object.Name)
+		queryObjectNames.Delete(object.Name)
+	}
+
+	checkQueryDone(t, handle)
+}
+
 func TestQueryVersionAndAtime(t *testing.T) {
 	var (
 		proxyURL   = tutils.RandomProxyURL()
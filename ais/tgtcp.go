@@ -657,6 +657,8 @@ func (t *targetrunner) _recvBMD(newBMD *bucketMD, msg *aisMsg, tag, caller strin
 			}
 			if obck.Props.EC.Enabled && !nbck.Props.EC.Enabled {
 				xreg.DoAbort(cmn.ActECEncode, nbck)
+				xreg.DoAbort(cmn.ActECRelayout, nbck)
+				ec.RemoveRelayoutMarker(nbck.Bck)
 			}
 			return true
 		})
@@ -78,6 +78,12 @@ func (t *targetrunner) downloadHandler(w http.ResponseWriter, r *http.Request) {
 			t.invalmsghdlr(w, r, err.Error(), http.StatusForbidden)
 			return
 		}
+		if dlBodyBase.Schedule != "" {
+			if err := downloader.AddSchedule(uuid, dlb); err != nil {
+				t.invalmsghdlr(w, r, err.Error())
+			}
+			return
+		}
 
 		dlJob, err := downloader.ParseStartDownloadRequest(ctx, t, bck, uuid, dlb, downloaderXact)
 		if err != nil {
@@ -123,7 +129,11 @@ func (t *targetrunner) downloadHandler(w http.ResponseWriter, r *http.Request) {
 					return
 				}
 			}
-			response, statusCode, respErr = downloaderXact.ListJobs(regex)
+			if payload.Schedules {
+				response, statusCode = downloader.ListSchedules(regex), http.StatusOK
+			} else {
+				response, statusCode, respErr = downloaderXact.ListJobs(regex)
+			}
 		}
 	case http.MethodDelete:
 		items, err := t.checkRESTItems(w, r, 1, false, cmn.Version, cmn.Download)
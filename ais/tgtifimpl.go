@@ -230,6 +230,8 @@ func (t *targetrunner) _recvObjDM(w http.ResponseWriter, hdr transport.ObjHdr, o
 	}
 	lom.SetAtimeUnix(hdr.ObjAttrs.Atime)
 	lom.SetVersion(hdr.ObjAttrs.Version)
+	lom.SetUserMD(hdr.ObjAttrs.UserMD)
+	lom.SetTags(hdr.ObjAttrs.Tags)
 
 	params := cluster.PutObjectParams{
 		Tag:          fs.WorkfilePut,
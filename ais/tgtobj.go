@@ -58,6 +58,8 @@ type (
 		cold bool
 		// if true, poi won't erasure-encode an object when finalizing
 		skipEC bool
+		// if true, PUT fails when the object already exists (`If-None-Match: *`)
+		createOnly bool
 	}
 
 	getObjInfo struct {
@@ -75,6 +77,8 @@ type (
 		isGFN bool
 		// true: chunked transfer (en)coding as per https://tools.ietf.org/html/rfc7230#page-36
 		chunked bool
+		// preconditions of the conditional GET, if any
+		cond *cmn.CondHdr
 	}
 
 	// Contains information packed in append handle.
@@ -115,6 +119,12 @@ type (
 
 func (poi *putObjInfo) putObject() (errCode int, err error) {
 	lom := poi.lom
+	// create-only: fail early, prior to receiving the content
+	if poi.createOnly {
+		if errCode, err = poi.checkCreateOnly(); err != nil {
+			return
+		}
+	}
 	// optimize out if the checksums do match
 	if !poi.cksumToUse.IsEmpty() {
 		if lom.Cksum().Equal(poi.cksumToUse) {
@@ -169,6 +179,9 @@ func (poi *putObjInfo) finalize() (errCode int, err error) {
 	}
 
 	poi.t.putMirror(poi.lom)
+	if poi.lom.IsDirty() && poi.lom.Bprops().WritePolicy == cmn.WritePolicyBack {
+		poi.t.writeBack(poi.lom)
+	}
 	return
 }
 
@@ -180,16 +193,21 @@ func (poi *putObjInfo) tryFinalize() (errCode int, err error) {
 	)
 	if bck.IsRemote() && !poi.migrated {
 		var version string
-		if bck.IsCloud() || bck.IsHTTP() {
+		switch {
+		case bck.IsCloud() && (bck.Props.WritePolicy == cmn.WritePolicyBack ||
+			bck.Props.WritePolicy == cmn.WritePolicyNever):
+			// the Cloud gets updated later (if ever) - see xrun.XactWriteBack
+			lom.SetCustomMD(cmn.SimpleKVs{cluster.DirtyObjMD: cmn.GenTie()})
+		case bck.IsCloud() || bck.IsHTTP():
 			version, errCode, err = poi.putCloud()
-		} else {
+		default:
 			version, errCode, err = poi.putRemoteAIS()
 		}
 		if err != nil {
 			glog.Errorf("%s: PUT failed, err: %v", lom, err)
 			return
 		}
-		if lom.VersionConf().Enabled {
+		if lom.VersionConf().Enabled && version != "" {
 			lom.SetVersion(version)
 		}
 	}
@@ -208,14 +226,38 @@ func (poi *putObjInfo) tryFinalize() (errCode int, err error) {
 	lom.Lock(true)
 	defer lom.Unlock(true)
 
-	if bck.IsAIS() && lom.VersionConf().Enabled && !poi.migrated {
+	if poi.createOnly { // check again under lock
+		if errCode, err = poi.checkCreateOnly(); err != nil {
+			return
+		}
+	}
+	var (
+		keepVersions = bck.IsAIS() && lom.VersionConf().KeepVersions() && !poi.migrated
+		arch         *cluster.VersArchive
+	)
+	if keepVersions {
+		// the current content is preserved (linked) as prior version while the
+		// object itself stays intact until replaced
+		if arch, err = lom.PrepareArchive(); err != nil {
+			return
+		}
+		lom.SetVersion(arch.Next)
+	} else if bck.IsAIS() && lom.VersionConf().Enabled && !poi.migrated {
 		if err = lom.IncVersion(); err != nil {
 			return
 		}
 	}
 	if err := cmn.Rename(poi.workFQN, lom.FQN); err != nil {
+		if arch != nil {
+			arch.Abort()
+		}
 		return 0, fmt.Errorf("rename failed => %s: %w", lom, err)
 	}
+	if arch != nil {
+		if errC := arch.Commit(); errC != nil {
+			glog.Errorf("%s: failed to index prior version: %v", lom, errC)
+		}
+	}
 	if lom.HasCopies() {
 		if err = lom.DelAllCopies(); err != nil {
 			return
@@ -225,6 +267,21 @@ func (poi *putObjInfo) tryFinalize() (errCode int, err error) {
 		return
 	}
 	lom.ReCache()
+	if keepVersions {
+		if _, _, err := lom.TrimVersions(); err != nil {
+			glog.Errorf("%s: failed to trim prior versions: %v", lom, err)
+		}
+	}
+	return
+}
+
+func (poi *putObjInfo) checkCreateOnly() (errCode int, err error) {
+	if err = fs.Access(poi.lom.FQN); err == nil {
+		return http.StatusPreconditionFailed, fmt.Errorf("%s: PUT failed, the object already exists", poi.lom)
+	}
+	if os.IsNotExist(err) {
+		err = nil
+	}
 	return
 }
 
@@ -422,10 +479,16 @@ do:
 			return
 		}
 		goi.lom.Lock(false)
+		if goi.cond != nil {
+			if errCode, err = goi.checkCond(false /*cold GET*/); err != nil || errCode != 0 {
+				goi.lom.Unlock(false)
+				return
+			}
+		}
 		goto get
 	}
-	// exists && remote|cloud: check ver if requested
-	if !coldGet && goi.lom.Bck().IsRemote() {
+	// exists && remote|cloud: check ver if requested (a dirty object is newer than its Cloud version)
+	if !coldGet && goi.lom.Bck().IsRemote() && !goi.lom.IsDirty() {
 		if goi.lom.Version() != "" && goi.lom.VersionConf().ValidateWarmGet {
 			goi.lom.Unlock(false)
 			if coldGet, errCode, err = goi.t.CheckCloudVersion(goi.ctx, goi.lom); err != nil {
@@ -449,9 +512,22 @@ do:
 		}
 	}
 
+	// conditional GET: evaluate preconditions prior to reading (or cold-GETting) the object
+	if !coldGet && goi.cond != nil {
+		if errCode, err = goi.checkCond(false /*cold GET*/); err != nil || errCode != 0 {
+			goi.lom.Unlock(false)
+			return
+		}
+	}
+
 	// 3. coldget
 	if coldGet {
 		goi.lom.Unlock(false) // `GetCold` will lock again and return with object locked
+		if goi.cond != nil {
+			if errCode, err = goi.checkCond(true /*cold GET*/); err != nil || errCode != 0 {
+				return
+			}
+		}
 		if !capRead {
 			capRead = true
 			cs = fs.GetCapStatus()
@@ -461,6 +537,7 @@ do:
 			return http.StatusBadRequest, cs.Err
 		}
 		goi.lom.SetAtimeUnix(goi.started.UnixNano())
+		goi.lom.IncAccessCnt()
 		if errCode, err := goi.t.GetCold(goi.ctx, goi.lom, cluster.GetCold); err != nil {
 			return errCode, err
 		}
@@ -679,6 +756,106 @@ func (goi *getObjInfo) getFromNeighbor(lom *cluster.LOM, tsi *cluster.Snode) (ok
 	return
 }
 
+// conditional GET: evaluates preconditions against the local object or, prior
+// to cold GET, against the Cloud one; returns http.StatusNotModified (having
+// written the status) or error if the request is not to be performed
+func (goi *getObjInfo) checkCond(coldGet bool) (errCode int, err error) {
+	var (
+		status int
+		rw, ok = goi.w.(http.ResponseWriter)
+	)
+	if !ok {
+		return http.StatusInternalServerError,
+			fmt.Errorf("%s: cannot evaluate preconditions of a non-HTTP GET (%T)", goi.lom, goi.w)
+	}
+	if coldGet {
+		status, errCode, err = goi.cloudCondStatus()
+	} else {
+		status, err = objCondStatus(goi.cond, http.MethodGet, goi.lom)
+		errCode = http.StatusInternalServerError
+	}
+	switch {
+	case err != nil:
+		return
+	case status == http.StatusNotModified:
+		rw.Header().Del(cmn.HeaderContentLength)
+		rw.WriteHeader(status)
+		return status, nil
+	case status != 0:
+		return status, fmt.Errorf("%s: %s", goi.lom, http.StatusText(status))
+	}
+	return 0, nil
+}
+
+// evaluates preconditions against the Cloud object that is about to be cold-GET:
+// its entity tag is the MD5 reported by the provider (which becomes the object's
+// checksum if the bucket is configured with MD5), and its modification time is now
+func (goi *getObjInfo) cloudCondStatus() (status, errCode int, err error) {
+	var objMeta cmn.SimpleKVs
+	if len(goi.cond.IfMatch) > 0 || len(goi.cond.IfNoneMatch) > 0 {
+		if objMeta, errCode, err = goi.t.Cloud(goi.lom.Bck()).HeadObj(goi.ctx, goi.lom); err != nil {
+			return
+		}
+	}
+	status = cloudObjCondStatus(goi.cond, http.MethodGet, goi.lom, objMeta)
+	return
+}
+
+// evaluates preconditions of the request against the Cloud object given its
+// metadata (as returned by `HeadObj`) - see `cloudCondStatus`
+func cloudObjCondStatus(cond *cmn.CondHdr, method string, lom *cluster.LOM, objMeta cmn.SimpleKVs) int {
+	var etags []string
+	if md5, ok := objMeta[cluster.MD5ObjMD]; ok && lom.CksumConf().Type == cmn.ChecksumMD5 {
+		etags = []string{md5}
+	}
+	return cond.Check(method, etags, time.Now())
+}
+
+// entity tag of the object (see cmn/cond.go) is its checksum value
+func objETags(lom *cluster.LOM) []string {
+	if cksum := lom.Cksum(); !cksum.IsEmpty() {
+		return []string{cksum.Value()}
+	}
+	return nil
+}
+
+// evaluates preconditions of the request against the existing (loaded) object;
+// returns zero if the request is to be performed
+func objCondStatus(cond *cmn.CondHdr, method string, lom *cluster.LOM) (int, error) {
+	finfo, err := os.Stat(lom.FQN)
+	if err != nil {
+		return 0, err
+	}
+	return cond.Check(method, objETags(lom), finfo.ModTime()), nil
+}
+
+// conditional HEAD: returns false if the request is not to be performed
+// (having written the response)
+func (t *targetrunner) headObjCond(w http.ResponseWriter, r *http.Request, lom *cluster.LOM, cond *cmn.CondHdr) bool {
+	status, err := objCondStatus(cond, http.MethodHead, lom)
+	return t.headCondResp(w, r, lom, status, err)
+}
+
+// conditional HEAD of the Cloud object that is not present locally
+func (t *targetrunner) headCloudObjCond(w http.ResponseWriter, r *http.Request, lom *cluster.LOM, cond *cmn.CondHdr,
+	objMeta cmn.SimpleKVs) bool {
+	return t.headCondResp(w, r, lom, cloudObjCondStatus(cond, http.MethodHead, lom, objMeta), nil)
+}
+
+func (t *targetrunner) headCondResp(w http.ResponseWriter, r *http.Request, lom *cluster.LOM, status int, err error) bool {
+	switch {
+	case err != nil:
+		t.invalmsghdlr(w, r, err.Error())
+	case status == http.StatusNotModified:
+		w.WriteHeader(status)
+	case status != 0:
+		t.invalmsghdlrsilent(w, r, fmt.Sprintf("%s: %s", lom, http.StatusText(status)), status)
+	default:
+		return true
+	}
+	return false
+}
+
 func (goi *getObjInfo) finalize(coldGet bool) (retry bool, errCode int, err error) {
 	var (
 		file    *os.File
@@ -783,6 +960,9 @@ func (goi *getObjInfo) finalize(coldGet bool) (retry bool, errCode int, err erro
 		}
 		hdr.Set(cmn.HeaderObjSize, strconv.FormatInt(goi.lom.Size(), 10))
 		hdr.Set(cmn.HeaderObjAtime, cmn.UnixNano2S(goi.lom.AtimeUnix()))
+		for k, v := range goi.lom.UserMD() {
+			hdr.Add(cmn.HeaderObjUserMD, k+"="+v)
+		}
 		if r != nil {
 			hdr.Set(cmn.HeaderContentLength, strconv.FormatInt(r.Length, 10))
 		} else {
@@ -829,6 +1009,7 @@ func (goi *getObjInfo) finalize(coldGet bool) (retry bool, errCode int, err erro
 	// GFN: atime must be already set
 	if !coldGet && !goi.isGFN {
 		goi.lom.SetAtimeUnix(goi.started.UnixNano())
+		goi.lom.IncAccessCnt()
 		goi.lom.ReCache() // GFN and cold GETs already did this
 	}
 
@@ -1095,6 +1276,7 @@ func (coi *copyObjInfo) copyReader(lom *cluster.LOM, objNameTo string) (copied b
 		si = coi.t.si
 
 		reader  cmn.ReadOpenCloser
+		objMeta cmn.ObjHeaderMetaProvider
 		cleanUp func()
 	)
 
@@ -1117,11 +1299,12 @@ func (coi *copyObjInfo) copyReader(lom *cluster.LOM, objNameTo string) (copied b
 		return
 	}
 
-	if reader, _, cleanUp, err = coi.DP.Reader(lom); err != nil {
+	if reader, objMeta, cleanUp, err = coi.DP.Reader(lom); err != nil {
 		return false, 0, err
 	}
 	defer cleanUp()
 
+	dst.SetUserMD(objMeta.UserMD())
 	params := cluster.PutObjectParams{
 		Tag:          "copy-dp",
 		Reader:       reader,
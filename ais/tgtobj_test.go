@@ -5,6 +5,7 @@
 package ais
 
 import (
+	"context"
 	"flag"
 	"io"
 	"io/ioutil"
@@ -19,11 +20,13 @@ import (
 	"github.com/NVIDIA/aistore/devtools/tutils/readers"
 	"github.com/NVIDIA/aistore/fs"
 	"github.com/NVIDIA/aistore/stats"
+	"github.com/NVIDIA/aistore/xaction"
 )
 
 const (
-	testMountpath = "/tmp/ais-test-mpath" // mpath is created and deleted during the test
-	testBucket    = "bck"
+	testMountpath   = "/tmp/ais-test-mpath" // mpath is created and deleted during the test
+	testBucket      = "bck"
+	testNeverBucket = "bck-never" // Cloud bucket with cmn.WritePolicyNever
 )
 
 var (
@@ -37,6 +40,12 @@ type (
 	discardRW struct {
 		w io.Writer
 	}
+
+	// records the objects deleted from the Cloud
+	delRecorderCloud struct {
+		cluster.CloudProvider
+		deleted []string
+	}
 )
 
 func newDiscardRW() *discardRW {
@@ -49,6 +58,11 @@ func (drw *discardRW) Write(p []byte) (int, error) { return drw.w.Write(p) }
 func (drw *discardRW) Header() http.Header         { return make(http.Header) }
 func (drw *discardRW) WriteHeader(statusCode int)  {}
 
+func (c *delRecorderCloud) DeleteObj(_ context.Context, lom *cluster.LOM) (int, error) {
+	c.deleted = append(c.deleted, lom.ObjName)
+	return 0, nil
+}
+
 func TestMain(m *testing.M) {
 	flag.Parse()
 
@@ -76,12 +90,60 @@ func TestMain(m *testing.M) {
 			Type: cmn.ChecksumNone,
 		},
 	})
+	neverBck := cluster.NewBck(testNeverBucket, cmn.ProviderAmazon, cmn.NsGlobal)
+	bmd.add(neverBck, &cmn.BucketProps{
+		Cksum: cmn.CksumConf{
+			Type: cmn.ChecksumNone,
+		},
+		WritePolicy: cmn.WritePolicyNever,
+	})
 	t.owner.bmd.put(bmd)
-	fs.CreateBuckets("test", bck.Bck)
+	fs.CreateBuckets("test", bck.Bck, neverBck.Bck)
 
 	m.Run()
 }
 
+// Write-never bucket: deleting the object must not touch the Cloud and the
+// objects must never be flushed by write-back.
+func TestWriteNever(tt *testing.T) {
+	cloud := &delRecorderCloud{}
+	t.cloud[cmn.ProviderAmazon] = cloud
+	defer delete(t.cloud, cmn.ProviderAmazon)
+
+	lom := &cluster.LOM{ObjName: "never"}
+	if err := lom.Init(cmn.Bck{Name: testNeverBucket, Provider: cmn.ProviderAmazon, Ns: cmn.NsGlobal}); err != nil {
+		tt.Fatal(err)
+	}
+	r, _ := readers.NewRandReader(cmn.KiB, cmn.ChecksumNone)
+	poi := &putObjInfo{
+		started: time.Now(),
+		t:       t,
+		lom:     lom,
+		r:       r,
+		workFQN: path.Join(testMountpath, "never.work"),
+	}
+	if _, err := poi.putObject(); err != nil {
+		tt.Fatal(err)
+	}
+	if !lom.IsDirty() {
+		tt.Fatalf("%s: expected the object not to be written to the Cloud", lom)
+	}
+	if _, err := t.DeleteObject(context.Background(), lom, false /*evict*/); err != nil {
+		tt.Fatal(err)
+	}
+	if _, err := os.Stat(lom.FQN); !os.IsNotExist(err) {
+		tt.Fatalf("%s: expected the object to be deleted, err: %v", lom, err)
+	}
+	if len(cloud.deleted) != 0 {
+		tt.Fatalf("%s: expected the Cloud object to be left intact, deleted: %v", lom, cloud.deleted)
+	}
+
+	err := t.cmdXactStart(&xaction.XactReqMsg{Kind: cmn.ActWriteBack}, lom.Bck())
+	if err == nil {
+		tt.Fatalf("expected %q to be rejected for write-never bucket", cmn.ActWriteBack)
+	}
+}
+
 func BenchmarkObjPut(b *testing.B) {
 	benches := []struct {
 		fileSize int64
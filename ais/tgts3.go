@@ -6,6 +6,7 @@ package ais
 
 import (
 	"context"
+	"encoding/xml"
 	"fmt"
 	"net/http"
 	"path"
@@ -20,27 +21,93 @@ import (
 	"github.com/NVIDIA/aistore/fs"
 )
 
-// PUT s3/bckName/objName
+// [METHOD] /s3
 func (t *targetrunner) s3Handler(w http.ResponseWriter, r *http.Request) {
 	apiItems, err := t.checkRESTItems(w, r, 0, true, cmn.S3)
 	if err != nil {
 		return
 	}
 
+	q := r.URL.Query()
 	switch r.Method {
 	case http.MethodHead:
 		t.headObjS3(w, r, apiItems)
 	case http.MethodGet:
+		if len(apiItems) == 1 {
+			if _, ok := q[s3compat.URLParamMptUploads]; ok {
+				t.listMptUploads(w, r, apiItems[0])
+				return
+			}
+		}
+		if q.Get(s3compat.URLParamUploadID) != "" {
+			t.listMptParts(w, r, apiItems, q)
+			return
+		}
+		if _, ok := q[s3compat.URLParamTagging]; ok {
+			t.getObjTaggingS3(w, r, apiItems)
+			return
+		}
 		t.getObjS3(w, r, apiItems)
 	case http.MethodPut:
+		if q.Get(s3compat.URLParamPartNum) != "" && q.Get(s3compat.URLParamUploadID) != "" {
+			t.putObjMptPart(w, r, apiItems, q)
+			return
+		}
+		if _, ok := q[s3compat.URLParamTagging]; ok {
+			t.putObjTaggingS3(w, r, apiItems)
+			return
+		}
 		t.putObjS3(w, r, apiItems)
+	case http.MethodPost:
+		if _, ok := q[s3compat.URLParamMptUploads]; ok {
+			t.startMpt(w, r, apiItems)
+			return
+		}
+		if q.Get(s3compat.URLParamUploadID) != "" {
+			t.completeMpt(w, r, apiItems, q)
+			return
+		}
+		t.invalmsghdlrf(w, r, "Invalid HTTP Method: %v %s", r.Method, r.URL.Path)
 	case http.MethodDelete:
+		if q.Get(s3compat.URLParamUploadID) != "" {
+			t.abortMpt(w, r, apiItems, q)
+			return
+		}
+		if _, ok := q[s3compat.URLParamTagging]; ok {
+			t.delObjTaggingS3(w, r, apiItems)
+			return
+		}
 		t.delObjS3(w, r, apiItems)
 	default:
 		t.invalmsghdlrf(w, r, "Invalid HTTP Method: %v %s", r.Method, r.URL.Path)
 	}
 }
 
+// initialize LOM of the object addressed by S3 request: s3/bckName/objName
+func (t *targetrunner) s3LOM(w http.ResponseWriter, r *http.Request, items []string) (lom *cluster.LOM) {
+	if len(items) < 2 {
+		t.invalmsghdlr(w, r, "object name is undefined")
+		return nil
+	}
+	bck := cluster.NewBck(items[0], cmn.ProviderAIS, cmn.NsGlobal)
+	if err := bck.Init(t.owner.bmd, nil); err != nil {
+		t.invalmsghdlr(w, r, err.Error())
+		return nil
+	}
+	lom = &cluster.LOM{ObjName: path.Join(items[1:]...)}
+	if err := lom.Init(bck.Bck); err != nil {
+		if _, ok := err.(*cmn.ErrorRemoteBucketDoesNotExist); ok {
+			t.BMDVersionFixup(r, cmn.Bck{}, true /* sleep */)
+			err = lom.Init(bck.Bck)
+		}
+		if err != nil {
+			t.invalmsghdlr(w, r, err.Error())
+			return nil
+		}
+	}
+	return lom
+}
+
 func (t *targetrunner) copyObjS3(w http.ResponseWriter, r *http.Request, items []string) {
 	if len(items) < 2 {
 		t.invalmsghdlr(w, r, "object name is undefined")
@@ -134,6 +201,11 @@ func (t *targetrunner) directPutObjS3(w http.ResponseWriter, r *http.Request, it
 
 	// TODO: lom.SetCustomMD(cluster.AmazonMD5ObjMD, checksum)
 
+	s3compat.UserMDFromHeader(r.Header)
+	if err := s3compat.TagsFromHeader(r.Header); err != nil {
+		t.invalmsghdlr(w, r, err.Error())
+		return
+	}
 	if errCode, err := t.doPut(r, lom, started); err != nil {
 		t.fsErr(err, lom.FQN)
 		t.invalmsghdlr(w, r, err.Error(), errCode)
@@ -151,7 +223,7 @@ func (t *targetrunner) putObjS3(w http.ResponseWriter, r *http.Request, items []
 	t.copyObjS3(w, r, items)
 }
 
-// GET s3/<bucket-name/<object-name>[?uuid=<etl-uuid>]
+// GET s3/<bucket-name/<object-name>[?uuid=<etl-uuid>][?versionId=<id>]
 func (t *targetrunner) getObjS3(w http.ResponseWriter, r *http.Request, items []string) {
 	if len(items) < 2 {
 		t.invalmsghdlr(w, r, "object name is undefined")
@@ -186,6 +258,15 @@ func (t *targetrunner) getObjS3(w http.ResponseWriter, r *http.Request, items []
 		}
 		return
 	}
+	if version := r.URL.Query().Get(s3compat.URLParamVersionID); version != "" {
+		t.getObjVersion(w, r, lom, version, false /*head*/, s3VerHdr)
+		return
+	}
+	cond, err := cmn.ParseCondHdr(r.Header)
+	if err != nil {
+		t.invalmsghdlr(w, r, err.Error())
+		return
+	}
 	if err = lom.Load(true); err != nil {
 		t.invalmsghdlr(w, r, err.Error())
 		return
@@ -199,6 +280,7 @@ func (t *targetrunner) getObjS3(w http.ResponseWriter, r *http.Request, items []
 		w:       w,
 		ctx:     context.Background(),
 		ranges:  cmn.RangesQuery{Range: r.Header.Get(cmn.HeaderRange), Size: objSize},
+		cond:    cond,
 	}
 	s3compat.SetHeaderFromLOM(w.Header(), lom, objSize)
 	if errCode, err := goi.getObject(); err != nil {
@@ -210,7 +292,7 @@ func (t *targetrunner) getObjS3(w http.ResponseWriter, r *http.Request, items []
 	}
 }
 
-// HEAD s3/bckName/objName
+// HEAD s3/bckName/objName[?versionId=<id>]
 func (t *targetrunner) headObjS3(w http.ResponseWriter, r *http.Request, items []string) {
 	var err error
 	if len(items) < 2 {
@@ -234,6 +316,10 @@ func (t *targetrunner) headObjS3(w http.ResponseWriter, r *http.Request, items [
 		}
 		return
 	}
+	if version := r.URL.Query().Get(s3compat.URLParamVersionID); version != "" {
+		t.getObjVersion(w, r, lom, version, true /*head*/, s3VerHdr)
+		return
+	}
 
 	lom.Lock(false)
 	if err = lom.Load(true); err != nil && !cmn.IsObjNotExist(err) { // (doesnotexist -> ok, other)
@@ -248,6 +334,14 @@ func (t *targetrunner) headObjS3(w http.ResponseWriter, r *http.Request, items [
 		t.invalmsghdlrstatusf(w, r, http.StatusNotFound, "%s/%s %s", bucket, objName, cmn.DoesNotExist)
 		return
 	}
+	cond, err := cmn.ParseCondHdr(r.Header)
+	if err != nil {
+		t.invalmsghdlr(w, r, err.Error())
+		return
+	}
+	if cond != nil && !t.headObjCond(w, r, lom, cond) {
+		return
+	}
 
 	if isETLRequest(r.URL.Query()) {
 		s3compat.SetETLHeader(w.Header(), lom)
@@ -256,7 +350,7 @@ func (t *targetrunner) headObjS3(w http.ResponseWriter, r *http.Request, items [
 	s3compat.SetHeaderFromLOM(w.Header(), lom, lom.Size())
 }
 
-// DEL s3/bckName/objName
+// DEL s3/bckName/objName[?versionId=<id>]
 func (t *targetrunner) delObjS3(w http.ResponseWriter, r *http.Request, items []string) {
 	bck := cluster.NewBck(items[0], cmn.ProviderAIS, cmn.NsGlobal)
 	if err := bck.Init(t.owner.bmd, nil); err != nil {
@@ -273,6 +367,10 @@ func (t *targetrunner) delObjS3(w http.ResponseWriter, r *http.Request, items []
 		t.invalmsghdlr(w, r, err.Error())
 		return
 	}
+	if version := r.URL.Query().Get(s3compat.URLParamVersionID); version != "" {
+		t.delObjVersion(w, r, lom, version)
+		return
+	}
 	errCode, err := t.DeleteObject(context.Background(), lom, false)
 	if err != nil {
 		if errCode == http.StatusNotFound {
@@ -288,3 +386,93 @@ func (t *targetrunner) delObjS3(w http.ResponseWriter, r *http.Request, items []
 	// EC cleanup if EC is enabled
 	ec.ECM.CleanupObject(lom)
 }
+
+func s3VerHdr(hdr http.Header, vlom *cluster.LOM) {
+	s3compat.SetHeaderFromLOM(hdr, vlom, vlom.Size())
+}
+
+// GET s3/bckName/objName?tagging
+func (t *targetrunner) getObjTaggingS3(w http.ResponseWriter, r *http.Request, items []string) {
+	lom := t.s3LOM(w, r, items)
+	if lom == nil {
+		return
+	}
+	lom.Lock(false)
+	err := lom.Load()
+	lom.Unlock(false)
+	if err != nil {
+		t.objTaggingErr(w, r, lom, err)
+		return
+	}
+	tagging := s3compat.NewTagging(lom.Tags())
+	w.Header().Set(cmn.HeaderContentType, cmn.ContentXML)
+	w.Write(tagging.MustMarshal())
+}
+
+// PUT s3/bckName/objName?tagging
+// Replaces the entire tag set of an existing object.
+func (t *targetrunner) putObjTaggingS3(w http.ResponseWriter, r *http.Request, items []string) {
+	lom := t.s3LOM(w, r, items)
+	if lom == nil {
+		return
+	}
+	tagging := &s3compat.Tagging{}
+	err := xml.NewDecoder(r.Body).Decode(tagging)
+	cmn.Close(r.Body)
+	if err != nil {
+		t.invalmsghdlr(w, r, err.Error())
+		return
+	}
+	tags, err := tagging.Tags()
+	if err != nil {
+		t.invalmsghdlr(w, r, err.Error())
+		return
+	}
+	if err := t.setObjTags(lom, tags); err != nil {
+		t.objTaggingErr(w, r, lom, err)
+	}
+}
+
+// DELETE s3/bckName/objName?tagging
+func (t *targetrunner) delObjTaggingS3(w http.ResponseWriter, r *http.Request, items []string) {
+	lom := t.s3LOM(w, r, items)
+	if lom == nil {
+		return
+	}
+	if err := t.setObjTags(lom, nil); err != nil {
+		t.objTaggingErr(w, r, lom, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// NOTE: the tags are updated in place (object's version does not change),
+// along with mirror copies; erasure coded objects get re-encoded so that
+// EC metadata carries the new tags.
+func (t *targetrunner) setObjTags(lom *cluster.LOM, tags cmn.SimpleKVs) error {
+	lom.Lock(true)
+	if err := lom.Load(false); err != nil {
+		lom.Unlock(true)
+		return err
+	}
+	lom.SetTags(tags)
+	if err := lom.PersistWithCopies(); err != nil {
+		lom.Unlock(true)
+		t.fsErr(err, lom.FQN)
+		return err
+	}
+	lom.ReCache()
+	lom.Unlock(true)
+	if err := ec.ECM.EncodeObject(lom); err != nil && err != ec.ErrorECDisabled {
+		return err
+	}
+	return nil
+}
+
+func (t *targetrunner) objTaggingErr(w http.ResponseWriter, r *http.Request, lom *cluster.LOM, err error) {
+	if cmn.IsObjNotExist(err) {
+		t.invalmsghdlrsilent(w, r, fmt.Sprintf("%s: %v", lom, err), http.StatusNotFound)
+		return
+	}
+	t.invalmsghdlr(w, r, err.Error())
+}
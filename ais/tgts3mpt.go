@@ -0,0 +1,455 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/ais/s3compat"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/memsys"
+)
+
+//
+// S3 multipart upload: all requests of a given upload are redirected by proxies
+// to the same target - the one that owns (HRW) the destination object.
+//
+// NOTE: in-progress uploads (mptUploads) are kept in memory by the target, and
+// so they are lost when the target restarts - and also when the object moves to
+// another target (as per HRW) upon cluster membership change: the requests of
+// the upload then get redirected to the target that does not have it. In both
+// cases the upload fails with NoSuchUpload (see errLostUpload) and must be
+// restarted by the client.
+// Parts are stored on the object's mountpath as fs.MultipartType content and
+// are removed when the upload is completed or aborted; parts of the uploads that
+// were lost are removed by LRU.
+//
+// Upload ID is a part of the parts' file names, and so only the IDs generated
+// by the target (see cmn.IsValidUUID) are accepted.
+//
+
+type (
+	mptUploads struct {
+		sync.RWMutex
+		m map[string]*mptUpload // upload ID => upload
+	}
+	mptUpload struct {
+		bckName string
+		objName string
+		ctime   time.Time
+		userMD  cmn.SimpleKVs       // user-defined metadata of the resulting object
+		parts   []*s3compat.MptPart // sorted by part number
+	}
+)
+
+func (ups *mptUploads) init(id string, lom *cluster.LOM, userMD cmn.SimpleKVs) {
+	ups.Lock()
+	if ups.m == nil {
+		ups.m = make(map[string]*mptUpload, 4)
+	}
+	ups.m[id] = &mptUpload{
+		bckName: lom.BckName(),
+		objName: lom.ObjName,
+		ctime:   time.Now(),
+		userMD:  userMD,
+		parts:   make([]*s3compat.MptPart, 0, 8),
+	}
+	ups.Unlock()
+}
+
+// checks that the upload exists and belongs to the given object
+func (ups *mptUploads) exists(id string, lom *cluster.LOM) error {
+	ups.RLock()
+	_, err := ups.get(id, lom)
+	ups.RUnlock()
+	return err
+}
+
+// returns the upload only if it belongs to the given object (caller must lock)
+func (ups *mptUploads) get(id string, lom *cluster.LOM) (*mptUpload, error) {
+	upload, ok := ups.m[id]
+	if !ok || upload.bckName != lom.BckName() || upload.objName != lom.ObjName {
+		return nil, s3compat.NewErrNoSuchUpload(id)
+	}
+	return upload, nil
+}
+
+// adds a new part to an existing upload; a part with the same number, if exists,
+// gets replaced (and its file removed) - as per S3 semantics
+func (ups *mptUploads) addPart(id string, lom *cluster.LOM, npart *s3compat.MptPart) error {
+	var prev *s3compat.MptPart
+	ups.Lock()
+	upload, err := ups.get(id, lom)
+	if err != nil {
+		ups.Unlock()
+		return err
+	}
+	idx := sort.Search(len(upload.parts), func(i int) bool { return upload.parts[i].Num >= npart.Num })
+	switch {
+	case idx == len(upload.parts):
+		upload.parts = append(upload.parts, npart)
+	case upload.parts[idx].Num == npart.Num:
+		prev = upload.parts[idx]
+		upload.parts[idx] = npart
+	default:
+		upload.parts = append(upload.parts, nil)
+		copy(upload.parts[idx+1:], upload.parts[idx:])
+		upload.parts[idx] = npart
+	}
+	ups.Unlock()
+	if prev != nil && prev.FQN != npart.FQN {
+		if err := cmn.RemoveFile(prev.FQN); err != nil {
+			glog.Errorf("failed to remove replaced part %s: %v", prev.FQN, err)
+		}
+	}
+	return nil
+}
+
+// validates the list of parts received with CompleteMultipartUpload request and
+// returns the corresponding uploaded parts (in order) and user-defined metadata
+func (ups *mptUploads) checkParts(id string, lom *cluster.LOM, parts []*s3compat.PartInfo) ([]*s3compat.MptPart, cmn.SimpleKVs, error) {
+	ups.RLock()
+	defer ups.RUnlock()
+	upload, err := ups.get(id, lom)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(parts) == 0 {
+		return nil, nil, fmt.Errorf("upload %q: the list of parts is empty", id)
+	}
+	res := make([]*s3compat.MptPart, 0, len(parts))
+	for i, part := range parts {
+		if i > 0 && part.PartNumber <= parts[i-1].PartNumber {
+			return nil, nil, fmt.Errorf("upload %q: parts are not in ascending order", id)
+		}
+		idx := sort.Search(len(upload.parts), func(i int) bool { return upload.parts[i].Num >= part.PartNumber })
+		if idx == len(upload.parts) || upload.parts[idx].Num != part.PartNumber {
+			return nil, nil, fmt.Errorf("upload %q: part %d not found", id, part.PartNumber)
+		}
+		mpart := upload.parts[idx]
+		if etag := strings.Trim(part.ETag, "\""); etag != "" && etag != mpart.MD5 {
+			return nil, nil, fmt.Errorf("upload %q: part %d ETag mismatch (%q vs %q)", id, part.PartNumber, etag, mpart.MD5)
+		}
+		res = append(res, mpart)
+	}
+	return res, upload.userMD, nil
+}
+
+// returns the parts of an upload sorted by part number
+func (ups *mptUploads) listParts(id string, lom *cluster.LOM) ([]*s3compat.PartInfo, error) {
+	ups.RLock()
+	defer ups.RUnlock()
+	upload, err := ups.get(id, lom)
+	if err != nil {
+		return nil, err
+	}
+	parts := make([]*s3compat.PartInfo, 0, len(upload.parts))
+	for _, part := range upload.parts {
+		parts = append(parts, &s3compat.PartInfo{ETag: part.MD5, PartNumber: part.Num, Size: part.Size})
+	}
+	return parts, nil
+}
+
+// returns all in-progress uploads of a given bucket
+func (ups *mptUploads) listUploads(bckName string) *s3compat.ListMptUploadsResult {
+	result := &s3compat.ListMptUploadsResult{Bucket: bckName}
+	ups.RLock()
+	for id, upload := range ups.m {
+		if upload.bckName != bckName {
+			continue
+		}
+		result.Uploads = append(result.Uploads, &s3compat.UploadInfo{
+			Key:       upload.objName,
+			UploadID:  id,
+			Initiated: upload.ctime.Format(time.RFC3339),
+		})
+	}
+	ups.RUnlock()
+	return result
+}
+
+// removes the upload and all its parts
+func (ups *mptUploads) finish(id string, lom *cluster.LOM) error {
+	ups.Lock()
+	upload, err := ups.get(id, lom)
+	if err != nil {
+		ups.Unlock()
+		return err
+	}
+	delete(ups.m, id)
+	ups.Unlock()
+	for _, part := range upload.parts {
+		if err := cmn.RemoveFile(part.FQN); err != nil {
+			glog.Errorf("failed to remove part %s: %v", part.FQN, err)
+		}
+	}
+	return nil
+}
+
+// returns the upload ID if it is valid (see cmn.IsValidUUID)
+func (t *targetrunner) mptUploadID(w http.ResponseWriter, r *http.Request, q url.Values) (string, bool) {
+	uploadID := q.Get(s3compat.URLParamUploadID)
+	if !cmn.IsValidUUID(uploadID) {
+		t.invalmsghdlr(w, r, s3compat.NewErrNoSuchUpload(uploadID).Error(), http.StatusNotFound)
+		return "", false
+	}
+	return uploadID, true
+}
+
+func (t *targetrunner) mptUploadErr(w http.ResponseWriter, r *http.Request, err error) {
+	if s3compat.IsErrNoSuchUpload(err) {
+		t.invalmsghdlr(w, r, errLostUpload(err).Error(), http.StatusNotFound)
+	} else {
+		t.invalmsghdlr(w, r, err.Error())
+	}
+}
+
+func errLostUpload(err error) error {
+	return fmt.Errorf("%v (in-progress uploads do not survive target restart and cluster membership change)", err)
+}
+
+// POST s3/bckName/objName?uploads
+func (t *targetrunner) startMpt(w http.ResponseWriter, r *http.Request, items []string) {
+	lom := t.s3LOM(w, r, items)
+	if lom == nil {
+		return
+	}
+	s3compat.UserMDFromHeader(r.Header)
+	userMD, err := cmn.UserMDFromHTTPHdr(r.Header)
+	if err != nil {
+		t.invalmsghdlr(w, r, err.Error())
+		return
+	}
+	uploadID := cmn.GenUUID()
+	t.mpt.init(uploadID, lom, userMD)
+	result := &s3compat.InitiateMptUploadResult{Bucket: lom.BckName(), Key: lom.ObjName, UploadID: uploadID}
+	w.Header().Set(cmn.HeaderContentType, cmn.ContentXML)
+	w.Write(result.MustMarshal())
+	if glog.FastV(4, glog.SmoduleAIS) {
+		glog.Infof("%s: started multipart upload %q => %s", t.si, uploadID, lom)
+	}
+}
+
+// PUT s3/bckName/objName?partNumber=<n>&uploadId=<id>
+func (t *targetrunner) putObjMptPart(w http.ResponseWriter, r *http.Request, items []string, q url.Values) {
+	if cs := fs.GetCapStatus(); cs.OOS {
+		t.invalmsghdlr(w, r, cs.Err.Error())
+		return
+	}
+	partNum, err := s3compat.ParsePartNum(q.Get(s3compat.URLParamPartNum))
+	if err != nil {
+		t.invalmsghdlr(w, r, err.Error())
+		return
+	}
+	uploadID, ok := t.mptUploadID(w, r, q)
+	if !ok {
+		return
+	}
+	lom := t.s3LOM(w, r, items)
+	if lom == nil {
+		return
+	}
+	// fail early - prior to receiving the part
+	if err := t.mpt.exists(uploadID, lom); err != nil {
+		t.mptUploadErr(w, r, err)
+		return
+	}
+	var (
+		prefix  = uploadID + "." + strconv.FormatInt(partNum, 10)
+		partFQN = fs.CSM.GenContentParsedFQN(lom.ParsedFQN(), fs.MultipartType, prefix)
+		size    int64
+		buf     []byte
+		slab    *memsys.Slab
+	)
+	if sizeStr := r.Header.Get(cmn.HeaderContentLength); sizeStr != "" {
+		if size, err = strconv.ParseInt(sizeStr, 10, 64); err != nil {
+			size = 0
+		}
+	}
+	file, err := lom.CreateFile(partFQN)
+	if err != nil {
+		t.invalmsghdlr(w, r, err.Error())
+		return
+	}
+	if size == 0 {
+		buf, slab = t.gmm.Alloc()
+	} else {
+		buf, slab = t.gmm.Alloc(size)
+	}
+	cksum := cmn.NewCksumHash(cmn.ChecksumMD5)
+	written, err := io.CopyBuffer(cmn.NewWriterMulti(cksum.H, file), r.Body, buf)
+	slab.Free(buf)
+	cmn.Close(r.Body)
+	if err == nil {
+		err = file.Close()
+	} else {
+		cmn.Close(file)
+	}
+	if err == nil {
+		cksum.Finalize()
+		err = checkContentMD5(r.Header, cksum.Value())
+	}
+	if err == nil {
+		err = t.mpt.addPart(uploadID, lom, &s3compat.MptPart{
+			MD5:  cksum.Value(),
+			FQN:  partFQN,
+			Size: written,
+			Num:  partNum,
+		})
+	}
+	if err != nil {
+		if errRm := cmn.RemoveFile(partFQN); errRm != nil {
+			glog.Errorf("Nested (%v): failed to remove %s, err: %v", err, partFQN, errRm)
+		}
+		t.mptUploadErr(w, r, err)
+		return
+	}
+	s3compat.SetPartETag(w.Header(), cksum.Value())
+}
+
+// S3 clients may provide base64-encoded MD5 of the content (Content-MD5)
+func checkContentMD5(header http.Header, md5hex string) error {
+	b64 := header.Get(s3compat.HeaderContentMD5)
+	if b64 == "" {
+		return nil
+	}
+	expected, err := base64.StdEncoding.DecodeString(b64)
+	if err != nil {
+		return fmt.Errorf("invalid %s header %q: %v", s3compat.HeaderContentMD5, b64, err)
+	}
+	if hex.EncodeToString(expected) != md5hex {
+		return fmt.Errorf("%s mismatch: %s != %s", s3compat.HeaderContentMD5, hex.EncodeToString(expected), md5hex)
+	}
+	return nil
+}
+
+// POST s3/bckName/objName?uploadId=<id>
+// Assemble the object out of the uploaded parts and PUT it as usual (including
+// checksumming, versioning, mirroring, and EC).
+func (t *targetrunner) completeMpt(w http.ResponseWriter, r *http.Request, items []string, q url.Values) {
+	started := time.Now()
+	uploadID, ok := t.mptUploadID(w, r, q)
+	if !ok {
+		return
+	}
+	lom := t.s3LOM(w, r, items)
+	if lom == nil {
+		return
+	}
+	req := &s3compat.CompleteMptUpload{}
+	err := xml.NewDecoder(r.Body).Decode(req)
+	cmn.Close(r.Body)
+	if err != nil {
+		t.invalmsghdlr(w, r, err.Error())
+		return
+	}
+	parts, userMD, err := t.mpt.checkParts(uploadID, lom, req.Parts)
+	if err != nil {
+		t.mptUploadErr(w, r, err)
+		return
+	}
+	files, err := s3compat.OpenParts(parts)
+	if err != nil {
+		t.invalmsghdlr(w, r, err.Error())
+		return
+	}
+	readers := make([]io.Reader, 0, len(files))
+	for _, f := range files {
+		readers = append(readers, f)
+	}
+	if lom.Bck().IsAIS() && lom.VersionConf().Enabled {
+		lom.Load() // need to know the current version if versioning enabled
+	}
+	lom.SetAtimeUnix(started.UnixNano())
+	lom.SetUserMD(userMD)
+	poi := &putObjInfo{
+		started: started,
+		t:       t,
+		lom:     lom,
+		r:       ioutil.NopCloser(io.MultiReader(readers...)),
+		size:    s3compat.PartsSize(parts),
+		ctx:     context.Background(),
+		workFQN: fs.CSM.GenContentParsedFQN(lom.ParsedFQN(), fs.WorkfileType, fs.WorkfilePut),
+	}
+	errCode, err := poi.putObject()
+	for _, f := range files {
+		cmn.Close(f)
+	}
+	if err != nil {
+		t.fsErr(err, lom.FQN)
+		t.invalmsghdlr(w, r, err.Error(), errCode)
+		return
+	}
+	if err := t.mpt.finish(uploadID, lom); err != nil {
+		glog.Warningf("%s: %v", t.si, err) // aborted concurrently
+	}
+
+	result := &s3compat.CompleteMptUploadResult{Bucket: lom.BckName(), Key: lom.ObjName, ETag: s3compat.MptETag(parts)}
+	w.Header().Set(cmn.HeaderContentType, cmn.ContentXML)
+	w.Write(result.MustMarshal())
+	if glog.FastV(4, glog.SmoduleAIS) {
+		glog.Infof("%s: completed multipart upload %q (%d parts) => %s", t.si, uploadID, len(parts), lom)
+	}
+}
+
+// DELETE s3/bckName/objName?uploadId=<id>
+func (t *targetrunner) abortMpt(w http.ResponseWriter, r *http.Request, items []string, q url.Values) {
+	uploadID, ok := t.mptUploadID(w, r, q)
+	if !ok {
+		return
+	}
+	lom := t.s3LOM(w, r, items)
+	if lom == nil {
+		return
+	}
+	if err := t.mpt.finish(uploadID, lom); err != nil {
+		t.mptUploadErr(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GET s3/bckName/objName?uploadId=<id>
+func (t *targetrunner) listMptParts(w http.ResponseWriter, r *http.Request, items []string, q url.Values) {
+	uploadID, ok := t.mptUploadID(w, r, q)
+	if !ok {
+		return
+	}
+	lom := t.s3LOM(w, r, items)
+	if lom == nil {
+		return
+	}
+	parts, err := t.mpt.listParts(uploadID, lom)
+	if err != nil {
+		t.mptUploadErr(w, r, err)
+		return
+	}
+	result := &s3compat.ListPartsResult{Bucket: lom.BckName(), Key: lom.ObjName, UploadID: uploadID, Parts: parts}
+	w.Header().Set(cmn.HeaderContentType, cmn.ContentXML)
+	w.Write(result.MustMarshal())
+}
+
+// GET s3/bckName?uploads
+// NOTE: returns JSON - the proxy merges the results and replies with XML
+func (t *targetrunner) listMptUploads(w http.ResponseWriter, r *http.Request, bckName string) {
+	result := t.mpt.listUploads(bckName)
+	t.writeJSON(w, r, result, "list-mpt-uploads")
+}
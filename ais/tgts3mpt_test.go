@@ -0,0 +1,48 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/NVIDIA/aistore/ais/s3compat"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/fs"
+)
+
+// The part of the upload that does not exist (or has invalid ID) must be
+// rejected without writing anything to the disk.
+func TestMptPartUploadID(tt *testing.T) {
+	cmn.InitShortID(0)
+	for _, uploadID := range []string{"", "../../../../tmp/mpt-escape", "Abcdefghi/../x", cmn.GenUUID()} {
+		var (
+			q = url.Values{
+				s3compat.URLParamUploadID: []string{uploadID},
+				s3compat.URLParamPartNum:  []string{"1"},
+			}
+			r = httptest.NewRequest(http.MethodPut, "/s3/"+testBucket+"/obj?"+q.Encode(), strings.NewReader("part"))
+			w = httptest.NewRecorder()
+		)
+		t.putObjMptPart(w, r, []string{testBucket, "obj"}, q)
+		if w.Code != http.StatusNotFound {
+			tt.Errorf("upload %q: expected status %d, got %d", uploadID, http.StatusNotFound, w.Code)
+		}
+	}
+	if _, err := os.Stat("/tmp/mpt-escape.1"); !os.IsNotExist(err) {
+		tt.Fatalf("expected no part outside the mountpath, err: %v", err)
+	}
+	filepath.Walk(testMountpath, func(path string, fi os.FileInfo, err error) error {
+		if err == nil && !fi.IsDir() && strings.Contains(path, "/%"+fs.MultipartType+"/") {
+			tt.Errorf("unexpected part %s", path)
+		}
+		return nil
+	})
+}
@@ -305,6 +305,15 @@ func (t *targetrunner) setBucketProps(c *txnServerCtx) error {
 				return err
 			}
 
+			c.addNotif(xact) // ditto
+			go xact.Run()
+		} else if reLayoutEC(txnSetBprops.bprops, txnSetBprops.nprops) {
+			xreg.DoAbort(cmn.ActECRelayout, c.bck)
+			xact, err := xreg.RenewECRelayout(t, c.bck, c.uuid)
+			if err != nil {
+				return err
+			}
+
 			c.addNotif(xact) // ditto
 			go xact.Run()
 		}
@@ -334,7 +343,7 @@ func (t *targetrunner) validateNprops(bck *cluster.Bck, msg *aisMsg) (nprops *cm
 			return nprops, cs.Err
 		}
 	}
-	if nprops.EC.Enabled && !bck.Props.EC.Enabled {
+	if (nprops.EC.Enabled && !bck.Props.EC.Enabled) || reLayoutEC(bck.Props, nprops) {
 		err = cs.Err
 	}
 	return
@@ -567,7 +576,7 @@ func (t *targetrunner) validateTransferBckTxn(bckFrom *cluster.Bck, action strin
 // etlBucket uses transferBucket xaction to transform the whole bucket. The only difference is that instead of copying the
 // same bytes, it creates a reader based on given ETL transformation.
 func (t *targetrunner) etlBucket(c *txnServerCtx, msg *cmn.Bck2BckMsg) (err error) {
-	if err := k8s.Detect(); err != nil {
+	if err := k8s.Detect(); err != nil && !cmn.GCO.Get().ETL.AllowProcess {
 		return err
 	}
 	if msg.ID == "" {
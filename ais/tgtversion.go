@@ -0,0 +1,147 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+)
+
+//
+// object versioning: GET, HEAD, and DELETE a given version of the object
+// (see also cluster/lom_version.go)
+//
+
+type verHdrCb func(hdr http.Header, vlom *cluster.LOM)
+
+func nativeVerHdr(hdr http.Header, vlom *cluster.LOM) {
+	vlom.ToHTTPHdr(hdr)
+	hdr.Set(cmn.HeaderContentLength, strconv.FormatInt(vlom.Size(), 10))
+}
+
+// GET (or HEAD) a given version of the object - current or prior one.
+// NOTE: range reads are not supported.
+func (t *targetrunner) getObjVersion(w http.ResponseWriter, r *http.Request, lom *cluster.LOM, version string,
+	head bool, hdrCb ...verHdrCb) {
+	var (
+		vlom  *cluster.LOM
+		err   error
+		setHd = nativeVerHdr
+	)
+	if len(hdrCb) > 0 {
+		setHd = hdrCb[0]
+	}
+	if !lom.Bck().IsAIS() {
+		t.invalmsghdlrf(w, r, "%s: versions of the objects are kept only in ais buckets", lom)
+		return
+	}
+	lom.Lock(false)
+	defer lom.Unlock(false)
+	if err = lom.Load(); err == nil && lom.Version() == version {
+		vlom = lom
+	} else if vlom, err = lom.LoadVersion(version); err != nil {
+		if cmn.IsObjNotExist(err) {
+			t.invalmsghdlrsilent(w, r, err.Error(), http.StatusNotFound)
+		} else {
+			t.invalmsghdlr(w, r, err.Error())
+		}
+		return
+	}
+	if vlom.IsDelMarker() {
+		t.invalmsghdlrstatusf(w, r, http.StatusMethodNotAllowed, "%s: version %q is a delete marker", lom, version)
+		return
+	}
+	setHd(w.Header(), vlom)
+	if head {
+		return
+	}
+	file, err := os.Open(vlom.FQN)
+	if err != nil {
+		t.fsErr(err, vlom.FQN)
+		t.invalmsghdlr(w, r, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	buf, slab := t.gmm.Alloc(vlom.Size())
+	_, err = io.CopyBuffer(w, file, buf)
+	slab.Free(buf)
+	cmn.Close(file)
+	if err != nil {
+		glog.Errorf("GET %s version %q: %v", lom, version, err)
+	}
+}
+
+// DELETE a given version of the object: current one (with no delete marker
+// created) or prior one (including delete markers).
+func (t *targetrunner) delObjVersion(w http.ResponseWriter, r *http.Request, lom *cluster.LOM, version string) {
+	if !lom.Bck().IsAIS() {
+		t.invalmsghdlrf(w, r, "%s: versions of the objects are kept only in ais buckets", lom)
+		return
+	}
+	lom.Lock(true)
+	defer lom.Unlock(true)
+	if err := lom.Load(false); err == nil && lom.Version() == version {
+		if err = t.delCurVersion(lom); err != nil {
+			t.invalmsghdlr(w, r, err.Error())
+		}
+		return
+	}
+	if err := lom.DelVersion(version); err != nil {
+		if cmn.IsObjNotExist(err) {
+			t.invalmsghdlrsilent(w, r, err.Error(), http.StatusNotFound)
+		} else {
+			t.invalmsghdlr(w, r, err.Error())
+		}
+	}
+}
+
+// delete the current version and make the newest prior version current
+// NOTE: uname for LOM must be already locked.
+func (t *targetrunner) delCurVersion(lom *cluster.LOM) (err error) {
+	if err = lom.Remove(); err != nil {
+		return
+	}
+	promoted, err := lom.PromoteVersion()
+	if err != nil || !promoted {
+		return
+	}
+	lomCur := &cluster.LOM{ObjName: lom.ObjName}
+	if err = lomCur.Init(lom.Bck().Bck); err != nil {
+		return
+	}
+	if err = lomCur.Load(false); err != nil {
+		return
+	}
+	lomCur.ReCache()
+	t.putMirror(lomCur)
+	return
+}
+
+// delete the object while keeping its content as prior version, and
+// leaving behind a delete marker
+// NOTE: uname for LOM must be already locked.
+func (t *targetrunner) delObjKeepVersions(lom *cluster.LOM) (err error) {
+	var version string
+	if lom.HasCopies() {
+		if err = lom.DelAllCopies(); err != nil {
+			return
+		}
+	}
+	if version, err = lom.ArchiveVersion(); err != nil {
+		return
+	}
+	if err = lom.PutDelMarker(version); err != nil {
+		return
+	}
+	if _, _, err := lom.TrimVersions(); err != nil {
+		glog.Errorf("%s: failed to trim prior versions: %v", lom, err)
+	}
+	return
+}
@@ -142,6 +142,11 @@ func (t *targetrunner) cmdXactStart(xactMsg *xaction.XactReqMsg, bck *cluster.Bc
 			glog.Errorf(erfmb, xactMsg.Kind, bck)
 		}
 		go t.RunLRU(xactMsg.ID, xactMsg.Force != nil && *xactMsg.Force, xactMsg.Buckets...)
+	case cmn.ActScrub:
+		if bck != nil {
+			glog.Errorf(erfmb, xactMsg.Kind, bck)
+		}
+		go t.runScrub(xactMsg.ID)
 	case cmn.ActResilver:
 		if bck != nil {
 			glog.Errorf(erfmb, xactMsg.Kind, bck)
@@ -173,10 +178,29 @@ func (t *targetrunner) cmdXactStart(xactMsg *xaction.XactReqMsg, bck *cluster.Bc
 		go xact.Run()
 	case cmn.ActLoadLomCache:
 		return xreg.RenewBckLoadLomCache(t, xactMsg.ID, bck)
+	case cmn.ActWriteBack:
+		if bck.Props.WritePolicy == cmn.WritePolicyNever {
+			return fmt.Errorf("cannot start %q: bucket %s has %q write policy", xactMsg, bck, cmn.WritePolicyNever)
+		}
+		xreg.RenewWriteBack(t, bck, xactMsg.ID, true /*scan*/)
+	case cmn.ActECScrub:
+		xact, err := xreg.RenewECScrub(t, bck, xactMsg.ID)
+		if err != nil {
+			return err
+		}
+		xact.AddNotif(&xaction.NotifXact{
+			NotifBase: nl.NotifBase{
+				When: cluster.UponTerm,
+				Dsts: []string{equalIC},
+				F:    t.callerNotifyFin,
+			},
+			Xact: xact,
+		})
+		go xact.Run()
 	// 3. cannot start
 	case cmn.ActPutCopies:
 		return fmt.Errorf("cannot start %q (is driven by PUTs into a mirrored bucket)", xactMsg)
-	case cmn.ActDownload, cmn.ActEvictObjects, cmn.ActDelete, cmn.ActMakeNCopies, cmn.ActECEncode:
+	case cmn.ActDownload, cmn.ActEvictObjects, cmn.ActDelete, cmn.ActMakeNCopies, cmn.ActECEncode, cmn.ActECRelayout:
 		return fmt.Errorf("initiating %q must be done via a separate documented API", xactMsg)
 	// 4. unknown
 	case "":
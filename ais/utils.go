@@ -202,11 +202,17 @@ func reEC(bprops, nprops *cmn.BucketProps, bck *cluster.Bck) bool {
 		}
 		return false
 	}
-	if !bprops.EC.Enabled {
-		return true
+	return !bprops.EC.Enabled
+}
+
+// EC layout of the already erasure coded bucket changes - re-encode
+func reLayoutEC(bprops, nprops *cmn.BucketProps) bool {
+	if !bprops.EC.Enabled || !nprops.EC.Enabled {
+		return false
 	}
 	return bprops.EC.DataSlices != nprops.EC.DataSlices ||
-		bprops.EC.ParitySlices != nprops.EC.ParitySlices
+		bprops.EC.ParitySlices != nprops.EC.ParitySlices ||
+		bprops.EC.ObjSizeLimit != nprops.EC.ObjSizeLimit
 }
 
 func withRetry(cond func() bool) (ok bool) {
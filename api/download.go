@@ -114,6 +114,22 @@ func DownloadGetList(baseParams BaseParams, regex string) (dlList downloader.DlJ
 	return dlList, err
 }
 
+// DownloadGetSchedules returns the scheduled (recurring) downloads.
+func DownloadGetSchedules(baseParams BaseParams, regex string) (schedules downloader.DlScheduleInfos, err error) {
+	dlBody := downloader.DlAdminBody{
+		Regex:     regex,
+		Schedules: true,
+	}
+	baseParams.Method = http.MethodGet
+	err = DoHTTPRequest(ReqParams{
+		BaseParams: baseParams,
+		Path:       cmn.JoinWords(cmn.Version, cmn.Download),
+		Body:       cmn.MustMarshal(dlBody),
+	}, &schedules)
+	sort.Sort(schedules)
+	return schedules, err
+}
+
 func AbortDownload(baseParams BaseParams, id string) error {
 	dlBody := downloader.DlAdminBody{
 		ID: id,
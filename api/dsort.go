@@ -23,6 +23,16 @@ func StartDSort(baseParams BaseParams, rs dsort.RequestSpec) (string, error) {
 	return id, err
 }
 
+// ResumeDSort resumes aborted (resumable) dSort job, eg. after a target restart.
+func ResumeDSort(baseParams BaseParams, managerUUID string) error {
+	baseParams.Method = http.MethodPost
+	return DoHTTPRequest(ReqParams{
+		BaseParams: baseParams,
+		Path:       cmn.JoinWords(cmn.Version, cmn.Sort, cmn.Resume),
+		Query:      url.Values{cmn.URLParamUUID: []string{managerUUID}},
+	})
+}
+
 func AbortDSort(baseParams BaseParams, managerUUID string) error {
 	baseParams.Method = http.MethodDelete
 	return DoHTTPRequest(ReqParams{
@@ -33,6 +33,18 @@ func ETLBuild(baseParams BaseParams, msg etl.BuildMsg) (id string, err error) {
 	return id, err
 }
 
+// ETLPipeline defines the pipeline of the initialized ETLs, returns ID of the
+// pipeline which can be used in place of any ETL ID.
+func ETLPipeline(baseParams BaseParams, stages []string) (id string, err error) {
+	baseParams.Method = http.MethodPost
+	err = DoHTTPRequest(ReqParams{
+		BaseParams: baseParams,
+		Path:       cmn.JoinWords(cmn.Version, cmn.ETL, cmn.ETLPipe),
+		Body:       cmn.MustMarshal(etl.PipelineMsg{Stages: stages}),
+	}, &id)
+	return id, err
+}
+
 func ETLList(baseParams BaseParams) (list []etl.Info, err error) {
 	baseParams.Method = http.MethodGet
 	err = DoHTTPRequest(ReqParams{
@@ -49,7 +49,10 @@ type PutObjectArgs struct {
 	Object     string
 	Cksum      *cmn.Cksum
 	Reader     cmn.ReadOpenCloser
-	Size       uint64 // optional
+	Size       uint64        // optional
+	UserMD     cmn.SimpleKVs // optional user-defined metadata (replaces existing one, if any)
+	Tags       cmn.SimpleKVs // optional object tags (replace existing ones, if any)
+	CreateOnly bool          // optional: fail with 412 if the object already exists
 }
 
 type PromoteArgs struct {
@@ -119,6 +122,12 @@ func HeadObject(baseParams BaseParams, bck cmn.Bck, object string, checkExists .
 	if err != nil {
 		return nil, err
 	}
+	if objProps.UserMD, err = cmn.UserMDFromHTTPHdr(resp.Header); err != nil {
+		return nil, err
+	}
+	if objProps.Tags, err = cmn.TagsFromHTTPHdr(resp.Header); err != nil {
+		return nil, err
+	}
 	return objProps, nil
 }
 
@@ -132,6 +141,20 @@ func DeleteObject(baseParams BaseParams, bck cmn.Bck, object string) error {
 	})
 }
 
+// DeleteObjectVersion deletes a given version of the object: current or prior
+// one (including delete markers). Applies to ais buckets that keep prior versions
+// (see `versioning.max_versions`).
+func DeleteObjectVersion(baseParams BaseParams, bck cmn.Bck, object, version string) error {
+	baseParams.Method = http.MethodDelete
+	query := url.Values{cmn.URLParamObjVersion: []string{version}}
+	query = cmn.AddBckToQuery(query, bck)
+	return DoHTTPRequest(ReqParams{
+		BaseParams: baseParams,
+		Path:       cmn.JoinWords(cmn.Version, cmn.Objects, bck.Name, object),
+		Query:      query,
+	})
+}
+
 // EvictObject evicts an object specified by bucket/object.
 func EvictObject(baseParams BaseParams, bck cmn.Bck, object string) error {
 	baseParams.Method = http.MethodDelete
@@ -303,6 +326,15 @@ func PutObject(args PutObjectArgs) (err error) {
 		if args.Size != 0 {
 			req.ContentLength = int64(args.Size) // as per https://tools.ietf.org/html/rfc7230#section-3.3.2
 		}
+		for k, v := range args.UserMD {
+			req.Header.Add(cmn.HeaderObjUserMD, k+"="+v)
+		}
+		for k, v := range args.Tags {
+			req.Header.Add(cmn.HeaderObjTags, k+"="+v)
+		}
+		if args.CreateOnly {
+			req.Header.Set(cmn.HeaderIfNoneMatch, cmn.ETagAny)
+		}
 
 		setAuthToken(req, args.BaseParams)
 		return req, nil
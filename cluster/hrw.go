@@ -7,13 +7,38 @@ package cluster
 import (
 	"errors"
 	"fmt"
+	"sort"
+	"unsafe"
 
+	"github.com/NVIDIA/aistore/3rdparty/atomic"
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/fs"
 	"github.com/NVIDIA/aistore/xoshiro256"
 	"github.com/OneOfOne/xxhash"
 )
 
+// failure domain levels (see `domainGroups.spread`)
+const (
+	domainZone = iota
+	domainRack
+	domainHost
+	domainAny
+)
+
+// Targets grouped by failure domains - computed once per Smap version (see
+// `getDomainGroups`) since it does not depend on the object.
+type domainGroups struct {
+	smap    *Smap
+	version int64
+	tcnt    int
+	targets Nodes            // all targets, none if not labeled with domains
+	groups  [domainAny][]int // level => target's index => index of its domain
+	ngroups [domainAny]int   // level => number of domains
+}
+
+// the groups of the most recently used Smap
+var lastDomainGroups atomic.Pointer
+
 // A variant of consistent hash based on rendezvous algorithm by Thaler and Ravishankar,
 // aka highest random weight (HRW)
 
@@ -86,6 +111,10 @@ func (hl *hrwList) get() Nodes {
 // returns resulting subset (aka slice) that has the requested length = count.
 // Returns error if the cluster does not have enough targets.
 // If count == length of Smap.Tmap, the function returns as many targets as possible.
+//
+// When targets are labeled with failure domains (see `Snode.Domain`), the list
+// is spread across domains (see `domainGroups.spread`) while the first target
+// in the list remains the one with the highest HRW (as per `HrwTarget`).
 func HrwTargetList(uname string, smap *Smap, count int) (sis Nodes, err error) {
 	cnt := smap.CountTargets()
 	if cnt < count {
@@ -93,16 +122,19 @@ func HrwTargetList(uname string, smap *Smap, count int) (sis Nodes, err error) {
 		return
 	}
 	digest := xxhash.ChecksumString64S(uname, cmn.MLCG32)
-	hlist := newHrwList(count)
-
-	for _, tsi := range smap.Tmap {
-		cs := xoshiro256.Hash(tsi.idDigest ^ digest)
-		if tsi.inMaintenance() {
-			continue
+	if dg := getDomainGroups(smap); len(dg.targets) > 0 {
+		sis = dg.spread(digest, count)
+	} else {
+		hlist := newHrwList(count)
+		for _, tsi := range smap.Tmap {
+			cs := xoshiro256.Hash(tsi.idDigest ^ digest)
+			if tsi.inMaintenance() {
+				continue
+			}
+			hlist.add(cs, tsi)
 		}
-		hlist.add(cs, tsi)
+		sis = hlist.get()
 	}
-	sis = hlist.get()
 	if count != cnt && len(sis) < count {
 		err = fmt.Errorf("insufficient targets: required %d, available %d, %s", count, len(sis), smap)
 		return nil, err
@@ -110,6 +142,111 @@ func HrwTargetList(uname string, smap *Smap, count int) (sis Nodes, err error) {
 	return sis, nil
 }
 
+// getDomainGroups returns the targets of the Smap grouped by failure domains -
+// cached for the (most recent) Smap version.
+func getDomainGroups(smap *Smap) *domainGroups {
+	if p := lastDomainGroups.Load(); p != nil {
+		dg := (*domainGroups)(p)
+		if dg.smap == smap && dg.version == smap.Version && dg.tcnt == len(smap.Tmap) {
+			return dg
+		}
+	}
+	dg := &domainGroups{smap: smap, version: smap.Version, tcnt: len(smap.Tmap)}
+	if smap.hasDomains() {
+		dg.targets = make(Nodes, 0, len(smap.Tmap))
+		for _, tsi := range smap.Tmap {
+			dg.targets = append(dg.targets, tsi)
+		}
+		for level := domainZone; level < domainAny; level++ {
+			var (
+				idx  = make(map[Domain]int, len(dg.targets))
+				grps = make([]int, len(dg.targets))
+			)
+			for i, tsi := range dg.targets {
+				dom := tsi.Domain.upTo(level)
+				g, ok := idx[dom]
+				if !ok {
+					g = len(idx)
+					idx[dom] = g
+				}
+				grps[i] = g
+			}
+			dg.groups[level], dg.ngroups[level] = grps, len(idx)
+		}
+	}
+	lastDomainGroups.Store(unsafe.Pointer(dg))
+	return dg
+}
+
+// spread selects up to `count` targets (in the order of their HRW weights) so
+// that the selected targets share a failure domain only if there are no
+// targets left in the other domains. Domains are tried from the widest to the
+// narrowest: first the zones, then the racks (within a zone), then the hosts;
+// the remaining targets are taken in the order of their weights.
+// At each level, the target with the highest weight is taken from each domain
+// not selected yet - without sorting all the targets.
+//
+// NOTE: the result for a given `count` is always a prefix of the result for a
+// bigger one - rebalance relies on it when it computes the full list.
+func (dg *domainGroups) spread(digest uint64, count int) Nodes {
+	var (
+		weights = make([]uint64, len(dg.targets))
+		used    = make([]bool, len(dg.targets))
+		active  int
+	)
+	for i, tsi := range dg.targets {
+		if tsi.inMaintenance() {
+			continue
+		}
+		weights[i] = xoshiro256.Hash(tsi.idDigest ^ digest)
+		active++
+	}
+	sis := make(Nodes, 0, cmn.Min(count, active))
+	for level := domainZone; level < domainAny && len(sis) < cap(sis); level++ {
+		var (
+			grps  = dg.groups[level]
+			best  = make([]int, dg.ngroups[level])
+			cands = make([]int, 0, dg.ngroups[level])
+		)
+		for g := range best {
+			best[g] = -1
+		}
+		for i, tsi := range dg.targets {
+			g := grps[i]
+			switch {
+			case best[g] == -2 || tsi.inMaintenance():
+			case used[i]:
+				best[g] = -2 // already selected from this domain
+			case best[g] == -1 || weights[i] > weights[best[g]]:
+				best[g] = i
+			}
+		}
+		for _, i := range best {
+			if i >= 0 {
+				cands = append(cands, i)
+			}
+		}
+		sort.Slice(cands, func(a, b int) bool { return weights[cands[a]] > weights[cands[b]] })
+		for _, i := range cands {
+			if len(sis) == cap(sis) {
+				break
+			}
+			used[i] = true
+			sis = append(sis, dg.targets[i])
+		}
+	}
+	if rest := cap(sis) - len(sis); rest > 0 {
+		hlist := newHrwList(rest)
+		for i, tsi := range dg.targets {
+			if !used[i] && !tsi.inMaintenance() {
+				hlist.add(weights[i], tsi)
+			}
+		}
+		sis = append(sis, hlist.get()...)
+	}
+	return sis
+}
+
 func HrwProxy(smap *Smap, idToSkip string) (pi *Snode, err error) {
 	var max uint64
 	for pid, psi := range smap.Pmap {
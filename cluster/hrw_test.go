@@ -0,0 +1,123 @@
+// Package cluster provides common interfaces and local access to cluster-level metadata
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package cluster
+
+import (
+	"fmt"
+
+	"github.com/NVIDIA/aistore/cmn"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("HRW", func() {
+	const objCnt = 200
+
+	newSmap := func(domains ...Domain) *Smap {
+		smap := &Smap{Tmap: make(NodeMap, len(domains)), Pmap: make(NodeMap)}
+		for idx, dom := range domains {
+			si := &Snode{DaemonID: fmt.Sprintf("t%d", idx), DaemonType: cmn.Target, Domain: dom}
+			si.Digest()
+			smap.Tmap[si.ID()] = si
+		}
+		return smap
+	}
+
+	uname := func(idx int) string { return fmt.Sprintf("bck/obj-%d", idx) }
+
+	countDomains := func(sis Nodes, level int) int {
+		doms := make(map[Domain]struct{}, len(sis))
+		for _, si := range sis {
+			doms[si.Domain.upTo(level)] = struct{}{}
+		}
+		return len(doms)
+	}
+
+	checkConsistent := func(smap *Smap, count int) {
+		for i := 0; i < objCnt; i++ {
+			main, err := HrwTarget(uname(i), smap)
+			Expect(err).NotTo(HaveOccurred())
+			all, err := HrwTargetList(uname(i), smap, smap.CountTargets())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(all).To(HaveLen(smap.CountTargets()))
+			sis, err := HrwTargetList(uname(i), smap, count)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(sis).To(Equal(all[:count]))
+			Expect(sis[0]).To(Equal(main))
+		}
+	}
+
+	It("should select targets by weight when there are no domains", func() {
+		smap := newSmap(make([]Domain, 6)...)
+		checkConsistent(smap, 3)
+	})
+
+	It("should spread targets across zones", func() {
+		smap := newSmap(
+			Domain{Zone: "z1"}, Domain{Zone: "z1"}, Domain{Zone: "z1"},
+			Domain{Zone: "z2"}, Domain{Zone: "z2"},
+			Domain{Zone: "z3"},
+		)
+		checkConsistent(smap, 3)
+		for i := 0; i < objCnt; i++ {
+			sis, err := HrwTargetList(uname(i), smap, 3)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(countDomains(sis, domainZone)).To(Equal(3))
+
+			sis, err = HrwTargetList(uname(i), smap, 5)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(countDomains(sis[:3], domainZone)).To(Equal(3))
+		}
+	})
+
+	It("should spread targets across racks and hosts", func() {
+		var domains []Domain
+		for _, rack := range []string{"r1", "r2"} {
+			for _, host := range []string{"h1", "h2"} {
+				// two targets per host
+				dom := Domain{Zone: "z1", Rack: rack, Host: host}
+				domains = append(domains, dom, dom)
+			}
+		}
+		smap := newSmap(domains...)
+		checkConsistent(smap, 4)
+		for i := 0; i < objCnt; i++ {
+			sis, err := HrwTargetList(uname(i), smap, 4)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(countDomains(sis[:2], domainRack)).To(Equal(2))
+			Expect(countDomains(sis, domainHost)).To(Equal(4))
+		}
+	})
+
+	It("should regroup targets when Smap version changes", func() {
+		smap := newSmap(make([]Domain, 4)...)
+		for i := 0; i < objCnt; i++ {
+			_, err := HrwTargetList(uname(i), smap, 2)
+			Expect(err).NotTo(HaveOccurred())
+		}
+		// relabel (new version of the same Smap)
+		smap.Tmap["t0"].Domain, smap.Tmap["t1"].Domain = Domain{Zone: "z1"}, Domain{Zone: "z1"}
+		smap.Tmap["t2"].Domain, smap.Tmap["t3"].Domain = Domain{Zone: "z2"}, Domain{Zone: "z2"}
+		smap.Version++
+		checkConsistent(smap, 2)
+		for i := 0; i < objCnt; i++ {
+			sis, err := HrwTargetList(uname(i), smap, 2)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(countDomains(sis, domainZone)).To(Equal(2))
+		}
+	})
+
+	It("should skip targets in maintenance", func() {
+		smap := newSmap(Domain{Zone: "z1"}, Domain{Zone: "z2"}, Domain{Zone: "z2"})
+		smap.Tmap["t0"].Flags = smap.Tmap["t0"].Flags.Set(SnodeMaintenance)
+		for i := 0; i < objCnt; i++ {
+			sis, err := HrwTargetList(uname(i), smap, 2)
+			Expect(err).NotTo(HaveOccurred())
+			for _, si := range sis {
+				Expect(si.ID()).NotTo(Equal("t0"))
+			}
+		}
+	})
+})
@@ -49,6 +49,11 @@ type (
 		cksum    *cmn.Cksum // ReCache(ref)
 		copies   fs.MPI     // ditto
 		customMD cmn.SimpleKVs
+		userMD   cmn.SimpleKVs // user-defined metadata
+		tags     cmn.SimpleKVs // object tags
+		// number of times the object was read (see cmn.LRUPolicyLFU), as of
+		// the last flush of the pending counts (see lom_cache_hk.go)
+		accessCnt int64
 	}
 	LOM struct {
 		md        lmeta             // local meta
@@ -104,8 +109,13 @@ func (lom *LOM) SetCksum(cksum *cmn.Cksum)    { lom.md.cksum = cksum }
 func (lom *LOM) Atime() time.Time             { return time.Unix(0, lom.md.atime) }
 func (lom *LOM) AtimeUnix() int64             { return lom.md.atime }
 func (lom *LOM) SetAtimeUnix(tu int64)        { lom.md.atime = tu }
+func (lom *LOM) AccessCnt() int64             { return lom.md.accessCnt + pendingAccess.get(lom.Uname()) }
 func (lom *LOM) SetCustomMD(md cmn.SimpleKVs) { lom.md.customMD = md }
 func (lom *LOM) CustomMD() cmn.SimpleKVs      { return lom.md.customMD }
+func (lom *LOM) SetUserMD(md cmn.SimpleKVs)   { lom.md.userMD = md }
+func (lom *LOM) UserMD() cmn.SimpleKVs        { return lom.md.userMD }
+func (lom *LOM) SetTags(tags cmn.SimpleKVs)   { lom.md.tags = tags }
+func (lom *LOM) Tags() cmn.SimpleKVs          { return lom.md.tags }
 func (lom *LOM) GetCustomMD(key string) (string, bool) {
 	value, exists := lom.md.customMD[key]
 	return value, exists
@@ -117,6 +127,12 @@ func (lom *LOM) BckName() string          { return lom.bck.Name }
 func (lom *LOM) Bprops() *cmn.BucketProps { return lom.bck.Props }
 func (lom *LOM) GetFQN() string           { return lom.FQN }
 
+// write-back: the object is yet to be written to the Cloud (see DirtyObjMD)
+func (lom *LOM) IsDirty() bool {
+	_, ok := lom.md.customMD[DirtyObjMD]
+	return ok
+}
+
 func (lom *LOM) ParsedFQN() fs.ParsedFQN {
 	return fs.ParsedFQN{
 		Digest:      lom.Digest,
@@ -130,6 +146,7 @@ func (lom *LOM) ParsedFQN() fs.ParsedFQN {
 func (lom *LOM) MirrorConf() *cmn.MirrorConf  { return &lom.Bprops().Mirror }
 func (lom *LOM) CksumConf() *cmn.CksumConf    { return lom.bck.CksumConf() }
 func (lom *LOM) VersionConf() cmn.VersionConf { return lom.bck.VersionConf() }
+func (lom *LOM) WritePolicy() string          { return lom.Bprops().WritePolicy }
 
 func (lom *LOM) CopyMetadata(from *LOM) {
 	lom.md.copies = nil
@@ -145,6 +162,9 @@ func (lom *LOM) CopyMetadata(from *LOM) {
 	lom.md.size = from.md.size
 	lom.md.version = from.md.version
 	lom.md.atime = from.md.atime
+	lom.md.accessCnt = from.md.accessCnt
+	lom.md.userMD = from.md.userMD
+	lom.md.tags = from.md.tags
 }
 
 func (lom *LOM) CloneCopiesMd() int {
@@ -184,6 +204,12 @@ func (lom *LOM) FromHTTPHdr(hdr http.Header) {
 		}
 		lom.SetCustomMD(md)
 	}
+	if md, err := cmn.UserMDFromHTTPHdr(hdr); err == nil && len(md) > 0 {
+		lom.SetUserMD(md)
+	}
+	if tags, err := cmn.TagsFromHTTPHdr(hdr); err == nil && len(tags) > 0 {
+		lom.SetTags(tags)
+	}
 }
 
 ////////////////////////////
@@ -330,6 +356,15 @@ func (lom *LOM) DelExtraCopies(fqn ...string) (removed bool, err error) {
 	return
 }
 
+// PersistWithCopies persists metadata of the object and all its copies.
+// NOTE: uname for LOM must be already locked.
+func (lom *LOM) PersistWithCopies() (err error) {
+	if err = lom.Persist(); err != nil {
+		return
+	}
+	return lom.syncMetaWithCopies()
+}
+
 // syncMetaWithCopies tries to make sure that all copies have identical metadata.
 // NOTE: uname for LOM must be already locked.
 func (lom *LOM) syncMetaWithCopies() (err error) {
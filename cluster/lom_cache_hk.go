@@ -6,10 +6,12 @@ package cluster
 
 import (
 	"os"
+	"sync"
 	"time"
 
 	"github.com/NVIDIA/aistore/3rdparty/atomic"
 	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/hk"
 	"github.com/NVIDIA/aistore/memsys"
 )
@@ -20,20 +22,36 @@ const (
 	mphEvictAtime = time.Minute * 20  // high
 	mpnEvictAtime = time.Hour         // normal
 	iniEvictAtime = mpnEvictAtime / 2 // initial
+
+	accessFlushIval = time.Minute * 30 // persist access counts
+	maxAccessCnts   = 64 * 1024        // max objects with pending access counts
 )
 
-type lcHK struct {
-	mm      *memsys.MMSA
-	t       Target
-	running atomic.Bool
-}
+type (
+	lcHK struct {
+		mm      *memsys.MMSA
+		t       Target
+		running atomic.Bool
+	}
+	// access counts (see cmn.LRUPolicyLFU) that are yet to be persisted
+	accessCnts struct {
+		mtx      sync.Mutex
+		cnts     map[string]int64 // uname => count
+		dropped  int64            // accesses of the objects that didn't fit (since last flush)
+		flushing atomic.Bool
+	}
+)
 
-var lchk lcHK
+var (
+	lchk          lcHK
+	pendingAccess = accessCnts{cnts: make(map[string]int64)}
+)
 
 func initLomCacheHK(mm *memsys.MMSA, t Target) {
 	lchk.mm, lchk.t = mm, t
 	lchk.running.Store(false)
 	hk.Reg("lom-cache.gc", lchk.housekeep, iniEvictAtime)
+	hk.Reg("lom-cache.access", pendingAccess.flush, accessFlushIval)
 }
 
 func (lchk *lcHK) housekeep() (d time.Duration) {
@@ -114,3 +132,98 @@ func (lom *LOM) flushAtime(atime time.Time) {
 		glog.Errorf("%s: flush atime err: %v", lom, err)
 	}
 }
+
+////////////////
+// accessCnts //
+////////////////
+
+// only the frequency-based eviction policies make use of the counts
+func (lom *LOM) IncAccessCnt() {
+	switch lom.Bprops().LRU.Policy {
+	case cmn.LRUPolicyLFU, cmn.LRUPolicyGDSF:
+		pendingAccess.add(lom.Uname(), 1)
+	}
+}
+
+// the number of tracked objects is capped: accesses of the objects beyond
+// the limit are not counted
+func (ac *accessCnts) add(uname string, cnt int64) {
+	ac.mtx.Lock()
+	if _, ok := ac.cnts[uname]; ok || len(ac.cnts) < maxAccessCnts {
+		ac.cnts[uname] += cnt
+	} else {
+		ac.dropped += cnt
+	}
+	ac.mtx.Unlock()
+}
+
+func (ac *accessCnts) get(uname string) (cnt int64) {
+	ac.mtx.Lock()
+	cnt = ac.cnts[uname]
+	ac.mtx.Unlock()
+	return
+}
+
+// subtracts the persisted count, keeping the accesses that happened meanwhile
+func (ac *accessCnts) sub(uname string, cnt int64) {
+	ac.mtx.Lock()
+	if ac.cnts[uname] -= cnt; ac.cnts[uname] <= 0 {
+		delete(ac.cnts, uname)
+	}
+	ac.mtx.Unlock()
+}
+
+// adds the accumulated counts to the persistent ones, in batch and at most once
+// per object per interval; busy objects are retried next time.
+// Persisting is done asynchronously so as not to hold up other housekeepers.
+func (ac *accessCnts) flush() time.Duration {
+	if !ac.flushing.CAS(false, true) {
+		return accessFlushIval // still running
+	}
+	ac.mtx.Lock()
+	cnts := make(map[string]int64, len(ac.cnts))
+	for uname, cnt := range ac.cnts {
+		cnts[uname] = cnt
+	}
+	if ac.dropped > 0 {
+		glog.Warningf("access counts: dropped %d accesses (max %d tracked objects)", ac.dropped, maxAccessCnts)
+		ac.dropped = 0
+	}
+	ac.mtx.Unlock()
+
+	go ac.persist(cnts)
+	return accessFlushIval
+}
+
+func (ac *accessCnts) persist(cnts map[string]int64) {
+	defer ac.flushing.Store(false)
+	for uname, cnt := range cnts {
+		bck, objName := parseUname(uname)
+		lom := &LOM{ObjName: objName}
+		if err := lom.Init(bck.Bck); err != nil {
+			ac.sub(uname, cnt) // the bucket is gone
+			continue
+		}
+		if lom.flushAccessCnt(cnt) {
+			ac.sub(uname, cnt)
+		}
+	}
+}
+
+// returns false if the object is busy or failed to persist (to retry)
+func (lom *LOM) flushAccessCnt(cnt int64) bool {
+	if !lom.TryLock(true) {
+		return false
+	}
+	defer lom.Unlock(true)
+	if err := lom.Load(false); err != nil {
+		return cmn.IsObjNotExist(err) // drop the count if the object is gone
+	}
+	lom.md.accessCnt += cnt
+	if err := lom.PersistWithCopies(); err != nil {
+		glog.Errorf("%s: flush access count err: %v", lom, err)
+		return false
+	}
+	lom.ReCache()
+	return true
+}
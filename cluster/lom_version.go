@@ -0,0 +1,379 @@
+// Package cluster provides common interfaces and local access to cluster-level metadata
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package cluster
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/jsp"
+	"github.com/NVIDIA/aistore/fs"
+)
+
+//
+// Object versioning (ais buckets): when enabled and configured to keep prior
+// versions (see cmn.VersionConf), overwritten content of an object is preserved
+// as fs.ObjVersionType content on the same mountpath - one file per version,
+// with the object's metadata (xattr) intact. Deleting the object leaves behind
+// a delete marker: an empty file with DelMarkerObjMD custom metadata.
+// The modification time of a prior version is the time it became noncurrent.
+//
+// Prior versions of the object are listed in its versions index (also
+// fs.ObjVersionType content, see versIdxName) so that neither PUT nor listing
+// has to read the directory and load metadata of each version.
+//
+
+const (
+	// unversioned content (e.g., written prior to enabling versioning) gets archived as
+	lomUnversioned = "0"
+	// (the suffix of) the versions index
+	versIdxName = "idx"
+)
+
+type (
+	ObjVersion struct {
+		Version   string
+		FQN       string
+		Size      int64
+		Mtime     int64 // UnixNano
+		Cksum     *cmn.Cksum
+		DelMarker bool
+	}
+
+	// versions index: prior versions of the object, newest first
+	versIdx struct {
+		Latest string      `json:"latest"` // the last assigned version ID (while there are prior versions)
+		Vers   []*versInfo `json:"versions"`
+	}
+	// VersArchive is the current content of the object being preserved as its
+	// prior version (see LOM.PrepareArchive).
+	VersArchive struct {
+		Next string // the version ID that follows all existing versions
+		lom  *LOM
+		cur  *LOM // the current content (nil if the object does not exist)
+		ver  string
+		vfqn string
+		idx  *versIdx
+	}
+	versInfo struct {
+		Version    string `json:"version"`
+		Size       int64  `json:"size,string"`
+		Mtime      int64  `json:"mtime,string"`
+		CksumType  string `json:"cksum_type,omitempty"`
+		CksumValue string `json:"cksum_value,omitempty"`
+		DelMarker  bool   `json:"del_marker,omitempty"`
+	}
+)
+
+func (lom *LOM) VersionFQN(version string) string {
+	return fs.CSM.GenContentParsedFQN(lom.ParsedFQN(), fs.ObjVersionType, version)
+}
+
+func (lom *LOM) IsDelMarker() bool {
+	_, ok := lom.md.customMD[DelMarkerObjMD]
+	return ok
+}
+
+// LOM instance to access (metadata of) a given version of the object
+func (lom *LOM) versionLOM(fqn string) *LOM {
+	vlom := &LOM{}
+	*vlom = *lom
+	vlom.md = lmeta{}
+	vlom.FQN = fqn
+	return vlom
+}
+
+func (lom *LOM) loadVersIdx() (idx *versIdx, err error) {
+	idx = &versIdx{}
+	if _, err = jsp.Load(lom.VersionFQN(versIdxName), idx, jsp.Plain()); err != nil && os.IsNotExist(err) {
+		err = nil
+	}
+	return
+}
+
+// The index is kept even when there are no prior versions left: the latest
+// version ID must survive so that new versions never reuse the IDs that were
+// already assigned.
+// NOTE: uname for LOM must be already locked.
+func (lom *LOM) saveVersIdx(idx *versIdx) error {
+	fqn := lom.VersionFQN(versIdxName)
+	if len(idx.Vers) == 0 && idx.Latest == "" {
+		return cmn.RemoveFile(fqn)
+	}
+	return jsp.Save(fqn, idx, jsp.Plain())
+}
+
+func (idx *versIdx) find(version string) int {
+	for i, vi := range idx.Vers {
+		if vi.Version == version {
+			return i
+		}
+	}
+	return -1
+}
+
+func (idx *versIdx) add(vi *versInfo) {
+	idx.Vers = append([]*versInfo{vi}, idx.Vers...)
+	if idx.Latest == "" || cmn.ObjVersionLess(idx.Latest, vi.Version) {
+		idx.Latest = vi.Version
+	}
+}
+
+func (idx *versIdx) remove(i int) { idx.Vers = append(idx.Vers[:i], idx.Vers[i+1:]...) }
+
+// ListVersions returns prior versions of the object (including delete markers),
+// newest first.
+func (lom *LOM) ListVersions() (vers []*ObjVersion, err error) {
+	idx, err := lom.loadVersIdx()
+	if err != nil {
+		return
+	}
+	vers = make([]*ObjVersion, 0, len(idx.Vers))
+	for _, vi := range idx.Vers {
+		ver := &ObjVersion{
+			Version:   vi.Version,
+			FQN:       lom.VersionFQN(vi.Version),
+			Size:      vi.Size,
+			Mtime:     vi.Mtime,
+			DelMarker: vi.DelMarker,
+		}
+		if vi.CksumType != "" {
+			ver.Cksum = cmn.NewCksum(vi.CksumType, vi.CksumValue)
+		}
+		vers = append(vers, ver)
+	}
+	return
+}
+
+// LoadVersion returns LOM that represents a given prior version of the object.
+func (lom *LOM) LoadVersion(version string) (vlom *LOM, err error) {
+	vlom = lom.versionLOM(lom.VersionFQN(version))
+	finfo, err := os.Stat(vlom.FQN)
+	if err != nil {
+		if os.IsNotExist(err) {
+			err = cmn.NewNotFoundError("%s version %q", lom, version)
+		}
+		return nil, err
+	}
+	if _, err = vlom.lmfs(true); err != nil {
+		return nil, err
+	}
+	vlom.md.atime = finfo.ModTime().UnixNano()
+	vlom.loaded = true
+	return
+}
+
+// ArchiveVersion preserves the current content of the object (if exists) as its
+// prior version and returns the version ID that follows all existing versions.
+// NOTE: uname for LOM must be already locked.
+func (lom *LOM) ArchiveVersion() (next string, err error) {
+	arch, err := lom.PrepareArchive()
+	if err != nil {
+		return
+	}
+	if arch.cur != nil {
+		if err = cmn.RemoveFile(lom.FQN); err != nil {
+			arch.Abort()
+			return
+		}
+	}
+	return arch.Next, arch.Commit()
+}
+
+// PrepareArchive links the current content of the object (if exists) as its
+// prior version - the object itself stays intact. The caller then replaces the
+// object and either commits the archive (see VersArchive.Commit) or, if the
+// replacement fails, aborts it.
+// NOTE: uname for LOM must be already locked.
+func (lom *LOM) PrepareArchive() (arch *VersArchive, err error) {
+	arch = &VersArchive{lom: lom}
+	if arch.idx, err = lom.loadVersIdx(); err != nil {
+		return nil, err
+	}
+	cur := lom.versionLOM(lom.FQN)
+	if err = cur.FromFS(); err != nil {
+		if !os.IsNotExist(err) {
+			return nil, err
+		}
+		err = nil
+	} else {
+		arch.ver = cmn.Either(cur.Version(), lomUnversioned)
+		arch.vfqn = lom.VersionFQN(arch.ver)
+		if err = cmn.RemoveFile(arch.vfqn); err != nil { // (overwritten)
+			return nil, err
+		}
+		if err = cmn.CreateDir(filepath.Dir(arch.vfqn)); err != nil {
+			return nil, err
+		}
+		if err = os.Link(lom.FQN, arch.vfqn); err != nil {
+			return nil, err
+		}
+		arch.cur = cur
+	}
+	latest := arch.idx.Latest
+	if arch.cur != nil && (latest == "" || cmn.ObjVersionLess(latest, arch.ver)) {
+		latest = arch.ver
+	}
+	if latest == "" {
+		arch.Next = lomInitialVersion
+		return
+	}
+	n, errV := strconv.ParseInt(latest, 10, 64)
+	if errV != nil {
+		arch.Abort()
+		return nil, fmt.Errorf("%s: invalid version %q: %v", lom, latest, errV)
+	}
+	arch.Next = strconv.FormatInt(n+1, 10)
+	return
+}
+
+// Commit adds the archived content to the versions index.
+// NOTE: must be called after the object has been replaced (or removed).
+func (arch *VersArchive) Commit() (err error) {
+	lom, idx := arch.lom, arch.idx
+	if cur := arch.cur; cur != nil {
+		ver := arch.ver
+		if cur.HasCopies() { // copies (if any) are not preserved
+			cur.md.copies, cur.FQN = nil, arch.vfqn
+			if errP := cur.Persist(); errP != nil {
+				glog.Errorf("%s: failed to persist metadata of the version %q: %v", lom, ver, errP)
+			}
+		}
+		now := time.Now()
+		if errT := os.Chtimes(arch.vfqn, now, now); errT != nil {
+			glog.Errorf("%s: failed to set mtime of the version %q: %v", lom, ver, errT)
+		}
+		lom.Uncache()
+		vi := &versInfo{Version: ver, Size: cur.Size(), Mtime: now.UnixNano()}
+		if cksum := cur.Cksum(); cksum != nil {
+			vi.CksumType, vi.CksumValue = cksum.Get()
+		}
+		if i := idx.find(ver); i >= 0 {
+			idx.remove(i) // (overwritten)
+		}
+		idx.add(vi)
+	}
+	if arch.Next == lomInitialVersion && idx.Latest == "" && len(idx.Vers) == 0 {
+		return nil // nothing to keep
+	}
+	idx.Latest = arch.Next
+	return lom.saveVersIdx(idx)
+}
+
+// Abort removes the link created by PrepareArchive.
+func (arch *VersArchive) Abort() {
+	if arch.cur == nil {
+		return
+	}
+	if err := cmn.RemoveFile(arch.vfqn); err != nil {
+		glog.Errorf("%s: failed to remove %s: %v", arch.lom, arch.vfqn, err)
+	}
+}
+
+// PutDelMarker creates a delete marker with a given version ID.
+// NOTE: uname for LOM must be already locked.
+func (lom *LOM) PutDelMarker(version string) (err error) {
+	fqn := lom.VersionFQN(version)
+	file, err := lom.CreateFile(fqn)
+	if err != nil {
+		return
+	}
+	if err = file.Close(); err == nil {
+		marker := lom.versionLOM(fqn)
+		marker.md.version = version
+		marker.md.customMD = cmn.SimpleKVs{DelMarkerObjMD: "true"}
+		err = marker.Persist()
+	}
+	if err == nil {
+		var idx *versIdx
+		if idx, err = lom.loadVersIdx(); err == nil {
+			idx.add(&versInfo{Version: version, Mtime: time.Now().UnixNano(), DelMarker: true})
+			err = lom.saveVersIdx(idx)
+		}
+	}
+	if err != nil {
+		if errRm := cmn.RemoveFile(fqn); errRm != nil {
+			glog.Errorf("nested err: %v", errRm)
+		}
+	}
+	return
+}
+
+// DelVersion permanently removes a given prior version (or delete marker).
+// NOTE: uname for LOM must be already locked.
+func (lom *LOM) DelVersion(version string) error {
+	idx, err := lom.loadVersIdx()
+	if err != nil {
+		return err
+	}
+	i := idx.find(version)
+	if i < 0 {
+		return cmn.NewNotFoundError("%s version %q", lom, version)
+	}
+	if err := cmn.RemoveFile(lom.VersionFQN(version)); err != nil {
+		return err
+	}
+	idx.remove(i)
+	return lom.saveVersIdx(idx)
+}
+
+// PromoteVersion makes the newest prior version current - unless it is
+// a delete marker, in which case the object remains deleted.
+// NOTE: uname for LOM must be already locked, the current version - removed.
+func (lom *LOM) PromoteVersion() (promoted bool, err error) {
+	idx, err := lom.loadVersIdx()
+	if err != nil || len(idx.Vers) == 0 || idx.Vers[0].DelMarker {
+		return
+	}
+	if err = cmn.Rename(lom.VersionFQN(idx.Vers[0].Version), lom.FQN); err != nil {
+		return
+	}
+	idx.remove(0)
+	if err = lom.saveVersIdx(idx); err != nil {
+		return
+	}
+	lom.Uncache()
+	return true, nil
+}
+
+// TrimVersions enforces retention policy of the bucket: removes prior versions
+// beyond `versioning.max_versions` and the ones older than `versioning.max_age`.
+// NOTE: uname for LOM must be already locked.
+func (lom *LOM) TrimVersions() (removed int, size int64, err error) {
+	var (
+		conf   = lom.VersionConf()
+		maxAge = int64(conf.MaxAgeDuration())
+		now    = time.Now().UnixNano()
+		idx    *versIdx
+		kept   []*versInfo
+	)
+	if idx, err = lom.loadVersIdx(); err != nil {
+		return
+	}
+	for i, vi := range idx.Vers {
+		if i < conf.MaxVersions && (maxAge == 0 || vi.Mtime+maxAge > now) {
+			kept = append(kept, vi)
+			continue
+		}
+		if err = cmn.RemoveFile(lom.VersionFQN(vi.Version)); err != nil {
+			kept = append(kept, idx.Vers[i:]...)
+			break
+		}
+		removed++
+		size += vi.Size
+	}
+	if removed > 0 {
+		idx.Vers = kept
+		if errS := lom.saveVersIdx(idx); err == nil {
+			err = errS
+		}
+	}
+	return
+}
@@ -0,0 +1,216 @@
+// Package cluster_test provides tests for cluster package
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package cluster_test
+
+import (
+	"os"
+
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/fs"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("LOM Versions", func() {
+	const (
+		tmpDir   = "/tmp/lom_version_test"
+		verMpath = tmpDir + "/mpath"
+
+		bucketLocal = "LOM_TEST_Versions"
+		maxVersions = 2
+	)
+
+	localBck := cmn.Bck{Name: bucketLocal, Provider: cmn.ProviderAIS, Ns: cmn.NsGlobal}
+
+	_ = fs.CSM.RegisterContentType(fs.ObjectType, &fs.ObjectContentResolver{})
+	_ = fs.CSM.RegisterContentType(fs.ObjVersionType, &fs.ObjVersionContentResolver{})
+
+	var (
+		mix     = fs.MountpathInfo{Path: verMpath}
+		bmdMock = cluster.NewBaseBownerMock(
+			cluster.NewBck(
+				bucketLocal, cmn.ProviderAIS, cmn.NsGlobal,
+				&cmn.BucketProps{
+					Cksum:      cmn.CksumConf{Type: cmn.ChecksumXXHash},
+					Versioning: cmn.VersionConf{Enabled: true, MaxVersions: maxVersions},
+				},
+			),
+		)
+		tMock cluster.Target
+
+		testObjectName = "versions-foldr/test-obj.ext"
+		localFQN       = mix.MakePathFQN(localBck, fs.ObjectType, testObjectName)
+	)
+
+	BeforeEach(func() {
+		_ = cmn.CreateDir(verMpath)
+		fs.DisableFsIDCheck()
+		_, _ = fs.Add(verMpath, "daeID")
+		tMock = cluster.NewTargetMock(bmdMock)
+	})
+
+	AfterEach(func() {
+		_, _ = fs.Remove(verMpath)
+		_ = os.RemoveAll(tmpDir)
+	})
+
+	// overwrite the object the way PUT does when prior versions are kept
+	overwrite := func(size int) string {
+		lom := NewBasicLom(localFQN, tMock)
+		next, err := lom.ArchiveVersion()
+		Expect(err).NotTo(HaveOccurred())
+		createTestFile(localFQN, size)
+		lom = NewBasicLom(localFQN, tMock)
+		lom.SetSize(int64(size))
+		lom.SetVersion(next)
+		Expect(lom.Persist()).NotTo(HaveOccurred())
+		lom.Uncache()
+		return next
+	}
+
+	It("should keep prior versions of the object", func() {
+		filePut(localFQN, 10, tMock)
+		Expect(overwrite(20)).To(Equal("2"))
+		Expect(overwrite(30)).To(Equal("3"))
+
+		lom := NewBasicLom(localFQN, tMock)
+		Expect(lom.Load(false)).NotTo(HaveOccurred())
+		Expect(lom.Version()).To(Equal("3"))
+		Expect(lom.Size()).To(BeEquivalentTo(30))
+
+		vers, err := lom.ListVersions()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(vers).To(HaveLen(2))
+		Expect(vers[0].Version).To(Equal("2"))
+		Expect(vers[0].Size).To(BeEquivalentTo(20))
+		Expect(vers[1].Version).To(Equal("1"))
+		Expect(vers[1].Size).To(BeEquivalentTo(10))
+
+		vlom, err := lom.LoadVersion("1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(vlom.Version()).To(Equal("1"))
+		Expect(vlom.Size()).To(BeEquivalentTo(10))
+		Expect(vlom.IsDelMarker()).To(BeFalse())
+
+		_, err = lom.LoadVersion("5")
+		Expect(cmn.IsObjNotExist(err)).To(BeTrue())
+	})
+
+	It("should trim versions beyond max_versions", func() {
+		filePut(localFQN, 10, tMock)
+		for i := 0; i < maxVersions+2; i++ {
+			overwrite(10)
+		}
+		lom := NewBasicLom(localFQN, tMock)
+		removed, size, err := lom.TrimVersions()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(removed).To(Equal(2))
+		Expect(size).To(BeEquivalentTo(20))
+
+		vers, err := lom.ListVersions()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(vers).To(HaveLen(maxVersions))
+		Expect(vers[0].Version).To(Equal("4"))
+		Expect(vers[1].Version).To(Equal("3"))
+	})
+
+	It("should leave delete marker", func() {
+		filePut(localFQN, 10, tMock)
+		lom := NewBasicLom(localFQN, tMock)
+		next, err := lom.ArchiveVersion()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(lom.PutDelMarker(next)).NotTo(HaveOccurred())
+		Expect(localFQN).NotTo(BeAnExistingFile())
+
+		vers, err := lom.ListVersions()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(vers).To(HaveLen(2))
+		Expect(vers[0].Version).To(Equal("2"))
+		Expect(vers[0].DelMarker).To(BeTrue())
+		Expect(vers[1].DelMarker).To(BeFalse())
+
+		Expect(lom.DelVersion("2")).NotTo(HaveOccurred())
+		Expect(cmn.IsObjNotExist(lom.DelVersion("2"))).To(BeTrue())
+	})
+
+	It("should promote the newest prior version", func() {
+		filePut(localFQN, 10, tMock)
+		overwrite(20)
+		overwrite(30)
+
+		lom := NewBasicLom(localFQN, tMock)
+		Expect(lom.Remove()).NotTo(HaveOccurred())
+		promoted, err := lom.PromoteVersion()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(promoted).To(BeTrue())
+
+		lom = NewBasicLom(localFQN, tMock)
+		Expect(lom.Load(false)).NotTo(HaveOccurred())
+		Expect(lom.Version()).To(Equal("2"))
+		Expect(lom.Size()).To(BeEquivalentTo(20))
+		vers, err := lom.ListVersions()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(vers).To(HaveLen(1))
+		Expect(vers[0].Version).To(Equal("1"))
+
+		// versions keep increasing
+		Expect(overwrite(10)).To(Equal("4"))
+	})
+
+	It("should not promote delete marker", func() {
+		filePut(localFQN, 10, tMock)
+		lom := NewBasicLom(localFQN, tMock)
+		next, err := lom.ArchiveVersion()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(lom.PutDelMarker(next)).NotTo(HaveOccurred())
+
+		promoted, err := lom.PromoteVersion()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(promoted).To(BeFalse())
+		Expect(localFQN).NotTo(BeAnExistingFile())
+	})
+
+	It("should not reuse version IDs after removing all versions", func() {
+		filePut(localFQN, 10, tMock)
+		Expect(overwrite(20)).To(Equal("2"))
+		// delete the object (keeping it as version "2" with delete marker "3")
+		lom := NewBasicLom(localFQN, tMock)
+		next, err := lom.ArchiveVersion()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(next).To(Equal("3"))
+		Expect(lom.PutDelMarker(next)).NotTo(HaveOccurred())
+		for _, ver := range []string{"1", "2", "3"} {
+			Expect(lom.DelVersion(ver)).NotTo(HaveOccurred())
+		}
+		vers, err := lom.ListVersions()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(vers).To(BeEmpty())
+		Expect(lom.VersionFQN("idx")).To(BeAnExistingFile())
+
+		next, err = NewBasicLom(localFQN, tMock).ArchiveVersion()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(next).To(Equal("4"))
+	})
+
+	It("should keep the object intact when archiving is aborted", func() {
+		filePut(localFQN, 10, tMock)
+		lom := NewBasicLom(localFQN, tMock)
+		arch, err := lom.PrepareArchive()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(arch.Next).To(Equal("2"))
+		Expect(localFQN).To(BeAnExistingFile())
+		Expect(lom.VersionFQN("1")).To(BeAnExistingFile())
+
+		arch.Abort()
+		Expect(lom.VersionFQN("1")).NotTo(BeAnExistingFile())
+		lom = NewBasicLom(localFQN, tMock)
+		Expect(lom.Load(false)).NotTo(HaveOccurred())
+		Expect(lom.Size()).To(BeEquivalentTo(10))
+		vers, err := lom.ListVersions()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(vers).To(BeEmpty())
+	})
+})
@@ -8,6 +8,7 @@ import (
 	"encoding/binary"
 	"errors"
 	"fmt"
+	"strconv"
 	"strings"
 	"syscall"
 
@@ -52,13 +53,16 @@ const (
 	lomObjSize
 	lomObjCopies
 	lomCustomMD
+	lomUserMD
+	lomTags
+	lomAccessCnt
 )
 
 // packing format separators
 const (
 	copyFQNSepa  = "\x00"
 	customMDSepa = "\x01"
-	recordSepa   = "\xe3/\xbd"
+	recordSepa   = cmn.MDRecordSepa
 	lenRecSepa   = len(recordSepa)
 )
 
@@ -70,6 +74,8 @@ const (
 	SourceAmazonObjMD = cmn.ProviderAmazon
 	SourceGoogleObjMD = cmn.ProviderGoogle
 	SourceAzureObjMD  = cmn.ProviderAzure
+	SourceHDFSObjMD   = cmn.ProviderHDFS
+	SourceFSObjMD     = cmn.ProviderFS
 	SourceHTTPObjMD   = cmn.ProviderHTTP
 	SourceWebObjMD    = "web"
 
@@ -78,6 +84,12 @@ const (
 	MD5ObjMD     = cmn.ChecksumMD5
 
 	OrigURLObjMD = "orig_url"
+
+	DelMarkerObjMD = "delete_marker" // see lom_version.go
+
+	// not yet written to the Cloud (see cmn.WritePolicyBack); the value
+	// identifies the PUT that made the object dirty
+	DirtyObjMD = "dirty"
 )
 
 func (lom *LOM) LoadMetaFromFS() error { _, err := lom.lmfs(true); return err }
@@ -272,6 +284,18 @@ func (md *lmeta) unmarshal(buf []byte) (err error) {
 			for i := 0; i < len(entries); i += 2 {
 				md.customMD[entries[i]] = entries[i+1]
 			}
+		case lomUserMD:
+			if md.userMD, err = _unmarshKVs(val); err != nil {
+				return errors.New(invalid + " #6.1")
+			}
+		case lomTags:
+			if md.tags, err = _unmarshKVs(val); err != nil {
+				return errors.New(invalid + " #6.2")
+			}
+		case lomAccessCnt:
+			if md.accessCnt, err = strconv.ParseInt(val, 10, 64); err != nil {
+				return errors.New(invalid + " #6.3")
+			}
 		default:
 			return errors.New(invalid + " #6")
 		}
@@ -316,6 +340,20 @@ func (md *lmeta) marshal(mm *memsys.MMSA, mdSize int64) (buf []byte) {
 		buf = _marshRecord(mm, buf, lomCustomMD, "", false)
 		buf = _marshCustomMD(mm, buf, md.customMD)
 	}
+	if len(md.userMD) > 0 {
+		buf = mm.Append(buf, recordSepa)
+		buf = _marshRecord(mm, buf, lomUserMD, "", false)
+		buf = _marshCustomMD(mm, buf, md.userMD)
+	}
+	if len(md.tags) > 0 {
+		buf = mm.Append(buf, recordSepa)
+		buf = _marshRecord(mm, buf, lomTags, "", false)
+		buf = _marshCustomMD(mm, buf, md.tags)
+	}
+	if md.accessCnt > 0 {
+		buf = mm.Append(buf, recordSepa)
+		buf = _marshRecord(mm, buf, lomAccessCnt, strconv.FormatInt(md.accessCnt, 10), false)
+	}
 
 	// checksum, prepend, and return
 	buf[0] = mdVersion
@@ -358,7 +396,7 @@ func _marshCustomMD(mm *memsys.MMSA, buf []byte, md cmn.SimpleKVs) []byte {
 		num = len(md)
 	)
 	for k, v := range md {
-		cmn.Assert(k != "" && v != "")
+		cmn.Assert(k != "")
 		i++
 		buf = mm.Append(buf, k)
 		buf = mm.Append(buf, customMDSepa)
@@ -369,3 +407,15 @@ func _marshCustomMD(mm *memsys.MMSA, buf []byte, md cmn.SimpleKVs) []byte {
 	}
 	return buf
 }
+
+func _unmarshKVs(val string) (cmn.SimpleKVs, error) {
+	entries := strings.Split(val, customMDSepa)
+	if len(entries)%2 != 0 {
+		return nil, fmt.Errorf("odd number of entries (%d)", len(entries))
+	}
+	kvs := make(cmn.SimpleKVs, len(entries)/2)
+	for i := 0; i < len(entries); i += 2 {
+		kvs[entries[i]] = entries[i+1]
+	}
+	return kvs, nil
+}
@@ -6,6 +6,7 @@ package cluster_test
 
 import (
 	"os"
+	"sync"
 
 	"github.com/NVIDIA/aistore/cluster"
 	"github.com/NVIDIA/aistore/cmn"
@@ -34,7 +35,10 @@ var _ = Describe("LOM Xattributes", func() {
 		bmdMock       = cluster.NewBaseBownerMock(
 			cluster.NewBck(
 				bucketLocal, cmn.ProviderAIS, cmn.NsGlobal,
-				&cmn.BucketProps{Cksum: cmn.CksumConf{Type: cmn.ChecksumXXHash}},
+				&cmn.BucketProps{
+					Cksum: cmn.CksumConf{Type: cmn.ChecksumXXHash},
+					LRU:   cmn.LRUConf{Policy: cmn.LRUPolicyLFU},
+				},
 			),
 		)
 		tMock cluster.Target
@@ -115,6 +119,65 @@ var _ = Describe("LOM Xattributes", func() {
 				Expect(lom.CustomMD()).To(BeEquivalentTo(newLom.CustomMD()))
 			})
 
+			It("should save user-defined metadata and tags", func() {
+				lom := filePut(localFQN, testFileSize, tMock)
+				lom.SetCksum(cmn.NewCksum(cmn.ChecksumXXHash, "test_checksum"))
+				lom.SetUserMD(cmn.SimpleKVs{
+					"color":      "red",
+					"owner":      "data=team",
+					"empty-note": "",
+				})
+				lom.SetTags(cmn.SimpleKVs{"split": "train", "reviewed": ""})
+				Expect(lom.Persist()).NotTo(HaveOccurred())
+
+				hrwLom := &cluster.LOM{ObjName: testObjectName}
+				Expect(hrwLom.Init(localBck)).NotTo(HaveOccurred())
+				hrwLom.Uncache()
+
+				newLom := NewBasicLom(localFQN, tMock)
+				err := newLom.Load(false)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(newLom.CustomMD()).To(BeEmpty())
+				Expect(newLom.UserMD()).To(HaveLen(3))
+				Expect(newLom.UserMD()).To(BeEquivalentTo(lom.UserMD()))
+				Expect(newLom.Tags()).To(HaveLen(2))
+				Expect(newLom.Tags()).To(BeEquivalentTo(lom.Tags()))
+			})
+
+			It("should count concurrent accesses", func() {
+				const numAccesses = 100
+				lom := filePut(localFQN, testFileSize, tMock)
+				Expect(lom.AccessCnt()).To(BeZero())
+				wg := &sync.WaitGroup{}
+				for i := 0; i < numAccesses; i++ {
+					wg.Add(1)
+					go func(getLom *cluster.LOM) {
+						defer wg.Done()
+						getLom.IncAccessCnt()
+					}(NewBasicLom(localFQN, tMock))
+				}
+				wg.Wait()
+
+				hrwLom := &cluster.LOM{ObjName: testObjectName}
+				Expect(hrwLom.Init(localBck)).NotTo(HaveOccurred())
+				hrwLom.Uncache()
+
+				newLom := NewBasicLom(localFQN, tMock)
+				Expect(newLom.Load(false)).NotTo(HaveOccurred())
+				Expect(newLom.AccessCnt()).To(BeEquivalentTo(numAccesses))
+				Expect(newLom.Size()).To(BeEquivalentTo(testFileSize))
+			})
+
+			It("should not count accesses when not using them", func() {
+				props, _ := bmdMock.Get().Get(cluster.NewBck(bucketLocal, cmn.ProviderAIS, cmn.NsGlobal, nil))
+				props.LRU.Policy = cmn.LRUPolicyLRU
+				defer func() { props.LRU.Policy = cmn.LRUPolicyLFU }()
+
+				lom := filePut(mix.MakePathFQN(localBck, fs.ObjectType, "lru-obj"), testFileSize, tMock)
+				lom.IncAccessCnt()
+				Expect(lom.AccessCnt()).To(BeZero())
+			})
+
 			It("should override old values", func() {
 				lom := filePut(localFQN, testFileSize, tMock)
 				lom.SetCksum(cmn.NewCksum(cmn.ChecksumXXHash, "test_checksum"))
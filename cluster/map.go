@@ -54,6 +54,13 @@ type (
 		DirectURL  string `json:"direct_url"`
 	}
 
+	// Snode's failure domain (see `cmn.DomainConf`)
+	Domain struct {
+		Zone string `json:"zone,omitempty"`
+		Rack string `json:"rack,omitempty"`
+		Host string `json:"host,omitempty"`
+	}
+
 	// Snode - a node (gateway or target) in a cluster
 	Snode struct {
 		DaemonID        string     `json:"daemon_id"`
@@ -62,6 +69,7 @@ type (
 		IntraControlNet NetInfo    `json:"intra_control_net"` // cmn.NetworkIntraControl
 		IntraDataNet    NetInfo    `json:"intra_data_net"`    // cmn.NetworkIntraData
 		Flags           SnodeFlags `json:"flags"`             // enum cmn.Snode*
+		Domain          Domain     `json:"domain"`            // failure domain (zone, rack, host)
 		idDigest        uint64
 		name            string
 		LocalNet        *net.IPNet `json:"-"`
@@ -89,6 +97,31 @@ type (
 	}
 )
 
+////////////
+// Domain //
+////////////
+
+func (dom Domain) IsEmpty() bool { return dom == Domain{} }
+
+// upTo returns the domain truncated to the given level (e.g., zone and rack).
+func (dom Domain) upTo(level int) Domain {
+	switch level {
+	case domainZone:
+		return Domain{Zone: dom.Zone}
+	case domainRack:
+		return Domain{Zone: dom.Zone, Rack: dom.Rack}
+	default:
+		return dom
+	}
+}
+
+func (dom Domain) String() string {
+	if dom.IsEmpty() {
+		return "-"
+	}
+	return dom.Zone + "/" + dom.Rack + "/" + dom.Host
+}
+
 ////////////////
 // SnodeFlags //
 ////////////////
@@ -199,7 +232,13 @@ func (d *Snode) URL(network string) string {
 	}
 }
 
+// Equals includes the failure domain: the node that changed its domain
+// re-registers (see also `EqualsIgnoreDomain`).
 func (d *Snode) Equals(other *Snode) bool {
+	return d.EqualsIgnoreDomain(other) && d.Domain == other.Domain
+}
+
+func (d *Snode) EqualsIgnoreDomain(other *Snode) bool {
 	if d == nil || other == nil {
 		return false
 	}
@@ -306,6 +345,16 @@ func (m *Smap) CountActiveTargets() (count int) {
 	return
 }
 
+// hasDomains returns true if any target is labeled with a failure domain.
+func (m *Smap) hasDomains() bool {
+	for _, t := range m.Tmap {
+		if !t.Domain.IsEmpty() {
+			return true
+		}
+	}
+	return false
+}
+
 func (m *Smap) CountNonElectable() (count int) {
 	for _, p := range m.Pmap {
 		if p.nonElectable() {
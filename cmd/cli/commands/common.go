@@ -63,6 +63,7 @@ const (
 	subcmdPrimary   = "primary"
 	subcmdInit      = "init"
 	subcmdBuild     = "build"
+	subcmdPipeline  = "pipeline"
 	subcmdList      = commandList
 	subcmdLogs      = "logs"
 	subcmdStop      = "stop"
@@ -81,6 +82,8 @@ const (
 	subcmdShowRemoteAIS = subcmdRemoteAIS
 	subcmdShowCluster   = subcmdCluster
 	subcmdShowMpath     = subcmdMountpath
+	subcmdShowECHealth  = "ec-health"
+	subcmdShowScrub     = cmn.ActScrub
 
 	// Create subcommands
 	subcmdCreateBucket = subcmdBucket
@@ -283,6 +286,18 @@ var (
 		Name:  "object-list,from",
 		Usage: "path to file containing JSON array of strings with object names to download",
 	}
+	scheduleFlag = cli.StringFlag{
+		Name:  "schedule",
+		Usage: "run download periodically: cron expression (UTC), e.g. '0 */6 * * *', or '@every <duration>', e.g. '@every 12h'",
+	}
+	manifestFlag = cli.BoolFlag{
+		Name:  "manifest",
+		Usage: "treat source as manifest (CSV or JSONL, in a bucket or on the web) listing the objects to download",
+	}
+	manifestFormatFlag = cli.StringFlag{
+		Name:  "manifest-format",
+		Usage: "format of the manifest: 'csv' or 'jsonl' (deduced from the extension if omitted)",
+	}
 	syncFlag             = cli.BoolFlag{Name: "sync", Usage: "sync bucket with cloud"}
 	progressIntervalFlag = cli.StringFlag{Name: "progress-interval", Value: downloader.DownloadProgressInterval.String(), Usage: "interval(in secs) at which progress will be monitored, e.g. '10s'"}
 
@@ -305,6 +320,7 @@ var (
 	}
 	fileCountFlag = cli.IntFlag{Name: "fcount", Value: 5, Usage: "number of files inside single shard"}
 	specFileFlag  = cli.StringFlag{Name: "file,f", Value: "", Usage: "path to file with dSort specification"}
+	resumeFlag    = cli.StringFlag{Name: "resume", Usage: "ID of the aborted (resumable) dSort job to resume"}
 
 	// Object
 	listFlag      = cli.StringFlag{Name: "list", Usage: "comma separated list of object names, eg. 'o1,o2,o3'"}
@@ -349,6 +365,7 @@ var (
 		Name:  "runtime",
 		Usage: "runtime which should be used when running the provided code", Required: true,
 	}
+	etlCacheFlag    = cli.BoolFlag{Name: "cache", Usage: "cache transformed objects on the targets"}
 	waitTimeoutFlag = cli.DurationFlag{
 		Name:  "wait-timeout",
 		Usage: "determines how long ais target should wait for pod to become ready",
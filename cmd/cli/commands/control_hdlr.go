@@ -32,10 +32,15 @@ var (
 			descriptionFlag,
 			limitConnectionsFlag,
 			objectsListFlag,
+			manifestFlag,
+			manifestFormatFlag,
+			scheduleFlag,
+			syncFlag,
 			progressIntervalFlag,
 		},
 		subcmdStartDsort: {
 			specFileFlag,
+			resumeFlag,
 		},
 		commandPrefetch: append(
 			baseLstRngFlags,
@@ -254,10 +259,15 @@ func startDownloadHandler(c *cli.Context) error {
 		}
 	}
 
-	src, dst := c.Args().Get(0), c.Args().Get(1)
-	source, err := parseSource(src)
-	if err != nil {
-		return err
+	var (
+		src, dst = c.Args().Get(0), c.Args().Get(1)
+		source   dlSource
+		err      error
+	)
+	if !flagIsSet(c, manifestFlag) {
+		if source, err = parseSource(src); err != nil {
+			return err
+		}
 	}
 	bucket, pathSuffix, err := parseDest(dst)
 	if err != nil {
@@ -282,6 +292,7 @@ func startDownloadHandler(c *cli.Context) error {
 		Timeout:          timeout,
 		Description:      description,
 		ProgressInterval: progressInterval,
+		Schedule:         parseStrFlag(c, scheduleFlag),
 		Limits: downloader.DlLimits{
 			Connections:  parseIntFlag(c, limitConnectionsFlag),
 			BytesPerHour: int(limitBPH),
@@ -290,7 +301,9 @@ func startDownloadHandler(c *cli.Context) error {
 
 	// Heuristics to determine the download type.
 	var dlType downloader.DlType
-	if objectsListPath != "" {
+	if flagIsSet(c, manifestFlag) {
+		dlType = downloader.DlTypeManifest
+	} else if objectsListPath != "" {
 		dlType = downloader.DlTypeMulti
 	} else if strings.Contains(source.link, "{") && strings.Contains(source.link, "}") {
 		dlType = downloader.DlTypeRange
@@ -377,6 +390,13 @@ func startDownloadHandler(c *cli.Context) error {
 			Prefix: source.cloud.prefix,
 		}
 		id, err = api.DownloadWithParam(defaultAPIParams, dlType, payload)
+	case downloader.DlTypeManifest:
+		payload := downloader.DlManifestBody{
+			DlBase:   basePayload,
+			Manifest: src,
+			Format:   parseStrFlag(c, manifestFormatFlag),
+		}
+		id, err = api.DownloadWithParam(defaultAPIParams, dlType, payload)
 	default:
 		cmn.Assert(false)
 	}
@@ -386,6 +406,10 @@ func startDownloadHandler(c *cli.Context) error {
 	}
 
 	fmt.Fprintln(c.App.Writer, id)
+	if basePayload.Schedule != "" {
+		fmt.Fprintf(c.App.Writer, "Run `ais show download` to list the scheduled downloads, `ais rm download %s` to remove the schedule.\n", id)
+		return nil
+	}
 	fmt.Fprintf(c.App.Writer, "Run `ais show download %s --progress` to monitor the progress of downloading.\n", id)
 	return nil
 }
@@ -410,6 +434,13 @@ func startDsortHandler(c *cli.Context) (err error) {
 		id       string
 		specPath = parseStrFlag(c, specFileFlag)
 	)
+	if id = parseStrFlag(c, resumeFlag); id != "" {
+		if err = api.ResumeDSort(defaultAPIParams, id); err != nil {
+			return
+		}
+		fmt.Fprintln(c.App.Writer, id)
+		return
+	}
 	if c.NArg() == 0 && specPath == "" {
 		return missingArgumentsError(c, "job specification")
 	} else if c.NArg() > 0 && specPath != "" {
@@ -18,17 +18,45 @@ import (
 	"github.com/NVIDIA/aistore/cluster"
 	"github.com/NVIDIA/aistore/cmd/cli/templates"
 	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/ec"
 	"github.com/NVIDIA/aistore/ios"
+	"github.com/NVIDIA/aistore/scrub"
 	"github.com/NVIDIA/aistore/stats"
 	"github.com/NVIDIA/aistore/xaction"
 	"github.com/urfave/cli"
 	"golang.org/x/sync/errgroup"
 )
 
-type targetDiskStats struct {
-	stats    map[string]*ios.SelectedDiskStats
-	targetID string
-}
+type (
+	targetDiskStats struct {
+		stats    map[string]*ios.SelectedDiskStats
+		targetID string
+	}
+
+	// results of the latest EC scrub of a bucket, summed up across targets
+	ecBckHealth struct {
+		Bck     cmn.Bck   `json:"bck"`
+		ScrubID string    `json:"scrub_id"`
+		Health  string    `json:"health"`
+		Running bool      `json:"running"`
+		Aborted bool      `json:"aborted"`
+		Objects int64     `json:"objects,string"`
+		EndTime time.Time `json:"end_time"`
+		ec.ScrubStatsExt
+	}
+
+	// results of the latest scrub on a target
+	targetScrubStats struct {
+		TargetID string    `json:"target_id"`
+		ScrubID  string    `json:"scrub_id"`
+		Running  bool      `json:"running"`
+		Aborted  bool      `json:"aborted"`
+		Objects  int64     `json:"objects,string"`
+		Bytes    int64     `json:"bytes,string"`
+		EndTime  time.Time `json:"end_time"`
+		scrub.StatsExt
+	}
+)
 
 var (
 	proxy  = make(map[string]*stats.DaemonStatus)
@@ -354,3 +382,87 @@ func displayRebStats(tw *tabwriter.Writer, daeID string, st *xaction.BaseXactSta
 		startTime, endTime, st.AbortedX,
 	)
 }
+
+// ecHealthFromStats sums up the stats of the latest EC scrub of each bucket.
+func ecHealthFromStats(xactStats api.NodesXactMultiStats) []*ecBckHealth {
+	latest := make(map[string]*xaction.BaseXactStatsExt)
+	for _, daemonStats := range xactStats {
+		for _, st := range daemonStats {
+			uname := st.BckX.String()
+			if prev, ok := latest[uname]; !ok || st.StartTimeX.After(prev.StartTimeX) {
+				latest[uname] = st
+			}
+		}
+	}
+	health := make([]*ecBckHealth, 0, len(latest))
+	for _, st := range latest {
+		h := &ecBckHealth{Bck: st.BckX, ScrubID: st.IDX}
+		for _, nodeStat := range xactStats.GetNodesXactStat(st.IDX) {
+			ext := &ec.ScrubStatsExt{}
+			if err := cmn.MorphMarshal(nodeStat.Ext, ext); err != nil {
+				continue
+			}
+			h.Objects += nodeStat.ObjCountX
+			h.Healthy += ext.Healthy
+			h.Repaired += ext.Repaired
+			h.Degraded += ext.Degraded
+			h.Lost += ext.Lost
+			h.Missing += ext.Missing
+			h.Unverified += ext.Unverified
+			h.Running = h.Running || nodeStat.Running()
+			h.Aborted = h.Aborted || nodeStat.Aborted()
+			if nodeStat.EndTimeX.After(h.EndTime) {
+				h.EndTime = nodeStat.EndTimeX
+			}
+		}
+		switch {
+		case h.Lost > 0:
+			h.Health = "lost"
+		case h.Degraded > 0:
+			h.Health = "degraded"
+		case h.Running || h.Aborted:
+			h.Health = "unknown"
+		case h.Repaired > 0:
+			h.Health = "repaired"
+		default:
+			h.Health = "ok"
+		}
+		if h.Running {
+			h.EndTime = time.Time{}
+		}
+		health = append(health, h)
+	}
+	sort.Slice(health, func(i, j int) bool { return health[i].Bck.String() < health[j].Bck.String() })
+	return health
+}
+
+// scrubFromStats returns the stats of the latest scrub of each target.
+func scrubFromStats(xactStats api.NodesXactMultiStats) []*targetScrubStats {
+	res := make([]*targetScrubStats, 0, len(xactStats))
+	for daemonID, daemonStats := range xactStats {
+		var latest *xaction.BaseXactStatsExt
+		for _, st := range daemonStats {
+			if latest == nil || st.StartTimeX.After(latest.StartTimeX) {
+				latest = st
+			}
+		}
+		if latest == nil {
+			continue
+		}
+		st := &targetScrubStats{
+			TargetID: daemonID,
+			ScrubID:  latest.IDX,
+			Running:  latest.Running(),
+			Aborted:  latest.Aborted(),
+			Objects:  latest.ObjCountX,
+			Bytes:    latest.BytesCountX,
+			EndTime:  latest.EndTimeX,
+		}
+		if err := cmn.MorphMarshal(latest.Ext, &st.StatsExt); err != nil {
+			continue
+		}
+		res = append(res, st)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].TargetID < res[j].TargetID })
+	return res
+}
@@ -312,7 +312,15 @@ func downloadJobsList(c *cli.Context, regex string) error {
 	if err != nil {
 		return err
 	}
-	return templates.DisplayOutput(list, c.App.Writer, templates.DownloadListTmpl)
+	if err := templates.DisplayOutput(list, c.App.Writer, templates.DownloadListTmpl); err != nil {
+		return err
+	}
+	schedules, err := api.DownloadGetSchedules(defaultAPIParams, regex)
+	if err != nil || len(schedules) == 0 {
+		return err
+	}
+	fmt.Fprintln(c.App.Writer)
+	return templates.DisplayOutput(schedules, c.App.Writer, templates.DownloadScheduleListTmpl)
 }
 
 func downloadJobStatus(c *cli.Context, id string) error {
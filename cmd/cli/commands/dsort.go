@@ -479,8 +479,9 @@ func printCondensedStats(w io.Writer, id string) error {
 	}
 
 	var (
-		aborted  bool
-		finished = true
+		aborted      bool
+		finished     = true
+		resumedPhase string
 
 		elapsedTime    time.Duration
 		extractionTime time.Duration
@@ -490,6 +491,9 @@ func printCondensedStats(w io.Writer, id string) error {
 	for _, tm := range resp {
 		aborted = aborted || tm.Aborted.Load()
 		finished = finished && tm.Creation.Finished
+		if tm.ResumedPhase != "" {
+			resumedPhase = tm.ResumedPhase
+		}
 
 		elapsedTime = cmn.MaxDuration(elapsedTime, tm.ElapsedTime())
 		if tm.Extraction.Finished {
@@ -511,6 +515,10 @@ func printCondensedStats(w io.Writer, id string) error {
 		}
 	}
 
+	if resumedPhase != "" {
+		_, _ = fmt.Fprintf(w, "DSort job was resumed after %s phase.\n", resumedPhase)
+	}
+
 	if aborted {
 		_, _ = fmt.Fprintf(w, "DSort job was aborted. Check detailed metrics for encountered errors.\n")
 		return nil
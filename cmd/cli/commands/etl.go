@@ -40,9 +40,17 @@ var etlCmds = []cli.Command{
 					depsFileFlag,
 					runtimeFlag,
 					waitTimeoutFlag,
+					etlCacheFlag,
 				},
 				Action: etlBuildHandler,
 			},
+			{
+				Name:         subcmdPipeline,
+				Usage:        "define pipeline of initialized ETLs run in sequence",
+				ArgsUsage:    "ETL_ID ETL_ID [ETL_ID...]",
+				Action:       etlPipelineHandler,
+				BashComplete: etlIDCompletions,
+			},
 			{
 				Name:   subcmdList,
 				Usage:  "list all ETLs",
@@ -137,6 +145,7 @@ func etlBuildHandler(c *cli.Context) (err error) {
 
 	msg.Runtime = parseStrFlag(c, runtimeFlag)
 	msg.WaitTimeout = cmn.DurationJSON(parseDurationFlag(c, waitTimeoutFlag))
+	msg.Cache = flagIsSet(c, etlCacheFlag)
 
 	if err := msg.Validate(); err != nil {
 		return err
@@ -150,6 +159,18 @@ func etlBuildHandler(c *cli.Context) (err error) {
 	return nil
 }
 
+func etlPipelineHandler(c *cli.Context) (err error) {
+	if c.NArg() < 2 {
+		return missingArgumentsError(c, "ETL_ID ETL_ID [ETL_ID...]")
+	}
+	id, err := api.ETLPipeline(defaultAPIParams, c.Args())
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(c.App.Writer, "%s\n", id)
+	return nil
+}
+
 func etlListHandler(c *cli.Context) (err error) {
 	list, err := api.ETLList(defaultAPIParams)
 	if err != nil {
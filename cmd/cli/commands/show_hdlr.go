@@ -7,6 +7,7 @@ package commands
 
 import (
 	"fmt"
+	"net/http"
 	"sort"
 	"strings"
 	"sync"
@@ -99,6 +100,12 @@ var (
 		subcmdShowMpath: {
 			jsonFlag,
 		},
+		subcmdShowECHealth: {
+			jsonFlag,
+		},
+		subcmdShowScrub: {
+			jsonFlag,
+		},
 	}
 
 	showCmds = []cli.Command{
@@ -224,6 +231,20 @@ var (
 					Action:       showMpathHandler,
 					BashComplete: daemonCompletions(completeTargets),
 				},
+				{
+					Name:         subcmdShowECHealth,
+					Usage:        "show health of erasure coded buckets (as per the latest EC scrub)",
+					ArgsUsage:    optionalBucketArgument,
+					Flags:        showCmdsFlags[subcmdShowECHealth],
+					Action:       showECHealthHandler,
+					BashComplete: bucketCompletions(),
+				},
+				{
+					Name:   subcmdShowScrub,
+					Usage:  "show results of the latest scrub (bit rot detection) of each target",
+					Flags:  showCmdsFlags[subcmdShowScrub],
+					Action: showScrubHandler,
+				},
 			},
 		},
 	}
@@ -414,6 +435,48 @@ func showRebalanceHandler(c *cli.Context) (err error) {
 	return showRebalance(c, flagIsSet(c, refreshFlag), calcRefreshRate(c))
 }
 
+func showECHealthHandler(c *cli.Context) (err error) {
+	var bck cmn.Bck
+	if c.NArg() > 0 {
+		if bck, err = parseBckURI(c, c.Args().First()); err != nil {
+			return
+		}
+		if bck, _, err = validateBucket(c, bck, "", false); err != nil {
+			return
+		}
+	}
+	xactArgs := api.XactReqArgs{Kind: cmn.ActECScrub, Bck: bck}
+	xactStats, err := api.QueryXactionStats(defaultAPIParams, xactArgs)
+	if err != nil {
+		if httpErr, ok := err.(*cmn.HTTPError); !ok || httpErr.Status != http.StatusNotFound {
+			return err
+		}
+	}
+	health := ecHealthFromStats(xactStats)
+	if len(health) == 0 {
+		fmt.Fprintf(c.App.Writer, "No EC scrub found, use `%s %s %s BUCKET_NAME` to start one\n",
+			cliName, commandStart, cmn.ActECScrub)
+		return nil
+	}
+	return templates.DisplayOutput(health, c.App.Writer, templates.ECHealthTmpl, flagIsSet(c, jsonFlag))
+}
+
+func showScrubHandler(c *cli.Context) (err error) {
+	xactArgs := api.XactReqArgs{Kind: cmn.ActScrub}
+	xactStats, err := api.QueryXactionStats(defaultAPIParams, xactArgs)
+	if err != nil {
+		if httpErr, ok := err.(*cmn.HTTPError); !ok || httpErr.Status != http.StatusNotFound {
+			return err
+		}
+	}
+	scrubStats := scrubFromStats(xactStats)
+	if len(scrubStats) == 0 {
+		fmt.Fprintf(c.App.Writer, "No scrub found, use `%s %s %s` to start one\n", cliName, commandStart, cmn.ActScrub)
+		return nil
+	}
+	return templates.DisplayOutput(scrubStats, c.App.Writer, templates.ScrubTmpl, flagIsSet(c, jsonFlag))
+}
+
 func showBckPropsHandler(c *cli.Context) (err error) {
 	return showBucketProps(c)
 }
@@ -329,6 +329,24 @@ func parseSource(rawURL string) (source dlSource, err error) {
 				prefix: strings.TrimPrefix(fullPath, "/"),
 			},
 		}, nil
+	case cmn.ProviderHDFS:
+		// NOTE: HDFS is accessed via RPC, so there is no link - only Cloud download.
+		return dlSource{
+			link: "",
+			cloud: dlSourceCloud{
+				bck:    cmn.Bck{Name: host, Provider: cmn.ProviderHDFS},
+				prefix: strings.TrimPrefix(fullPath, "/"),
+			},
+		}, nil
+	case cmn.ProviderFS:
+		// NOTE: the directory is local to the targets, so there is no link - only Cloud download.
+		return dlSource{
+			link: "",
+			cloud: dlSourceCloud{
+				bck:    cmn.Bck{Name: host, Provider: cmn.ProviderFS},
+				prefix: strings.TrimPrefix(fullPath, "/"),
+			},
+		}, nil
 	case cmn.AISScheme:
 		// TODO: add support for the remote cluster
 		scheme = "http" // TODO: How about `https://`?
@@ -935,6 +953,9 @@ func bckPropList(props *cmn.BucketProps, verbose bool) (propList []prop, err err
 		if props.Extra.OrigURLBck != "" {
 			propList = append(propList, prop{Name: "original-url", Value: props.Extra.OrigURLBck})
 		}
+		if props.WritePolicy != "" {
+			propList = append(propList, prop{Name: "write-policy", Value: props.WritePolicy})
+		}
 	} else {
 		err = cmn.IterFields(props, func(uniqueTag string, field cmn.IterField) (err error, b bool) {
 			value := fmt.Sprintf("%v", field.Value())
@@ -148,6 +148,26 @@ func TestParseSourceValidURIs(t *testing.T) {
 				},
 			},
 		},
+		{
+			input: "hdfs://bucket/subfolder/objname.tar",
+			expected: dlSource{
+				link: "",
+				cloud: dlSourceCloud{
+					bck:    cmn.Bck{Name: "bucket", Provider: cmn.ProviderHDFS},
+					prefix: "subfolder/objname.tar",
+				},
+			},
+		},
+		{
+			input: "fs://bucket/subfolder/objname.tar",
+			expected: dlSource{
+				link: "",
+				cloud: dlSourceCloud{
+					bck:    cmn.Bck{Name: "bucket", Provider: cmn.ProviderFS},
+					prefix: "subfolder/objname.tar",
+				},
+			},
+		},
 
 		{
 			input:    "src.com/image001.tar.gz",
@@ -348,6 +368,16 @@ func TestParseBckObjectURI(t *testing.T) {
 			uri:         "az:///",
 			expectedBck: cmn.Bck{Provider: cmn.ProviderAzure},
 		},
+		{
+			uri:         "hdfs://bucket/dir/file.tar",
+			expectedBck: cmn.Bck{Name: "bucket", Provider: cmn.ProviderHDFS},
+			expectedObj: "dir/file.tar",
+		},
+		{
+			uri:         "fs://bucket/dir/file.tar",
+			expectedBck: cmn.Bck{Name: "bucket", Provider: cmn.ProviderFS},
+			expectedObj: "dir/file.tar",
+		},
 		// errors
 		{uri: "ais://%something", expectedErr: true},
 		{uri: "aiss://", expectedErr: true},
@@ -240,14 +240,37 @@ const (
 		"{{end}}\t {{$value.ErrorCnt}}\t {{$value.Description}}\n"
 	DownloadListTmpl = DownloadListHeader + "{{ range $key, $value := . }}" + DownloadListBody + "{{end}}"
 
+	DownloadScheduleListHeader = "SCHEDULE ID\t SCHEDULE\t TYPE\t BUCKET\t RUNS\t LAST RUN\t NEXT RUN\t DESCRIPTION\n"
+	DownloadScheduleListBody   = "{{$value.ID}}\t {{$value.Schedule}}\t {{$value.Type}}\t {{$value.Bck}}\t {{$value.Runs}}\t " +
+		"{{if (IsUnsetTime $value.LastRun)}}-{{else}}{{FormatTime $value.LastRun}}{{end}}" +
+		"{{if $value.LastErr}} (failed){{end}}\t " +
+		"{{if (IsUnsetTime $value.NextRun)}}-{{else}}{{FormatTime $value.NextRun}}{{end}}\t {{$value.Description}}\n"
+	DownloadScheduleListTmpl = DownloadScheduleListHeader + "{{ range $key, $value := . }}" + DownloadScheduleListBody + "{{end}}"
+
 	DSortListHeader = "JOB ID\t STATUS\t START\t FINISH\t DESCRIPTION\n"
 	DSortListBody   = "{{$value.ID}}\t " +
 		"{{if $value.Aborted}}Aborted" +
 		"{{else if $value.Archived}}Finished" +
 		"{{else}}Running" +
-		"{{end}}\t {{FormatTime $value.StartedTime}}\t {{FormatTime $value.FinishTime}} \t {{$value.Description}}\n"
+		"{{end}}{{if $value.ResumedPhase}} (resumed after {{$value.ResumedPhase}}){{end}}\t {{FormatTime $value.StartedTime}}\t {{FormatTime $value.FinishTime}} \t {{$value.Description}}\n"
 	DSortListTmpl = DSortListHeader + "{{ range $value := . }}" + DSortListBody + "{{end}}"
 
+	// EC scrub templates
+	ECHealthHeader = "BUCKET\t HEALTH\t OBJECTS\t HEALTHY\t REPAIRED\t DEGRADED\t LOST\t MISSING SLICES\t UNVERIFIED SLICES\t SCRUBBED\n"
+	ECHealthBody   = "{{$value.Bck}}\t {{$value.Health}}\t {{$value.Objects}}\t {{$value.Healthy}}\t " +
+		"{{$value.Repaired}}\t {{$value.Degraded}}\t {{$value.Lost}}\t {{$value.Missing}}\t {{$value.Unverified}}\t " +
+		"{{if $value.Running}}running{{else}}{{FormatTime $value.EndTime}}{{if $value.Aborted}} (aborted){{end}}{{end}}\n"
+	ECHealthTmpl = ECHealthHeader + "{{ range $value := . }}" + ECHealthBody + "{{end}}"
+
+	// Scrub templates
+	ScrubHeader = "TARGET\t OBJECTS\t SIZE\t CORRUPTED\t MISSING COPIES\t REPAIRED\t FAILED\t SCRUBBED\n"
+	ScrubBody   = "{{$value.TargetID}}\t {{$value.Objects}}\t {{FormatBytesSigned $value.Bytes 2}}\t {{$value.Corrupted}}\t " +
+		"{{$value.Missing}}\t {{$value.Repaired}}\t {{$value.Failed}}\t " +
+		"{{if $value.Running}}running{{else}}{{FormatTime $value.EndTime}}{{if $value.Aborted}} (aborted){{end}}{{end}}\n"
+	ScrubUnrepairedTmpl = "{{ range $value := . }}{{range $obj := $value.Unrepaired}}" +
+		"Unrepaired: {{$obj}} ({{$value.TargetID}})\n{{end}}{{end}}"
+	ScrubTmpl = ScrubHeader + "{{ range $value := . }}" + ScrubBody + "{{end}}" + ScrubUnrepairedTmpl
+
 	// Xactions templates
 	XactionsBodyTmpl     = XactionsBaseBodyTmpl + XactionsExtBodyTmpl
 	XactionsBaseBodyTmpl = XactionStatsHeader +
@@ -7,7 +7,9 @@ package cmn
 import (
 	"fmt"
 	"net/http"
+	"net/url"
 	"reflect"
+	"strconv"
 	"strings"
 
 	"github.com/NVIDIA/aistore/cmn/debug"
@@ -18,6 +20,7 @@ const (
 	SelectCached    = 1 << iota // list only cached (Cloud buckets only)
 	SelectMisplaced             // Include misplaced
 	SelectDeleted               // Include marked for deletion
+	SelectVersions              // Include prior versions and delete markers (ais buckets only)
 )
 
 // ActionMsg is a JSON-formatted control structures for the REST API
@@ -47,6 +50,7 @@ type (
 		Props             string `json:"props"`              // e.g. "checksum,size"
 		TimeFormat        string `json:"time_format"`        // "RFC822" default - see the enum above
 		Prefix            string `json:"prefix"`             // objname filter: return names starting with prefix
+		Delimiter         string `json:"delimiter"`          // roll up names that contain delimiter (after prefix) into directories
 		PageSize          uint   `json:"pagesize"`           // max entries returned by list objects call
 		StartAfter        string `json:"start_after"`        // start listing after (AIS buckets only)
 		ContinuationToken string `json:"continuation_token"` // `BucketList.ContinuationToken`
@@ -146,6 +150,9 @@ type (
 		// Bucket access attributes - see Allow* above
 		Access AccessAttrs `json:"access,string"`
 
+		// Cloud buckets: when PUT objects get written to the Cloud - see WritePolicy* enum
+		WritePolicy string `json:"write_policy"`
+
 		// Extra contains additional information which can depend on the provider.
 		Extra struct {
 			// [HTTP provider] Original URL prior to hashing.
@@ -153,6 +160,9 @@ type (
 
 			// [AWS provider] Region where the cloud bucket is located.
 			CloudRegion string `json:"cloud_region,omitempty" list:"readonly"`
+
+			// [AWS provider] S3-compatible endpoint of the bucket (see CloudConfAWS).
+			AWS ExtraPropsAWS `json:"aws" list:"omitempty"`
 		} `json:"extra,omitempty" list:"readonly"`
 
 		// unique bucket ID
@@ -165,18 +175,36 @@ type (
 		Renamed string `list:"omit"`
 	}
 	BucketPropsToUpdate struct {
-		BackendBck *BckToUpdate         `json:"backend_bck"`
-		Versioning *VersionConfToUpdate `json:"versioning"`
-		Cksum      *CksumConfToUpdate   `json:"checksum"`
-		LRU        *LRUConfToUpdate     `json:"lru"`
-		Mirror     *MirrorConfToUpdate  `json:"mirror"`
-		EC         *ECConfToUpdate      `json:"ec"`
-		Access     *AccessAttrs         `json:"access,string"`
+		BackendBck  *BckToUpdate         `json:"backend_bck"`
+		Versioning  *VersionConfToUpdate `json:"versioning"`
+		Cksum       *CksumConfToUpdate   `json:"checksum"`
+		LRU         *LRUConfToUpdate     `json:"lru"`
+		Mirror      *MirrorConfToUpdate  `json:"mirror"`
+		EC          *ECConfToUpdate      `json:"ec"`
+		Access      *AccessAttrs         `json:"access,string"`
+		WritePolicy *string              `json:"write_policy"`
+		Extra       *ExtraToUpdate       `json:"extra"`
 	}
 	BckToUpdate struct {
 		Name     *string `json:"name"`
 		Provider *string `json:"provider"`
 	}
+	ExtraToUpdate struct {
+		AWS *ExtraPropsAWSToUpdate `json:"aws"`
+	}
+
+	ExtraPropsAWS struct {
+		Endpoint       string `json:"endpoint,omitempty"`         // e.g., "http://minio:9000"; empty - Amazon S3
+		Profile        string `json:"profile,omitempty"`          // named profile in the shared credentials file
+		ForcePathStyle bool   `json:"force_path_style,omitempty"` // "endpoint/bucket" rather than "bucket.endpoint"
+		SkipVerify     bool   `json:"skip_verify,omitempty"`      // HTTPS endpoint with self-signed certificate
+	}
+	ExtraPropsAWSToUpdate struct {
+		Endpoint       *string `json:"endpoint"`
+		Profile        *string `json:"profile"`
+		ForcePathStyle *bool   `json:"force_path_style"`
+		SkipVerify     *bool   `json:"skip_verify"`
+	}
 )
 
 // object properties
@@ -193,6 +221,8 @@ type (
 		ParitySlices int              `list:"omit"`
 		IsECCopy     bool             `list:"omit"`
 		Present      bool             `json:"present"`
+		UserMD       SimpleKVs        `json:"user_md" list:"omit"`
+		Tags         SimpleKVs        `json:"tags" list:"omit"`
 	}
 	ObjectCksumProps struct {
 		Type  string `json:"type"`
@@ -209,7 +239,8 @@ var GetPropsDefault = []string{
 // GetPropsAll is a list of all `GetProps*` options.
 // NOTE: do **NOT** forget update this array when a prop is added/removed.
 var GetPropsAll = append(GetPropsDefault,
-	GetPropsVersion, GetPropsCached, GetTargetURL, GetPropsStatus, GetPropsCopies, GetPropsEC,
+	GetPropsVersion, GetPropsCached, GetTargetURL, GetPropsStatus, GetPropsCopies, GetPropsEC, GetPropsUserMD,
+	GetPropsTags,
 )
 
 ///////////////
@@ -222,6 +253,8 @@ func (msg *SelectMsg) NeedLocalMD() bool {
 	return msg.WantProp(GetPropsAtime) ||
 		msg.WantProp(GetPropsStatus) ||
 		msg.WantProp(GetPropsCopies) ||
+		msg.WantProp(GetPropsUserMD) ||
+		msg.WantProp(GetPropsTags) ||
 		msg.WantProp(GetPropsCached)
 }
 
@@ -321,6 +354,12 @@ func (c *VersionConf) String() string {
 	} else {
 		text += "no"
 	}
+	if c.KeepVersions() {
+		text += " | Keep versions: " + strconv.Itoa(c.MaxVersions)
+		if c.MaxAge != "" {
+			text += " (max age " + c.MaxAge + ")"
+		}
+	}
 
 	return text
 }
@@ -353,8 +392,15 @@ func (c *LRUConf) String() string {
 	if !c.Enabled {
 		return "Disabled"
 	}
-	return fmt.Sprintf("Watermarks: %d%%/%d%% | Do not evict time: %s | OOS: %v%%",
+	s := fmt.Sprintf("Watermarks: %d%%/%d%% | Do not evict time: %s | OOS: %v%%",
 		c.LowWM, c.HighWM, c.DontEvictTimeStr, c.OOS)
+	if c.Policy != "" && c.Policy != LRUPolicyLRU {
+		s += " | Policy: " + c.Policy
+	}
+	if c.Quota > 0 {
+		s += " | Quota: " + B2S(c.Quota, 2)
+	}
+	return s
 }
 
 func (c *MirrorConf) String() string {
@@ -476,6 +522,30 @@ func (bp *BucketProps) Validate(targetCnt int) error {
 		}
 	}
 
+	if bp.Extra.AWS != (ExtraPropsAWS{}) {
+		if bp.Provider != ProviderAmazon {
+			return fmt.Errorf("S3 endpoint (extra.aws) can only be set for %q buckets", ProviderAmazon)
+		}
+		if err := bp.Extra.AWS.Validate(); err != nil {
+			return err
+		}
+	}
+
+	switch bp.WritePolicy {
+	case "", WritePolicyThrough:
+	case WritePolicyBack, WritePolicyNever:
+		bck := Bck{Provider: bp.Provider, Props: bp}
+		if !bck.IsCloud() {
+			return fmt.Errorf("write policy %q can only be set for Cloud buckets", bp.WritePolicy)
+		}
+	default:
+		return fmt.Errorf("invalid write policy %q, must be one of (%s, %s, %s)",
+			bp.WritePolicy, WritePolicyThrough, WritePolicyBack, WritePolicyNever)
+	}
+
+	if err := bp.Versioning.ValidateAsProps(); err != nil {
+		return err
+	}
 	validationArgs := &ValidationArgs{TargetCnt: targetCnt}
 	validators := []PropsValidator{&bp.Cksum, &bp.LRU, &bp.Mirror, &bp.EC}
 	for _, validator := range validators {
@@ -490,6 +560,17 @@ func (bp *BucketProps) Validate(targetCnt int) error {
 	return nil
 }
 
+func (c *ExtraPropsAWS) Validate() error {
+	if c.Endpoint == "" {
+		return nil
+	}
+	u, err := url.Parse(c.Endpoint)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("invalid S3 endpoint %q: expecting http(s)://host[:port]", c.Endpoint)
+	}
+	return nil
+}
+
 func (bp *BucketProps) Apply(propsToUpdate BucketPropsToUpdate) {
 	copyProps(propsToUpdate, bp)
 }
@@ -36,6 +36,7 @@ const (
 	ActRebalance      = "rebalance"
 	ActResilver       = "resilver"
 	ActLRU            = "lru"
+	ActScrub          = "scrub"
 	ActSyncLB         = "synclb"
 	ActCreateLB       = "createlb"
 	ActDestroyLB      = "destroylb"
@@ -68,10 +69,13 @@ const (
 	ActPutCopies      = "putcopies"
 	ActMakeNCopies    = "makencopies"
 	ActLoadLomCache   = "loadlomcache"
-	ActECGet          = "ecget"    // erasure decode objects
-	ActECPut          = "ecput"    // erasure encode objects
-	ActECRespond      = "ecresp"   // respond to other targets' EC requests
-	ActECEncode       = "ecencode" // erasure code a bucket
+	ActWriteBack      = "writeback"
+	ActECGet          = "ecget"      // erasure decode objects
+	ActECPut          = "ecput"      // erasure encode objects
+	ActECRespond      = "ecresp"     // respond to other targets' EC requests
+	ActECEncode       = "ecencode"   // erasure code a bucket
+	ActECRelayout     = "ecrelayout" // re-encode erasure coded bucket with the new slice counts
+	ActECScrub        = "ecscrub"    // verify and rebuild EC slices and replicas of a bucket
 	ActStartGFN       = "metasync-start-gfn"
 	ActRecoverBck     = "recoverbck"
 	ActAttach         = "attach"
@@ -136,6 +140,8 @@ const (
 	HeaderObjCksumVal  = "checksum.value" // Checksum Value
 	HeaderObjAtime     = "atime"          // Object access time
 	HeaderObjCustomMD  = "custom_md"      // Object custom metadata
+	HeaderObjUserMD    = "user_md"        // Object user-defined metadata (key=value)
+	HeaderObjTags      = "tags"           // Object tags (key=value)
 	HeaderObjSize      = "size"           // Object size (bytes)
 	HeaderObjVersion   = "version"        // Object version/generation - ais or Cloud
 	HeaderObjECMeta    = "ec_meta"        // Info about EC object/slice/replica
@@ -187,6 +193,7 @@ const (
 	URLParamSilent           = "sln" // true: destination should not log errors (HEAD request)
 	URLParamRebStatus        = "rbs" // true: get detailed rebalancing status
 	URLParamRebData          = "rbd" // true: get EC rebalance data (pulling data if push way fails)
+	URLParamECVerify         = "ecv" // true: verify EC slice (replica) prior to returning its metadata
 	URLParamTaskAction       = "tac" // "start", "status", "result"
 	URLParamClusterInfo      = "cii" // true: Health to return ais.clusterInfo
 	URLParamRecvType         = "rtp" // to tell real PUT from migration PUT
@@ -201,6 +208,8 @@ const (
 	URLParamTotalCompressedSize       = "tcs"
 	URLParamTotalInputShardsExtracted = "tise"
 	URLParamTotalUncompressedSize     = "tunc"
+	URLParamResumePhase               = "rph"
+	URLParamSkippedObjects            = "skipobj"
 
 	// 2PC transactions - control plane
 	URLParamNetwTimeout  = "xnt" // [begin, start-commit] timeout
@@ -212,6 +221,9 @@ const (
 
 	// HTTP bucket support
 	URLParamOrigURL = "origurl"
+
+	// object versioning: GET, HEAD, or DELETE a given version of the object
+	URLParamObjVersion = "version"
 )
 
 // enum: task action (cmn.URLParamTaskAction)
@@ -259,6 +271,8 @@ const (
 	GetPropsStatus   = "status"
 	GetPropsCopies   = "copies"
 	GetPropsEC       = "ec"
+	GetPropsUserMD   = "user_md"
+	GetPropsTags     = "tags"
 )
 
 // BucketEntry.Status
@@ -273,6 +287,9 @@ const (
 	EntryStatusBits = 5                          // N bits
 	EntryStatusMask = (1 << EntryStatusBits) - 1 // mask for N low bits
 	EntryIsCached   = 1 << (EntryStatusBits + 1) // StatusMaskBits + 1
+	EntryIsDir      = 1 << (EntryStatusBits + 2) // common prefix of the names rolled up by delimiter
+	EntryIsPrevVer  = 1 << (EntryStatusBits + 3) // prior (noncurrent) version of the object
+	EntryIsDelMark  = 1 << (EntryStatusBits + 4) // delete marker (implies EntryIsPrevVer)
 )
 
 // List objects default page size
@@ -280,6 +297,25 @@ const (
 	DefaultListPageSizeAIS = 10000
 )
 
+// max number of prior versions of an object (see VersionConf.MaxVersions)
+const MaxObjVersions = 1000
+
+// Write policy of a Cloud bucket (see BucketProps.WritePolicy)
+const (
+	WritePolicyThrough = "write-through" // PUT completes when the object is written to the Cloud (default)
+	WritePolicyBack    = "write-back"    // PUT completes upon local commit; the object is flushed in the background
+	WritePolicyNever   = "write-never"   // the object is stored locally and not written to the Cloud
+)
+
+// Eviction policy of a bucket (see LRUConf.Policy)
+const (
+	LRUPolicyLRU  = "lru"  // least recently used first (default)
+	LRUPolicyLFU  = "lfu"  // least frequently used first
+	LRUPolicyGDSF = "gdsf" // Greedy-Dual-Size-Frequency: least frequently used per byte first
+)
+
+var SupportedLRUPolicies = []string{LRUPolicyLRU, LRUPolicyLFU, LRUPolicyGDSF}
+
 // RESTful URL path: l1/l2/l3
 const (
 	// l1
@@ -335,6 +371,8 @@ const (
 	Records     = "records"
 	Shards      = "shards"
 	FinishedAck = "finished-ack"
+	Resume      = "resume"
+	Checkpoint  = "checkpoint"
 	List        = "list"
 	Remove      = "remove"
 	Next        = "next"
@@ -352,6 +390,7 @@ const (
 	ETL       = "etl"
 	ETLInit   = Init
 	ETLBuild  = "build"
+	ETLPipe   = "pipeline"
 	ETLList   = List
 	ETLLogs   = "logs"
 	ETLObject = "object"
@@ -21,7 +21,9 @@ const (
 	ProviderAIS    = "ais"
 	ProviderAzure  = "azure"
 	ProviderHTTP   = "ht"
-	allProviders   = "aws, gcp, ais, azure, ht"
+	ProviderHDFS   = "hdfs"
+	ProviderFS     = "fs"
+	allProviders   = "aws, gcp, ais, azure, ht, hdfs, fs"
 
 	NsUUIDPrefix = '@' // BEWARE: used by on-disk layout
 	NsNamePrefix = '#' // BEWARE: used by on-disk layout
@@ -92,6 +94,8 @@ var (
 		ProviderAmazon,
 		ProviderAzure,
 		ProviderHTTP,
+		ProviderHDFS,
+		ProviderFS,
 	)
 )
 
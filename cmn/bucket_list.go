@@ -14,15 +14,18 @@ package cmn
 // 0-2: objects status, all statuses are mutually exclusive, so it can hold up
 //      to 8 different statuses. Now only OK=0, Moved=1, Deleted=2 are supported
 // 3:   CheckExists (for cloud bucket it shows if the object in local cache)
+// 4:   IsDir (the entry is a common prefix of the names rolled up by delimiter)
 type BucketEntry struct {
-	Name      string `json:"name" msg:"n"`                            // name of the object - NOTE: Does not include the bucket name.
-	Size      int64  `json:"size,string,omitempty" msg:"s,omitempty"` // size in bytes
-	Checksum  string `json:"checksum,omitempty" msg:"cs,omitempty"`   // checksum
-	Atime     string `json:"atime,omitempty" msg:"a,omitempty"`       // formatted as per SelectMsg.TimeFormat
-	Version   string `json:"version,omitempty" msg:"v,omitempty"`     // version/generation ID. In GCP it is int64, in AWS it is a string
-	TargetURL string `json:"target_url,omitempty" msg:"t,omitempty"`  // URL of target which has the entry
-	Copies    int16  `json:"copies,omitempty" msg:"c,omitempty"`      // ## copies (non-replicated = 1)
-	Flags     uint16 `json:"flags,omitempty" msg:"f,omitempty"`       // object flags, like CheckExists, IsMoved etc
+	Name      string            `json:"name" msg:"n"`                            // name of the object - NOTE: Does not include the bucket name.
+	Size      int64             `json:"size,string,omitempty" msg:"s,omitempty"` // size in bytes
+	Checksum  string            `json:"checksum,omitempty" msg:"cs,omitempty"`   // checksum
+	Atime     string            `json:"atime,omitempty" msg:"a,omitempty"`       // formatted as per SelectMsg.TimeFormat
+	Version   string            `json:"version,omitempty" msg:"v,omitempty"`     // version/generation ID. In GCP it is int64, in AWS it is a string
+	TargetURL string            `json:"target_url,omitempty" msg:"t,omitempty"`  // URL of target which has the entry
+	Copies    int16             `json:"copies,omitempty" msg:"c,omitempty"`      // ## copies (non-replicated = 1)
+	Flags     uint16            `json:"flags,omitempty" msg:"f,omitempty"`       // object flags, like CheckExists, IsMoved etc
+	UserMD    map[string]string `json:"user_md,omitempty" msg:"u,omitempty"`     // user-defined metadata
+	Tags      map[string]string `json:"tags,omitempty" msg:"g,omitempty"`        // object tags
 }
 
 func (be *BucketEntry) CheckExists() bool {
@@ -33,6 +36,18 @@ func (be *BucketEntry) SetExists() {
 	be.Flags |= EntryIsCached
 }
 
+func (be *BucketEntry) IsDir() bool {
+	return be.Flags&EntryIsDir != 0
+}
+
+func (be *BucketEntry) IsPrevVersion() bool {
+	return be.Flags&EntryIsPrevVer != 0
+}
+
+func (be *BucketEntry) IsDelMarker() bool {
+	return be.Flags&EntryIsDelMark != 0
+}
+
 func (be *BucketEntry) IsStatusOK() bool {
 	return be.Flags&EntryStatusMask == 0
 }
@@ -40,7 +55,7 @@ func (be *BucketEntry) IsStatusOK() bool {
 func (be *BucketEntry) String() string { return "{" + be.Name + "}" }
 
 func (be *BucketEntry) CopyWithProps(propsSet StringSet) (ne *BucketEntry) {
-	ne = &BucketEntry{Name: be.Name}
+	ne = &BucketEntry{Name: be.Name, Flags: be.Flags & (EntryIsDir | EntryIsPrevVer | EntryIsDelMark)}
 	if propsSet.Contains(GetPropsSize) {
 		ne.Size = be.Size
 	}
@@ -59,6 +74,12 @@ func (be *BucketEntry) CopyWithProps(propsSet StringSet) (ne *BucketEntry) {
 	if propsSet.Contains(GetPropsCopies) {
 		ne.Copies = be.Copies
 	}
+	if propsSet.Contains(GetPropsUserMD) {
+		ne.UserMD = be.UserMD
+	}
+	if propsSet.Contains(GetPropsTags) {
+		ne.Tags = be.Tags
+	}
 	return
 }
 
@@ -76,6 +76,66 @@ func (z *BucketEntry) DecodeMsg(dc *msgp.Reader) (err error) {
 				err = msgp.WrapError(err, "Flags")
 				return
 			}
+		case "u":
+			var zb0002 uint32
+			zb0002, err = dc.ReadMapHeader()
+			if err != nil {
+				err = msgp.WrapError(err, "UserMD")
+				return
+			}
+			if z.UserMD == nil {
+				z.UserMD = make(map[string]string, zb0002)
+			} else if len(z.UserMD) > 0 {
+				for key := range z.UserMD {
+					delete(z.UserMD, key)
+				}
+			}
+			for zb0002 > 0 {
+				zb0002--
+				var za0001 string
+				var za0002 string
+				za0001, err = dc.ReadString()
+				if err != nil {
+					err = msgp.WrapError(err, "UserMD")
+					return
+				}
+				za0002, err = dc.ReadString()
+				if err != nil {
+					err = msgp.WrapError(err, "UserMD", za0001)
+					return
+				}
+				z.UserMD[za0001] = za0002
+			}
+		case "g":
+			var zb0003 uint32
+			zb0003, err = dc.ReadMapHeader()
+			if err != nil {
+				err = msgp.WrapError(err, "Tags")
+				return
+			}
+			if z.Tags == nil {
+				z.Tags = make(map[string]string, zb0003)
+			} else if len(z.Tags) > 0 {
+				for key := range z.Tags {
+					delete(z.Tags, key)
+				}
+			}
+			for zb0003 > 0 {
+				zb0003--
+				var za0003 string
+				var za0004 string
+				za0003, err = dc.ReadString()
+				if err != nil {
+					err = msgp.WrapError(err, "Tags")
+					return
+				}
+				za0004, err = dc.ReadString()
+				if err != nil {
+					err = msgp.WrapError(err, "Tags", za0003)
+					return
+				}
+				z.Tags[za0003] = za0004
+			}
 		default:
 			err = dc.Skip()
 			if err != nil {
@@ -90,8 +150,8 @@ func (z *BucketEntry) DecodeMsg(dc *msgp.Reader) (err error) {
 // EncodeMsg implements msgp.Encodable
 func (z *BucketEntry) EncodeMsg(en *msgp.Writer) (err error) {
 	// omitempty: check for empty values
-	zb0001Len := uint32(8)
-	var zb0001Mask uint8 /* 8 bits */
+	zb0001Len := uint32(10)
+	var zb0001Mask uint16 /* 10 bits */
 	if z.Size == 0 {
 		zb0001Len--
 		zb0001Mask |= 0x2
@@ -120,6 +180,14 @@ func (z *BucketEntry) EncodeMsg(en *msgp.Writer) (err error) {
 		zb0001Len--
 		zb0001Mask |= 0x80
 	}
+	if z.UserMD == nil {
+		zb0001Len--
+		zb0001Mask |= 0x100
+	}
+	if z.Tags == nil {
+		zb0001Len--
+		zb0001Mask |= 0x200
+	}
 	// variable map header, size zb0001Len
 	err = en.Append(0x80 | uint8(zb0001Len))
 	if err != nil {
@@ -222,12 +290,73 @@ func (z *BucketEntry) EncodeMsg(en *msgp.Writer) (err error) {
 			return
 		}
 	}
+	if (zb0001Mask & 0x100) == 0 { // if not empty
+		// write "u"
+		err = en.Append(0xa1, 0x75)
+		if err != nil {
+			return
+		}
+		err = en.WriteMapHeader(uint32(len(z.UserMD)))
+		if err != nil {
+			err = msgp.WrapError(err, "UserMD")
+			return
+		}
+		for za0001, za0002 := range z.UserMD {
+			err = en.WriteString(za0001)
+			if err != nil {
+				err = msgp.WrapError(err, "UserMD")
+				return
+			}
+			err = en.WriteString(za0002)
+			if err != nil {
+				err = msgp.WrapError(err, "UserMD", za0001)
+				return
+			}
+		}
+	}
+	if (zb0001Mask & 0x200) == 0 { // if not empty
+		// write "g"
+		err = en.Append(0xa1, 0x67)
+		if err != nil {
+			return
+		}
+		err = en.WriteMapHeader(uint32(len(z.Tags)))
+		if err != nil {
+			err = msgp.WrapError(err, "Tags")
+			return
+		}
+		for za0003, za0004 := range z.Tags {
+			err = en.WriteString(za0003)
+			if err != nil {
+				err = msgp.WrapError(err, "Tags")
+				return
+			}
+			err = en.WriteString(za0004)
+			if err != nil {
+				err = msgp.WrapError(err, "Tags", za0003)
+				return
+			}
+		}
+	}
 	return
 }
 
 // Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
 func (z *BucketEntry) Msgsize() (s int) {
-	s = 1 + 2 + msgp.StringPrefixSize + len(z.Name) + 2 + msgp.Int64Size + 3 + msgp.StringPrefixSize + len(z.Checksum) + 2 + msgp.StringPrefixSize + len(z.Atime) + 2 + msgp.StringPrefixSize + len(z.Version) + 2 + msgp.StringPrefixSize + len(z.TargetURL) + 2 + msgp.Int16Size + 2 + msgp.Uint16Size
+	s = 1 + 2 + msgp.StringPrefixSize + len(z.Name) + 2 + msgp.Int64Size + 3 + msgp.StringPrefixSize + len(z.Checksum) + 2 + msgp.StringPrefixSize + len(z.Atime) + 2 + msgp.StringPrefixSize + len(z.Version) + 2 + msgp.StringPrefixSize + len(z.TargetURL) + 2 + msgp.Int16Size + 2 + msgp.Uint16Size + 2 + msgp.MapHeaderSize
+	if z.UserMD != nil {
+		for za0001, za0002 := range z.UserMD {
+			_ = za0002
+			s += msgp.StringPrefixSize + len(za0001) + msgp.StringPrefixSize + len(za0002)
+		}
+	}
+	s += 2 + msgp.MapHeaderSize
+	if z.Tags != nil {
+		for za0003, za0004 := range z.Tags {
+			_ = za0004
+			s += msgp.StringPrefixSize + len(za0003) + msgp.StringPrefixSize + len(za0004)
+		}
+	}
 	return
 }
 
@@ -43,6 +43,10 @@ const (
 	ExtTarTgz = ".tar.gz"
 	// ExtZip is zip files extension
 	ExtZip = ".zip"
+	// ExtTFRecord is TensorFlow TFRecord files extension
+	ExtTFRecord = ".tfrecord"
+	// ExtRecordIO is MXNet RecordIO files extension
+	ExtRecordIO = ".rec"
 
 	// misc
 	SizeofI64 = int(unsafe.Sizeof(uint64(0)))
@@ -78,6 +82,10 @@ var (
 		SkipVerifyCrt string
 		UseHTTPS      string
 
+		Zone string
+		Rack string
+		Host string
+
 		NumTarget string
 		NumProxy  string
 	}{
@@ -87,6 +95,11 @@ var (
 		SkipVerifyCrt: "AIS_SKIP_VERIFY_CRT",
 		UseHTTPS:      "AIS_USE_HTTPS",
 
+		// Failure domain of the node (override `DomainConf`)
+		Zone: "AIS_ZONE",
+		Rack: "AIS_RACK",
+		Host: "AIS_HOST",
+
 		// Env variables used for tests or CI
 		NumTarget: "NUM_TARGET",
 		NumProxy:  "NUM_PROXY",
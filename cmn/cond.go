@@ -0,0 +1,141 @@
+// Package cmn provides common low-level types and utilities for all aistore projects
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package cmn
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Conditional requests (Ref: https://tools.ietf.org/html/rfc7232)
+//
+// Object's entity tag is its checksum value (see the caller), and the last
+// modification time is the time the object's content was written.
+// If-Match uses strong comparison (a weak entity tag never matches), while
+// If-None-Match uses weak comparison (section 2.3.2).
+
+const (
+	// matches any existing object: e.g., `If-None-Match: *` makes PUT create-only
+	ETagAny = "*"
+
+	etagWeakPrefix = "W/"
+)
+
+type CondHdr struct {
+	IfMatch           []string  // entity tags (unquoted, weak ones prefixed with "W/") or ETagAny
+	IfNoneMatch       []string  // ditto
+	IfModifiedSince   time.Time // zero if not specified
+	IfUnmodifiedSince time.Time // ditto
+}
+
+// ParseCondHdr returns preconditions of the request or nil if there are none.
+func ParseCondHdr(hdr http.Header) (cond *CondHdr, err error) {
+	c := &CondHdr{
+		IfMatch:     parseETags(hdr.Get(HeaderIfMatch)),
+		IfNoneMatch: parseETags(hdr.Get(HeaderIfNoneMatch)),
+	}
+	if c.IfModifiedSince, err = parseHTTPTime(hdr, HeaderIfModifiedSince); err != nil {
+		return
+	}
+	if c.IfUnmodifiedSince, err = parseHTTPTime(hdr, HeaderIfUnmodifiedSince); err != nil {
+		return
+	}
+	if len(c.IfMatch) == 0 && len(c.IfNoneMatch) == 0 && c.IfModifiedSince.IsZero() && c.IfUnmodifiedSince.IsZero() {
+		return nil, nil
+	}
+	return c, nil
+}
+
+// comma-separated list of (optionally, quoted and/or weak) entity tags
+func parseETags(s string) (etags []string) {
+	if s == "" {
+		return nil
+	}
+	for _, etag := range strings.Split(s, ",") {
+		var (
+			etag = strings.TrimSpace(etag)
+			weak = strings.HasPrefix(etag, etagWeakPrefix)
+		)
+		etag = strings.Trim(strings.TrimPrefix(etag, etagWeakPrefix), "\"")
+		if etag == "" {
+			continue
+		}
+		if weak {
+			etag = etagWeakPrefix + etag
+		}
+		etags = append(etags, etag)
+	}
+	return
+}
+
+func parseHTTPTime(hdr http.Header, name string) (t time.Time, err error) {
+	s := hdr.Get(name)
+	if s == "" {
+		return
+	}
+	if t, err = http.ParseTime(s); err != nil {
+		err = fmt.Errorf("invalid %s header %q: %v", name, s, err)
+	}
+	return
+}
+
+// object's entity tags are always strong; `weak` selects the comparison function
+func matchETags(condETags, objETags []string, weak bool) bool {
+	for _, c := range condETags {
+		if c == ETagAny {
+			return true
+		}
+		if strings.HasPrefix(c, etagWeakPrefix) {
+			if !weak {
+				continue
+			}
+			c = strings.TrimPrefix(c, etagWeakPrefix)
+		}
+		for _, etag := range objETags {
+			if etag != "" && c == etag {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// CreateOnly returns true if the request must fail when the object exists.
+func (c *CondHdr) CreateOnly() bool {
+	return c != nil && len(c.IfNoneMatch) == 1 && c.IfNoneMatch[0] == ETagAny
+}
+
+// Check evaluates the preconditions against the existing object in the order
+// prescribed by RFC 7232 (section 6). Returns zero if the request is to be
+// performed, http.StatusNotModified (GET and HEAD only), or
+// http.StatusPreconditionFailed otherwise.
+func (c *CondHdr) Check(method string, objETags []string, mtime time.Time) int {
+	var (
+		read  = method == http.MethodGet || method == http.MethodHead
+		mtsec = mtime.Truncate(time.Second) // HTTP dates have 1s resolution
+	)
+	if len(c.IfMatch) > 0 {
+		if !matchETags(c.IfMatch, objETags, false /*weak*/) {
+			return http.StatusPreconditionFailed
+		}
+	} else if !c.IfUnmodifiedSince.IsZero() && mtsec.After(c.IfUnmodifiedSince) {
+		return http.StatusPreconditionFailed
+	}
+	if len(c.IfNoneMatch) > 0 {
+		if !matchETags(c.IfNoneMatch, objETags, true /*weak*/) {
+			return 0
+		}
+		if read {
+			return http.StatusNotModified
+		}
+		return http.StatusPreconditionFailed
+	}
+	if read && !c.IfModifiedSince.IsZero() && !mtsec.After(c.IfModifiedSince) {
+		return http.StatusNotModified
+	}
+	return 0
+}
@@ -135,16 +135,19 @@ type (
 		Rebalance   RebalanceConf   `json:"rebalance"`
 		Replication ReplicationConf `json:"replication"`
 		Cksum       CksumConf       `json:"checksum"`
+		Scrub       ScrubConf       `json:"scrub"`
 		Versioning  VersionConf     `json:"versioning"`
 		FSpaths     FSPathsConf     `json:"fspaths"`
 		TestFSP     TestfspathConf  `json:"test_fspaths"`
 		Net         NetConf         `json:"net"`
+		Domain      DomainConf      `json:"domain"`
 		FSHC        FSHCConf        `json:"fshc"`
 		Auth        AuthConf        `json:"auth"`
 		Keepalive   KeepaliveConf   `json:"keepalivetracker"`
 		Downloader  DownloaderConf  `json:"downloader"`
 		DSort       DSortConf       `json:"distributed_sort"`
 		Compression CompressionConf `json:"compression"`
+		ETL         ETLConf         `json:"etl"`
 	}
 	CloudConf struct {
 		Conf map[string]interface{} `json:"conf,omitempty"` // implementation depends on cloud provider
@@ -164,6 +167,34 @@ type (
 	CloudConfAIS map[string][]string // cluster alias -> [urls...]
 	CloudInfoAIS map[string]*RemoteAISInfo
 
+	// S3-compatible endpoint(s) of the aws provider: the default one (Amazon S3
+	// unless specified otherwise) and, optionally, per-namespace ones - e.g.,
+	// `aws://#minio/bucket` is accessed via the endpoint named "minio".
+	// Bucket's `extra.aws` props, if present, take precedence.
+	CloudConfAWS struct {
+		AWSEndpointConf
+		Namespaces map[string]AWSEndpointConf `json:"namespaces,omitempty"`
+	}
+	AWSEndpointConf struct {
+		ExtraPropsAWS
+		Region string `json:"region,omitempty"` // default region of the endpoint
+	}
+
+	// HDFS: buckets are the directories under `root`, objects - the files
+	// in (the subdirectories of) those directories
+	CloudConfHDFS struct {
+		Addresses           []string `json:"addresses"`                       // namenode(s), e.g. "namenode:8020"
+		User                string   `json:"user,omitempty"`                  // HDFS user; defaults to the user running the target
+		Root                string   `json:"root,omitempty"`                  // defaults to "/"
+		UseDatanodeHostname bool     `json:"use_datanode_hostname,omitempty"` // connect to datanodes via hostnames rather than IPs
+	}
+
+	// local filesystem (e.g., NFS or Lustre mounted at the same path on all
+	// targets): buckets are the directories under `root`
+	CloudConfFS struct {
+		Root string `json:"root"`
+	}
+
 	MirrorConf struct {
 		Copies      int64 `json:"copies"`       // num local copies
 		Burst       int   `json:"burst_buffer"` // channel buffer size
@@ -263,14 +294,43 @@ type (
 		// CapacityUpdTime is the parsed value of CapacityUpdTimeStr
 		CapacityUpdTime time.Duration `json:"-"`
 
+		// Policy determines the order in which the objects of a bucket get evicted:
+		// LRUPolicyLRU (default), LRUPolicyLFU, or LRUPolicyGDSF
+		Policy string `json:"policy"`
+
+		// Quota: max size (bytes) of the bucket on each target - when exceeded, LRU
+		// evicts the bucket's own objects regardless of the used capacity; zero means no quota
+		Quota int64 `json:"quota"`
+
 		// Enabled: LRU will only run when set to true
 		Enabled bool `json:"enabled"`
 	}
+	// ScrubConf configures periodic verification of the stored objects and
+	// their copies against the checksums stored in their metadata (see `scrub`).
+	ScrubConf struct {
+		// IntervalStr denotes the period of time between two consecutive scrubs
+		IntervalStr string `json:"interval"`
+
+		// Interval is the parsed value of IntervalStr
+		Interval time.Duration `json:"-"`
+
+		// RateStr limits the amount of data read per second from each mountpath (e.g. "20MB");
+		// zero (or empty) means no limit other than the target utilization
+		RateStr string `json:"rate"`
+
+		// Rate is the parsed value of RateStr (bytes per second)
+		Rate int64 `json:"-"`
+
+		// Enabled: periodic scrub will only run when set to true
+		Enabled bool `json:"enabled"`
+	}
 	LRUConfToUpdate struct {
-		LowWM   *int64 `json:"lowwm"`
-		HighWM  *int64 `json:"highwm"`
-		OOS     *int64 `json:"out_of_space"`
-		Enabled *bool  `json:"enabled"`
+		LowWM   *int64  `json:"lowwm"`
+		HighWM  *int64  `json:"highwm"`
+		OOS     *int64  `json:"out_of_space"`
+		Policy  *string `json:"policy"`
+		Quota   *int64  `json:"quota"`
+		Enabled *bool   `json:"enabled"`
 	}
 	DiskConf struct {
 		DiskUtilLowWM   int64         `json:"disk_util_low_wm"`  // no throttling below
@@ -331,10 +391,19 @@ type (
 
 		// Validate object version upon warm GET.
 		ValidateWarmGet bool `json:"validate_warm_get"`
+
+		// MaxVersions: the number of prior versions (including delete markers)
+		// to keep for each object (ais buckets only); zero - overwrite the content.
+		MaxVersions int `json:"max_versions"`
+
+		// MaxAge: prior versions older than that get removed by LRU; empty - no limit.
+		MaxAge string `json:"max_age"`
 	}
 	VersionConfToUpdate struct {
-		Enabled         *bool `json:"enabled"`
-		ValidateWarmGet *bool `json:"validate_warm_get"`
+		Enabled         *bool   `json:"enabled"`
+		ValidateWarmGet *bool   `json:"validate_warm_get"`
+		MaxVersions     *int    `json:"max_versions"`
+		MaxAge          *string `json:"max_age"`
 	}
 
 	TestfspathConf struct {
@@ -353,6 +422,16 @@ type (
 		UseIntraData     bool     `json:"-"`
 	}
 
+	// DomainConf labels the failure domain of the node. Targets that share a
+	// label are assumed to fail together - HRW spreads EC slices and replicas
+	// across zones first, then racks and then hosts (see `cluster.HrwTargetList`).
+	// Labels can be overridden with AIS_ZONE, AIS_RACK and AIS_HOST variables.
+	DomainConf struct {
+		Zone string `json:"zone"`
+		Rack string `json:"rack"`
+		Host string `json:"host"`
+	}
+
 	L4Conf struct {
 		Proto               string `json:"proto"`              // tcp, udp
 		PortStr             string `json:"port"`               // listening port
@@ -418,6 +497,11 @@ type (
 	FSPathsConf struct {
 		Paths StringSet `json:"paths,omitempty"`
 	}
+	ETLConf struct {
+		// AllowProcess: targets may run ETL as a local process (executable or Go plugin),
+		// with the privileges of the target itself (see `etl.ProcessSpec`)
+		AllowProcess bool `json:"allow_process"`
+	}
 	// lz4 block and frame formats: http://fastcompression.blogspot.com/2013/04/lz4-streaming-format-final.html
 	CompressionConf struct {
 		BlockMaxSize int  `json:"block_size"` // *uncompressed* block max size
@@ -543,6 +627,7 @@ var (
 var (
 	_ Validator = (*CloudConf)(nil)
 	_ Validator = (*CksumConf)(nil)
+	_ Validator = (*ScrubConf)(nil)
 	_ Validator = (*LRUConf)(nil)
 	_ Validator = (*MirrorConf)(nil)
 	_ Validator = (*ECConf)(nil)
@@ -622,6 +707,36 @@ func (c *CloudConf) Validate(_ *Config) (err error) {
 				break
 			}
 			c.Conf[provider] = aisConf
+		case ProviderAmazon:
+			var awsConf CloudConfAWS
+			if err := jsoniter.Unmarshal(b, &awsConf); err != nil {
+				return fmt.Errorf("invalid cloud specification: %v", err)
+			}
+			if err := awsConf.Validate(); err != nil {
+				return err
+			}
+			c.Conf[provider] = awsConf
+			c.setProvider(provider)
+		case ProviderHDFS:
+			var hdfsConf CloudConfHDFS
+			if err := jsoniter.Unmarshal(b, &hdfsConf); err != nil {
+				return fmt.Errorf("invalid cloud specification: %v", err)
+			}
+			if err := hdfsConf.Validate(); err != nil {
+				return err
+			}
+			c.Conf[provider] = hdfsConf
+			c.setProvider(provider)
+		case ProviderFS:
+			var fsConf CloudConfFS
+			if err := jsoniter.Unmarshal(b, &fsConf); err != nil {
+				return fmt.Errorf("invalid cloud specification: %v", err)
+			}
+			if err := fsConf.Validate(); err != nil {
+				return err
+			}
+			c.Conf[provider] = fsConf
+			c.setProvider(provider)
 		case "":
 			continue
 		default:
@@ -634,7 +749,7 @@ func (c *CloudConf) Validate(_ *Config) (err error) {
 func (c *CloudConf) setProvider(provider string) {
 	var ns Ns
 	switch provider {
-	case ProviderAmazon, ProviderGoogle, ProviderAzure:
+	case ProviderAmazon, ProviderGoogle, ProviderAzure, ProviderHDFS, ProviderFS:
 		ns = NsGlobal
 
 	default:
@@ -654,6 +769,55 @@ func (c *CloudConf) ProviderConf(provider string, newConf ...interface{}) (conf
 	return
 }
 
+func (c *CloudConfAWS) Validate() error {
+	if err := c.ExtraPropsAWS.Validate(); err != nil {
+		return fmt.Errorf("invalid cloud.conf.aws: %v", err)
+	}
+	for name, ep := range c.Namespaces {
+		if err := (Ns{Name: name}).Validate(); err != nil {
+			return fmt.Errorf("invalid cloud.conf.aws namespace: %v", err)
+		}
+		if ep.Endpoint == "" {
+			return fmt.Errorf("invalid cloud.conf.aws: no endpoint for namespace %q", name)
+		}
+		if err := ep.ExtraPropsAWS.Validate(); err != nil {
+			return fmt.Errorf("invalid cloud.conf.aws namespace %q: %v", name, err)
+		}
+	}
+	return nil
+}
+
+// Endpoint returns the configuration of S3 endpoint for the bucket in
+// the given namespace.
+func (c *CloudConfAWS) Endpoint(ns Ns) AWSEndpointConf {
+	if ns.Name != "" {
+		if ep, ok := c.Namespaces[ns.Name]; ok {
+			return ep
+		}
+	}
+	return c.AWSEndpointConf
+}
+
+func (c *CloudConfHDFS) Validate() error {
+	if len(c.Addresses) == 0 {
+		return errors.New("invalid cloud.conf.hdfs: no namenode addresses")
+	}
+	if c.Root == "" {
+		c.Root = "/"
+	} else if !strings.HasPrefix(c.Root, "/") {
+		return fmt.Errorf("invalid cloud.conf.hdfs: root %q is not an absolute path", c.Root)
+	}
+	return nil
+}
+
+func (c *CloudConfFS) Validate() error {
+	if !filepath.IsAbs(c.Root) {
+		return fmt.Errorf("invalid cloud.conf.fs: root %q is not an absolute path", c.Root)
+	}
+	c.Root = filepath.Clean(c.Root)
+	return nil
+}
+
 func (c *DiskConf) Validate(_ *Config) (err error) {
 	lwm, hwm, maxwm := c.DiskUtilLowWM, c.DiskUtilHighWM, c.DiskUtilMaxWM
 	if lwm <= 0 || hwm <= lwm || maxwm <= hwm || maxwm > 100 {
@@ -690,6 +854,12 @@ func (c *LRUConf) Validate(_ *Config) (err error) {
 	if c.CapacityUpdTime, err = time.ParseDuration(c.CapacityUpdTimeStr); err != nil {
 		return fmt.Errorf("invalid lru.capacity_upd_time format: %v", err)
 	}
+	if !IsValidLRUPolicy(c.Policy) {
+		return fmt.Errorf("invalid lru.policy: %q (expected one of %v)", c.Policy, SupportedLRUPolicies)
+	}
+	if c.Quota < 0 {
+		return fmt.Errorf("invalid lru.quota: %d", c.Quota)
+	}
 	return nil
 }
 
@@ -700,6 +870,12 @@ func (c *LRUConf) ValidateAsProps(args *ValidationArgs) (err error) {
 	return c.Validate(nil)
 }
 
+// IsValidLRUPolicy returns true if the policy is supported; empty policy
+// stands for the default (LRUPolicyLRU).
+func IsValidLRUPolicy(policy string) bool {
+	return policy == "" || StringInSlice(policy, SupportedLRUPolicies)
+}
+
 func (c *CksumConf) Validate(_ *Config) (err error) {
 	return ValidateCksumType(c.Type)
 }
@@ -712,14 +888,55 @@ func (c *CksumConf) ShouldValidate() bool {
 	return c.ValidateColdGet || c.ValidateObjMove || c.ValidateWarmGet
 }
 
+func (c *ScrubConf) Validate(_ *Config) (err error) {
+	if c.IntervalStr != "" {
+		if c.Interval, err = time.ParseDuration(c.IntervalStr); err != nil {
+			return fmt.Errorf("invalid scrub.interval format: %v", err)
+		}
+	}
+	if c.Enabled && c.Interval <= 0 {
+		return fmt.Errorf("invalid scrub.interval: %q (expected positive duration)", c.IntervalStr)
+	}
+	if c.Rate, err = S2B(c.RateStr); err != nil || c.Rate < 0 {
+		return fmt.Errorf("invalid scrub.rate: %q", c.RateStr)
+	}
+	return nil
+}
+
 func (c *VersionConf) Validate(_ *Config) error {
 	if !c.Enabled && c.ValidateWarmGet {
 		return errors.New("versioning.validate_warm_get requires versioning to be enabled")
 	}
+	if c.MaxVersions < 0 || c.MaxVersions > MaxObjVersions {
+		return fmt.Errorf("invalid versioning.max_versions: %d (expected value in range [0, %d])",
+			c.MaxVersions, MaxObjVersions)
+	}
+	if c.MaxAge != "" {
+		if d, err := time.ParseDuration(c.MaxAge); err != nil || d <= 0 {
+			return fmt.Errorf("invalid versioning.max_age: %q", c.MaxAge)
+		}
+	}
 	return nil
 }
 
-func (c *VersionConf) ValidateAsProps() error { return c.Validate(nil) }
+func (c *VersionConf) ValidateAsProps() error {
+	if !c.Enabled {
+		return nil
+	}
+	return c.Validate(nil)
+}
+
+// KeepVersions returns true if prior versions of objects are to be preserved.
+func (c VersionConf) KeepVersions() bool { return c.Enabled && c.MaxVersions > 0 }
+
+// MaxAgeDuration returns parsed MaxAge; zero means no limit.
+func (c VersionConf) MaxAgeDuration() time.Duration {
+	if c.MaxAge == "" {
+		return 0
+	}
+	d, _ := time.ParseDuration(c.MaxAge)
+	return d
+}
 
 func (c *MirrorConf) Validate(_ *Config) error {
 	if c.UtilThresh < 0 || c.UtilThresh > 100 {
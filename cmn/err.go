@@ -92,6 +92,10 @@ type (
 		ETLName string
 		PodName string
 		SvcName string
+
+		// Set when the error is encountered in the stage of the ETL pipeline.
+		Pipeline string
+		Stage    int // position of the stage in the pipeline, starting from 1
 	}
 )
 
@@ -379,6 +383,9 @@ func (e *ETLError) Error() string {
 	if e.SvcName != "" {
 		s = append(s, fmt.Sprintf("service=%q", e.SvcName))
 	}
+	if e.Pipeline != "" {
+		s = append(s, fmt.Sprintf("pipeline=%q", e.Pipeline), fmt.Sprintf("stage=%d", e.Stage))
+	}
 
 	return fmt.Sprintf("[%s] %s", strings.Join(s, ","), e.Reason)
 }
@@ -411,6 +418,12 @@ func (e *ETLError) withSvcName(name string) *ETLError {
 	return e
 }
 
+func (e *ETLError) withPipeline(pipeline string, stage int) *ETLError {
+	if pipeline != "" {
+		e.Pipeline, e.Stage = pipeline, stage
+	}
+	return e
+}
 func (e *ETLError) WithPodName(name string) *ETLError {
 	if name != "" {
 		e.PodName = name
@@ -427,7 +440,8 @@ func (e *ETLError) WithContext(ctx *ETLErrorContext) *ETLError {
 		withUUID(ctx.UUID).
 		WithPodName(ctx.PodName).
 		withETLName(ctx.ETLName).
-		withSvcName(ctx.SvcName)
+		withSvcName(ctx.SvcName).
+		withPipeline(ctx.Pipeline, ctx.Stage)
 }
 
 ////////////////////////////
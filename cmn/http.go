@@ -25,6 +25,22 @@ import (
 	jsoniter "github.com/json-iterator/go"
 )
 
+// max size of user-defined object metadata and limits for object tags
+// (the same limits as in Amazon S3); the number of user-defined metadata
+// entries is limited as well, so that the metadata always fits intra-cluster
+// object headers (see transport.maxHeaderSize)
+const (
+	MaxUserMDSize    = 2 * KiB
+	MaxUserMDEntries = 64
+	MaxObjTags       = 10
+	MaxTagKeyLen     = 128
+	MaxTagValueLen   = 256
+)
+
+// MDRecordSepa separates records of the object metadata stored in xattr;
+// user-defined metadata and tags must not contain it
+const MDRecordSepa = "\xe3/\xbd"
+
 const (
 	HeaderRange                 = "Range" // Ref: https://www.w3.org/Protocols/rfc2616/rfc2616-sec14.html#sec14.35
 	HeaderRangeValPrefix        = "bytes="
@@ -36,6 +52,13 @@ const (
 	HeaderAccept                = "Accept"
 	HeaderLocation              = "Location"
 	HeaderETag                  = "ETag" // Ref: https://developer.mozilla.org/en-US/docs/Web/HTTP/Headers/ETag
+	HeaderLastModified          = "Last-Modified"
+
+	// conditional requests - see cond.go
+	HeaderIfMatch           = "If-Match"
+	HeaderIfNoneMatch       = "If-None-Match"
+	HeaderIfModifiedSince   = "If-Modified-Since"
+	HeaderIfUnmodifiedSince = "If-Unmodified-Since"
 )
 
 // Ref: https://www.iana.org/assignments/media-types/media-types.xhtml
@@ -86,6 +109,8 @@ type (
 		Version() string
 		AtimeUnix() int64
 		CustomMD() SimpleKVs
+		UserMD() SimpleKVs
+		Tags() SimpleKVs
 	}
 
 	HTTPMuxers map[string]*mux.ServeMux // by http.Method
@@ -534,9 +559,107 @@ func ToHTTPHdr(meta ObjHeaderMetaProvider, hdrs ...http.Header) (hdr http.Header
 	for k, v := range meta.CustomMD() {
 		hdr.Add(HeaderObjCustomMD, strings.Join([]string{k, v}, "="))
 	}
+	for k, v := range meta.UserMD() {
+		hdr.Add(HeaderObjUserMD, strings.Join([]string{k, v}, "="))
+	}
+	for k, v := range meta.Tags() {
+		hdr.Add(HeaderObjTags, strings.Join([]string{k, v}, "="))
+	}
 	return hdr
 }
 
+// UserMDFromHTTPHdr parses and validates user-defined object metadata - the
+// `HeaderObjUserMD` header(s) in the "key=value" format.
+func UserMDFromHTTPHdr(hdr http.Header) (SimpleKVs, error) {
+	md, err := kvsFromHTTPHdr(hdr, HeaderObjUserMD)
+	if err == nil {
+		err = ValidateUserMD(md)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return md, nil
+}
+
+// TagsFromHTTPHdr parses and validates object tags - the `HeaderObjTags`
+// header(s) in the "key=value" format.
+func TagsFromHTTPHdr(hdr http.Header) (SimpleKVs, error) {
+	tags, err := kvsFromHTTPHdr(hdr, HeaderObjTags)
+	if err == nil {
+		err = ValidateObjTags(tags)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return tags, nil
+}
+
+func kvsFromHTTPHdr(hdr http.Header, name string) (SimpleKVs, error) {
+	entries := hdr.Values(name)
+	if len(entries) == 0 {
+		return nil, nil
+	}
+	kvs := make(SimpleKVs, len(entries))
+	for _, entry := range entries {
+		kv := strings.SplitN(entry, "=", 2)
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("invalid %s %q: expecting key=value", name, entry)
+		}
+		if _, ok := kvs[kv[0]]; ok {
+			return nil, fmt.Errorf("invalid %s: duplicate key %q", name, kv[0])
+		}
+		kvs[kv[0]] = kv[1]
+	}
+	return kvs, nil
+}
+
+// ValidateUserMD checks that user-defined metadata is well-formed and fits
+// into the size limit.
+func ValidateUserMD(md SimpleKVs) error {
+	var size int
+	if len(md) > MaxUserMDEntries {
+		return fmt.Errorf("number of user metadata entries %d exceeds the limit (%d)", len(md), MaxUserMDEntries)
+	}
+	for k, v := range md {
+		if k == "" {
+			return errors.New("user metadata key cannot be empty")
+		}
+		if !validMDEntry(k, v) {
+			return fmt.Errorf("user metadata %q contains invalid characters", k)
+		}
+		size += len(k) + len(v)
+	}
+	if size > MaxUserMDSize {
+		return fmt.Errorf("user metadata size %d exceeds the limit (%d)", size, MaxUserMDSize)
+	}
+	return nil
+}
+
+// ValidateObjTags checks the number of tags and the lengths of their keys
+// and values.
+func ValidateObjTags(tags SimpleKVs) error {
+	if len(tags) > MaxObjTags {
+		return fmt.Errorf("number of tags %d exceeds the limit (%d)", len(tags), MaxObjTags)
+	}
+	for k, v := range tags {
+		if k == "" || len(k) > MaxTagKeyLen {
+			return fmt.Errorf("invalid tag key %q: length must be between 1 and %d", k, MaxTagKeyLen)
+		}
+		if len(v) > MaxTagValueLen {
+			return fmt.Errorf("invalid value of the tag %q: length must not exceed %d", k, MaxTagValueLen)
+		}
+		if !validMDEntry(k, v) {
+			return fmt.Errorf("tag %q contains invalid characters", k)
+		}
+	}
+	return nil
+}
+
+func validMDEntry(k, v string) bool {
+	return !strings.ContainsAny(k, "=\x00\x01") && !strings.ContainsAny(v, "\x00\x01") &&
+		!strings.Contains(k, MDRecordSepa) && !strings.Contains(v, MDRecordSepa)
+}
+
 ////////////////
 // HTTPMuxers //
 ////////////////
@@ -6,33 +6,73 @@ package cmn
 
 import (
 	"sort"
+	"strings"
 )
 
+// SortBckEntries sorts entries by name; prior versions of an object (if listed)
+// follow the object itself, newest first.
 func SortBckEntries(bckEntries []*BucketEntry) {
 	entryLess := func(i, j int) bool {
-		if bckEntries[i].Name == bckEntries[j].Name {
-			return bckEntries[i].Flags&EntryStatusMask < bckEntries[j].Flags&EntryStatusMask
+		ei, ej := bckEntries[i], bckEntries[j]
+		if ei.Name != ej.Name {
+			return ei.Name < ej.Name
 		}
-		return bckEntries[i].Name < bckEntries[j].Name
+		if ei.IsPrevVersion() != ej.IsPrevVersion() {
+			return !ei.IsPrevVersion()
+		}
+		if ei.IsPrevVersion() {
+			return ObjVersionLess(ej.Version, ei.Version)
+		}
+		return ei.Flags&EntryStatusMask < ej.Flags&EntryStatusMask
 	}
 	sort.Slice(bckEntries, entryLess)
 }
 
+// ObjVersionLess compares (numeric) version IDs of ais objects.
+func ObjVersionLess(a, b string) bool {
+	if len(a) != len(b) {
+		return len(a) < len(b)
+	}
+	return a < b
+}
+
+// DirEntryName returns the common prefix (aka "directory") that the object
+// name rolls up into when listing with a given prefix and delimiter - the
+// part of the name up to and including the first delimiter that follows
+// the prefix. Returns empty string if the name does not roll up.
+func DirEntryName(prefix, delimiter, objName string) string {
+	if delimiter == "" || !strings.HasPrefix(objName, prefix) {
+		return ""
+	}
+	idx := strings.Index(objName[len(prefix):], delimiter)
+	if idx < 0 {
+		return ""
+	}
+	return objName[:len(prefix)+idx+len(delimiter)]
+}
+
 func deduplicateBckEntries(bckEntries []*BucketEntry, maxSize uint) ([]*BucketEntry, string) {
 	objCount := uint(len(bckEntries))
 
-	j := 0
-	token := ""
+	var (
+		j, cnt int
+		token  string
+	)
 	for _, obj := range bckEntries {
 		if j > 0 && bckEntries[j-1].Name == obj.Name {
-			continue
+			// prior versions of the object are not duplicates (and are
+			// never separated from the object by page boundary)
+			if !obj.IsPrevVersion() || (bckEntries[j-1].IsPrevVersion() && bckEntries[j-1].Version == obj.Version) {
+				continue
+			}
+		} else {
+			if maxSize > 0 && cnt == int(maxSize) {
+				break
+			}
+			cnt++
 		}
 		bckEntries[j] = obj
 		j++
-
-		if maxSize > 0 && j == int(maxSize) {
-			break
-		}
 	}
 
 	// Set extra infos to nil to avoid memory leaks
@@ -6,6 +6,7 @@ package cmn
 
 import (
 	"math/rand"
+	"strings"
 
 	"github.com/NVIDIA/aistore/3rdparty/atomic"
 	"github.com/teris-io/shortid"
@@ -40,9 +41,20 @@ func GenUUID() (uuid string) {
 	return h + uuid + t
 }
 
+// IsValidUUID returns true if the `uuid` may have been generated by GenUUID:
+// in particular, it consists only of the characters of the uuidABC (and so it
+// can be safely used as a part of a file name).
 func IsValidUUID(uuid string) bool {
 	const idlen = 9 // as per https://github.com/teris-io/shortid#id-length
-	return len(uuid) >= idlen && isAlpha(uuid[0])
+	if len(uuid) < idlen || !isAlpha(uuid[0]) {
+		return false
+	}
+	for i := 1; i < len(uuid); i++ {
+		if strings.IndexByte(uuidABC, uuid[i]) < 0 {
+			return false
+		}
+	}
+	return true
 }
 
 func isAlpha(c byte) bool {
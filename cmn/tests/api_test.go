@@ -118,6 +118,24 @@ var _ = Describe("API", func() {
 					Access: 10,
 				},
 			),
+			Entry("anonymous nested struct",
+				cmn.BucketProps{
+					Provider: cmn.ProviderAmazon,
+				},
+				cmn.BucketPropsToUpdate{
+					Extra: &cmn.ExtraToUpdate{
+						AWS: &cmn.ExtraPropsAWSToUpdate{
+							Endpoint:       api.String("http://localhost:9000"),
+							ForcePathStyle: api.Bool(true),
+						},
+					},
+				},
+				func() (props cmn.BucketProps) {
+					props.Provider = cmn.ProviderAmazon
+					props.Extra.AWS = cmn.ExtraPropsAWS{Endpoint: "http://localhost:9000", ForcePathStyle: true}
+					return
+				}(),
+			),
 			Entry("all fields",
 				cmn.BucketProps{},
 				cmn.BucketPropsToUpdate{
@@ -146,7 +164,8 @@ var _ = Describe("API", func() {
 						ParitySlices: api.Int(1024),
 						Compression:  api.String("false"),
 					},
-					Access: api.AccessAttrs(1024),
+					Access:      api.AccessAttrs(1024),
+					WritePolicy: api.String(cmn.WritePolicyBack),
 				},
 				cmn.BucketProps{
 					Versioning: cmn.VersionConf{
@@ -174,7 +193,8 @@ var _ = Describe("API", func() {
 						ParitySlices: 1024,
 						Compression:  "false",
 					},
-					Access: 1024,
+					Access:      1024,
+					WritePolicy: cmn.WritePolicyBack,
 				},
 			),
 		)
@@ -54,3 +54,64 @@ func TestValidateMpath(t *testing.T) {
 		}
 	}
 }
+
+func TestCloudConfAWS(t *testing.T) {
+	conf := cmn.CloudConf{Conf: map[string]interface{}{
+		cmn.ProviderAmazon: map[string]interface{}{
+			"endpoint": "https://s3.example.com",
+			"namespaces": map[string]interface{}{
+				"minio": map[string]interface{}{"endpoint": "http://localhost:9000", "force_path_style": true},
+			},
+		},
+	}}
+	tassert.CheckFatal(t, conf.Validate(nil))
+	_, ok := conf.Providers[cmn.ProviderAmazon]
+	tassert.Errorf(t, ok, "expected %q provider to be enabled", cmn.ProviderAmazon)
+
+	v, _ := conf.ProviderConf(cmn.ProviderAmazon)
+	awsConf := v.(cmn.CloudConfAWS)
+	ep := awsConf.Endpoint(cmn.Ns{Name: "minio"})
+	tassert.Errorf(t, ep.Endpoint == "http://localhost:9000" && ep.ForcePathStyle, "unexpected endpoint: %+v", ep)
+	ep = awsConf.Endpoint(cmn.NsGlobal)
+	tassert.Errorf(t, ep.Endpoint == "https://s3.example.com", "unexpected default endpoint: %+v", ep)
+
+	for _, endpoint := range []string{"localhost:9000", "ftp://localhost", "http://"} {
+		conf := cmn.CloudConf{Conf: map[string]interface{}{
+			cmn.ProviderAmazon: map[string]interface{}{"endpoint": endpoint},
+		}}
+		tassert.Errorf(t, conf.Validate(nil) != nil, "expected endpoint %q to be invalid", endpoint)
+	}
+}
+
+func TestCloudConfHDFS(t *testing.T) {
+	conf := cmn.CloudConf{Conf: map[string]interface{}{
+		cmn.ProviderHDFS: map[string]interface{}{"addresses": []string{"namenode:8020"}},
+	}}
+	tassert.CheckFatal(t, conf.Validate(nil))
+	v, _ := conf.ProviderConf(cmn.ProviderHDFS)
+	hdfsConf := v.(cmn.CloudConfHDFS)
+	tassert.Errorf(t, hdfsConf.Root == "/", "expected default root, got %q", hdfsConf.Root)
+
+	for _, spec := range []map[string]interface{}{
+		{},
+		{"addresses": []string{"namenode:8020"}, "root": "relative/path"},
+	} {
+		conf := cmn.CloudConf{Conf: map[string]interface{}{cmn.ProviderHDFS: spec}}
+		tassert.Errorf(t, conf.Validate(nil) != nil, "expected %v to be invalid", spec)
+	}
+}
+
+func TestCloudConfFS(t *testing.T) {
+	conf := cmn.CloudConf{Conf: map[string]interface{}{
+		cmn.ProviderFS: map[string]interface{}{"root": "/mnt/nfs/"},
+	}}
+	tassert.CheckFatal(t, conf.Validate(nil))
+	v, _ := conf.ProviderConf(cmn.ProviderFS)
+	fsConf := v.(cmn.CloudConfFS)
+	tassert.Errorf(t, fsConf.Root == "/mnt/nfs", "expected cleaned root, got %q", fsConf.Root)
+
+	for _, root := range []string{"", "relative/path"} {
+		conf := cmn.CloudConf{Conf: map[string]interface{}{cmn.ProviderFS: map[string]interface{}{"root": root}}}
+		tassert.Errorf(t, conf.Validate(nil) != nil, "expected root %q to be invalid", root)
+	}
+}
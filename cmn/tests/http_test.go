@@ -5,8 +5,10 @@
 package tests
 
 import (
+	"net/http"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/NVIDIA/aistore/cmn"
 )
@@ -85,3 +87,89 @@ func TestMatchRESTItems(t *testing.T) {
 		}
 	}
 }
+
+func TestCondHdr(t *testing.T) {
+	var (
+		mtime    = time.Date(2020, 10, 1, 12, 0, 0, 500, time.UTC)
+		before   = mtime.Add(-time.Hour).Format(http.TimeFormat)
+		after    = mtime.Add(time.Hour).Format(http.TimeFormat)
+		same     = mtime.Format(http.TimeFormat)
+		objETags = []string{"a1b2c3"}
+	)
+	tests := []struct {
+		name   string
+		method string
+		hdr    map[string]string
+		status int
+	}{
+		{name: "if-match", method: http.MethodGet, hdr: map[string]string{cmn.HeaderIfMatch: `"a1b2c3"`}},
+		{name: "if-match-list", method: http.MethodGet, hdr: map[string]string{cmn.HeaderIfMatch: `"x", a1b2c3`}},
+		{
+			name: "if-match-weak", method: http.MethodGet, hdr: map[string]string{cmn.HeaderIfMatch: `W/"a1b2c3"`},
+			status: http.StatusPreconditionFailed,
+		},
+		{name: "if-match-any", method: http.MethodHead, hdr: map[string]string{cmn.HeaderIfMatch: "*"}},
+		{
+			name: "if-match-fail", method: http.MethodGet, hdr: map[string]string{cmn.HeaderIfMatch: `"x"`},
+			status: http.StatusPreconditionFailed,
+		},
+		{
+			name: "if-none-match", method: http.MethodGet, hdr: map[string]string{cmn.HeaderIfNoneMatch: `W/"a1b2c3"`},
+			status: http.StatusNotModified,
+		},
+		{name: "if-none-match-ok", method: http.MethodGet, hdr: map[string]string{cmn.HeaderIfNoneMatch: `"x"`}},
+		{
+			name: "if-none-match-put", method: http.MethodPut, hdr: map[string]string{cmn.HeaderIfNoneMatch: "*"},
+			status: http.StatusPreconditionFailed,
+		},
+		{
+			name: "if-modified-since", method: http.MethodGet, hdr: map[string]string{cmn.HeaderIfModifiedSince: same},
+			status: http.StatusNotModified,
+		},
+		{name: "if-modified-since-ok", method: http.MethodGet, hdr: map[string]string{cmn.HeaderIfModifiedSince: before}},
+		{name: "if-unmodified-since", method: http.MethodGet, hdr: map[string]string{cmn.HeaderIfUnmodifiedSince: after}},
+		{
+			name: "if-unmodified-since-fail", method: http.MethodGet,
+			hdr:    map[string]string{cmn.HeaderIfUnmodifiedSince: before},
+			status: http.StatusPreconditionFailed,
+		},
+		{
+			// If-None-Match takes precedence over If-Modified-Since
+			name: "if-none-match-and-modified-since", method: http.MethodGet,
+			hdr:    map[string]string{cmn.HeaderIfNoneMatch: `"x"`, cmn.HeaderIfModifiedSince: same},
+			status: 0,
+		},
+		{
+			// If-Match takes precedence over If-Unmodified-Since
+			name: "if-match-and-unmodified-since", method: http.MethodGet,
+			hdr:    map[string]string{cmn.HeaderIfMatch: "a1b2c3", cmn.HeaderIfUnmodifiedSince: before},
+			status: 0,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			hdr := make(http.Header)
+			for k, v := range test.hdr {
+				hdr.Set(k, v)
+			}
+			cond, err := cmn.ParseCondHdr(hdr)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if status := cond.Check(test.method, objETags, mtime); status != test.status {
+				t.Errorf("expected status %d, got %d", test.status, status)
+			}
+		})
+	}
+
+	if cond, err := cmn.ParseCondHdr(http.Header{}); cond != nil || err != nil {
+		t.Errorf("expected no preconditions, got %+v (err: %v)", cond, err)
+	}
+	if _, err := cmn.ParseCondHdr(http.Header{cmn.HeaderIfModifiedSince: []string{"yesterday"}}); err == nil {
+		t.Error("expected invalid time to fail")
+	}
+	cond, _ := cmn.ParseCondHdr(http.Header{cmn.HeaderIfNoneMatch: []string{"*"}})
+	if !cond.CreateOnly() {
+		t.Error("expected create-only PUT")
+	}
+}
@@ -73,6 +73,8 @@ var _ = Describe("IterFields", func() {
 
 					"versioning.enabled":           false,
 					"versioning.validate_warm_get": false,
+					"versioning.max_versions":      0,
+					"versioning.max_age":           "",
 
 					"checksum.type":              cmn.ChecksumXXHash,
 					"checksum.validate_warm_get": false,
@@ -86,12 +88,15 @@ var _ = Describe("IterFields", func() {
 					"lru.out_of_space":      int64(0),
 					"lru.dont_evict_time":   "",
 					"lru.capacity_upd_time": "",
+					"lru.policy":            "",
+					"lru.quota":             int64(0),
 
 					"extra.original_url": "",
 					"extra.cloud_region": "",
 
-					"access":  cmn.AccessAttrs(0),
-					"created": int64(0),
+					"access":       cmn.AccessAttrs(0),
+					"write_policy": "",
+					"created":      int64(0),
 				},
 			),
 			Entry("list BucketPropsToUpdate fields",
@@ -124,6 +129,8 @@ var _ = Describe("IterFields", func() {
 
 					"versioning.enabled":           (*bool)(nil),
 					"versioning.validate_warm_get": (*bool)(nil),
+					"versioning.max_versions":      (*int)(nil),
+					"versioning.max_age":           (*string)(nil),
 
 					"checksum.type":              api.String(cmn.ChecksumXXHash),
 					"checksum.validate_warm_get": (*bool)(nil),
@@ -135,8 +142,16 @@ var _ = Describe("IterFields", func() {
 					"lru.lowwm":        (*int64)(nil),
 					"lru.highwm":       (*int64)(nil),
 					"lru.out_of_space": (*int64)(nil),
+					"lru.policy":       (*string)(nil),
+					"lru.quota":        (*int64)(nil),
 
-					"access": api.AccessAttrs(1024),
+					"extra.aws.endpoint":         (*string)(nil),
+					"extra.aws.profile":          (*string)(nil),
+					"extra.aws.force_path_style": (*bool)(nil),
+					"extra.aws.skip_verify":      (*bool)(nil),
+
+					"access":       api.AccessAttrs(1024),
+					"write_policy": (*string)(nil),
 				},
 			),
 			Entry("check for omit tag",
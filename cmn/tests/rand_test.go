@@ -23,3 +23,14 @@ func TestRandStringStrongSmoke(t *testing.T) {
 	}
 	tassert.Fatalf(t, len(ss) == iterations, "expected to generate %d unique strings, got %d", iterations, len(ss))
 }
+
+func TestIsValidUUID(t *testing.T) {
+	cmn.InitShortID(0)
+	for i := 0; i < 1000; i++ {
+		uuid := cmn.GenUUID()
+		tassert.Errorf(t, cmn.IsValidUUID(uuid), "expected generated %q to be valid", uuid)
+	}
+	for _, uuid := range []string{"", "abc", "1bcdefghijk", "abcdefghi/jk", "abcdefghi/../../etc", "Abcdefghi.1", "abcdefghi jk"} {
+		tassert.Errorf(t, !cmn.IsValidUUID(uuid), "expected %q to be invalid", uuid)
+	}
+}
@@ -54,6 +54,8 @@ cat > $AIS_CONF_FILE <<EOL
 		"out_of_space":      95,
 		"dont_evict_time":   "120m",
 		"capacity_upd_time": "10m",
+		"policy":            "lru",
+		"quota":             0,
 		"enabled":           true
 	},
 	"disk":{
@@ -78,13 +80,20 @@ cat > $AIS_CONF_FILE <<EOL
 		"validate_obj_move":	false,
 		"enable_read_range":	false
 	},
+	"scrub": {
+		"enabled":  ${SCRUB_ENABLED:-false},
+		"interval": "${SCRUB_INTERVAL:-168h}",
+		"rate":     "${SCRUB_RATE:-0}"
+	},
 	"compression": {
 		"block_size": ${BLOCK_SIZE:-262144},
 		"checksum":   ${CHECKSUM:-false}
 	},
 	"versioning": {
 		"enabled":           true,
-		"validate_warm_get": false
+		"validate_warm_get": false,
+		"max_versions":      0,
+		"max_age":           ""
 	},
 	"fspaths": {
 		$AIS_FS_PATHS
@@ -115,6 +124,11 @@ cat > $AIS_CONF_FILE <<EOL
 			"skip_verify":       ${AIS_SKIP_VERIFY_CRT:-false}
 		}
 	},
+	"domain": {
+		"zone": "",
+		"rack": "",
+		"host": ""
+	},
 	"fshc": {
 		"enabled":     true,
 		"test_files":  4,
@@ -150,6 +164,9 @@ cat > $AIS_CONF_FILE <<EOL
 		"dsorter_mem_threshold": "100GB",
 		"compression":           "${COMPRESSION:-never}",
 		"call_timeout":          "10m"
+	},
+	"etl": {
+		"allow_process": ${ETL_ALLOW_PROCESS:-false}
 	}
 }
 EOL
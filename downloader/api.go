@@ -24,6 +24,9 @@ const (
 	DlTypeMulti  DlType = "multi"
 	DlTypeCloud  DlType = "cloud"
 
+	DlTypeManifest DlType = "manifest"
+	DlTypeIndex    DlType = "index"
+
 	DownloadProgressInterval = 10 * time.Second
 )
 
@@ -58,6 +61,23 @@ type (
 
 	DlJobInfos []*DlJobInfo
 
+	// Summary info of the scheduled (recurring) download
+	DlScheduleInfo struct {
+		ID          string    `json:"id"`
+		Description string    `json:"description"`
+		Type        DlType    `json:"type"`
+		Bck         cmn.Bck   `json:"bucket"`
+		Schedule    string    `json:"schedule"`
+		Runs        int       `json:"runs"`
+		LastJobID   string    `json:"last_job_id,omitempty"` // ID of the download job started by the last run
+		LastErr     string    `json:"last_error,omitempty"`  // error starting the last run (if any)
+		CreatedTime time.Time `json:"created_time"`
+		LastRun     time.Time `json:"last_run"`
+		NextRun     time.Time `json:"next_run"`
+	}
+
+	DlScheduleInfos []*DlScheduleInfo
+
 	DlStatusResp struct {
 		DlJobInfo
 		CurrentTasks  []TaskDlInfo  `json:"current_tasks,omitempty"`
@@ -153,6 +173,23 @@ func (j DlJobInfo) String() string {
 	return sb.String()
 }
 
+// Aggregate merges the info of the same schedule reported by another target.
+func (s *DlScheduleInfo) Aggregate(rhs *DlScheduleInfo) {
+	if rhs.LastRun.After(s.LastRun) {
+		s.LastRun, s.LastJobID, s.Runs = rhs.LastRun, rhs.LastJobID, rhs.Runs
+	}
+	if !rhs.NextRun.IsZero() && (s.NextRun.IsZero() || rhs.NextRun.Before(s.NextRun)) {
+		s.NextRun = rhs.NextRun
+	}
+	if s.LastErr == "" {
+		s.LastErr = rhs.LastErr
+	}
+}
+
+func (d DlScheduleInfos) Len() int           { return len(d) }
+func (d DlScheduleInfos) Less(i, j int) bool { return d[i].CreatedTime.Before(d[j].CreatedTime) }
+func (d DlScheduleInfos) Swap(i, j int)      { d[i], d[j] = d[j], d[i] }
+
 func (d DlJobInfos) Len() int {
 	return len(d)
 }
@@ -199,6 +236,9 @@ type DlBase struct {
 	Timeout          string   `json:"timeout"`
 	ProgressInterval string   `json:"progress_interval"`
 	Limits           DlLimits `json:"limits"`
+	// Cron expression or "@every <duration>" - when set, the download runs
+	// periodically (see `AddSchedule`).
+	Schedule string `json:"schedule,omitempty"`
 }
 
 func (b *DlBase) Validate() error {
@@ -247,6 +287,7 @@ type DlAdminBody struct {
 	ID              string `json:"id"`
 	Regex           string `json:"regex"`
 	OnlyActiveTasks bool   `json:"only_active_tasks"` // Skips detailed info about tasks finished/errored
+	Schedules       bool   `json:"schedules"`         // Lists the scheduled downloads instead of the jobs
 }
 
 func (b *DlAdminBody) Validate(requireID bool) error {
@@ -431,3 +472,69 @@ func (b *DlCloudBody) Describe() string {
 	}
 	return fmt.Sprintf("cloud prefetch -> %s", b.Bck)
 }
+
+// Manifest request
+type DlManifestBody struct {
+	DlBase
+	Manifest string `json:"manifest"` // bucket URI (eg. "ais://bucket/list.csv") or URL of the manifest
+	Format   string `json:"format"`   // one of: `ManifestFormatCSV`, `ManifestFormatJSONL` (deduced from extension if empty)
+}
+
+func (b *DlManifestBody) Validate() (err error) {
+	if err := b.DlBase.Validate(); err != nil {
+		return err
+	}
+	if b.Manifest == "" {
+		return errors.New("missing 'manifest' in the request body")
+	}
+	if !isManifestURL(b.Manifest) {
+		if _, _, err := cmn.ParseBckObjectURI(b.Manifest); err != nil {
+			return fmt.Errorf("invalid 'manifest' %q: %v", b.Manifest, err)
+		}
+	}
+	b.Format, err = ManifestFormat(b.Manifest, b.Format)
+	return
+}
+
+func (b *DlManifestBody) Describe() string {
+	if b.Description != "" {
+		return b.Description
+	}
+	return fmt.Sprintf("%s -> %s", b.Manifest, b.Bck)
+}
+
+func (b *DlManifestBody) String() string {
+	return fmt.Sprintf("bucket: %q, manifest: %q", b.Bck, b.Manifest)
+}
+
+// HTTP index request
+type DlIndexBody struct {
+	DlBase
+	Index  string `json:"index"` // URL of the HTML page linking the files (e.g., directory listing)
+	Sync   bool   `json:"sync"`  // remove the objects that are no longer linked from the index
+	Suffix string `json:"suffix"`
+}
+
+func (b *DlIndexBody) Validate() error {
+	if err := b.DlBase.Validate(); err != nil {
+		return err
+	}
+	if b.Index == "" {
+		return errors.New("missing 'index' in the request body")
+	}
+	if !isIndexURL(b.Index) {
+		return fmt.Errorf("invalid 'index' %q: expected HTTP(S) URL", b.Index)
+	}
+	return nil
+}
+
+func (b *DlIndexBody) Describe() string {
+	if b.Description != "" {
+		return b.Description
+	}
+	return fmt.Sprintf("%s -> %s", b.Index, b.Bck)
+}
+
+func (b *DlIndexBody) String() string {
+	return fmt.Sprintf("bucket: %q, index: %q", b.Bck, b.Index)
+}
@@ -5,6 +5,8 @@
 package downloader
 
 import (
+	"strings"
+
 	"github.com/NVIDIA/aistore/3rdparty/atomic"
 	"github.com/NVIDIA/aistore/cluster"
 	"github.com/NVIDIA/aistore/cmn"
@@ -22,10 +24,14 @@ const (
 type (
 	DiffResolverCtx interface {
 		CompareObjects(*cluster.LOM, *DstElement) (bool, error)
-		IsObjFromCloud(*cluster.LOM) (bool, error)
+		IsObjFromRemote(*cluster.LOM) (bool, error)
 	}
 
-	defaultDiffResolverCtx struct{}
+	defaultDiffResolverCtx struct {
+		// web objects downloaded from links with this prefix are treated as
+		// remote (see `DlJob.syncPrefix`)
+		webPrefix string
+	}
 
 	// DiffResolver is entity that computes difference between two streams
 	// of objects. The streams are expected to be in sorted order.
@@ -44,14 +50,16 @@ type (
 	}
 
 	WebResource struct {
-		ObjName string
-		Link    string
+		ObjName  string
+		Link     string
+		expected *dlExpected
 	}
 
 	DstElement struct {
-		ObjName string
-		Version string
-		Link    string
+		ObjName  string
+		Version  string
+		Link     string
+		expected *dlExpected
 	}
 
 	DiffResolverResult struct {
@@ -62,6 +70,10 @@ type (
 	}
 )
 
+func newDefaultDiffResolverCtx(webPrefix string) *defaultDiffResolverCtx {
+	return &defaultDiffResolverCtx{webPrefix: webPrefix}
+}
+
 func NewDiffResolver(ctx DiffResolverCtx) *DiffResolver {
 	if ctx == nil {
 		ctx = &defaultDiffResolverCtx{}
@@ -92,7 +104,7 @@ func (dr *DiffResolver) Start() {
 				}
 				dst, dstOk = <-dr.dstCh
 			} else if !dstOk || (srcOk && src.ObjName < dst.ObjName) {
-				cloud, err := dr.ctx.IsObjFromCloud(src)
+				remote, err := dr.ctx.IsObjFromRemote(src)
 				if err != nil {
 					dr.resultCh <- DiffResolverResult{
 						Action: DiffResolverErr,
@@ -102,8 +114,7 @@ func (dr *DiffResolver) Start() {
 					}
 					return
 				}
-				if cloud {
-					cmn.Assert(!dstOk || dst.Link == "") // destination must be cloud as well
+				if remote {
 					dr.resultCh <- DiffResolverResult{
 						Action: DiffResolverDelete,
 						Src:    src,
@@ -165,8 +176,9 @@ func (dr *DiffResolver) PushDst(v interface{}) {
 		}
 	case *WebResource:
 		d = &DstElement{
-			ObjName: x.ObjName,
-			Link:    x.Link,
+			ObjName:  x.ObjName,
+			Link:     x.Link,
+			expected: x.expected,
 		}
 	default:
 		cmn.Assertf(false, "%T", x)
@@ -203,7 +215,9 @@ func (*defaultDiffResolverCtx) CompareObjects(src *cluster.LOM, dst *DstElement)
 	return compareObjects(src, dst)
 }
 
-func (*defaultDiffResolverCtx) IsObjFromCloud(src *cluster.LOM) (bool, error) {
+// IsObjFromRemote returns true if the object was downloaded from the source
+// that is being synced (and so must be deleted when the source no longer has it).
+func (ctx *defaultDiffResolverCtx) IsObjFromRemote(src *cluster.LOM) (bool, error) {
 	if err := src.Load(); err != nil {
 		if cmn.IsObjNotExist(err) {
 			return false, nil
@@ -214,5 +228,12 @@ func (*defaultDiffResolverCtx) IsObjFromCloud(src *cluster.LOM) (bool, error) {
 	if !ok {
 		return false, nil
 	}
-	return objSrc != cluster.SourceWebObjMD, nil
+	if objSrc != cluster.SourceWebObjMD {
+		return true, nil
+	}
+	if ctx.webPrefix == "" {
+		return false, nil
+	}
+	link, ok := src.GetCustomMD(cluster.OrigURLObjMD)
+	return ok && strings.HasPrefix(link, ctx.webPrefix), nil
 }
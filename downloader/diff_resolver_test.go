@@ -37,7 +37,7 @@ func (*mockDiffResolverCtx) CompareObjects(*cluster.LOM, *downloader.DstElement)
 	return true, nil
 }
 
-func (*mockDiffResolverCtx) IsObjFromCloud(lom *cluster.LOM) (bool, error) {
+func (*mockDiffResolverCtx) IsObjFromRemote(lom *cluster.LOM) (bool, error) {
 	return lom.FQN == fromCloudFQN, nil
 }
 
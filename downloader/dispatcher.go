@@ -156,7 +156,7 @@ func (d *dispatcher) dispatchDownload(job DlJob) (ok bool) {
 		return !aborted
 	}
 
-	diffResolver := NewDiffResolver(nil)
+	diffResolver := NewDiffResolver(newDefaultDiffResolverCtx(job.syncPrefix()))
 
 	diffResolver.Start()
 
@@ -236,8 +236,9 @@ func (d *dispatcher) dispatchDownload(job DlJob) (ok bool) {
 
 				if obj.link != "" {
 					diffResolver.PushDst(&WebResource{
-						ObjName: obj.objName,
-						Link:    obj.link,
+						ObjName:  obj.objName,
+						Link:     obj.link,
+						expected: obj.expected,
 					})
 				} else {
 					diffResolver.PushDst(&CloudResource{
@@ -261,6 +262,7 @@ func (d *dispatcher) dispatchDownload(job DlJob) (ok bool) {
 					objName:   dst.ObjName,
 					link:      dst.Link,
 					fromCloud: dst.Link == "",
+					expected:  dst.expected,
 				}
 			} else {
 				src := result.Src
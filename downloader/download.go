@@ -308,6 +308,9 @@ func (d *Downloader) AbortJob(id string) (resp interface{}, statusCode int, err
 func (d *Downloader) RemoveJob(id string) (resp interface{}, statusCode int, err error) {
 	d.IncPending()
 	defer d.DecPending()
+	if removeSchedule(id) {
+		return nil, http.StatusOK, nil
+	}
 	req := &request{
 		action: actRemove,
 		id:     id,
@@ -0,0 +1,118 @@
+// Package downloader implements functionality to download resources into AIS cluster from external source.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package downloader
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/NVIDIA/aistore/cmn"
+)
+
+// HTTP index is the HTML page that links the files to download - typically,
+// the directory listing generated by the web server. The files linked directly
+// from the index directory are downloaded, while the links to subdirectories,
+// other locations, and the links with query (e.g., sorting the listing) are
+// skipped. With `sync` set, the objects that were downloaded from the index
+// directory but are no longer linked get removed.
+
+// The index is read as a whole (unlike the manifest), hence the limit.
+const maxIndexSize = 64 * cmn.MiB
+
+var hrefRegex = regexp.MustCompile(`(?i)\shref\s*=\s*(?:"([^"]*)"|'([^']*)'|([^\s>]+))`)
+
+type indexEntry struct {
+	objName string
+	link    string
+}
+
+func isIndexURL(index string) bool {
+	scheme, _ := cmn.ParseURLScheme(index)
+	return scheme == "http" || scheme == "https"
+}
+
+// indexDir returns the URL of the index directory: the links prefixed with it
+// are the objects of the index (see `DlJob.syncPrefix`).
+func indexDir(index string) (string, error) {
+	u, err := url.Parse(index)
+	if err != nil {
+		return "", err
+	}
+	u.RawQuery, u.Fragment = "", ""
+	if i := strings.LastIndexByte(u.Path, '/'); i >= 0 {
+		u.Path = u.Path[:i+1]
+	} else {
+		u.Path = "/"
+	}
+	u.RawPath = ""
+	return u.String(), nil
+}
+
+func getIndex(index string) ([]indexEntry, error) {
+	resp, err := clientForURL(index).Get(index) // nolint:bodyclose // closed below
+	if err != nil {
+		return nil, err
+	}
+	defer cmn.Close(resp.Body)
+	if resp.StatusCode >= http.StatusBadRequest {
+		return nil, fmt.Errorf("failed to read index %q: %s", index, resp.Status)
+	}
+	b, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxIndexSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(b) > maxIndexSize {
+		return nil, fmt.Errorf("index %q exceeds %s", index, cmn.B2S(maxIndexSize, 0))
+	}
+	return parseIndex(index, string(b))
+}
+
+// parseIndex returns the entries of the index sorted by object name.
+func parseIndex(index, page string) ([]indexEntry, error) {
+	base, err := url.Parse(index)
+	if err != nil {
+		return nil, err
+	}
+	dir, err := indexDir(index)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		entries = make([]indexEntry, 0, 16)
+		names   = make(map[string]struct{}, 16)
+		self    = *base
+	)
+	self.RawQuery, self.Fragment = "", ""
+	selfLink := self.String()
+	for _, m := range hrefRegex.FindAllStringSubmatch(page, -1) {
+		href := m[1] + m[2] + m[3] // only one of the groups matches
+		u, err := base.Parse(strings.TrimSpace(href))
+		if err != nil || u.RawQuery != "" {
+			continue
+		}
+		u.Fragment = ""
+		link := u.String()
+		if link == selfLink || !strings.HasPrefix(link, dir) {
+			continue // other location (including the parent directory)
+		}
+		name, err := url.PathUnescape(link[len(dir):])
+		if err != nil || name == "" || strings.Contains(name, "/") {
+			continue // the index itself or subdirectory
+		}
+		if _, ok := names[name]; ok {
+			continue
+		}
+		names[name] = struct{}{}
+		entries = append(entries, indexEntry{objName: name, link: link})
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].objName < entries[j].objName })
+	return entries, nil
+}
@@ -0,0 +1,54 @@
+// Package downloader implements functionality to download resources into AIS cluster from external source.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package downloader
+
+import (
+	"testing"
+
+	"github.com/NVIDIA/aistore/devtools/tutils/tassert"
+)
+
+func TestIndexDir(t *testing.T) {
+	tests := []struct {
+		index, dir string
+	}{
+		{"http://example.com/data/", "http://example.com/data/"},
+		{"http://example.com/data/index.html", "http://example.com/data/"},
+		{"http://example.com/data/?C=M;O=D", "http://example.com/data/"},
+		{"https://example.com", "https://example.com/"},
+	}
+	for _, test := range tests {
+		dir, err := indexDir(test.index)
+		tassert.CheckFatal(t, err)
+		tassert.Errorf(t, dir == test.dir, "%q: expected %q, got %q", test.index, test.dir, dir)
+	}
+}
+
+func TestParseIndex(t *testing.T) {
+	const page = `<html><body><h1>Index of /data/</h1>
+<a href="../">../</a>
+<a href="?C=N;O=D">Name</a>
+<a href="sub/">sub/</a>
+<a href="train-001.tgz">train-001.tgz</a>
+<a HREF='train%20002.tgz'>train 002.tgz</a>
+<a href=/data/labels.csv>labels.csv</a>
+<a href="http://example.com/data/train-001.tgz#top">train-001.tgz</a>
+<a href="http://other.com/data/x.tgz">x.tgz</a>
+<a href="/other/y.tgz">y.tgz</a>
+<a href="index.html">index.html</a>
+</body></html>`
+
+	entries, err := parseIndex("http://example.com/data/index.html", page)
+	tassert.CheckFatal(t, err)
+	expected := []indexEntry{
+		{objName: "labels.csv", link: "http://example.com/data/labels.csv"},
+		{objName: "train 002.tgz", link: "http://example.com/data/train%20002.tgz"},
+		{objName: "train-001.tgz", link: "http://example.com/data/train-001.tgz"},
+	}
+	tassert.Fatalf(t, len(entries) == len(expected), "expected %d entries, got %v", len(expected), entries)
+	for i := range expected {
+		tassert.Errorf(t, entries[i] == expected[i], "expected %v, got %v", expected[i], entries[i])
+	}
+}
@@ -7,14 +7,17 @@ package downloader
 import (
 	"context"
 	"errors"
+	"io"
 	"path"
 	"strings"
 	"time"
 
 	"github.com/NVIDIA/aistore/3rdparty/atomic"
+	"github.com/NVIDIA/aistore/3rdparty/glog"
 	"github.com/NVIDIA/aistore/cluster"
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/nl"
+	"github.com/NVIDIA/aistore/stats"
 )
 
 const (
@@ -27,6 +30,8 @@ var (
 	_ DlJob = (*sliceDlJob)(nil)
 	_ DlJob = (*cloudBucketDlJob)(nil)
 	_ DlJob = (*rangeDlJob)(nil)
+	_ DlJob = (*manifestDlJob)(nil)
+	_ DlJob = (*indexDlJob)(nil)
 )
 
 var errAISBckReq = errors.New("regular download requires ais bucket")
@@ -36,6 +41,7 @@ type (
 		objName   string
 		link      string
 		fromCloud bool
+		expected  *dlExpected // expected size and checksums (optional)
 	}
 
 	DlJob interface {
@@ -57,6 +63,10 @@ type (
 		// Checks if object name matches the request.
 		checkObj(objName string) bool
 
+		// Sync of the web download: the objects downloaded from the links
+		// with this prefix are removed when no longer listed (empty if none).
+		syncPrefix() string
+
 		// genNext is supposed to fulfill the following protocol:
 		//  `ok` is set to `true` if there is batch to process, `false` otherwise
 		genNext() (objs []dlObj, ok bool, err error)
@@ -116,6 +126,29 @@ type (
 		continuationToken string
 	}
 
+	manifestDlJob struct {
+		baseDlJob
+		t        cluster.Target
+		manifest string // bucket URI or URL
+		format   string
+		r        io.ReadCloser
+		entries  manifestReader
+		objs     []dlObj // objects' metas which are ready to be downloaded
+		done     bool    // true = the manifest is exhausted, nothing left to read
+	}
+
+	indexDlJob struct {
+		baseDlJob
+		t       cluster.Target
+		index   string // URL of the index
+		dir     string // URL of the index directory (see `indexDir`)
+		suffix  string
+		sync    bool
+		objs    []dlObj // objects' metas owned by the target (sorted)
+		current int
+		loaded  bool
+	}
+
 	downloadJobInfo struct {
 		ID          string `json:"id"`
 		Description string `json:"description"`
@@ -163,6 +196,7 @@ func (j *baseDlJob) ActiveStats() (*DlStatusResp, error) {
 	return resp.(*DlStatusResp), nil
 }
 func (j *baseDlJob) checkObj(string) bool  { cmn.Assert(false); return false }
+func (j *baseDlJob) syncPrefix() string    { return "" }
 func (j *baseDlJob) throttler() *throttler { return j.t }
 func (j *baseDlJob) cleanup() {
 	j.throttler().stop()
@@ -423,6 +457,179 @@ func newRangeDlJob(t cluster.Target, id string, bck *cluster.Bck, payload *DlRan
 	return job, nil
 }
 
+func newManifestDlJob(t cluster.Target, id string, bck *cluster.Bck, payload *DlManifestBody, dlXact *Downloader) (*manifestDlJob, error) {
+	if !bck.IsAIS() {
+		return nil, errAISBckReq
+	}
+	base := newBaseDlJob(t, id, bck, payload.Timeout, payload.Describe(), payload.Limits, dlXact)
+	job := &manifestDlJob{
+		baseDlJob: *base,
+		t:         t,
+		manifest:  payload.Manifest,
+		format:    payload.Format,
+	}
+	return job, nil
+}
+
+// NOTE: the number of entries is not known until the whole manifest is read.
+func (j *manifestDlJob) Len() int { return -1 }
+func (j *manifestDlJob) genNext() ([]dlObj, bool, error) {
+	if j.done {
+		return nil, false, nil
+	}
+	if err := j.getNextObjs(); err != nil {
+		j.close()
+		return nil, false, err
+	}
+	return j.objs, true, nil
+}
+
+// Streams the manifest until the batch of objects owned by the target is
+// collected or the manifest is over.
+func (j *manifestDlJob) getNextObjs() (err error) {
+	var (
+		smap  = j.t.Sowner().Get()
+		sid   = j.t.Snode().ID()
+		entry *ManifestEntry
+	)
+	if j.entries == nil {
+		if j.r, err = openManifest(j.t, j.manifest); err != nil {
+			return err
+		}
+		if j.entries, err = newManifestReader(j.r, j.format); err != nil {
+			return err
+		}
+	}
+	j.objs = j.objs[:0]
+	for len(j.objs) < downloadBatchSize {
+		if entry, err = j.entries.next(); err != nil {
+			if err == io.EOF {
+				j.done = true
+				j.close()
+				return nil
+			}
+			errEntry, ok := err.(*errManifestEntry)
+			if !ok {
+				return err // failed to read the manifest
+			}
+			if err = j.entryFailed(smap, sid, errEntry); err != nil {
+				return err
+			}
+			continue
+		}
+		obj, err := makeDlObj(smap, sid, j.bck, entry.ObjName, entry.Link)
+		if err != nil {
+			if err == errInvalidTarget {
+				continue
+			}
+			return err
+		}
+		obj.expected = entry.expected()
+		j.objs = append(j.objs, obj)
+	}
+	return nil
+}
+
+// Every target reads the whole manifest - the invalid entry gets reported by
+// the target that would have downloaded it (by the object name or, when the
+// name is unknown, by the line number).
+func (j *manifestDlJob) entryFailed(smap *cluster.Smap, sid string, errEntry *errManifestEntry) error {
+	name := errEntry.objName()
+	si, err := cluster.HrwTarget(j.bck.MakeUname(name), smap)
+	if err != nil {
+		return err
+	}
+	if si.ID() != sid {
+		return nil
+	}
+	glog.Warningf("download job %q: %v", j.ID(), errEntry)
+	j.dlXact.statsT.Add(stats.ErrDownloadCount, 1)
+	dlStore.incScheduled(j.ID())
+	dlStore.persistError(j.ID(), name, errEntry.Error())
+	dlStore.incErrorCnt(j.ID())
+	return nil
+}
+
+func (j *manifestDlJob) close() {
+	if j.r != nil {
+		cmn.Close(j.r)
+		j.r = nil
+	}
+}
+
+func (j *manifestDlJob) cleanup() {
+	j.close()
+	j.baseDlJob.cleanup()
+}
+
+func newIndexDlJob(t cluster.Target, id string, bck *cluster.Bck, payload *DlIndexBody, dlXact *Downloader) (*indexDlJob, error) {
+	if !bck.IsAIS() {
+		return nil, errAISBckReq
+	}
+	dir, err := indexDir(payload.Index)
+	if err != nil {
+		return nil, err
+	}
+	base := newBaseDlJob(t, id, bck, payload.Timeout, payload.Describe(), payload.Limits, dlXact)
+	job := &indexDlJob{
+		baseDlJob: *base,
+		t:         t,
+		index:     payload.Index,
+		dir:       dir,
+		suffix:    payload.Suffix,
+		sync:      payload.Sync,
+	}
+	return job, nil
+}
+
+// NOTE: the number of entries is not known until the index is read.
+func (j *indexDlJob) Len() int                     { return -1 }
+func (j *indexDlJob) Sync() bool                   { return j.sync }
+func (j *indexDlJob) checkObj(objName string) bool { return strings.HasSuffix(objName, j.suffix) }
+func (j *indexDlJob) syncPrefix() string           { return j.dir }
+
+// Reads the index upon the first call and returns the objects owned by the
+// target, sorted by name (as required by sync), in batches.
+func (j *indexDlJob) genNext() (objs []dlObj, ok bool, err error) {
+	if !j.loaded {
+		if err := j.load(); err != nil {
+			return nil, false, err
+		}
+	}
+	if j.current == len(j.objs) {
+		return nil, false, nil
+	}
+	end := cmn.Min(j.current+downloadBatchSize, len(j.objs))
+	objs, j.current = j.objs[j.current:end], end
+	return objs, true, nil
+}
+
+func (j *indexDlJob) load() error {
+	entries, err := getIndex(j.index)
+	if err != nil {
+		return err
+	}
+	var (
+		smap = j.t.Sowner().Get()
+		sid  = j.t.Snode().ID()
+	)
+	for _, entry := range entries {
+		if !j.checkObj(entry.objName) {
+			continue
+		}
+		obj, err := makeDlObj(smap, sid, j.bck, entry.objName, entry.link)
+		if err != nil {
+			if err == errInvalidTarget {
+				continue
+			}
+			return err
+		}
+		j.objs = append(j.objs, obj)
+	}
+	j.loaded = true
+	return nil
+}
+
 func (d *downloadJobInfo) ToDlJobInfo() DlJobInfo {
 	return DlJobInfo{
 		ID:            d.ID,
@@ -0,0 +1,420 @@
+// Package downloader implements functionality to download resources into AIS cluster from external source.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package downloader
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	jsoniter "github.com/json-iterator/go"
+)
+
+// Manifest lists the objects to download, one entry per line (see
+// `ManifestEntry`). Manifest is never loaded into memory as a whole - each
+// target streams it and downloads the entries that it owns (HRW).
+//
+// Supported formats:
+// * CSV - the first line (header) names the columns, columns other than the
+//   fields of `ManifestEntry` are ignored,
+// * JSONL - each line is a JSON object.
+//
+// Invalid entries are reported (see `errManifestEntry`) and skipped, while
+// failure to read the manifest fails the whole job.
+
+const (
+	ManifestFormatCSV   = "csv"
+	ManifestFormatJSONL = "jsonl"
+)
+
+// Checksums of the manifest entries. NOTE: these are standard MD5 and SHA-256
+// (not to confuse with `cmn.ChecksumSHA256`) as published along with datasets.
+const (
+	manifestMD5    = "md5"
+	manifestSHA256 = "sha256"
+)
+
+type (
+	ManifestEntry struct {
+		Link    string `json:"url"`
+		ObjName string `json:"object_name"` // defaults to the base name of the link
+		Size    int64  `json:"size"`        // expected size, zero if unknown
+		MD5     string `json:"md5"`         // expected MD5 (hex)
+		SHA256  string `json:"sha256"`      // expected SHA-256 (hex)
+	}
+
+	manifestReader interface {
+		// next returns `io.EOF` when there are no more entries, and
+		// `errManifestEntry` when the entry is invalid (the next one can
+		// still be read).
+		next() (*ManifestEntry, error)
+	}
+
+	errManifestEntry struct {
+		line int
+		name string // object name, if known
+		err  error
+	}
+
+	csvManifest struct {
+		r    *csv.Reader
+		cols map[string]int
+		line int
+	}
+
+	jsonlManifest struct {
+		r    *bufio.Reader
+		line int
+	}
+
+	// expected properties of the object (as listed in the manifest)
+	dlExpected struct {
+		size   int64
+		cksums cmn.SimpleKVs // checksum type => hex value
+	}
+
+	// cksumReader computes the checksums of the object while it is being
+	// downloaded and fails the read at EOF upon mismatch - that is, before
+	// the object gets finalized.
+	cksumReader struct {
+		io.ReadCloser
+		exp    *dlExpected
+		hashes map[string]hash.Hash
+		size   int64
+	}
+)
+
+// interface guard
+var (
+	_ manifestReader = (*csvManifest)(nil)
+	_ manifestReader = (*jsonlManifest)(nil)
+)
+
+// ManifestFormat returns the format of the manifest, deduced from its
+// extension unless specified.
+func ManifestFormat(manifest, format string) (string, error) {
+	if format == "" {
+		switch strings.ToLower(path.Ext(manifestPath(manifest))) {
+		case ".csv":
+			format = ManifestFormatCSV
+		case ".jsonl", ".ndjson", ".json":
+			format = ManifestFormatJSONL
+		default:
+			return "", fmt.Errorf("cannot deduce format of the manifest %q, 'format' must be specified", manifest)
+		}
+	}
+	if format != ManifestFormatCSV && format != ManifestFormatJSONL {
+		return "", fmt.Errorf("unsupported manifest format %q (expected %q or %q)",
+			format, ManifestFormatCSV, ManifestFormatJSONL)
+	}
+	return format, nil
+}
+
+func manifestPath(manifest string) string {
+	if u, err := url.Parse(manifest); err == nil && u.Path != "" {
+		return u.Path
+	}
+	return manifest
+}
+
+func isManifestURL(manifest string) bool {
+	scheme, _ := cmn.ParseURLScheme(manifest)
+	return scheme == "http" || scheme == "https"
+}
+
+// openManifest opens the manifest stored either at the URL or in the bucket
+// (in which case it is read locally or from the target that owns it).
+func openManifest(t cluster.Target, manifest string) (io.ReadCloser, error) {
+	if isManifestURL(manifest) {
+		return getManifest(manifest, manifest, "")
+	}
+	bck, objName, err := cmn.ParseBckObjectURI(manifest)
+	if err != nil {
+		return nil, err
+	}
+	if objName == "" {
+		return nil, fmt.Errorf("manifest %q does not specify the object", manifest)
+	}
+	b := cluster.NewBckEmbed(bck)
+	if err := b.Init(t.Bowner(), t.Snode()); err != nil {
+		return nil, err
+	}
+	si, err := cluster.HrwTarget(b.MakeUname(objName), t.Sowner().Get())
+	if err != nil {
+		return nil, err
+	}
+	if si.ID() == t.Snode().ID() {
+		return openLocalManifest(t, b, objName)
+	}
+	var (
+		query = cmn.AddBckToQuery(nil, b.Bck)
+		link  = si.URL(cmn.NetworkIntraData) + cmn.JoinWords(cmn.Version, cmn.Objects, b.Name, objName) +
+			"?" + query.Encode()
+	)
+	return getManifest(manifest, link, t.Snode().ID())
+}
+
+// openLocalManifest opens the manifest stored on this target (cold GET-ting
+// it first, if need be).
+func openLocalManifest(t cluster.Target, bck *cluster.Bck, objName string) (io.ReadCloser, error) {
+	lom := &cluster.LOM{ObjName: objName}
+	if err := lom.Init(bck.Bck); err != nil {
+		return nil, err
+	}
+	lom.Lock(false)
+	err := lom.Load()
+	if err != nil && cmn.IsObjNotExist(err) && bck.IsRemote() {
+		lom.Unlock(false)
+		if _, err = t.GetCold(context.Background(), lom, cluster.PrefetchWait); err != nil {
+			return nil, err
+		}
+		lom.Lock(false)
+		err = lom.Load()
+	}
+	if err != nil {
+		lom.Unlock(false)
+		return nil, err
+	}
+	// NOTE: the open file remains readable when the object gets overwritten
+	fh, err := os.Open(lom.FQN)
+	lom.Unlock(false)
+	return fh, err
+}
+
+// getManifest GETs the manifest; callerID (if not empty) identifies
+// intra-cluster requests.
+func getManifest(manifest, link, callerID string) (io.ReadCloser, error) {
+	req, err := http.NewRequest(http.MethodGet, link, nil)
+	if err != nil {
+		return nil, err
+	}
+	if callerID != "" {
+		req.Header.Set(cmn.HeaderCallerID, callerID)
+	}
+	resp, err := clientForURL(link).Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= http.StatusBadRequest {
+		cmn.Close(resp.Body)
+		return nil, fmt.Errorf("failed to read manifest %q: %s", manifest, resp.Status)
+	}
+	return resp.Body, nil
+}
+
+func newManifestReader(r io.Reader, format string) (manifestReader, error) {
+	if format == ManifestFormatJSONL {
+		return &jsonlManifest{r: bufio.NewReader(r)}, nil
+	}
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+	header, err := cr.Read()
+	if err != nil {
+		if err == io.EOF {
+			err = errors.New("CSV manifest is missing the header")
+		}
+		return nil, err
+	}
+	m := &csvManifest{r: cr, cols: make(map[string]int, len(header)), line: 1}
+	for idx, col := range header {
+		m.cols[strings.ToLower(strings.TrimSpace(col))] = idx
+	}
+	if _, ok := m.cols["url"]; !ok {
+		return nil, errors.New("CSV manifest is missing the 'url' column")
+	}
+	return m, nil
+}
+
+/////////////////
+// csvManifest //
+/////////////////
+
+func (m *csvManifest) next() (*ManifestEntry, error) {
+	record, err := m.r.Read()
+	if err != nil {
+		if perr, ok := err.(*csv.ParseError); ok {
+			m.line++
+			return nil, &errManifestEntry{line: m.line, err: perr.Err}
+		}
+		return nil, err
+	}
+	m.line++
+	col := func(name string) string {
+		if idx, ok := m.cols[name]; ok && idx < len(record) {
+			return strings.TrimSpace(record[idx])
+		}
+		return ""
+	}
+	entry := &ManifestEntry{
+		Link:    col("url"),
+		ObjName: col("object_name"),
+		MD5:     col(manifestMD5),
+		SHA256:  col(manifestSHA256),
+	}
+	if size := col("size"); size != "" {
+		if entry.Size, err = strconv.ParseInt(size, 10, 64); err != nil {
+			err = fmt.Errorf("invalid size %q", size)
+			return nil, &errManifestEntry{line: m.line, name: entry.ObjName, err: err}
+		}
+	}
+	if err := entry.validate(); err != nil {
+		return nil, &errManifestEntry{line: m.line, name: entry.ObjName, err: err}
+	}
+	return entry, nil
+}
+
+///////////////////
+// jsonlManifest //
+///////////////////
+
+func (m *jsonlManifest) next() (*ManifestEntry, error) {
+	for {
+		b, err := m.r.ReadBytes('\n')
+		if err != nil && (err != io.EOF || len(b) == 0) {
+			return nil, err
+		}
+		m.line++
+		if b = bytes.TrimSpace(b); len(b) == 0 {
+			continue
+		}
+		entry := &ManifestEntry{}
+		if err := jsoniter.Unmarshal(b, entry); err != nil {
+			return nil, &errManifestEntry{line: m.line, err: err}
+		}
+		if err := entry.validate(); err != nil {
+			return nil, &errManifestEntry{line: m.line, name: entry.ObjName, err: err}
+		}
+		return entry, nil
+	}
+}
+
+///////////////////
+// ManifestEntry //
+///////////////////
+
+func (e *ManifestEntry) validate() error {
+	if e.Link == "" {
+		return errors.New("missing 'url'")
+	}
+	if e.ObjName == "" {
+		e.ObjName = path.Base(e.Link)
+		if e.ObjName == "." || e.ObjName == "/" {
+			e.ObjName = ""
+			return fmt.Errorf("can not extract a valid 'object_name' from the provided 'url': %q", e.Link)
+		}
+	}
+	if _, err := normalizeObjName(e.ObjName); err != nil {
+		return err
+	}
+	if e.Size < 0 {
+		return fmt.Errorf("invalid size %d", e.Size)
+	}
+	e.MD5, e.SHA256 = strings.ToLower(e.MD5), strings.ToLower(e.SHA256)
+	if e.MD5 != "" && !isHex(e.MD5, md5.Size) {
+		return fmt.Errorf("invalid md5 %q", e.MD5)
+	}
+	if e.SHA256 != "" && !isHex(e.SHA256, sha256.Size) {
+		return fmt.Errorf("invalid sha256 %q", e.SHA256)
+	}
+	return nil
+}
+
+func (e *ManifestEntry) expected() *dlExpected {
+	if e.Size == 0 && e.MD5 == "" && e.SHA256 == "" {
+		return nil
+	}
+	exp := &dlExpected{size: e.Size, cksums: make(cmn.SimpleKVs, 2)}
+	if e.MD5 != "" {
+		exp.cksums[manifestMD5] = e.MD5
+	}
+	if e.SHA256 != "" {
+		exp.cksums[manifestSHA256] = e.SHA256
+	}
+	return exp
+}
+
+//////////////////////
+// errManifestEntry //
+//////////////////////
+
+func (e *errManifestEntry) Error() string {
+	return fmt.Sprintf("invalid manifest entry (line %d): %v", e.line, e.err)
+}
+
+// name under which the error is reported (see `DlStatusResp.Errs`)
+func (e *errManifestEntry) objName() string {
+	if e.name != "" {
+		return e.name
+	}
+	return "manifest line " + strconv.Itoa(e.line)
+}
+
+func isHex(s string, size int) bool {
+	b, err := hex.DecodeString(s)
+	return err == nil && len(b) == size
+}
+
+////////////////
+// dlExpected //
+////////////////
+
+func (exp *dlExpected) wrapReader(r io.ReadCloser) *cksumReader {
+	cr := &cksumReader{ReadCloser: r, exp: exp, hashes: make(map[string]hash.Hash, len(exp.cksums))}
+	for ty := range exp.cksums {
+		switch ty {
+		case manifestMD5:
+			cr.hashes[ty] = md5.New()
+		case manifestSHA256:
+			cr.hashes[ty] = sha256.New()
+		}
+	}
+	return cr
+}
+
+// verify returns error describing the first mismatch (if any).
+func (exp *dlExpected) verify(cr *cksumReader) error {
+	if exp.size != 0 && exp.size != cr.size {
+		return fmt.Errorf("size mismatch: expected %d, got %d", exp.size, cr.size)
+	}
+	for ty, h := range cr.hashes {
+		if actual := hex.EncodeToString(h.Sum(nil)); actual != exp.cksums[ty] {
+			return fmt.Errorf("%s checksum mismatch: expected %s, got %s", ty, exp.cksums[ty], actual)
+		}
+	}
+	return nil
+}
+
+func (cr *cksumReader) Read(b []byte) (n int, err error) {
+	n, err = cr.ReadCloser.Read(b)
+	if n > 0 {
+		cr.size += int64(n)
+		for _, h := range cr.hashes {
+			h.Write(b[:n])
+		}
+	}
+	if err == io.EOF {
+		if errV := cr.exp.verify(cr); errV != nil {
+			err = errV
+		}
+	}
+	return
+}
@@ -0,0 +1,196 @@
+// Package downloader implements functionality to download resources into AIS cluster from external source.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package downloader
+
+import (
+	"io"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/NVIDIA/aistore/devtools/tutils/tassert"
+)
+
+const (
+	// MD5 and SHA-256 of "data"
+	dataMD5    = "8d777f385d3dfec8815d20f7496026dc"
+	dataSHA256 = "3a6eb0790f39ac87c94f3856b2dd2c5d110e6811602261a9a923d3bb23adc8b7"
+)
+
+func readManifest(manifest, format string) ([]*ManifestEntry, error) {
+	r, err := newManifestReader(strings.NewReader(manifest), format)
+	if err != nil {
+		return nil, err
+	}
+	var entries []*ManifestEntry
+	for {
+		entry, err := r.next()
+		if err == io.EOF {
+			return entries, nil
+		}
+		if err != nil {
+			return entries, err
+		}
+		entries = append(entries, entry)
+	}
+}
+
+func TestManifestFormat(t *testing.T) {
+	tests := []struct {
+		manifest string
+		format   string
+		expected string
+		fail     bool
+	}{
+		{manifest: "ais://lists/list.csv", expected: ManifestFormatCSV},
+		{manifest: "ais://lists/list.jsonl", expected: ManifestFormatJSONL},
+		{manifest: "http://example.com/list.CSV?alt=media", expected: ManifestFormatCSV},
+		{manifest: "ais://lists/list", format: ManifestFormatJSONL, expected: ManifestFormatJSONL},
+		{manifest: "ais://lists/list.csv", format: ManifestFormatJSONL, expected: ManifestFormatJSONL},
+		{manifest: "ais://lists/list", fail: true},
+		{manifest: "ais://lists/list.csv", format: "xml", fail: true},
+	}
+	for _, test := range tests {
+		format, err := ManifestFormat(test.manifest, test.format)
+		if test.fail {
+			tassert.Errorf(t, err != nil, "expected error for %q (format: %q)", test.manifest, test.format)
+			continue
+		}
+		tassert.CheckFatal(t, err)
+		tassert.Errorf(t, format == test.expected, "expected %q, got %q (manifest: %q)", test.expected, format, test.manifest)
+	}
+}
+
+func TestManifestCSV(t *testing.T) {
+	manifest := "url, md5,size,comment,object_name\n" +
+		"http://example.com/a.tar," + strings.ToUpper(dataMD5) + ",4,first,\n" +
+		"http://example.com/b.tar,,,second,dir/b.tar\n"
+	entries, err := readManifest(manifest, ManifestFormatCSV)
+	tassert.CheckFatal(t, err)
+	tassert.Fatalf(t, len(entries) == 2, "expected 2 entries, got %d", len(entries))
+
+	tassert.Errorf(t, entries[0].ObjName == "a.tar", "expected object name deduced from url, got %q", entries[0].ObjName)
+	tassert.Errorf(t, entries[0].MD5 == dataMD5, "expected %q, got %q", dataMD5, entries[0].MD5)
+	tassert.Errorf(t, entries[0].Size == 4, "expected size 4, got %d", entries[0].Size)
+	tassert.Errorf(t, entries[1].ObjName == "dir/b.tar", "expected %q, got %q", "dir/b.tar", entries[1].ObjName)
+	tassert.Errorf(t, entries[1].expected() == nil, "expected nothing to validate")
+}
+
+func TestManifestJSONL(t *testing.T) {
+	manifest := `{"url": "http://example.com/a.tar", "sha256": "` + dataSHA256 + `"}
+
+{"url": "http://example.com/b.tar", "object_name": "dir/b.tar", "size": 10}`
+	entries, err := readManifest(manifest, ManifestFormatJSONL)
+	tassert.CheckFatal(t, err)
+	tassert.Fatalf(t, len(entries) == 2, "expected 2 entries, got %d", len(entries))
+
+	tassert.Errorf(t, entries[0].ObjName == "a.tar", "expected object name deduced from url, got %q", entries[0].ObjName)
+	tassert.Errorf(t, entries[0].SHA256 == dataSHA256, "expected %q, got %q", dataSHA256, entries[0].SHA256)
+	tassert.Errorf(t, entries[1].Size == 10, "expected size 10, got %d", entries[1].Size)
+}
+
+func TestManifestInvalid(t *testing.T) {
+	tests := []struct {
+		name     string
+		manifest string
+		format   string
+	}{
+		{"empty CSV", "", ManifestFormatCSV},
+		{"CSV without url column", "object_name,md5\na,b\n", ManifestFormatCSV},
+		{"CSV missing url", "url,object_name\n,obj\n", ManifestFormatCSV},
+		{"CSV invalid size", "url,size\nhttp://example.com/a,ten\n", ManifestFormatCSV},
+		{"CSV invalid md5", "url,md5\nhttp://example.com/a,xyz\n", ManifestFormatCSV},
+		{"JSONL invalid entry", `{"url": "http://example.com/a"`, ManifestFormatJSONL},
+		{"JSONL short sha256", `{"url": "http://example.com/a", "sha256": "` + dataMD5 + `"}`, ManifestFormatJSONL},
+		{"JSONL negative size", `{"url": "http://example.com/a", "size": -1}`, ManifestFormatJSONL},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := readManifest(test.manifest, test.format)
+			tassert.Errorf(t, err != nil, "expected error")
+		})
+	}
+}
+
+func TestManifestVerify(t *testing.T) {
+	tests := []struct {
+		name  string
+		entry ManifestEntry
+		valid bool
+	}{
+		{"md5", ManifestEntry{MD5: dataMD5}, true},
+		{"sha256", ManifestEntry{SHA256: dataSHA256}, true},
+		{"all", ManifestEntry{Size: 4, MD5: dataMD5, SHA256: dataSHA256}, true},
+		{"size mismatch", ManifestEntry{Size: 5, MD5: dataMD5}, false},
+		{"md5 mismatch", ManifestEntry{MD5: strings.Repeat("0", 32)}, false},
+		{"sha256 mismatch", ManifestEntry{MD5: dataMD5, SHA256: strings.Repeat("0", 64)}, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			exp := test.entry.expected()
+			cr := exp.wrapReader(ioutil.NopCloser(strings.NewReader("data")))
+			_, err := io.Copy(ioutil.Discard, cr) // (fails at EOF upon mismatch)
+			if test.valid {
+				tassert.CheckFatal(t, err)
+			} else {
+				tassert.Errorf(t, err != nil, "expected mismatch")
+			}
+		})
+	}
+}
+
+func TestManifestEntryErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		manifest string
+		format   string
+		errs     []string // names under which the invalid entries get reported
+	}{
+		{
+			"CSV",
+			"url,md5,size\n" +
+				"http://example.com/a.tar,,\n" +
+				"http://example.com/b.tar,xyz,\n" +
+				",,4\n" +
+				"http://example.com/c.tar,\"x\"y,\n" +
+				"http://example.com/d.tar,,ten\n" +
+				"http://example.com/e.tar,,\n",
+			ManifestFormatCSV,
+			[]string{"b.tar", "manifest line 4", "manifest line 5", "manifest line 6"},
+		},
+		{
+			"JSONL",
+			`{"url": "http://example.com/a.tar"}
+{"url": "http://example.com/b.tar", "md5": "xyz"}
+{"url": "http://example.com/c.tar"
+{"size": 4}
+{"url": "http://example.com/e.tar"}`,
+			ManifestFormatJSONL,
+			[]string{"b.tar", "manifest line 3", "manifest line 4"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r, err := newManifestReader(strings.NewReader(test.manifest), test.format)
+			tassert.CheckFatal(t, err)
+			var names, errs []string
+			for {
+				entry, err := r.next()
+				if err == io.EOF {
+					break
+				}
+				if err != nil {
+					errEntry, ok := err.(*errManifestEntry)
+					tassert.Fatalf(t, ok, "expected invalid entry, got %v", err)
+					errs = append(errs, errEntry.objName())
+					continue
+				}
+				names = append(names, entry.ObjName)
+			}
+			tassert.Errorf(t, strings.Join(names, ",") == "a.tar,e.tar", "expected valid entries a.tar and e.tar, got %v", names)
+			tassert.Errorf(t, strings.Join(errs, ",") == strings.Join(test.errs, ","), "expected errors %v, got %v", test.errs, errs)
+		})
+	}
+}
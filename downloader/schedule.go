@@ -0,0 +1,568 @@
+// Package downloader implements functionality to download resources into AIS cluster from external source.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package downloader
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"math/bits"
+	"net/http"
+	"net/url"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/hk"
+	"github.com/NVIDIA/aistore/stats"
+	"github.com/NVIDIA/aistore/xaction/xreg"
+	jsoniter "github.com/json-iterator/go"
+)
+
+// Scheduled (recurring) downloads: the download request with `schedule` set
+// is not started right away - instead, each target stores it (see
+// `dlSchedule`) in the downloader's DB and starts the job every time the
+// schedule fires. Each run is a regular download job with the ID built of the
+// schedule's ID and the time of the run, the same on all targets. Since the
+// objects which are already present (and did not change) are skipped, the
+// runs download only new and changed objects - and, with `sync` set, remove
+// the objects which were deleted from the cloud bucket (or are no longer
+// linked from the HTTP index).
+//
+// The targets which join the cluster later receive the existing schedules from
+// the target with the lowest ID (among the ones which were already there) -
+// see `scheduler.ListenSmapChanged`.
+//
+// Schedule is either a cron expression (5 fields: minute, hour, day of month,
+// month, day of week - evaluated in UTC) or "@every <duration>" (runs aligned
+// to the multiples of the duration).
+
+const (
+	downloaderSchedules = "schedules"
+
+	scheduleEveryPrefix = "@every "
+	scheduleMinInterval = time.Minute
+	// How far in the future the next run of the cron schedule is searched for.
+	scheduleMaxYears = 5
+)
+
+var (
+	// global downloader scheduler
+	dlScheduler     *scheduler
+	dlSchedulerOnce sync.Once
+)
+
+type (
+	// schedule determines when the scheduled download runs
+	schedule interface {
+		// next returns the time of the first run after `t` (zero if none).
+		next(t time.Time) time.Time
+	}
+
+	everySchedule struct {
+		interval time.Duration
+	}
+
+	cronSchedule struct {
+		minute, hour, dom, month, dow uint64 // bitsets of allowed values
+		domStar, dowStar              bool
+	}
+
+	dlSchedule struct {
+		DlScheduleInfo
+		Body  DlBody `json:"body"`
+		sched schedule
+	}
+
+	scheduler struct {
+		mtx       sync.Mutex
+		t         cluster.Target
+		statsT    stats.Tracker
+		smap      *cluster.Smap
+		client    *http.Client
+		schedules map[string]*dlSchedule
+	}
+)
+
+// interface guard
+var (
+	_ schedule          = (*everySchedule)(nil)
+	_ schedule          = (*cronSchedule)(nil)
+	_ cluster.Slistener = (*scheduler)(nil)
+)
+
+// InitSchedules loads the schedules persisted by the target and resumes them.
+// The runs which were missed in the meantime are skipped.
+func InitSchedules(t cluster.Target, statsT stats.Tracker) {
+	dlSchedulerOnce.Do(func() {
+		config := cmn.GCO.Get()
+		initInfoStore(t.DB())
+		dlScheduler = &scheduler{
+			t:      t,
+			statsT: statsT,
+			smap:   t.Sowner().Get(),
+			client: cmn.NewClient(cmn.TransportArgs{
+				Timeout:    config.Client.Timeout,
+				UseHTTPS:   config.Net.HTTP.UseHTTPS,
+				SkipVerify: config.Net.HTTP.SkipVerify,
+			}),
+			schedules: make(map[string]*dlSchedule),
+		}
+		dlScheduler.load()
+		t.Sowner().Listeners().Reg(dlScheduler)
+	})
+}
+
+// ValidateSchedule checks if the download of the given type can be scheduled.
+func ValidateSchedule(ty DlType, spec string) error {
+	if ty != DlTypeCloud && ty != DlTypeManifest && ty != DlTypeIndex {
+		return fmt.Errorf("only %q, %q and %q downloads can be scheduled", DlTypeCloud, DlTypeManifest, DlTypeIndex)
+	}
+	_, err := parseSchedule(spec)
+	return err
+}
+
+// AddSchedule registers (and persists) the recurring download.
+func AddSchedule(id string, dlb DlBody) error {
+	var base DlBase
+	if err := jsoniter.Unmarshal(dlb.RawMessage, &base); err != nil {
+		return err
+	}
+	if err := ValidateSchedule(dlb.Type, base.Schedule); err != nil {
+		return err
+	}
+	if err := validateScheduled(dlb); err != nil {
+		return err
+	}
+	if dlScheduler == nil {
+		return fmt.Errorf("download schedules are not initialized")
+	}
+	sched, _ := parseSchedule(base.Schedule)
+	s := &dlSchedule{
+		DlScheduleInfo: DlScheduleInfo{
+			ID:          id,
+			Type:        dlb.Type,
+			Bck:         base.Bck,
+			Schedule:    base.Schedule,
+			Description: base.Description,
+			CreatedTime: time.Now(),
+		},
+		Body:  dlb,
+		sched: sched,
+	}
+	if s.NextRun = sched.next(s.CreatedTime); s.NextRun.IsZero() {
+		return fmt.Errorf("schedule %q never fires", base.Schedule)
+	}
+	return dlScheduler.add(s)
+}
+
+// ListSchedules returns the schedules which description matches the regex.
+func ListSchedules(descRegex *regexp.Regexp) map[string]*DlScheduleInfo {
+	if dlScheduler == nil {
+		return map[string]*DlScheduleInfo{}
+	}
+	return dlScheduler.list(descRegex)
+}
+
+func removeSchedule(id string) bool {
+	return dlScheduler != nil && dlScheduler.remove(id)
+}
+
+func validateScheduled(dlb DlBody) error {
+	var body interface{ Validate() error }
+	switch dlb.Type {
+	case DlTypeCloud:
+		body = &DlCloudBody{}
+	case DlTypeManifest:
+		body = &DlManifestBody{}
+	case DlTypeIndex:
+		body = &DlIndexBody{}
+	default:
+		cmn.Assertf(false, "%s", dlb.Type)
+	}
+	if err := jsoniter.Unmarshal(dlb.RawMessage, body); err != nil {
+		return err
+	}
+	return body.Validate()
+}
+
+///////////////
+// scheduler //
+///////////////
+
+func (s *scheduler) load() {
+	records, err := dlStore.driver.GetAll(downloaderCollection, downloaderSchedules)
+	if err != nil {
+		glog.Error(err)
+		return
+	}
+	for _, r := range records {
+		sch := &dlSchedule{}
+		if err := jsoniter.Unmarshal([]byte(r), sch); err != nil {
+			glog.Error(err)
+			continue
+		}
+		if sch.sched, err = parseSchedule(sch.Schedule); err != nil {
+			glog.Errorf("download schedule %q: %v", sch.ID, err)
+			continue
+		}
+		if now := time.Now(); sch.NextRun.Before(now) {
+			sch.NextRun = sch.sched.next(now)
+		}
+		if err := s.add(sch); err != nil {
+			glog.Error(err)
+			continue
+		}
+		glog.Infof("resumed download schedule %s (next run: %s)", sch.ID, sch.NextRun.Format(time.RFC3339))
+	}
+}
+
+func (s *scheduler) add(sch *dlSchedule) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if _, ok := s.schedules[sch.ID]; ok {
+		return fmt.Errorf("download schedule %q already exists", sch.ID)
+	}
+	if err := s.persist(sch); err != nil {
+		return err
+	}
+	s.schedules[sch.ID] = sch
+	hk.Reg(sch.hkName(), func() time.Duration { return s.fire(sch.ID) }, time.Until(sch.NextRun))
+	return nil
+}
+
+// remove unregisters the schedule (if exists), the jobs run so far are kept.
+func (s *scheduler) remove(id string) bool {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	sch, ok := s.schedules[id]
+	if !ok {
+		return false
+	}
+	delete(s.schedules, id)
+	hk.Unreg(sch.hkName())
+	if err := dlStore.driver.Delete(downloaderCollection, sch.key()); err != nil {
+		glog.Error(err)
+	}
+	return true
+}
+
+func (s *scheduler) list(descRegex *regexp.Regexp) map[string]*DlScheduleInfo {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	infos := make(map[string]*DlScheduleInfo, len(s.schedules))
+	for id, sch := range s.schedules {
+		if descRegex == nil || descRegex.MatchString(sch.Description) {
+			info := sch.DlScheduleInfo
+			infos[id] = &info
+		}
+	}
+	return infos
+}
+
+// fire starts the run of the schedule (if due) and returns the time until the
+// next run. The job is started without holding the lock - starting it may
+// take a while (e.g., reading the manifest or the index).
+func (s *scheduler) fire(id string) time.Duration {
+	s.mtx.Lock()
+	sch, ok := s.schedules[id]
+	if !ok {
+		// Removed in the meantime (and about to be unregistered).
+		s.mtx.Unlock()
+		return hk.DayInterval
+	}
+	now := time.Now()
+	if until := sch.NextRun.Sub(now); until > 0 {
+		s.mtx.Unlock()
+		return until
+	}
+	var (
+		runTime = sch.NextRun
+		jobID   = sch.jobID(runTime)
+		bck     = sch.Bck
+		body    = sch.Body
+	)
+	sch.LastRun, sch.LastJobID, sch.LastErr = runTime, jobID, ""
+	sch.Runs++
+	sch.NextRun = sch.sched.next(now)
+	next := sch.NextRun
+	s.mtx.Unlock()
+
+	err := s.start(bck, jobID, body)
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if err != nil {
+		glog.Errorf("download schedule %s: failed to start %s: %v", id, jobID, err)
+	}
+	// Removed while starting - nothing to update.
+	if sch, ok = s.schedules[id]; ok {
+		if err != nil {
+			sch.LastErr = err.Error()
+		}
+		if err := s.persist(sch); err != nil {
+			glog.Error(err)
+		}
+	}
+	if next.IsZero() {
+		return hk.DayInterval
+	}
+	return time.Until(next)
+}
+
+func (s *scheduler) start(sbck cmn.Bck, jobID string, body DlBody) error {
+	xact, err := xreg.RenewDownloader(s.t, s.statsT)
+	if err != nil {
+		return err
+	}
+	bck := cluster.NewBckEmbed(sbck)
+	if err := bck.Init(s.t.Bowner(), s.t.Snode()); err != nil {
+		return err
+	}
+	dlXact := xact.(*Downloader)
+	job, err := ParseStartDownloadRequest(context.Background(), s.t, bck, jobID, body, dlXact)
+	if err != nil {
+		return err
+	}
+	resp, statusCode, err := dlXact.Download(job)
+	if err == nil && statusCode >= http.StatusBadRequest {
+		err = fmt.Errorf("%v", resp)
+	}
+	return err
+}
+
+func (s *scheduler) persist(sch *dlSchedule) error {
+	return dlStore.driver.Set(downloaderCollection, sch.key(), sch)
+}
+
+// implementing cluster.Slistener interface
+func (*scheduler) String() string { return "download-scheduler" }
+
+// ListenSmapChanged pushes the existing schedules to the targets which joined
+// the cluster. Only one target does it: the one with the lowest ID among the
+// targets present in both the old and the new Smap. Pushing the schedule which
+// the target already has is harmless (rejected as existing).
+func (s *scheduler) ListenSmapChanged() {
+	smap := s.t.Sowner().Get()
+	s.mtx.Lock()
+	prev := s.smap
+	if smap.Version <= prev.Version {
+		s.mtx.Unlock()
+		return
+	}
+	s.smap = smap
+	bodies := make(map[string]DlBody, len(s.schedules))
+	for id, sch := range s.schedules {
+		bodies[id] = sch.Body
+	}
+	s.mtx.Unlock()
+
+	if len(bodies) == 0 {
+		return
+	}
+	var (
+		joined []*cluster.Snode
+		pusher string
+	)
+	for sid, si := range smap.Tmap {
+		if _, ok := prev.Tmap[sid]; !ok {
+			joined = append(joined, si)
+		} else if pusher == "" || sid < pusher {
+			pusher = sid
+		}
+	}
+	if len(joined) == 0 || pusher != s.t.Snode().ID() {
+		return
+	}
+	// NOTE: must not block the Smap listeners
+	go s.push(joined, bodies)
+}
+
+func (s *scheduler) push(nodes []*cluster.Snode, bodies map[string]DlBody) {
+	for _, si := range nodes {
+		for id, body := range bodies {
+			reqArgs := cmn.ReqArgs{
+				Method: http.MethodPost,
+				Base:   si.URL(cmn.NetworkIntraControl),
+				Path:   cmn.JoinWords(cmn.Version, cmn.Download),
+				Query:  url.Values{cmn.URLParamUUID: []string{id}},
+				Body:   cmn.MustMarshal(body),
+			}
+			if err := s.call(reqArgs); err != nil {
+				glog.Errorf("download schedule %s: failed to push to %s: %v", id, si, err)
+			}
+		}
+	}
+}
+
+func (s *scheduler) call(reqArgs cmn.ReqArgs) error {
+	req, err := reqArgs.Req()
+	if err != nil {
+		return err
+	}
+	resp, err := s.client.Do(req) // nolint:bodyclose // closed below
+	if err != nil {
+		return err
+	}
+	defer cmn.Close(resp.Body)
+	if resp.StatusCode < http.StatusBadRequest {
+		return nil
+	}
+	b, _ := ioutil.ReadAll(resp.Body)
+	if strings.Contains(string(b), "already exists") {
+		return nil
+	}
+	return fmt.Errorf("%s: %s", resp.Status, b)
+}
+
+////////////////
+// dlSchedule //
+////////////////
+
+func (sch *dlSchedule) key() string    { return path.Join(downloaderSchedules, sch.ID) }
+func (sch *dlSchedule) hkName() string { return "download-schedule-" + sch.ID }
+
+// jobID returns the ID of the run - it is the same on all targets.
+func (sch *dlSchedule) jobID(runTime time.Time) string {
+	return sch.ID + "-" + strconv.FormatInt(runTime.Unix(), 10)
+}
+
+//////////////
+// schedule //
+//////////////
+
+func parseSchedule(spec string) (schedule, error) {
+	spec = strings.TrimSpace(spec)
+	if strings.HasPrefix(spec, scheduleEveryPrefix) {
+		interval, err := time.ParseDuration(strings.TrimSpace(strings.TrimPrefix(spec, scheduleEveryPrefix)))
+		if err != nil {
+			return nil, fmt.Errorf("invalid schedule %q: %v", spec, err)
+		}
+		if interval < scheduleMinInterval {
+			return nil, fmt.Errorf("invalid schedule %q: interval must be at least %v", spec, scheduleMinInterval)
+		}
+		return &everySchedule{interval: interval}, nil
+	}
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("invalid schedule %q: expected 5 fields (minute, hour, day of month, month, day of week) or %q",
+			spec, scheduleEveryPrefix+"<duration>")
+	}
+	var (
+		cs     = &cronSchedule{}
+		ranges = []struct {
+			name     string
+			bits     *uint64
+			min, max int
+		}{
+			{"minute", &cs.minute, 0, 59},
+			{"hour", &cs.hour, 0, 23},
+			{"day of month", &cs.dom, 1, 31},
+			{"month", &cs.month, 1, 12},
+			{"day of week", &cs.dow, 0, 7},
+		}
+	)
+	for idx, r := range ranges {
+		b, err := parseCronField(fields[idx], r.min, r.max)
+		if err != nil {
+			return nil, fmt.Errorf("invalid schedule %q: %s: %v", spec, r.name, err)
+		}
+		*r.bits = b
+	}
+	// Sunday is both 0 and 7.
+	if cs.dow&(1<<7) != 0 {
+		cs.dow = cs.dow&^(1<<7) | 1
+	}
+	cs.domStar, cs.dowStar = fields[2] == "*", fields[4] == "*"
+	return cs, nil
+}
+
+// parseCronField parses comma separated list of: "*", "N", "N-M" - each
+// optionally followed by "/step".
+func parseCronField(field string, min, max int) (b uint64, err error) {
+	for _, part := range strings.Split(field, ",") {
+		var (
+			lo, hi = min, max
+			step   = 1
+			rng    = part
+		)
+		if i := strings.IndexByte(part, '/'); i >= 0 {
+			if step, err = strconv.Atoi(part[i+1:]); err != nil || step <= 0 {
+				return 0, fmt.Errorf("invalid step in %q", part)
+			}
+			rng = part[:i]
+		}
+		if rng != "*" {
+			bounds := strings.SplitN(rng, "-", 2)
+			if lo, err = strconv.Atoi(bounds[0]); err != nil {
+				return 0, fmt.Errorf("invalid value in %q", part)
+			}
+			hi = lo
+			if len(bounds) == 2 {
+				if hi, err = strconv.Atoi(bounds[1]); err != nil {
+					return 0, fmt.Errorf("invalid value in %q", part)
+				}
+			} else if step > 1 {
+				hi = max // "N/step" means "N-max/step"
+			}
+		}
+		if lo < min || hi > max || lo > hi {
+			return 0, fmt.Errorf("%q out of range [%d, %d]", part, min, max)
+		}
+		for v := lo; v <= hi; v += step {
+			b |= 1 << uint(v)
+		}
+	}
+	return b, nil
+}
+
+func (es *everySchedule) next(t time.Time) time.Time {
+	return t.Truncate(es.interval).Add(es.interval)
+}
+
+func (cs *cronSchedule) next(t time.Time) time.Time {
+	t = t.UTC().Truncate(time.Minute).Add(time.Minute)
+	limit := t.Year() + scheduleMaxYears
+	for t.Year() <= limit {
+		y, m, d := t.Date()
+		switch {
+		case cs.month&(1<<uint(m)) == 0:
+			t = time.Date(y, m+1, 1, 0, 0, 0, 0, time.UTC)
+		case !cs.dayMatches(t):
+			t = time.Date(y, m, d+1, 0, 0, 0, 0, time.UTC)
+		case cs.hour&(1<<uint(t.Hour())) == 0:
+			t = time.Date(y, m, d, t.Hour()+1, 0, 0, 0, time.UTC)
+		case cs.minute&(1<<uint(t.Minute())) == 0:
+			// Jump straight to the next allowed minute within the hour, if any.
+			if rest := cs.minute >> uint(t.Minute()); rest != 0 {
+				t = t.Add(time.Duration(bits.TrailingZeros64(rest)) * time.Minute)
+			} else {
+				t = time.Date(y, m, d, t.Hour()+1, 0, 0, 0, time.UTC)
+			}
+		default:
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// Same as cron: when both day of month and day of week are restricted, either
+// one has to match.
+func (cs *cronSchedule) dayMatches(t time.Time) bool {
+	var (
+		dom = cs.dom&(1<<uint(t.Day())) != 0
+		dow = cs.dow&(1<<uint(t.Weekday())) != 0
+	)
+	if cs.domStar || cs.dowStar {
+		return dom && dow
+	}
+	return dom || dow
+}
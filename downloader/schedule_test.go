@@ -0,0 +1,77 @@
+// Package downloader implements functionality to download resources into AIS cluster from external source.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package downloader
+
+import (
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/devtools/tutils/tassert"
+)
+
+func TestScheduleNext(t *testing.T) {
+	// Wednesday
+	now := time.Date(2020, time.December, 30, 10, 17, 42, 0, time.UTC)
+	tests := []struct {
+		spec     string
+		expected time.Time
+	}{
+		{"* * * * *", time.Date(2020, time.December, 30, 10, 18, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2020, time.December, 30, 10, 30, 0, 0, time.UTC)},
+		{"5,20 * * * *", time.Date(2020, time.December, 30, 10, 20, 0, 0, time.UTC)},
+		{"0 */6 * * *", time.Date(2020, time.December, 30, 12, 0, 0, 0, time.UTC)},
+		{"30 2 * * *", time.Date(2020, time.December, 31, 2, 30, 0, 0, time.UTC)},
+		{"0 0 1 * *", time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 0 * * 0", time.Date(2021, time.January, 3, 0, 0, 0, 0, time.UTC)},
+		{"0 0 * * 7", time.Date(2021, time.January, 3, 0, 0, 0, 0, time.UTC)},
+		{"0 0 * * 1-5", time.Date(2020, time.December, 31, 0, 0, 0, 0, time.UTC)},
+		// Either day of month or day of week.
+		{"0 0 15 * 5", time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 0 29 2 *", time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC)},
+		{"@every 1h", time.Date(2020, time.December, 30, 11, 0, 0, 0, time.UTC)},
+		{"@every 90m", time.Date(2020, time.December, 30, 10, 30, 0, 0, time.UTC)},
+	}
+	for _, test := range tests {
+		sched, err := parseSchedule(test.spec)
+		tassert.CheckFatal(t, err)
+		next := sched.next(now)
+		tassert.Errorf(t, next.Equal(test.expected), "%q: expected %s, got %s", test.spec, test.expected, next)
+	}
+}
+
+func TestScheduleNever(t *testing.T) {
+	sched, err := parseSchedule("0 0 31 2 *")
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, sched.next(time.Now()).IsZero(), "expected schedule to never fire")
+}
+
+func TestScheduleInvalid(t *testing.T) {
+	specs := []string{
+		"",
+		"* * * *",
+		"* * * * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"*/0 * * * *",
+		"5-1 * * * *",
+		"a * * * *",
+		"@every 10s",
+		"@every day",
+	}
+	for _, spec := range specs {
+		_, err := parseSchedule(spec)
+		tassert.Errorf(t, err != nil, "expected error for %q", spec)
+	}
+}
+
+func TestValidateSchedule(t *testing.T) {
+	tassert.CheckError(t, ValidateSchedule(DlTypeCloud, "@every 1h"))
+	tassert.CheckError(t, ValidateSchedule(DlTypeManifest, "0 * * * *"))
+	tassert.CheckError(t, ValidateSchedule(DlTypeIndex, "@every 6h"))
+	tassert.Errorf(t, ValidateSchedule(DlTypeRange, "@every 1h") != nil, "expected range download to be rejected")
+}
@@ -123,8 +123,12 @@ func (t *singleObjectTask) tryDownloadLocal(lom *cluster.LOM, timeout time.Durat
 		return false, err
 	}
 
+	var body io.ReadCloser = resp.Body
+	if t.obj.expected != nil {
+		body = t.obj.expected.wrapReader(resp.Body) // (PUT fails upon mismatch)
+	}
 	var (
-		r   = t.wrapReader(ctx, resp.Body)
+		r   = t.wrapReader(ctx, body)
 		roi = roiFromLink(t.obj.link, resp)
 	)
 
@@ -124,8 +124,30 @@ func ParseStartDownloadRequest(ctx context.Context, t cluster.Target, bck *clust
 		}
 		return newSingleDlJob(t, id, bck, dp, dlXact)
 
+	case DlTypeManifest:
+		dp := &DlManifestBody{}
+		err := jsoniter.Unmarshal(dlb.RawMessage, dp)
+		if err != nil {
+			return nil, err
+		}
+		if err := dp.Validate(); err != nil {
+			return nil, err
+		}
+		return newManifestDlJob(t, id, bck, dp, dlXact)
+
+	case DlTypeIndex:
+		dp := &DlIndexBody{}
+		err := jsoniter.Unmarshal(dlb.RawMessage, dp)
+		if err != nil {
+			return nil, err
+		}
+		if err := dp.Validate(); err != nil {
+			return nil, err
+		}
+		return newIndexDlJob(t, id, bck, dp, dlXact)
+
 	default:
-		return nil, errors.New("input does not match any of the supported formats (single, range, multi, cloud)")
+		return nil, errors.New("input does not match any of the supported formats (single, range, multi, cloud, manifest, index)")
 	}
 }
 
@@ -200,8 +222,9 @@ func roiFromLink(link string, resp *http.Response) (roi remoteObjInfo) {
 			roi.md[cluster.MD5ObjMD] = v
 		}
 	} else {
-		roi.md = make(cmn.SimpleKVs, 1)
+		roi.md = make(cmn.SimpleKVs, 2)
 		roi.md[cluster.SourceObjMD] = cluster.SourceWebObjMD
+		roi.md[cluster.OrigURLObjMD] = link // see `DlJob.syncPrefix`
 	}
 	roi.size = resp.ContentLength
 	return
@@ -247,6 +270,10 @@ func roiFromObjMeta(objMeta cmn.SimpleKVs) (roi remoteObjInfo) {
 		roi.md[cluster.SourceObjMD] = cluster.SourceAmazonObjMD
 	case cmn.ProviderAzure:
 		roi.md[cluster.SourceObjMD] = cluster.SourceAzureObjMD
+	case cmn.ProviderHDFS:
+		roi.md[cluster.SourceObjMD] = cluster.SourceHDFSObjMD
+	case cmn.ProviderFS:
+		roi.md[cluster.SourceObjMD] = cluster.SourceFSObjMD
 	default:
 		return
 	}
@@ -267,6 +294,9 @@ func roiFromObjMeta(objMeta cmn.SimpleKVs) (roi remoteObjInfo) {
 
 func compareObjects(src *cluster.LOM, dst *DstElement) (equal bool, err error) {
 	var roi remoteObjInfo
+	if dst.expected != nil && dst.expected.size != 0 && dst.expected.size != src.Size() {
+		return false, nil
+	}
 	if dst.Link != "" {
 		resp, err := headLink(dst.Link)
 		if err != nil {
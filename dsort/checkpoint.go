@@ -0,0 +1,339 @@
+// Package dsort provides distributed massively parallel resharding for very large datasets.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package dsort
+
+import (
+	"bufio"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/jsp"
+	"github.com/NVIDIA/aistore/dsort/extract"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/pkg/errors"
+	"github.com/tinylib/msgp/msgp"
+)
+
+// Resumable dSort jobs persist the checkpoints of the completed phases on one
+// of the mountpaths of each target (`<mountpath>/.ais.dsort/<job-uuid>`):
+//  * checkpoint - state of the job on the target (see `checkpoint`),
+//  * records - records extracted by the target (their contents are kept on the disk),
+//  * sorted - all the records sorted by the final target,
+//  * created - names of the shards that have been created by the target.
+//
+// When the job is resumed, targets restore the state and skip the phases which
+// have been completed by all of them. Shards which have been already created
+// are not created again.
+
+const (
+	checkpointFileName = "checkpoint"
+	recordsFileName    = "records"
+	sortedFileName     = "sorted"
+	createdFileName    = "created"
+)
+
+// checkpoint describes the progress of the resumable dSort job on a target.
+type checkpoint struct {
+	// Phase is the last completed phase, empty if none has been completed yet.
+	Phase   string             `json:"phase"`
+	RS      *ParsedRequestSpec `json:"rs"`
+	Targets []string           `json:"targets"` // IDs of the targets participating in the job
+
+	Compressed   int64            `json:"compressed,string"`
+	Uncompressed int64            `json:"uncompressed,string"`
+	Extraction   *LocalExtraction `json:"extraction,omitempty"`
+
+	// Created contains the names of the shards that have been created by the
+	// target. It is persisted separately and filled in only when loaded.
+	Created []string `json:"created,omitempty"`
+}
+
+func checkpointPath(managerUUID, name string) string {
+	return filepath.Join(fs.DSortCheckpointDirName, managerUUID, name)
+}
+
+// loadCheckpoint loads the checkpoint of the job along with the names of the
+// created shards. Returns nil checkpoint if there is none.
+func loadCheckpoint(managerUUID string) (*checkpoint, *fs.MountpathInfo, error) {
+	var mpathInfo *fs.MountpathInfo
+	for _, mpathInfo = range fs.FindPersisted(checkpointPath(managerUUID, checkpointFileName)) {
+		break
+	}
+	if mpathInfo == nil {
+		return nil, nil, nil
+	}
+
+	cp := &checkpoint{}
+	fpath := filepath.Join(mpathInfo.Path, checkpointPath(managerUUID, checkpointFileName))
+	if _, err := jsp.Load(fpath, cp, jsp.CksumSign()); err != nil {
+		return nil, nil, err
+	}
+
+	f, err := os.Open(filepath.Join(mpathInfo.Path, checkpointPath(managerUUID, createdFileName)))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return cp, mpathInfo, nil
+		}
+		return nil, nil, err
+	}
+	defer cmn.Close(f)
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if name := strings.TrimSpace(scanner.Text()); name != "" {
+			cp.Created = append(cp.Created, name)
+		}
+	}
+	return cp, mpathInfo, scanner.Err()
+}
+
+// removeCheckpoint removes the checkpoint of the job along with the contents
+// of the records which were kept on the disk so that the job could be resumed.
+func removeCheckpoint(managerUUID string) error {
+	cp, mpathInfo, err := loadCheckpoint(managerUUID)
+	if err != nil || cp == nil {
+		return err
+	}
+	if cp.Phase != "" {
+		records, err := loadRecords(mpathInfo, managerUUID, recordsFileName)
+		if err != nil {
+			return err
+		}
+		rm := extract.NewRecordManager(ctx.t, ctx.node.DaemonID, cp.RS.Bucket, cp.RS.Provider,
+			cp.RS.Extension, nil, nil, nil, nil)
+		rm.RestoreRecords(records)
+		rm.Cleanup()
+	}
+	return mpathInfo.Remove(checkpointPath(managerUUID, ""))
+}
+
+func loadRecords(mpathInfo *fs.MountpathInfo, managerUUID, name string) (*extract.Records, error) {
+	f, err := os.Open(filepath.Join(mpathInfo.Path, checkpointPath(managerUUID, name)))
+	if err != nil {
+		return nil, err
+	}
+	defer cmn.Close(f)
+	records := extract.NewRecords(1000)
+	if err := records.DecodeMsg(msgp.NewReaderSize(f, serializationBufSize)); err != nil {
+		return nil, errors.Errorf("failed to load %s checkpoint, err: %v", name, err)
+	}
+	return records, nil
+}
+
+// resumePhase returns the last phase completed by all the targets, empty if
+// the job needs to start over. Only the final target persists sorted records.
+func resumePhase(checkpoints map[string]*checkpoint, targets []string) string {
+	phase := ExtractionPhase
+	for _, daemonID := range targets {
+		cp, ok := checkpoints[daemonID]
+		if !ok || cp.Phase == "" {
+			return ""
+		}
+		if cp.Phase == SortingPhase {
+			phase = SortingPhase
+		}
+	}
+	return phase
+}
+
+func activeTargets(smap *cluster.Smap) []string {
+	targets := make([]string, 0, len(smap.Tmap))
+	for daemonID, si := range smap.Tmap {
+		if !smap.InMaintenance(si) {
+			targets = append(targets, daemonID)
+		}
+	}
+	sort.Strings(targets)
+	return targets
+}
+
+// initCheckpoint prepares the checkpoint of the resumable job. When the job is
+// resumed (`phase` is not empty) it restores the state persisted at the end of
+// the phase.
+func (m *Manager) initCheckpoint(phase string) error {
+	cp, mpathInfo, err := loadCheckpoint(m.ManagerUUID)
+	if err != nil {
+		return err
+	}
+	if phase == "" {
+		// Either a new job or the job which needs to start over - the latter
+		// also cleans up the records kept on the disk by the previous run.
+		if mpathInfo != nil {
+			if err := removeCheckpoint(m.ManagerUUID); err != nil {
+				return err
+			}
+		} else if mpathInfo, _, err = cluster.HrwMpath(m.ManagerUUID); err != nil {
+			return err
+		}
+		m.resumable.mpath = mpathInfo
+		m.resumable.cp = &checkpoint{RS: m.rs, Targets: activeTargets(m.smap)}
+		return m.saveCheckpoint()
+	}
+
+	if cp == nil || cp.Phase == "" {
+		return errors.Errorf("%s %s cannot be resumed: no phase has been completed", cmn.DSortName, m.ManagerUUID)
+	}
+	m.resumable.mpath, m.resumable.cp = mpathInfo, cp
+
+	name := recordsFileName
+	if phase == SortingPhase && cp.Phase == SortingPhase {
+		name = sortedFileName // the final target
+	}
+	records, err := loadRecords(mpathInfo, m.ManagerUUID, name)
+	if err != nil {
+		return err
+	}
+	m.recManager.RestoreRecords(records)
+	m.compression.compressed.Store(cp.Compressed)
+	m.compression.uncompressed.Store(cp.Uncompressed)
+	if cp.Extraction != nil {
+		m.Metrics.Extraction = cp.Extraction
+	}
+	m.Metrics.ResumedPhase = phase
+	m.resumable.resumed = phase
+	m.incrementRef(m.Metrics.Extraction.ExtractedRecordCnt)
+	glog.Infof("%s %s resumed after %s phase", cmn.DSortName, m.ManagerUUID, phase)
+	return nil
+}
+
+func (m *Manager) saveCheckpoint() error {
+	fpath := filepath.Join(m.resumable.mpath.Path, checkpointPath(m.ManagerUUID, checkpointFileName))
+	return jsp.Save(fpath, m.resumable.cp, jsp.CksumSign())
+}
+
+func (m *Manager) saveRecords(name string) error {
+	var (
+		fpath = filepath.Join(m.resumable.mpath.Path, checkpointPath(m.ManagerUUID, name))
+		tmp   = fpath + ".tmp"
+	)
+	f, err := cmn.CreateFile(tmp)
+	if err != nil {
+		return err
+	}
+	msgpw := msgp.NewWriterSize(f, serializationBufSize)
+	if err = m.recManager.Records.EncodeMsg(msgpw); err == nil {
+		err = msgpw.Flush()
+	}
+	cmn.Close(f)
+	if err != nil {
+		if errRm := cmn.RemoveFile(tmp); errRm != nil {
+			glog.Error(errRm)
+		}
+		return errors.Errorf("failed to save %s checkpoint, err: %v", name, err)
+	}
+	return cmn.Rename(tmp, fpath)
+}
+
+// checkpointExtraction persists the records extracted by the target. Contents
+// of the records which are kept in memory are moved to the disk so that they
+// survive the restart of the target.
+func (m *Manager) checkpointExtraction() error {
+	if m.resumable.cp == nil {
+		return nil
+	}
+
+	// Memory watcher must not move the contents concurrently.
+	m.dsorter.postRecordDistribution()
+	buf, slab := mm.Alloc()
+	m.recManager.RecordContents().Range(func(key, value interface{}) bool {
+		if m.extractCreator.SupportsOffset() {
+			m.recManager.ChangeStoreType(key.(string), extract.OffsetStoreType, value, buf)
+		} else {
+			m.recManager.ChangeStoreType(key.(string), extract.DiskStoreType, value, buf)
+		}
+		return true
+	})
+	slab.Free(buf)
+
+	if err := m.saveRecords(recordsFileName); err != nil {
+		return err
+	}
+	cp := m.resumable.cp
+	cp.Phase = ExtractionPhase
+	cp.Compressed, cp.Uncompressed = m.totalCompressedSize(), m.totalUncompressedSize()
+	cp.Extraction = m.Metrics.Extraction
+	return m.saveCheckpoint()
+}
+
+// checkpointSorting persists the sorted records (on the final target).
+func (m *Manager) checkpointSorting() error {
+	if m.resumable.cp == nil {
+		return nil
+	}
+	if err := m.saveRecords(sortedFileName); err != nil {
+		return err
+	}
+	m.resumable.cp.Phase = SortingPhase
+	return m.saveCheckpoint()
+}
+
+// markShardCreated records that the shard has been created so that it is not
+// created again when the job is resumed.
+func (m *Manager) markShardCreated(shardName string) error {
+	if m.resumable.cp == nil {
+		return nil
+	}
+	m.resumable.mu.Lock()
+	defer m.resumable.mu.Unlock()
+	fpath := filepath.Join(m.resumable.mpath.Path, checkpointPath(m.ManagerUUID, createdFileName))
+	f, err := os.OpenFile(fpath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	_, err = f.WriteString(shardName + "\n")
+	if errClose := f.Close(); err == nil {
+		err = errClose
+	}
+	return err
+}
+
+// createdShards returns the names of the shards created by all the targets
+// before the job was resumed.
+func (m *Manager) createdShards() (cmn.StringSet, error) {
+	created := cmn.NewStringSet()
+	if m.resumable.resumed == "" {
+		return created, nil
+	}
+	path := cmn.JoinWords(cmn.Version, cmn.Sort, cmn.Checkpoint, m.ManagerUUID)
+	for _, resp := range broadcast(http.MethodGet, path, nil, nil, m.smap.Tmap) {
+		if resp.err != nil {
+			return nil, resp.err
+		}
+		if resp.statusCode != http.StatusOK {
+			return nil, errors.Errorf("failed to get checkpoint from %s, err: %s", resp.si, string(resp.res))
+		}
+		cp := &checkpoint{}
+		if err := js.Unmarshal(resp.res, cp); err != nil {
+			return nil, err
+		}
+		created.Add(cp.Created...)
+	}
+	return created, nil
+}
+
+// cleanupCheckpoint removes the checkpoint once the job has successfully
+// finished. When the job is aborted, the checkpoint as well as the contents of
+// the extracted records are kept so that the job can be resumed.
+//
+// PRECONDITION: `m.mu` must be locked.
+func (m *Manager) cleanupCheckpoint() {
+	if m.resumable.cp == nil {
+		return
+	}
+	if m.aborted() {
+		if m.resumable.cp.Phase != "" {
+			m.recManager.KeepExtractionPaths()
+		}
+		return
+	}
+	if err := m.resumable.mpath.Remove(checkpointPath(m.ManagerUUID, "")); err != nil {
+		glog.Error(err)
+	}
+}
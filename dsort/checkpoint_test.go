@@ -0,0 +1,104 @@
+// Package dsort provides distributed massively parallel resharding for very large datasets.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package dsort
+
+import (
+	"os"
+
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/fs"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Checkpoint", func() {
+	const (
+		testDir     = "/tmp/dsort-checkpoint-tests"
+		managerUUID = "uuid"
+	)
+
+	BeforeEach(func() {
+		err := cmn.CreateDir(testDir)
+		Expect(err).ShouldNot(HaveOccurred())
+		fs.Init()
+		fs.Add(testDir, "daeID")
+	})
+
+	AfterEach(func() {
+		err := os.RemoveAll(testDir)
+		Expect(err).ShouldNot(HaveOccurred())
+	})
+
+	Context("resumePhase", func() {
+		targets := []string{"t1", "t2"}
+
+		It("should start over when any target has not completed a phase", func() {
+			Expect(resumePhase(map[string]*checkpoint{"t1": {Phase: ExtractionPhase}}, targets)).To(BeEmpty())
+			Expect(resumePhase(map[string]*checkpoint{
+				"t1": {Phase: ExtractionPhase},
+				"t2": {},
+			}, targets)).To(BeEmpty())
+		})
+
+		It("should resume after extraction when all targets have completed it", func() {
+			Expect(resumePhase(map[string]*checkpoint{
+				"t1": {Phase: ExtractionPhase},
+				"t2": {Phase: ExtractionPhase},
+			}, targets)).To(Equal(ExtractionPhase))
+		})
+
+		It("should resume after sorting when the final target has completed it", func() {
+			Expect(resumePhase(map[string]*checkpoint{
+				"t1": {Phase: ExtractionPhase},
+				"t2": {Phase: SortingPhase},
+			}, targets)).To(Equal(SortingPhase))
+		})
+	})
+
+	Context("persistence", func() {
+		It("should return no checkpoint when there is none", func() {
+			cp, mpathInfo, err := loadCheckpoint(managerUUID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cp).To(BeNil())
+			Expect(mpathInfo).To(BeNil())
+		})
+
+		It("should save and load checkpoint with created shards", func() {
+			mpathInfo, _, err := cluster.HrwMpath(managerUUID)
+			Expect(err).NotTo(HaveOccurred())
+
+			m := &Manager{ManagerUUID: managerUUID}
+			m.resumable.mpath = mpathInfo
+			m.resumable.cp = &checkpoint{
+				Phase:        ExtractionPhase,
+				RS:           &ParsedRequestSpec{Extension: cmn.ExtTar, Resumable: true},
+				Targets:      []string{"t1", "t2"},
+				Compressed:   1024,
+				Uncompressed: 2048,
+			}
+			Expect(m.saveCheckpoint()).NotTo(HaveOccurred())
+			Expect(m.markShardCreated("shard-1.tar")).NotTo(HaveOccurred())
+			Expect(m.markShardCreated("shard-2.tar")).NotTo(HaveOccurred())
+
+			cp, loadedMpath, err := loadCheckpoint(managerUUID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(loadedMpath.Path).To(Equal(mpathInfo.Path))
+			Expect(cp.Phase).To(Equal(ExtractionPhase))
+			Expect(cp.RS.Resumable).To(BeTrue())
+			Expect(cp.Targets).To(Equal([]string{"t1", "t2"}))
+			Expect(cp.Compressed).To(Equal(int64(1024)))
+			Expect(cp.Uncompressed).To(Equal(int64(2048)))
+			Expect(cp.Created).To(Equal([]string{"shard-1.tar", "shard-2.tar"}))
+
+			m.resumable.cp.Phase = "" // nothing to clean up besides the checkpoint
+			Expect(m.saveCheckpoint()).NotTo(HaveOccurred())
+			Expect(removeCheckpoint(managerUUID)).NotTo(HaveOccurred())
+			cp, _, err = loadCheckpoint(managerUUID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cp).To(BeNil())
+		})
+	})
+})
@@ -81,8 +81,16 @@ func (m *Manager) start() (err error) {
 	}
 
 	// Phase 1.
-	if err := m.extractLocalShards(); err != nil {
-		return err
+	if m.resumable.resumed == "" {
+		if err := m.extractLocalShards(); err != nil {
+			return err
+		}
+		if err := m.checkpointExtraction(); err != nil {
+			return err
+		}
+	} else {
+		// Records have been restored from the checkpoint.
+		m.dsorter.postExtraction()
 	}
 
 	s := binary.BigEndian.Uint64(m.rs.TargetOrderSalt)
@@ -93,15 +101,33 @@ func (m *Manager) start() (err error) {
 	}
 
 	// Phase 2.
-	curTargetIsFinal, err := m.participateInRecordDistribution(targetOrder)
-	if err != nil {
-		return err
+	var curTargetIsFinal bool
+	if m.resumable.resumed == SortingPhase {
+		// Sorted records have been restored from the checkpoint of the final target.
+		m.dsorter.postRecordDistribution()
+		curTargetIsFinal = targetOrder[len(targetOrder)-1].DaemonID == m.ctx.node.DaemonID
+		if !curTargetIsFinal {
+			m.recManager.Records.Drain()
+		} else if m.resumable.cp.Phase != SortingPhase {
+			return errors.Errorf("%s %s cannot be resumed: sorted records are missing", cmn.DSortName, m.ManagerUUID)
+		}
+	} else {
+		if curTargetIsFinal, err = m.participateInRecordDistribution(targetOrder); err != nil {
+			return err
+		}
+		if curTargetIsFinal {
+			if err := m.checkpointSorting(); err != nil {
+				return err
+			}
+		}
 	}
 
 	// Phase 3. - run only by the final target
 	if curTargetIsFinal {
 		shardSize := m.rs.OutputShardSize
-		if m.extractCreator.UsingCompression() {
+		// NOTE: compression ratio of the input shards tells nothing about the
+		// output shards when these are written in a different format.
+		if m.extractCreator.UsingCompression() && m.outputExtension() == m.rs.Extension {
 			// By making the assumption that the input content is reasonably
 			// uniform across all shards, the output shard size required (such
 			// that each gzip compressed output shard will have a size close to
@@ -272,6 +298,10 @@ func (m *Manager) extractShard(name string, metrics *LocalExtraction) func() err
 func (m *Manager) extractLocalShards() (err error) {
 	phaseInfo := &m.extractionPhase
 
+	if err := m.loadFilterKeys(); err != nil {
+		return err
+	}
+
 	phaseInfo.adjuster.start()
 	defer phaseInfo.adjuster.stop()
 
@@ -304,10 +334,15 @@ ExtractAllShards:
 		return err
 	}
 
+	m.recManager.FilterRecords()
+
 	// We will no longer reserve any memory
 	m.dsorter.postExtraction()
 
 	metrics.Lock()
+	// Objects of the records dropped by the filter are counted by the extract creators.
+	metrics.FilteredRecordCnt = m.recManager.FilteredCount()
+	metrics.ExtractedRecordCnt -= m.recManager.FilteredObjCount()
 	totalExtractedCount := metrics.ExtractedRecordCnt
 	metrics.Unlock()
 	m.incrementRef(totalExtractedCount)
@@ -465,7 +500,7 @@ exit:
 	}
 	metrics.Unlock()
 
-	return nil
+	return m.markShardCreated(shardName)
 }
 
 // participateInRecordDistribution coordinates the distributed merging and
@@ -636,7 +671,7 @@ func (m *Manager) generateShardsWithTemplate(maxSize int64) ([]*extract.Shard, e
 			return nil, errors.Errorf("number of shards to be created exceeds expected number of shards (%d)", shardCount)
 		}
 		shard := &extract.Shard{
-			Name: name + m.rs.Extension,
+			Name: name + m.outputExtension(),
 		}
 
 		shard.Size = curShardSize
@@ -653,6 +688,39 @@ func (m *Manager) generateShardsWithTemplate(maxSize int64) ([]*extract.Shard, e
 	return shards, nil
 }
 
+// loadFilterKeys fetches the names of the records which pass the filter.
+func (m *Manager) loadFilterKeys() error {
+	if m.filter == nil || m.rs.Filter.KeysFileURL == "" {
+		return nil
+	}
+
+	req, err := http.NewRequest(http.MethodGet, m.rs.Filter.KeysFileURL, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := m.client.Do(req) // nolint:bodyclose // closed inside cmn.Close
+	if err != nil {
+		return err
+	}
+	defer cmn.Close(resp.Body)
+	if resp.StatusCode != http.StatusOK {
+		return errors.Errorf("failed to fetch filter keys file %q, status: %s", m.rs.Filter.KeysFileURL, resp.Status)
+	}
+
+	keys := make(cmn.StringSet)
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		if key := strings.TrimSpace(scanner.Text()); key != "" {
+			keys.Add(key)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	m.filter.Keys = keys
+	return nil
+}
+
 func (m *Manager) generateShardsWithOrderingFile(maxSize int64) ([]*extract.Shard, error) {
 	var (
 		shards         = make([]*extract.Shard, 0)
@@ -789,6 +857,29 @@ func (m *Manager) distributeShardRecords(maxSize int64) error {
 		return err
 	}
 
+	// Shards which have been created before the job was resumed are skipped.
+	// References to the objects of their records are released on the targets
+	// which own the records.
+	created, err := m.createdShards()
+	if err != nil {
+		return err
+	}
+	skippedObjs := make(map[string]int64, m.smap.CountActiveTargets())
+	if len(created) > 0 {
+		toCreate := shards[:0]
+		for _, s := range shards {
+			if !created.Contains(s.Name) {
+				toCreate = append(toCreate, s)
+				continue
+			}
+			for _, r := range s.Records.All() {
+				skippedObjs[r.DaemonID] += int64(len(r.Objects))
+			}
+		}
+		glog.Infof("skipping %d shards which have been already created", len(shards)-len(toCreate))
+		shards = toCreate
+	}
+
 	// TODO: Following heuristic doesn't seem to be working correctly in
 	// all cases. When there is not much shards at each disk (like 1-5)
 	// then it may happen that some target will have more shards than other
@@ -866,7 +957,10 @@ func (m *Manager) distributeShardRecords(maxSize int64) error {
 			})
 
 			group.Go(func() error {
-				query := cmn.AddBckToQuery(nil, cmn.Bck{Provider: m.rs.Provider, Ns: cmn.NsGlobal})
+				query := cmn.AddBckToQuery(make(url.Values), cmn.Bck{Provider: m.rs.Provider, Ns: cmn.NsGlobal})
+				if skipped := skippedObjs[si.DaemonID]; skipped > 0 {
+					query.Set(cmn.URLParamSkippedObjects, strconv.FormatInt(skipped, 10))
+				}
 				reqArgs := &cmn.ReqArgs{
 					Method: http.MethodPost,
 					Base:   si.URL(cmn.NetworkIntraData),
@@ -0,0 +1,136 @@
+// Package extract provides provides functions for working with compressed files
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package extract
+
+import (
+	"io"
+	"strings"
+)
+
+// Converting shards from one format to another: records are extracted by the
+// input format's ExtractCreator and the output shards are created by the output
+// format's ExtractCreator. Since the record metadata (if any) is specific to
+// the format, it is stripped from the record's content and replaced with the
+// metadata of the output format. The metadata is synthesized from the record
+// name, so that the names (and extensions) of the records are preserved.
+
+// interface guard
+var (
+	_ ExtractCreator = (*convertExtractCreator)(nil)
+
+	_ metadataCreator = (*tarExtractCreator)(nil)
+	_ metadataCreator = (*targzExtractCreator)(nil)
+	_ metadataCreator = (*zipExtractCreator)(nil)
+)
+
+type (
+	convertExtractCreator struct {
+		ExtractCreator // input format - used to extract the records
+		out            ExtractCreator
+	}
+
+	// metadataCreator is implemented by ExtractCreators which keep (serialized)
+	// metadata along with each record's content.
+	metadataCreator interface {
+		newMetadata(name string) []byte
+	}
+
+	// skipWriter discards first `skip` bytes and writes the rest to the
+	// underlying writer.
+	skipWriter struct {
+		w    io.Writer
+		skip int64
+	}
+
+	origRecordObj struct {
+		rec      *Record
+		obj      *RecordObj
+		metadata []byte // metadata of the output format
+	}
+)
+
+// NewConvertExtractCreator returns ExtractCreator that extracts the shards with
+// `in` and creates the shards with `out`.
+func NewConvertExtractCreator(in, out ExtractCreator) ExtractCreator {
+	return &convertExtractCreator{ExtractCreator: in, out: out}
+}
+
+// CreateShard creates a new shard in the output format based on the Shard.
+func (c *convertExtractCreator) CreateShard(s *Shard, w io.Writer, loadContent LoadContentFunc) (written int64, err error) {
+	if isTar(c.ExtractCreator) && isTar(c.out) {
+		// tar and tar.gz share the metadata - there is nothing to convert.
+		return c.out.CreateShard(s, w, loadContent)
+	}
+
+	var (
+		records = NewRecords(s.Records.Len())
+		origs   = make(map[*RecordObj]origRecordObj, s.Records.Len())
+		mc, _   = c.out.(metadataCreator)
+	)
+	for _, rec := range s.Records.All() {
+		converted := &Record{Key: rec.Key, Name: rec.Name, DaemonID: rec.DaemonID, Objects: make([]*RecordObj, 0, len(rec.Objects))}
+		for _, obj := range rec.Objects {
+			var metadata []byte
+			if mc != nil {
+				metadata = mc.newMetadata(recordName(rec, obj))
+			}
+			convertedObj := &RecordObj{
+				ContentPath:    obj.ContentPath,
+				ObjectFileType: obj.ObjectFileType,
+				StoreType:      SGLStoreType, // content is always loaded via `loadContent`
+				MetadataSize:   int64(len(metadata)),
+				Size:           obj.Size,
+				Extension:      obj.Extension,
+			}
+			converted.Objects = append(converted.Objects, convertedObj)
+			origs[convertedObj] = origRecordObj{rec: rec, obj: obj, metadata: metadata}
+		}
+		records.Insert(converted)
+	}
+
+	convertContent := func(w io.Writer, rec *Record, obj *RecordObj) (int64, error) {
+		orig := origs[obj]
+		n, err := w.Write(orig.metadata)
+		written := int64(n)
+		if err != nil {
+			return written, err
+		}
+		loaded, err := loadContent(&skipWriter{w: w, skip: orig.obj.MetadataSize}, orig.rec, orig.obj)
+		if loaded -= orig.obj.MetadataSize; loaded > 0 {
+			written += loaded
+		}
+		return written, err
+	}
+	return c.out.CreateShard(&Shard{Size: s.Size, Records: records, Name: s.Name}, w, convertContent)
+}
+
+func (w *skipWriter) Write(p []byte) (int, error) {
+	if w.skip >= int64(len(p)) {
+		w.skip -= int64(len(p))
+		return len(p), nil
+	}
+	skipped := int(w.skip)
+	w.skip = 0
+	n, err := w.w.Write(p[skipped:])
+	return skipped + n, err
+}
+
+// recordName returns the name of the record object without the shard name.
+func recordName(rec *Record, obj *RecordObj) string {
+	name := rec.Name
+	if idx := strings.IndexByte(name, '|'); idx >= 0 {
+		name = name[idx+1:]
+	}
+	return name + obj.Extension
+}
+
+func isTar(ec ExtractCreator) bool {
+	switch ec.(type) {
+	case *tarExtractCreator, *targzExtractCreator:
+		return true
+	default:
+		return false
+	}
+}
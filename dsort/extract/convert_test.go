@@ -0,0 +1,104 @@
+// Package extract provides provides functions for working with compressed files
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package extract
+
+import (
+	"archive/tar"
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// convertShard creates shard with `ec` from records that were extracted with
+// metadata produced by `newMetadata` (if any).
+func convertShard(ec ExtractCreator, contents [][]byte, newMetadata func(name string) []byte) []byte {
+	var (
+		records  = NewRecords(len(contents))
+		metadata = make([][]byte, len(contents))
+	)
+	for i, content := range contents {
+		if newMetadata != nil {
+			metadata[i] = newMetadata(fmt.Sprintf("file-%d.txt", i))
+		}
+		records.Insert(&Record{
+			Name: fmt.Sprintf("shard-1|file-%d", i),
+			Objects: []*RecordObj{{
+				StoreType:    SGLStoreType,
+				MetadataSize: int64(len(metadata[i])),
+				Size:         int64(len(content)),
+				Extension:    ".txt",
+			}},
+		})
+	}
+	loadContent := func(w io.Writer, rec *Record, obj *RecordObj) (int64, error) {
+		var idx int
+		fmt.Sscanf(rec.Name, "shard-1|file-%d", &idx)
+		n, err := w.Write(append(metadata[idx], contents[idx]...))
+		return int64(n), err
+	}
+
+	buf := &bytes.Buffer{}
+	_, err := ec.CreateShard(&Shard{Records: records}, buf, loadContent)
+	Expect(err).NotTo(HaveOccurred())
+	return buf.Bytes()
+}
+
+var _ = Describe("Convert", func() {
+	var (
+		t        = cluster.NewTargetMock(nil)
+		_        = t.SmallMMSA() // small buffers are allocated by the sibling
+		contents = [][]byte{[]byte("first"), {}, bytes.Repeat([]byte("third"), 1000)}
+	)
+
+	It("should convert tar records to TFRecord", func() {
+		tfrecord := NewTFRecordExtractCreator(t)
+		ec := NewConvertExtractCreator(NewTarExtractCreator(t), tfrecord)
+		shard := convertShard(ec, contents, newRegularTarMetadata)
+
+		c, err := extractShard(tfrecord, "shard-1"+cmn.ExtTFRecord, shard)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(c.contents).To(Equal(contents))
+	})
+
+	It("should convert TFRecord records to tar", func() {
+		ec := NewConvertExtractCreator(NewTFRecordExtractCreator(t), NewTarExtractCreator(t))
+		shard := convertShard(ec, contents, nil)
+
+		tr := tar.NewReader(bytes.NewReader(shard))
+		for i := range contents {
+			header, err := tr.Next()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(header.Name).To(Equal(fmt.Sprintf("file-%d.txt", i)))
+			Expect(header.Typeflag).To(Equal(byte(tar.TypeReg)))
+			b, err := ioutil.ReadAll(tr)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(b).To(Equal(contents[i]))
+		}
+		_, err := tr.Next()
+		Expect(err).To(Equal(io.EOF))
+	})
+
+	It("should convert zip records to tar", func() {
+		zip := NewZipExtractCreator(t)
+		ec := NewConvertExtractCreator(zip, NewTarExtractCreator(t))
+		shard := convertShard(ec, contents, zip.(metadataCreator).newMetadata)
+
+		tr := tar.NewReader(bytes.NewReader(shard))
+		for i := range contents {
+			header, err := tr.Next()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(header.Name).To(Equal(fmt.Sprintf("file-%d.txt", i)))
+			b, err := ioutil.ReadAll(tr)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(b).To(Equal(contents[i]))
+		}
+	})
+})
@@ -0,0 +1,79 @@
+// Package extract provides provides functions for working with compressed files
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package extract
+
+import (
+	"math"
+	"regexp"
+	"strings"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/OneOfOne/xxhash"
+)
+
+// RecordFilter determines which records are extracted from the input shards.
+// All objects of a record (ie. all extensions) are either kept or dropped
+// together. The conditions that depend only on the name of the record are
+// evaluated upon extraction of each object (see `MatchName`), so that dropped
+// records are neither stored nor sent to other targets. The conditions on the
+// record as a whole (its extensions and total size) are evaluated once all
+// shards are extracted (see `MatchRecord`). All (non-empty) conditions must
+// be met for the record to pass.
+type RecordFilter struct {
+	NameRegex  *regexp.Regexp // matches the name of the record (as in the shard, without extension)
+	Extensions cmn.StringSet  // the record must have an object with one of the extensions
+	MinSize    int64          // minimum (total) size of the record
+	MaxSize    int64          // maximum (total) size of the record, 0 - unlimited
+	Keys       cmn.StringSet  // names of the records (without extension) to keep
+
+	// Sampling is deterministic: whether the record is kept depends only on
+	// its (unique) name and the seed.
+	SampleRatio      float64 // fraction of the records to keep, 0 - no sampling
+	SampleSeed       uint64
+	SampleComplement bool // keep exactly the records that sampling would drop
+}
+
+// MatchName returns true if the record with a given name should be extracted.
+func (f *RecordFilter) MatchName(uniqueName, recordName string) bool {
+	name := strings.TrimSuffix(recordName, Ext(recordName))
+	if f.NameRegex != nil && !f.NameRegex.MatchString(name) {
+		return false
+	}
+	if f.Keys != nil && !f.Keys.Contains(name) {
+		return false
+	}
+	if f.SampleRatio > 0 {
+		sampled := true
+		if f.SampleRatio < 1 {
+			h := xxhash.ChecksumString64S(uniqueName, f.SampleSeed)
+			sampled = float64(h)/math.MaxUint64 < f.SampleRatio
+		}
+		return sampled != f.SampleComplement
+	}
+	return true
+}
+
+// HasRecordConds returns true if the filter has conditions on the record as a whole.
+func (f *RecordFilter) HasRecordConds() bool {
+	return len(f.Extensions) > 0 || f.MinSize > 0 || f.MaxSize > 0
+}
+
+// MatchRecord returns true if the (extracted) record should be kept.
+func (f *RecordFilter) MatchRecord(record *Record) bool {
+	if len(f.Extensions) > 0 {
+		var found bool
+		for _, obj := range record.Objects {
+			if f.Extensions.Contains(obj.Extension) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	size := record.TotalSize()
+	return size >= f.MinSize && (f.MaxSize == 0 || size <= f.MaxSize)
+}
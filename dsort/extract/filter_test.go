@@ -0,0 +1,108 @@
+// Package extract provides provides functions for working with compressed files
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package extract
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"regexp"
+
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("RecordFilter", func() {
+	It("should filter records by name and keys", func() {
+		filter := &RecordFilter{
+			NameRegex: regexp.MustCompile("^train/"),
+			Keys:      cmn.NewStringSet("train/img-1", "train/img-2"),
+		}
+		Expect(filter.MatchName("shard|train/img-1", "train/img-1.jpg")).To(BeTrue())
+		Expect(filter.MatchName("shard|train/img-2", "train/img-2.cls")).To(BeTrue())
+		Expect(filter.MatchName("shard|val/img-1", "val/img-1.jpg")).To(BeFalse())
+		Expect(filter.MatchName("shard|train/img-3", "train/img-3.jpg")).To(BeFalse())
+		Expect(filter.HasRecordConds()).To(BeFalse())
+	})
+
+	It("should filter records by extensions and total size", func() {
+		filter := &RecordFilter{
+			Extensions: cmn.NewStringSet(".jpg"),
+			MinSize:    10,
+			MaxSize:    100,
+		}
+		record := func(sizes map[string]int64) *Record {
+			r := &Record{Name: "shard|img"}
+			for ext, size := range sizes {
+				r.Objects = append(r.Objects, &RecordObj{Extension: ext, Size: size})
+			}
+			return r
+		}
+		Expect(filter.HasRecordConds()).To(BeTrue())
+		Expect(filter.MatchRecord(record(map[string]int64{".jpg": 50, ".cls": 5}))).To(BeTrue())
+		Expect(filter.MatchRecord(record(map[string]int64{".jpg": 5, ".cls": 5}))).To(BeTrue())
+		Expect(filter.MatchRecord(record(map[string]int64{".txt": 50, ".cls": 5}))).To(BeFalse())
+		Expect(filter.MatchRecord(record(map[string]int64{".jpg": 3, ".cls": 5}))).To(BeFalse())
+		Expect(filter.MatchRecord(record(map[string]int64{".jpg": 60, ".cls": 60}))).To(BeFalse())
+	})
+
+	It("should sample records deterministically", func() {
+		var (
+			sample     = &RecordFilter{SampleRatio: 0.3, SampleSeed: 42}
+			complement = &RecordFilter{SampleRatio: 0.3, SampleSeed: 42, SampleComplement: true}
+			other      = &RecordFilter{SampleRatio: 0.3, SampleSeed: 43}
+			all        = &RecordFilter{SampleRatio: 1}
+			none       = &RecordFilter{SampleRatio: 1, SampleComplement: true}
+			sampled    int
+			differ     bool
+		)
+		for i := 0; i < 10000; i++ {
+			name := fmt.Sprintf("shard-%d|record-%d", i%10, i)
+			match := sample.MatchName(name, "record.jpg")
+			Expect(sample.MatchName(name, "record.cls")).To(Equal(match))
+			Expect(complement.MatchName(name, "record.jpg")).To(Equal(!match))
+			Expect(all.MatchName(name, "record.jpg")).To(BeTrue())
+			Expect(none.MatchName(name, "record.jpg")).To(BeFalse())
+			if match {
+				sampled++
+			}
+			differ = differ || other.MatchName(name, "record.jpg") != match
+		}
+		Expect(sampled).To(BeNumerically("~", 3000, 300))
+		Expect(differ).To(BeTrue())
+	})
+
+	It("should drop filtered out records upon extraction", func() {
+		var (
+			t     = cluster.NewTargetMock(nil)
+			_     = t.SmallMMSA() // small buffers are allocated by the sibling
+			ec    = NewTFRecordExtractCreator(t)
+			ke, _ = NewNameKeyExtractor()
+		)
+		contents := [][]byte{[]byte("first"), bytes.Repeat([]byte("second"), 10), []byte("third")}
+		shard := createShard(ec, contents)
+
+		rm := NewRecordManager(t, "target", "bucket", cmn.ProviderAIS, cmn.ExtTFRecord, ec, ke, &RecordFilter{MaxSize: 10}, nil)
+		lom := &cluster.LOM{ObjName: "shard" + cmn.ExtTFRecord}
+		_, count, err := ec.ExtractShard(lom, io.NewSectionReader(bytes.NewReader(shard), 0, int64(len(shard))), rm, false)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(count).To(Equal(3))
+		Expect(rm.Records.Len()).To(Equal(3))
+
+		rm.FilterRecords()
+		Expect(rm.FilteredCount()).To(Equal(int64(1)))
+		Expect(rm.FilteredObjCount()).To(Equal(int64(1)))
+		Expect(rm.Records.Len()).To(Equal(2))
+		stored := 0
+		rm.RecordContents().Range(func(_, _ interface{}) bool {
+			stored++
+			return true
+		})
+		Expect(stored).To(Equal(2))
+		rm.Cleanup()
+	})
+})
@@ -51,6 +51,11 @@ type (
 		ty  string // type of key extracted, supported: supportedFormatTypes
 		ext string // extension of object record whose content will be read
 	}
+	featureKeyExtractor struct {
+		ty      string // type of key extracted, supported: supportedFormatTypes
+		feature string // name of the feature embedded in the record
+		parse   func(b []byte, feature string) (string, error)
+	}
 )
 
 func NewMD5KeyExtractor() (KeyExtractor, error) {
@@ -108,8 +113,48 @@ func (ke *contentKeyExtractor) ExtractKey(ske *SingleKeyExtractor) (interface{},
 		return nil, err
 	}
 
-	key := string(b)
-	switch ke.ty {
+	return parseKey(string(b), ke.ty)
+}
+
+// NewFeatureKeyExtractor returns key extractor which reads the key from the
+// feature embedded in the record: `tf.train.Example` feature in case of TFRecord
+// and image record header field in case of RecordIO.
+func NewFeatureKeyExtractor(ty, feature, ext string) (KeyExtractor, error) {
+	if err := ValidateAlgorithmFormatType(ty); err != nil {
+		return nil, err
+	}
+	if err := ValidateFeature(feature, ext); err != nil {
+		return nil, err
+	}
+
+	ke := &featureKeyExtractor{ty: ty, feature: feature}
+	switch ext {
+	case cmn.ExtTFRecord:
+		ke.parse = tfExampleFeature
+	case cmn.ExtRecordIO:
+		ke.parse = recordIOHeaderField
+	}
+	return ke, nil
+}
+
+func (ke *featureKeyExtractor) PrepareExtractor(name string, r cmn.ReadSizer, ext string) (cmn.ReadSizer, *SingleKeyExtractor, bool) {
+	buf := &bytes.Buffer{}
+	tee := cmn.NewSizedReader(io.TeeReader(r, buf), r.Size())
+	return tee, &SingleKeyExtractor{name: name, buf: buf}, true
+}
+
+func (ke *featureKeyExtractor) ExtractKey(ske *SingleKeyExtractor) (interface{}, error) {
+	b := ske.buf.Bytes()
+	ske.buf = nil
+	key, err := ke.parse(b, ke.feature)
+	if err != nil {
+		return nil, errors.Errorf("record %q: %v", ske.name, err)
+	}
+	return parseKey(key, ke.ty)
+}
+
+func parseKey(key, ty string) (interface{}, error) {
+	switch ty {
 	case FormatTypeInt:
 		return strconv.ParseInt(key, 10, 64)
 	case FormatTypeFloat:
@@ -117,7 +162,7 @@ func (ke *contentKeyExtractor) ExtractKey(ske *SingleKeyExtractor) (interface{},
 	case FormatTypeString:
 		return key, nil
 	default:
-		return nil, errors.Errorf("not implemented extractor type: %s", ke.ty)
+		return nil, errors.Errorf("not implemented extractor type: %s", ty)
 	}
 }
 
@@ -128,3 +173,22 @@ func ValidateAlgorithmFormatType(ty string) error {
 
 	return nil
 }
+
+// ValidateFeature checks if the feature can be used as a key for shards with
+// the given extension.
+func ValidateFeature(feature, ext string) error {
+	if feature == "" {
+		return errors.New("feature name must be provided")
+	}
+	switch ext {
+	case cmn.ExtTFRecord:
+		return nil
+	case cmn.ExtRecordIO:
+		if !cmn.StringInSlice(feature, recordIOFeatures) {
+			return errors.Errorf("invalid RecordIO feature %q, should be one of: %v", feature, recordIOFeatures)
+		}
+		return nil
+	default:
+		return errors.Errorf("features are supported only for %q and %q shards", cmn.ExtTFRecord, cmn.ExtRecordIO)
+	}
+}
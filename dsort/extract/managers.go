@@ -13,6 +13,7 @@ import (
 	"strings"
 	"sync"
 
+	"github.com/NVIDIA/aistore/3rdparty/atomic"
 	"github.com/NVIDIA/aistore/3rdparty/glog"
 	"github.com/NVIDIA/aistore/cluster"
 	"github.com/NVIDIA/aistore/cmn"
@@ -74,6 +75,10 @@ type (
 
 		extractCreator  ExtractCreator
 		keyExtractor    KeyExtractor
+		filter          *RecordFilter // nil - no filtering
+		filtered        atomic.Int64  // number of records dropped by the filter
+		filteredObjs    atomic.Int64  // number of objects of the dropped records
+		filteredNames   *sync.Map     // names of the records dropped upon extraction
 		contents        *sync.Map
 		extractionPaths *sync.Map // Keys correspond to all paths to record contents on disk.
 
@@ -85,7 +90,7 @@ type (
 )
 
 func NewRecordManager(t cluster.Target, daemonID, bucket, provider, extension string, extractCreator ExtractCreator,
-	keyExtractor KeyExtractor, onDuplicatedRecords func(string) error) *RecordManager {
+	keyExtractor KeyExtractor, filter *RecordFilter, onDuplicatedRecords func(string) error) *RecordManager {
 	return &RecordManager{
 		Records: NewRecords(1000),
 
@@ -98,6 +103,8 @@ func NewRecordManager(t cluster.Target, daemonID, bucket, provider, extension st
 
 		extractCreator:  extractCreator,
 		keyExtractor:    keyExtractor,
+		filter:          filter,
+		filteredNames:   &sync.Map{},
 		contents:        &sync.Map{},
 		extractionPaths: &sync.Map{},
 	}
@@ -114,6 +121,23 @@ func (rm *RecordManager) ExtractRecordWithBuffer(args extractRecordArgs) (size i
 		recordUniqueName = rm.genRecordUniqueName(args.shardName, args.recordName)
 	)
 
+	if rm.filter != nil && !rm.filter.MatchName(recordUniqueName, args.recordName) {
+		// The content still needs to be read - extract creators may verify the
+		// checksum of the record or copy the record to the writer.
+		dst := ioutil.Discard
+		if args.w != nil {
+			dst = args.w
+		}
+		if _, err := io.CopyBuffer(dst, args.r, args.buf); err != nil {
+			return 0, errors.WithStack(err)
+		}
+		if _, loaded := rm.filteredNames.LoadOrStore(recordUniqueName, struct{}{}); !loaded {
+			rm.filtered.Inc()
+		}
+		rm.filteredObjs.Inc()
+		return 0, nil
+	}
+
 	// If the content already exists we should skip it but set error (caller
 	// needs to handle it properly).
 	if rm.Records.Exists(recordUniqueName, ext) {
@@ -213,6 +237,49 @@ func (rm *RecordManager) ExtractRecordWithBuffer(args extractRecordArgs) (size i
 	return size, nil
 }
 
+// FilterRecords drops the extracted records that do not pass the filter as
+// a whole (see `RecordFilter.MatchRecord`) and frees their contents.
+// NOTE: must be called once all shards are extracted.
+func (rm *RecordManager) FilterRecords() {
+	rm.filteredNames = &sync.Map{}
+	if rm.filter == nil || !rm.filter.HasRecordConds() {
+		return
+	}
+	for _, record := range rm.Records.Filter(rm.filter.MatchRecord) {
+		for _, obj := range record.Objects {
+			rm.freeContent(obj)
+		}
+		rm.filtered.Inc()
+		rm.filteredObjs.Add(int64(len(record.Objects)))
+	}
+}
+
+func (rm *RecordManager) freeContent(obj *RecordObj) {
+	fullContentPath := rm.FullContentPath(obj)
+	switch obj.StoreType {
+	case SGLStoreType:
+		if v, ok := rm.contents.Load(fullContentPath); ok {
+			v.(*memsys.SGL).Free()
+			rm.contents.Delete(fullContentPath)
+		}
+	case DiskStoreType:
+		if err := cmn.RemoveFile(fullContentPath); err != nil {
+			glog.Error(err)
+		}
+		rm.extractionPaths.Delete(fullContentPath)
+	}
+}
+
+// FilteredCount returns the number of records dropped by the filter.
+func (rm *RecordManager) FilteredCount() int64 {
+	return rm.filtered.Load()
+}
+
+// FilteredObjCount returns the number of objects of the records dropped by the filter.
+func (rm *RecordManager) FilteredObjCount() int64 {
+	return rm.filteredObjs.Load()
+}
+
 func (rm *RecordManager) EnqueueRecords(records *Records) {
 	rm.enqueued.mu.Lock()
 	rm.enqueued.records = append(rm.enqueued.records, records)
@@ -334,7 +401,8 @@ func (rm *RecordManager) ChangeStoreType(fullContentPath, newStoreType string, v
 		obj.ContentPath = shardName
 		obj.MetadataSize = rm.extractCreator.MetadataSize()
 	case DiskStoreType:
-		diskPath := rm.FullContentPath(obj)
+		// In SGLs and on the disk `contentPath` is the same.
+		diskPath := rm.FullContentPath(&RecordObj{ContentPath: obj.ContentPath, StoreType: DiskStoreType})
 		// No matter what the outcome we should store `path` in
 		// `extractionPaths` to make sure that all files, even incomplete ones,
 		// are deleted (if the file will not exist this is not much of a
@@ -364,6 +432,32 @@ func (rm *RecordManager) ExtractionPaths() *sync.Map {
 	return rm.extractionPaths
 }
 
+// RestoreRecords replaces the records with the ones that were persisted before
+// (and contents of which were stored on the disk) so that they are removed
+// in the cleanup as the extracted ones.
+func (rm *RecordManager) RestoreRecords(records *Records) {
+	for _, r := range records.All() {
+		if r.DaemonID != rm.daemonID {
+			continue
+		}
+		for _, obj := range r.Objects {
+			if obj.StoreType == DiskStoreType {
+				rm.extractionPaths.Store(rm.FullContentPath(obj), struct{}{})
+			}
+		}
+	}
+	rm.Records = records
+}
+
+// KeepExtractionPaths makes the cleanup leave the records contents stored on
+// the disk, so that they can be reused when the job is resumed.
+func (rm *RecordManager) KeepExtractionPaths() {
+	rm.extractionPaths.Range(func(k, v interface{}) bool {
+		rm.extractionPaths.Delete(k)
+		return true
+	})
+}
+
 func (rm *RecordManager) Cleanup() {
 	rm.Records.Drain()
 	rm.extractionPaths.Range(func(k, v interface{}) bool {
@@ -188,6 +188,27 @@ func (r *Records) Exists(name, ext string) (exists bool) {
 	return
 }
 
+// Filter removes the records for which `keep` returns false and returns them.
+func (r *Records) Filter(keep func(*Record) bool) (removed []*Record) {
+	r.Lock()
+	kept := r.arr[:0]
+	for _, record := range r.arr {
+		if keep(record) {
+			kept = append(kept, record)
+			continue
+		}
+		removed = append(removed, record)
+		delete(r.m, record.Name)
+		r.totalObjectCount -= len(record.Objects)
+	}
+	for i := len(kept); i < len(r.arr); i++ {
+		r.arr[i] = nil
+	}
+	r.arr = kept
+	r.Unlock()
+	return
+}
+
 func (r *Records) merge(records *Records) {
 	r.Insert(records.arr...)
 }
@@ -0,0 +1,262 @@
+// Package extract provides provides functions for working with compressed files
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package extract
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/debug"
+	"github.com/NVIDIA/aistore/fs"
+)
+
+// MXNet RecordIO file is a sequence of records, each framed as follows:
+//
+//   uint32 magic
+//   uint32 lrecord - upper 3 bits: continuation flag, lower 29 bits: length
+//   byte   data[length]
+//   padding to 4 bytes
+//
+// (all integers are little-endian). Record that contains the magic (at 4 bytes
+// aligned position) is split into parts: the magic itself is omitted, and the
+// parts are flagged as first, middle, and last, respectively. Same as TFRecord,
+// a record is named after the shard and its index in the shard.
+//
+// Records produced by MXNet `im2rec` start with the image record header
+// (`IRHeader`) which provides `flag`, `label`, `id`, and `id2` features.
+
+const (
+	recordIOMagic      = 0xced7230a
+	recordIOHeaderSize = 8
+	recordIOLenBits    = 29
+	recordIOLenMask    = 1<<recordIOLenBits - 1
+
+	// continuation flags
+	recordIOFull   = 0
+	recordIOFirst  = 1
+	recordIOMiddle = 2
+	recordIOLast   = 3
+
+	// IRHeader: uint32 flag, float32 label, uint64 id, uint64 id2
+	irHeaderSize = 24
+)
+
+var (
+	recordIOMagicBytes = []byte{0x0a, 0x23, 0xd7, 0xce}
+	recordIOPadding    [4]byte
+
+	// IRHeader fields that can be used as (sorting) keys
+	recordIOFeatures = []string{"flag", "label", "id", "id2"}
+
+	// interface guard
+	_ ExtractCreator = (*recordIOExtractCreator)(nil)
+)
+
+type recordIOExtractCreator struct {
+	t cluster.Target
+}
+
+func NewRecordIOExtractCreator(t cluster.Target) ExtractCreator {
+	return &recordIOExtractCreator{t: t}
+}
+
+// ExtractShard reads the RecordIO file and extracts its records.
+func (rc *recordIOExtractCreator) ExtractShard(lom *cluster.LOM, r *io.SectionReader, extractor RecordExtractor,
+	toDisk bool) (extractedSize int64, extractedCount int, err error) {
+	var (
+		size   int64
+		offset int64
+		header [recordIOHeaderSize]byte
+	)
+
+	buf, slab := rc.t.MMSA().Alloc(r.Size())
+	defer slab.Free(buf)
+
+	extractMethod := ExtractToMem
+	if toDisk {
+		extractMethod = ExtractToDisk
+	}
+
+	for offset < r.Size() {
+		var (
+			parts      []io.Reader
+			recordSize int64
+			next       = offset
+		)
+		for {
+			if err = readFullAt(r, header[:], next); err != nil {
+				return extractedSize, extractedCount, fmt.Errorf("%s: record %d: %v", lom.ObjName, extractedCount, err)
+			}
+			if binary.LittleEndian.Uint32(header[:4]) != recordIOMagic {
+				return extractedSize, extractedCount, fmt.Errorf("%s: record %d: invalid magic", lom.ObjName, extractedCount)
+			}
+			var (
+				lrecord    = binary.LittleEndian.Uint32(header[4:])
+				cflag      = lrecord >> recordIOLenBits
+				length     = int64(lrecord & recordIOLenMask)
+				dataOffset = next + recordIOHeaderSize
+			)
+			if dataOffset+length > r.Size() {
+				return extractedSize, extractedCount, fmt.Errorf("%s: record %d: %v", lom.ObjName, extractedCount, io.ErrUnexpectedEOF)
+			}
+			if len(parts) == 0 && cflag != recordIOFull && cflag != recordIOFirst ||
+				len(parts) > 0 && cflag != recordIOMiddle && cflag != recordIOLast {
+				return extractedSize, extractedCount, fmt.Errorf("%s: record %d: unexpected continuation flag %d",
+					lom.ObjName, extractedCount, cflag)
+			}
+			if len(parts) > 0 {
+				parts = append(parts, bytes.NewReader(recordIOMagicBytes))
+				recordSize += int64(len(recordIOMagicBytes))
+			}
+			parts = append(parts, io.NewSectionReader(r, dataOffset, length))
+			recordSize += length
+			next = dataOffset + align4(length)
+			if cflag == recordIOFull || cflag == recordIOLast {
+				break
+			}
+		}
+
+		data := parts[0]
+		if len(parts) > 1 {
+			data = io.MultiReader(parts...)
+		}
+		args := extractRecordArgs{
+			shardName:     lom.ObjName,
+			fileType:      fs.ObjectType,
+			recordName:    indexedRecordName(lom.ObjName, cmn.ExtRecordIO, extractedCount),
+			r:             cmn.NewSizedReader(data, recordSize),
+			extractMethod: extractMethod,
+			offset:        offset + recordIOHeaderSize,
+			buf:           buf,
+		}
+		if size, err = extractor.ExtractRecordWithBuffer(args); err != nil {
+			return extractedSize, extractedCount, err
+		}
+
+		offset = next
+		extractedSize += size
+		extractedCount++
+	}
+	return extractedSize, extractedCount, nil
+}
+
+// CreateShard creates a new RecordIO file based on the Shard. Each object of
+// each record makes a separate RecordIO record.
+func (rc *recordIOExtractCreator) CreateShard(s *Shard, w io.Writer, loadContent LoadContentFunc) (written int64, err error) {
+	var (
+		n    int64
+		data bytes.Buffer // the record is split at the magic, so it must be read in full first
+	)
+
+	for _, rec := range s.Records.All() {
+		for _, obj := range rec.Objects {
+			debug.Assert(obj.MetadataSize == 0)
+			data.Reset()
+			if _, err = loadContent(&data, rec, obj); err != nil {
+				return written, err
+			}
+			n, err = writeRecordIO(w, data.Bytes())
+			written += n
+			if err != nil {
+				return written, err
+			}
+		}
+	}
+	return written, nil
+}
+
+func (rc *recordIOExtractCreator) UsingCompression() bool {
+	return false
+}
+
+func (rc *recordIOExtractCreator) SupportsOffset() bool {
+	return false
+}
+
+func (rc *recordIOExtractCreator) MetadataSize() int64 {
+	return 0 // framing is computed when creating the shard
+}
+
+// writeRecordIO writes a single record - the same way `dmlc::RecordIOWriter` does.
+func writeRecordIO(w io.Writer, b []byte) (written int64, err error) {
+	if len(b) > recordIOLenMask {
+		return 0, fmt.Errorf("record too large (%d bytes)", len(b))
+	}
+	var (
+		header [recordIOHeaderSize]byte
+		dptr   int
+	)
+	writePart := func(cflag uint32, part []byte) error {
+		binary.LittleEndian.PutUint32(header[:4], recordIOMagic)
+		binary.LittleEndian.PutUint32(header[4:], cflag<<recordIOLenBits|uint32(len(part)))
+		if _, err := w.Write(header[:]); err != nil {
+			return err
+		}
+		written += recordIOHeaderSize
+		n, err := w.Write(part)
+		written += int64(n)
+		return err
+	}
+	for i := 0; i < len(b)&^3; i += 4 {
+		if binary.LittleEndian.Uint32(b[i:]) != recordIOMagic {
+			continue
+		}
+		cflag := uint32(recordIOMiddle)
+		if dptr == 0 {
+			cflag = recordIOFirst
+		}
+		if err = writePart(cflag, b[dptr:i]); err != nil {
+			return
+		}
+		dptr = i + 4
+	}
+	cflag := uint32(recordIOFull)
+	if dptr != 0 {
+		cflag = recordIOLast
+	}
+	if err = writePart(cflag, b[dptr:]); err != nil {
+		return
+	}
+	pad := align4(int64(len(b))) - int64(len(b))
+	n, err := w.Write(recordIOPadding[:pad])
+	written += int64(n)
+	return
+}
+
+func align4(n int64) int64 {
+	return (n + 3) &^ 3
+}
+
+// recordIOHeaderField returns the named field of the image record header.
+func recordIOHeaderField(b []byte, name string) (string, error) {
+	if len(b) < irHeaderSize {
+		return "", fmt.Errorf("record too short (%d bytes) to contain image record header", len(b))
+	}
+	flag := binary.LittleEndian.Uint32(b)
+	switch name {
+	case "flag":
+		return strconv.FormatUint(uint64(flag), 10), nil
+	case "label":
+		// When flag > 0, the header is followed by `flag` float32 labels.
+		if flag > 0 {
+			if len(b) < irHeaderSize+4 {
+				return "", fmt.Errorf("record too short (%d bytes) to contain label", len(b))
+			}
+			return formatFloat32(binary.LittleEndian.Uint32(b[irHeaderSize:])), nil
+		}
+		return formatFloat32(binary.LittleEndian.Uint32(b[4:])), nil
+	case "id":
+		return strconv.FormatUint(binary.LittleEndian.Uint64(b[8:]), 10), nil
+	case "id2":
+		return strconv.FormatUint(binary.LittleEndian.Uint64(b[16:]), 10), nil
+	default:
+		return "", fmt.Errorf("invalid image record header field %q, should be one of: %v", name, recordIOFeatures)
+	}
+}
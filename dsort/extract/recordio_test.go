@@ -0,0 +1,100 @@
+// Package extract provides provides functions for working with compressed files
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package extract
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("RecordIO", func() {
+	var (
+		t  = cluster.NewTargetMock(nil)
+		_  = t.SmallMMSA() // small buffers are allocated by the sibling
+		ec = NewRecordIOExtractCreator(t)
+	)
+
+	It("should create and extract shard", func() {
+		contents := [][]byte{
+			[]byte("first"),
+			{},
+			append([]byte("0123"), recordIOMagicBytes...), // split into two parts
+			bytes.Join([][]byte{recordIOMagicBytes, []byte("ab"), recordIOMagicBytes, recordIOMagicBytes, []byte("xyz")}, nil),
+			append([]byte("012"), recordIOMagicBytes...), // not aligned - not split
+		}
+		shard := createShard(ec, contents)
+		Expect(len(shard) % 4).To(BeZero())
+
+		c, err := extractShard(ec, "shard-1"+cmn.ExtRecordIO, shard)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(c.contents).To(HaveLen(len(contents)))
+		for i := range contents {
+			Expect(c.contents[i]).To(Equal(contents[i]))
+		}
+		Expect(c.names[4]).To(Equal("shard-1/0000000004.rec"))
+	})
+
+	It("should split record the same way as MXNet", func() {
+		buf := &bytes.Buffer{}
+		_, err := writeRecordIO(buf, append([]byte("0123"), recordIOMagicBytes...))
+		Expect(err).NotTo(HaveOccurred())
+
+		b := buf.Bytes()
+		Expect(b).To(HaveLen(2*recordIOHeaderSize + 4))
+		Expect(binary.LittleEndian.Uint32(b[4:])).To(Equal(uint32(recordIOFirst<<recordIOLenBits | 4)))
+		Expect(b[8:12]).To(Equal([]byte("0123")))
+		Expect(binary.LittleEndian.Uint32(b[16:])).To(Equal(uint32(recordIOLast << recordIOLenBits)))
+	})
+
+	It("should detect invalid magic", func() {
+		shard := createShard(ec, [][]byte{[]byte("first"), []byte("second")})
+		shard[16] ^= 0xff
+
+		_, err := extractShard(ec, "shard"+cmn.ExtRecordIO, shard)
+		Expect(err).To(MatchError(ContainSubstring("record 1: invalid magic")))
+	})
+
+	It("should extract key from image record header", func() {
+		header := make([]byte, irHeaderSize, irHeaderSize+8)
+		binary.LittleEndian.PutUint32(header[4:], math.Float32bits(3))
+		binary.LittleEndian.PutUint64(header[8:], 42)
+		record := append(header, []byte("image")...)
+
+		for _, test := range []struct {
+			feature string
+			ty      string
+			key     interface{}
+		}{
+			{feature: "label", ty: FormatTypeFloat, key: float64(3)},
+			{feature: "id", ty: FormatTypeInt, key: int64(42)},
+			{feature: "flag", ty: FormatTypeString, key: "0"},
+		} {
+			value, err := recordIOHeaderField(record, test.feature)
+			Expect(err).NotTo(HaveOccurred())
+			key, err := parseKey(value, test.ty)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(key).To(Equal(test.key))
+		}
+
+		// multiple labels follow the header
+		binary.LittleEndian.PutUint32(header, 2)
+		labels := make([]byte, 8)
+		binary.LittleEndian.PutUint32(labels, math.Float32bits(5))
+		value, err := recordIOHeaderField(append(header, labels...), "label")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(value).To(Equal("5"))
+
+		_, err = NewFeatureKeyExtractor(FormatTypeInt, "class", cmn.ExtRecordIO)
+		Expect(err).To(HaveOccurred())
+		_, err = recordIOHeaderField([]byte("short"), "id")
+		Expect(err).To(HaveOccurred())
+	})
+})
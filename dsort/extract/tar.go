@@ -284,6 +284,15 @@ func (t *tarExtractCreator) MetadataSize() int64 {
 	return tarBlockSize // size of tar header with padding
 }
 
+func (t *tarExtractCreator) newMetadata(name string) []byte {
+	return newRegularTarMetadata(name)
+}
+
+// newRegularTarMetadata returns the metadata of a regular file with given name.
+func newRegularTarMetadata(name string) []byte {
+	return cmn.MustMarshal(tarFileHeader{Typeflag: tar.TypeReg, Name: name, Mode: 0o644})
+}
+
 // Calculates padded value to 512 bytes
 func paddedSize(offset int64) int64 {
 	return offset + (-offset & (tarBlockSize - 1))
@@ -190,3 +190,7 @@ func (t *targzExtractCreator) SupportsOffset() bool {
 func (t *targzExtractCreator) MetadataSize() int64 {
 	return tarBlockSize // size of tar header with padding
 }
+
+func (t *targzExtractCreator) newMetadata(name string) []byte {
+	return newRegularTarMetadata(name)
+}
@@ -0,0 +1,332 @@
+// Package extract provides provides functions for working with compressed files
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package extract
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/debug"
+	"github.com/NVIDIA/aistore/fs"
+)
+
+// TFRecord file is a sequence of records, each framed as follows:
+//
+//   uint64 length
+//   uint32 masked CRC-32C of length
+//   byte   data[length]
+//   uint32 masked CRC-32C of data
+//
+// (all integers are little-endian). Records have no names - a record extracted
+// from a shard is named after the shard and its (zero-based) index in the shard,
+// e.g.: `shard-1/0000000042.tfrecord`.
+
+const (
+	tfRecordHeaderSize = 12
+	tfRecordFooterSize = 4
+	tfRecordMaskDelta  = 0xa282ead8
+
+	// protocol buffers wire types
+	pbVarint  = 0
+	pbFixed64 = 1
+	pbBytes   = 2
+	pbFixed32 = 5
+)
+
+var (
+	crc32c = crc32.MakeTable(crc32.Castagnoli)
+
+	errMalformedProto = errors.New("malformed protocol buffer message")
+
+	// interface guard
+	_ ExtractCreator = (*tfRecordExtractCreator)(nil)
+)
+
+type (
+	tfRecordExtractCreator struct {
+		t cluster.Target
+	}
+
+	// pbField is a single field of protocol buffer message.
+	pbField struct {
+		num  uint64
+		typ  uint64
+		v    uint64 // varint, fixed32 and fixed64
+		data []byte // length-delimited
+	}
+)
+
+func NewTFRecordExtractCreator(t cluster.Target) ExtractCreator {
+	return &tfRecordExtractCreator{t: t}
+}
+
+// ExtractShard reads the TFRecord file and extracts its records.
+func (t *tfRecordExtractCreator) ExtractShard(lom *cluster.LOM, r *io.SectionReader, extractor RecordExtractor,
+	toDisk bool) (extractedSize int64, extractedCount int, err error) {
+	var (
+		size   int64
+		offset int64
+		header [tfRecordHeaderSize]byte
+		footer [tfRecordFooterSize]byte
+		crc    = crc32.New(crc32c)
+	)
+
+	buf, slab := t.t.MMSA().Alloc(r.Size())
+	defer slab.Free(buf)
+
+	extractMethod := ExtractToMem
+	if toDisk {
+		extractMethod = ExtractToDisk
+	}
+
+	for offset < r.Size() {
+		if err = readFullAt(r, header[:], offset); err != nil {
+			return extractedSize, extractedCount, fmt.Errorf("%s: record %d: %v", lom.ObjName, extractedCount, err)
+		}
+		length := binary.LittleEndian.Uint64(header[:8])
+		if maskCRC(crc32.Checksum(header[:8], crc32c)) != binary.LittleEndian.Uint32(header[8:]) {
+			return extractedSize, extractedCount, fmt.Errorf("%s: record %d: corrupted length", lom.ObjName, extractedCount)
+		}
+		dataOffset := offset + tfRecordHeaderSize
+		if remaining := r.Size() - dataOffset - tfRecordFooterSize; remaining < 0 || length > uint64(remaining) {
+			return extractedSize, extractedCount, fmt.Errorf("%s: record %d: %v", lom.ObjName, extractedCount, io.ErrUnexpectedEOF)
+		}
+
+		crc.Reset()
+		data := io.NewSectionReader(r, dataOffset, int64(length))
+		args := extractRecordArgs{
+			shardName:     lom.ObjName,
+			fileType:      fs.ObjectType,
+			recordName:    indexedRecordName(lom.ObjName, cmn.ExtTFRecord, extractedCount),
+			r:             cmn.NewSizedReader(io.TeeReader(data, crc), int64(length)),
+			extractMethod: extractMethod,
+			offset:        dataOffset,
+			buf:           buf,
+		}
+		if size, err = extractor.ExtractRecordWithBuffer(args); err != nil {
+			return extractedSize, extractedCount, err
+		}
+
+		offset = dataOffset + int64(length)
+		if err = readFullAt(r, footer[:], offset); err != nil {
+			return extractedSize, extractedCount, fmt.Errorf("%s: record %d: %v", lom.ObjName, extractedCount, err)
+		}
+		if maskCRC(crc.Sum32()) != binary.LittleEndian.Uint32(footer[:]) {
+			return extractedSize, extractedCount, fmt.Errorf("%s: record %d: corrupted data", lom.ObjName, extractedCount)
+		}
+		offset += tfRecordFooterSize
+
+		extractedSize += size
+		extractedCount++
+	}
+	return extractedSize, extractedCount, nil
+}
+
+// CreateShard creates a new TFRecord file based on the Shard. Each object of
+// each record makes a separate TFRecord record.
+func (t *tfRecordExtractCreator) CreateShard(s *Shard, w io.Writer, loadContent LoadContentFunc) (written int64, err error) {
+	var (
+		n      int64
+		header [tfRecordHeaderSize]byte
+		footer [tfRecordFooterSize]byte
+		crc    = crc32.New(crc32c)
+		cw     = io.MultiWriter(w, crc)
+	)
+
+	for _, rec := range s.Records.All() {
+		for _, obj := range rec.Objects {
+			debug.Assert(obj.MetadataSize == 0)
+			binary.LittleEndian.PutUint64(header[:8], uint64(obj.Size))
+			binary.LittleEndian.PutUint32(header[8:], maskCRC(crc32.Checksum(header[:8], crc32c)))
+			if _, err = w.Write(header[:]); err != nil {
+				return written, err
+			}
+			written += tfRecordHeaderSize
+
+			crc.Reset()
+			if n, err = loadContent(cw, rec, obj); err != nil {
+				return written + n, err
+			}
+			written += n
+			if n != obj.Size {
+				return written, fmt.Errorf("record %q: expected %d bytes, got %d", rec.Name, obj.Size, n)
+			}
+
+			binary.LittleEndian.PutUint32(footer[:], maskCRC(crc.Sum32()))
+			if _, err = w.Write(footer[:]); err != nil {
+				return written, err
+			}
+			written += tfRecordFooterSize
+		}
+	}
+	return written, nil
+}
+
+func (t *tfRecordExtractCreator) UsingCompression() bool {
+	return false
+}
+
+func (t *tfRecordExtractCreator) SupportsOffset() bool {
+	return false
+}
+
+func (t *tfRecordExtractCreator) MetadataSize() int64 {
+	return 0 // length and checksums are computed when creating the shard
+}
+
+func maskCRC(crc uint32) uint32 {
+	return ((crc >> 15) | (crc << 17)) + tfRecordMaskDelta
+}
+
+func indexedRecordName(shardName, ext string, idx int) string {
+	return fmt.Sprintf("%s/%010d%s", strings.TrimSuffix(shardName, ext), idx, ext)
+}
+
+func readFullAt(r io.ReaderAt, b []byte, offset int64) error {
+	n, err := r.ReadAt(b, offset)
+	if n == len(b) {
+		return nil
+	}
+	if err == nil || err == io.EOF {
+		err = io.ErrUnexpectedEOF
+	}
+	return err
+}
+
+// tfExampleFeature returns the first value of the named feature of a serialized
+// `tf.train.Example` message:
+//
+//   message Example  { Features features = 1; }
+//   message Features { map<string, Feature> feature = 1; }
+//   message Feature  { oneof kind { BytesList bytes_list = 1; FloatList float_list = 2; Int64List int64_list = 3; } }
+func tfExampleFeature(b []byte, name string) (string, error) {
+	var (
+		feature []byte
+		found   bool
+	)
+	err := pbRange(b, func(features *pbField) error {
+		if features.num != 1 || features.typ != pbBytes {
+			return nil
+		}
+		return pbRange(features.data, func(entry *pbField) error {
+			if entry.num != 1 || entry.typ != pbBytes {
+				return nil
+			}
+			var (
+				key   string
+				value []byte
+			)
+			err := pbRange(entry.data, func(kv *pbField) error {
+				switch {
+				case kv.num == 1 && kv.typ == pbBytes:
+					key = string(kv.data)
+				case kv.num == 2 && kv.typ == pbBytes:
+					value = kv.data
+				}
+				return nil
+			})
+			if err == nil && key == name {
+				feature, found = value, true
+			}
+			return err
+		})
+	})
+	if err != nil {
+		return "", err
+	}
+	if !found {
+		return "", fmt.Errorf("feature %q not found", name)
+	}
+	return tfFeatureValue(feature, name)
+}
+
+func tfFeatureValue(feature []byte, name string) (value string, err error) {
+	var found bool
+	err = pbRange(feature, func(list *pbField) error {
+		if list.typ != pbBytes {
+			return nil
+		}
+		return pbRange(list.data, func(v *pbField) error {
+			if v.num != 1 || found {
+				return nil
+			}
+			switch {
+			case list.num == 1 && v.typ == pbBytes: // bytes_list
+				value, found = string(v.data), true
+			case list.num == 2 && v.typ == pbFixed32: // float_list
+				value, found = formatFloat32(uint32(v.v)), true
+			case list.num == 2 && v.typ == pbBytes: // float_list (packed)
+				if len(v.data) >= 4 {
+					value, found = formatFloat32(binary.LittleEndian.Uint32(v.data)), true
+				}
+			case list.num == 3 && v.typ == pbVarint: // int64_list
+				value, found = strconv.FormatInt(int64(v.v), 10), true
+			case list.num == 3 && v.typ == pbBytes: // int64_list (packed)
+				if x, n := binary.Uvarint(v.data); n > 0 {
+					value, found = strconv.FormatInt(int64(x), 10), true
+				}
+			}
+			return nil
+		})
+	})
+	if err == nil && !found {
+		err = fmt.Errorf("feature %q has no values", name)
+	}
+	return
+}
+
+func formatFloat32(bits uint32) string {
+	return strconv.FormatFloat(float64(math.Float32frombits(bits)), 'g', -1, 32)
+}
+
+// pbRange calls cb for each field of a protocol buffer message.
+func pbRange(b []byte, cb func(f *pbField) error) error {
+	var f pbField
+	for len(b) > 0 {
+		key, n := binary.Uvarint(b)
+		if n <= 0 {
+			return errMalformedProto
+		}
+		b = b[n:]
+		f.num, f.typ, f.v, f.data = key>>3, key&7, 0, nil
+		switch f.typ {
+		case pbVarint:
+			if f.v, n = binary.Uvarint(b); n <= 0 {
+				return errMalformedProto
+			}
+			b = b[n:]
+		case pbFixed64:
+			if len(b) < 8 {
+				return errMalformedProto
+			}
+			f.v, b = binary.LittleEndian.Uint64(b), b[8:]
+		case pbBytes:
+			l, n := binary.Uvarint(b)
+			if n <= 0 || l > uint64(len(b)-n) {
+				return errMalformedProto
+			}
+			f.data, b = b[n:n+int(l)], b[n+int(l):]
+		case pbFixed32:
+			if len(b) < 4 {
+				return errMalformedProto
+			}
+			f.v, b = uint64(binary.LittleEndian.Uint32(b)), b[4:]
+		default:
+			return errMalformedProto
+		}
+		if err := cb(&f); err != nil {
+			return err
+		}
+	}
+	return nil
+}
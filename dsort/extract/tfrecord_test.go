@@ -0,0 +1,162 @@
+// Package extract provides provides functions for working with compressed files
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package extract
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math"
+
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type recordsCollector struct {
+	names    []string
+	contents [][]byte
+}
+
+func (c *recordsCollector) ExtractRecordWithBuffer(args extractRecordArgs) (int64, error) {
+	b, err := ioutil.ReadAll(args.r)
+	if err != nil {
+		return 0, err
+	}
+	c.names = append(c.names, args.recordName)
+	c.contents = append(c.contents, b)
+	return int64(len(b)), nil
+}
+
+// createShard creates shard with records of given contents.
+func createShard(ec ExtractCreator, contents [][]byte) []byte {
+	records := NewRecords(len(contents))
+	for i, content := range contents {
+		records.Insert(&Record{
+			Name:    fmt.Sprintf("record-%d", i),
+			Objects: []*RecordObj{{Size: int64(len(content))}},
+		})
+	}
+	loadContent := func(w io.Writer, rec *Record, obj *RecordObj) (int64, error) {
+		var idx int
+		fmt.Sscanf(rec.Name, "record-%d", &idx)
+		n, err := w.Write(contents[idx])
+		return int64(n), err
+	}
+
+	buf := &bytes.Buffer{}
+	_, err := ec.CreateShard(&Shard{Records: records}, buf, loadContent)
+	Expect(err).NotTo(HaveOccurred())
+	return buf.Bytes()
+}
+
+func extractShard(ec ExtractCreator, shardName string, shard []byte) (*recordsCollector, error) {
+	var (
+		c   = &recordsCollector{}
+		lom = &cluster.LOM{ObjName: shardName}
+		r   = io.NewSectionReader(bytes.NewReader(shard), 0, int64(len(shard)))
+	)
+	_, count, err := ec.ExtractShard(lom, r, c, false)
+	if err == nil {
+		Expect(count).To(Equal(len(c.names)))
+	}
+	return c, err
+}
+
+func pbEncode(num uint64, data []byte) []byte {
+	b := make([]byte, 0, len(data)+2*binary.MaxVarintLen64)
+	b = append(b, encodeUvarint(num<<3|pbBytes)...)
+	b = append(b, encodeUvarint(uint64(len(data)))...)
+	return append(b, data...)
+}
+
+func encodeUvarint(x uint64) []byte {
+	b := make([]byte, binary.MaxVarintLen64)
+	return b[:binary.PutUvarint(b, x)]
+}
+
+func tfExample(features map[string][]byte) []byte {
+	var entries []byte
+	for name, feature := range features {
+		entries = append(entries, pbEncode(1, append(pbEncode(1, []byte(name)), pbEncode(2, feature)...))...)
+	}
+	return pbEncode(1, entries)
+}
+
+var _ = Describe("TFRecord", func() {
+	var (
+		t  = cluster.NewTargetMock(nil)
+		_  = t.SmallMMSA() // small buffers are allocated by the sibling
+		ec = NewTFRecordExtractCreator(t)
+	)
+
+	It("should create and extract shard", func() {
+		contents := [][]byte{[]byte("first"), {}, bytes.Repeat([]byte("third"), 1000)}
+		shard := createShard(ec, contents)
+
+		c, err := extractShard(ec, "dir/shard-1"+cmn.ExtTFRecord, shard)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(c.contents).To(Equal(contents))
+		Expect(c.names).To(Equal([]string{
+			"dir/shard-1/0000000000.tfrecord",
+			"dir/shard-1/0000000001.tfrecord",
+			"dir/shard-1/0000000002.tfrecord",
+		}))
+	})
+
+	It("should detect corrupted data", func() {
+		shard := createShard(ec, [][]byte{[]byte("first"), []byte("second")})
+		shard[len(shard)-tfRecordFooterSize-1] ^= 0xff
+
+		_, err := extractShard(ec, "shard"+cmn.ExtTFRecord, shard)
+		Expect(err).To(MatchError(ContainSubstring("record 1: corrupted data")))
+	})
+
+	It("should detect truncated shard", func() {
+		shard := createShard(ec, [][]byte{[]byte("first")})
+
+		_, err := extractShard(ec, "shard"+cmn.ExtTFRecord, shard[:len(shard)-1])
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should extract key from tf.train.Example feature", func() {
+		var floats [8]byte
+		binary.LittleEndian.PutUint32(floats[:], math.Float32bits(0.5))
+		binary.LittleEndian.PutUint32(floats[4:], math.Float32bits(1.5))
+		example := tfExample(map[string][]byte{
+			"label": pbEncode(3, pbEncode(1, append(encodeUvarint(7), encodeUvarint(8)...))),
+			"name":  pbEncode(1, append(pbEncode(1, []byte("cat")), pbEncode(1, []byte("dog"))...)),
+			"score": pbEncode(2, pbEncode(1, floats[:])),
+		})
+
+		for _, test := range []struct {
+			feature string
+			ty      string
+			key     interface{}
+		}{
+			{feature: "label", ty: FormatTypeInt, key: int64(7)},
+			{feature: "name", ty: FormatTypeString, key: "cat"},
+			{feature: "score", ty: FormatTypeFloat, key: 0.5},
+		} {
+			ke, err := NewFeatureKeyExtractor(test.ty, test.feature, cmn.ExtTFRecord)
+			Expect(err).NotTo(HaveOccurred())
+			r, ske, needRead := ke.PrepareExtractor("record", cmn.NewSizedReader(bytes.NewReader(example), int64(len(example))), cmn.ExtTFRecord)
+			Expect(needRead).To(BeTrue())
+			_, err = ioutil.ReadAll(r)
+			Expect(err).NotTo(HaveOccurred())
+			key, err := ke.ExtractKey(ske)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(key).To(Equal(test.key))
+		}
+
+		_, err := tfExampleFeature(example, "missing")
+		Expect(err).To(HaveOccurred())
+		_, err = tfExampleFeature([]byte{0x0a, 0xff}, "label")
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -200,3 +200,7 @@ func (z *zipExtractCreator) SupportsOffset() bool {
 func (z *zipExtractCreator) MetadataSize() int64 {
 	return 0 // zip does not have header size
 }
+
+func (z *zipExtractCreator) newMetadata(name string) []byte {
+	return cmn.MustMarshal(zipFileHeader{Name: name})
+}
@@ -23,6 +23,7 @@ import (
 	"github.com/NVIDIA/aistore/stats"
 	"github.com/NVIDIA/aistore/sys"
 	jsoniter "github.com/json-iterator/go"
+	"github.com/pkg/errors"
 	"github.com/tinylib/msgp/msgp"
 )
 
@@ -46,7 +47,11 @@ func ProxySortHandler(w http.ResponseWriter, r *http.Request) {
 
 	switch r.Method {
 	case http.MethodPost:
-		proxyStartSortHandler(w, r)
+		if len(apiItems) == 1 && apiItems[0] == cmn.Resume {
+			proxyResumeSortHandler(w, r)
+		} else {
+			proxyStartSortHandler(w, r)
+		}
 	case http.MethodGet:
 		proxyGetHandler(w, r)
 	case http.MethodDelete:
@@ -121,20 +126,110 @@ func proxyStartSortHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	managerUUID := cmn.GenUUID()
+	if !broadcastStart(w, r, managerUUID, b, nil) {
+		return
+	}
+	w.Write([]byte(managerUUID))
+}
+
+// POST /v1/sort/resume
+func proxyResumeSortHandler(w http.ResponseWriter, r *http.Request) {
+	var (
+		smap        = ctx.smapOwner.Get()
+		managerUUID = r.URL.Query().Get(cmn.URLParamUUID)
+		path        = cmn.JoinWords(cmn.Version, cmn.Sort, cmn.Metrics, managerUUID)
+		responses   = broadcast(http.MethodGet, path, nil, nil, smap.Tmap)
+	)
+
+	// Make sure that the job is not running anywhere.
+	for _, resp := range responses {
+		if resp.statusCode == http.StatusNotFound {
+			// Probably restarted target which does not know anything about this dsort op.
+			continue
+		}
+		if resp.err != nil {
+			cmn.InvalidHandlerWithMsg(w, r, resp.err.Error(), resp.statusCode)
+			return
+		}
+		metrics := &Metrics{}
+		if err := js.Unmarshal(resp.res, &metrics); err != nil {
+			cmn.InvalidHandlerWithMsg(w, r, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if !metrics.Archived.Load() {
+			cmn.InvalidHandlerWithMsg(w, r, fmt.Sprintf("%s job %q still in progress and cannot be resumed", cmn.DSortName, managerUUID))
+			return
+		}
+	}
+
+	var (
+		cp          *checkpoint
+		checkpoints = make(map[string]*checkpoint, len(smap.Tmap))
+	)
+	path = cmn.JoinWords(cmn.Version, cmn.Sort, cmn.Checkpoint, managerUUID)
+	responses = broadcast(http.MethodGet, path, nil, nil, smap.Tmap)
+	for _, resp := range responses {
+		if resp.statusCode == http.StatusNotFound {
+			continue
+		}
+		if resp.err != nil {
+			cmn.InvalidHandlerWithMsg(w, r, resp.err.Error(), resp.statusCode)
+			return
+		}
+		if resp.statusCode != http.StatusOK {
+			cmn.InvalidHandlerWithMsg(w, r, string(resp.res), resp.statusCode)
+			return
+		}
+		cp = &checkpoint{}
+		if err := js.Unmarshal(resp.res, cp); err != nil {
+			cmn.InvalidHandlerWithMsg(w, r, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		checkpoints[resp.si.DaemonID] = cp
+	}
+	if cp == nil {
+		msg := fmt.Sprintf("%s job %q not found or is not resumable", cmn.DSortName, managerUUID)
+		cmn.InvalidHandlerWithMsg(w, r, msg, http.StatusNotFound)
+		return
+	}
+	if !cmn.StrSlicesEqual(cp.Targets, activeTargets(smap)) {
+		msg := fmt.Sprintf("%s job %q cannot be resumed: targets have changed since the job started (expected: %v)",
+			cmn.DSortName, managerUUID, cp.Targets)
+		cmn.InvalidHandlerWithMsg(w, r, msg)
+		return
+	}
+
+	b, err := js.Marshal(cp.RS)
+	if err != nil {
+		cmn.InvalidHandlerWithMsg(w, r, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	phase := resumePhase(checkpoints, cp.Targets)
+	glog.Infof("[%s] resuming after %q phase", managerUUID, phase)
+	broadcastStart(w, r, managerUUID, b, url.Values{cmn.URLParamResumePhase: []string{phase}})
+}
+
+// broadcastStart initializes and starts the dSort job on all the targets.
+// Returns false (and responds with an error) if it fails on any target.
+func broadcastStart(w http.ResponseWriter, r *http.Request, managerUUID string, rs []byte, query url.Values) bool {
 	checkResponses := func(responses []response) error {
 		for _, resp := range responses {
-			if resp.err == nil {
+			err := resp.err
+			if err == nil && resp.statusCode >= http.StatusBadRequest {
+				err = errors.New(string(resp.res))
+			}
+			if err == nil {
 				continue
 			}
 
-			glog.Errorf("[%s] start sort request failed to be broadcast, err: %s", managerUUID, resp.err.Error())
+			glog.Errorf("[%s] start sort request failed to be broadcast, err: %s", managerUUID, err.Error())
 
 			path := cmn.JoinWords(cmn.Version, cmn.Sort, cmn.Abort, managerUUID)
 			broadcast(http.MethodDelete, path, nil, nil, ctx.smapOwner.Get().Tmap)
 
-			s := fmt.Sprintf("failed to execute start sort, err: %s, status: %d", resp.err.Error(), resp.statusCode)
+			s := fmt.Sprintf("failed to execute start sort, err: %s, status: %d", err.Error(), resp.statusCode)
 			cmn.InvalidHandlerWithMsg(w, r, s, http.StatusInternalServerError)
-			return resp.err
+			return err
 		}
 
 		return nil
@@ -155,9 +250,9 @@ func proxyStartSortHandler(w http.ResponseWriter, r *http.Request) {
 		glog.Infof("[%s] broadcasting init request to all targets", managerUUID)
 	}
 	path := cmn.JoinWords(cmn.Version, cmn.Sort, cmn.Init, managerUUID)
-	responses := broadcast(http.MethodPost, path, nil, b, ctx.smapOwner.Get().Tmap)
+	responses := broadcast(http.MethodPost, path, query, rs, ctx.smapOwner.Get().Tmap)
 	if err := checkResponses(responses); err != nil {
-		return
+		return false
 	}
 
 	if glog.V(4) {
@@ -165,11 +260,7 @@ func proxyStartSortHandler(w http.ResponseWriter, r *http.Request) {
 	}
 	path = cmn.JoinWords(cmn.Version, cmn.Sort, cmn.Start, managerUUID)
 	responses = broadcast(http.MethodPost, path, nil, nil, ctx.smapOwner.Get().Tmap)
-	if err := checkResponses(responses); err != nil {
-		return
-	}
-
-	w.Write([]byte(managerUUID))
+	return checkResponses(responses) == nil
 }
 
 // GET /v1/sort
@@ -410,6 +501,8 @@ func SortHandler(w http.ResponseWriter, r *http.Request) {
 		metricsHandler(w, r)
 	case cmn.FinishedAck:
 		finishedAckHandler(w, r)
+	case cmn.Checkpoint:
+		checkpointHandler(w, r)
 	default:
 		cmn.InvalidHandlerWithMsg(w, r, "invalid path")
 	}
@@ -438,6 +531,13 @@ func initSortHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	managerUUID := apiItems[0]
+	if rs.Resumable {
+		// The job could have already run (and been aborted) on this target.
+		if err := Managers.Remove(managerUUID); err != nil {
+			cmn.InvalidHandlerWithMsg(w, r, err.Error())
+			return
+		}
+	}
 	dsortManager, err := Managers.Add(managerUUID)
 	if err != nil {
 		cmn.InvalidHandlerWithMsg(w, r, err.Error())
@@ -448,6 +548,12 @@ func initSortHandler(w http.ResponseWriter, r *http.Request) {
 		cmn.InvalidHandlerWithMsg(w, r, err.Error())
 		return
 	}
+	if rs.Resumable {
+		if err = dsortManager.initCheckpoint(r.URL.Query().Get(cmn.URLParamResumePhase)); err != nil {
+			cmn.InvalidHandlerWithMsg(w, r, err.Error())
+			return
+		}
+	}
 }
 
 // startSortHandler is the handler called for the HTTP endpoint /v1/sort/start.
@@ -536,6 +642,15 @@ func shardsHandler(managers *ManagerGroup) http.HandlerFunc {
 			return
 		}
 
+		var skippedObjs int64
+		if skipped := r.URL.Query().Get(cmn.URLParamSkippedObjects); skipped != "" {
+			if skippedObjs, err = strconv.ParseInt(skipped, 10, 64); err != nil {
+				s := fmt.Sprintf("invalid %s in request to %s, err: %v", cmn.URLParamSkippedObjects, r.URL.String(), err)
+				cmn.InvalidHandlerWithMsg(w, r, s)
+				return
+			}
+		}
+
 		tmpMetadata := &CreationPhaseMetadata{}
 		if err := tmpMetadata.DecodeMsg(msgp.NewReaderSize(r.Body, serializationBufSize)); err != nil {
 			cmn.InvalidHandlerWithMsg(w, r, fmt.Sprintf("could not unmarshal request body, err: %v", err), http.StatusInternalServerError)
@@ -547,6 +662,9 @@ func shardsHandler(managers *ManagerGroup) http.HandlerFunc {
 			return
 		}
 
+		// Objects of the records in the shards that have been created before
+		// the job was resumed will not be requested.
+		dsortManager.decrementRef(skippedObjs)
 		dsortManager.creationPhase.metadata = *tmpMetadata
 		dsortManager.startShardCreation <- struct{}{}
 	}
@@ -662,6 +780,10 @@ func removeSortHandler(w http.ResponseWriter, r *http.Request) {
 		cmn.InvalidHandlerWithMsg(w, r, err.Error())
 		return
 	}
+	if err := removeCheckpoint(managerUUID); err != nil {
+		cmn.InvalidHandlerWithMsg(w, r, err.Error(), http.StatusInternalServerError)
+		return
+	}
 }
 
 func listSortHandler(w http.ResponseWriter, r *http.Request) {
@@ -718,6 +840,34 @@ func metricsHandler(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// checkpointHandler is the handler called for the HTTP endpoint /v1/sort/checkpoint.
+// A valid GET to this endpoint sends response with the checkpoint of the
+// resumable dSort job.
+func checkpointHandler(w http.ResponseWriter, r *http.Request) {
+	if !checkHTTPMethod(w, r, http.MethodGet) {
+		return
+	}
+	apiItems, err := checkRESTItems(w, r, 1, cmn.Version, cmn.Sort, cmn.Checkpoint)
+	if err != nil {
+		return
+	}
+
+	managerUUID := apiItems[0]
+	cp, _, err := loadCheckpoint(managerUUID)
+	if err != nil {
+		cmn.InvalidHandlerWithMsg(w, r, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if cp == nil {
+		s := fmt.Sprintf("invalid request: checkpoint of %s job %s does not exist", cmn.DSortName, managerUUID)
+		cmn.InvalidHandlerWithMsg(w, r, s, http.StatusNotFound)
+		return
+	}
+	if _, err := w.Write(cmn.MustMarshal(cp)); err != nil {
+		glog.Error(err)
+	}
+}
+
 // finishedAckHandler is the handler called for the HTTP endpoint /v1/sort/finished-ack.
 // A valid PUT to this endpoint acknowledges that daemonID has finished dSort operation.
 func finishedAckHandler(w http.ResponseWriter, r *http.Request) {
@@ -104,6 +104,7 @@ type (
 
 		recManager     *extract.RecordManager
 		extractCreator extract.ExtractCreator
+		filter         *extract.RecordFilter // nil - no filtering
 
 		startShardCreation chan struct{}
 		rs                 *ParsedRequestSpec
@@ -129,6 +130,12 @@ type (
 		creationPhase struct {
 			metadata CreationPhaseMetadata
 		}
+		resumable struct {
+			mu      sync.Mutex        // serializes updates of the created shards
+			mpath   *fs.MountpathInfo // mountpath on which the checkpoint is persisted
+			cp      *checkpoint       // nil - job is not resumable
+			resumed string            // phase after which the job has been resumed
+		}
 		finishedAck struct {
 			mu sync.Mutex
 			m  map[string]struct{} // finished acks: daemonID -> ack
@@ -370,6 +377,7 @@ func (m *Manager) finalCleanup() {
 	// The reason why this is not in regular cleanup is because we are only sure
 	// that this can be freed once we cleanup streams - streams are asynchronous
 	// and we may have race between in-flight request and cleanup.
+	m.cleanupCheckpoint()
 	m.recManager.Cleanup()
 
 	m.creationPhase.metadata.SendOrder = nil
@@ -448,6 +456,8 @@ func (m *Manager) setExtractCreator() (err error) {
 	switch m.rs.Algorithm.Kind {
 	case SortKindContent:
 		keyExtractor, err = extract.NewContentKeyExtractor(m.rs.Algorithm.FormatType, m.rs.Algorithm.Extension)
+	case SortKindFeature:
+		keyExtractor, err = extract.NewFeatureKeyExtractor(m.rs.Algorithm.FormatType, m.rs.Algorithm.Feature, m.rs.Extension)
 	case SortKindMD5:
 		keyExtractor, err = extract.NewMD5KeyExtractor()
 	default:
@@ -462,16 +472,9 @@ func (m *Manager) setExtractCreator() (err error) {
 		return m.react(m.rs.DuplicatedRecords, msg)
 	}
 
-	var extractCreator extract.ExtractCreator
-	switch m.rs.Extension {
-	case cmn.ExtTar:
-		extractCreator = extract.NewTarExtractCreator(m.ctx.t)
-	case cmn.ExtTarTgz, cmn.ExtTgz:
-		extractCreator = extract.NewTargzExtractCreator(m.ctx.t)
-	case cmn.ExtZip:
-		extractCreator = extract.NewZipExtractCreator(m.ctx.t)
-	default:
-		cmn.Assertf(false, "unknown extension %s", m.rs.Extension)
+	extractCreator := m.newExtractCreator(m.rs.Extension)
+	if m.outputExtension() != m.rs.Extension {
+		extractCreator = extract.NewConvertExtractCreator(extractCreator, m.newExtractCreator(m.rs.OutputExtension))
 	}
 
 	if !m.rs.DryRun {
@@ -480,12 +483,42 @@ func (m *Manager) setExtractCreator() (err error) {
 		m.extractCreator = extract.NopExtractCreator(extractCreator)
 	}
 
+	if m.rs.Filter != nil {
+		m.filter = m.rs.Filter.newExtractFilter()
+	}
+
 	m.recManager = extract.NewRecordManager(m.ctx.t, m.ctx.node.DaemonID, m.rs.Bucket, m.rs.Provider,
-		m.rs.Extension, m.extractCreator, keyExtractor, onDuplicatedRecords)
+		m.rs.Extension, m.extractCreator, keyExtractor, m.filter, onDuplicatedRecords)
 
 	return nil
 }
 
+// outputExtension returns the extension (format) of the output shards.
+func (m *Manager) outputExtension() string {
+	if m.rs.OutputExtension == "" {
+		return m.rs.Extension
+	}
+	return m.rs.OutputExtension
+}
+
+func (m *Manager) newExtractCreator(ext string) (extractCreator extract.ExtractCreator) {
+	switch ext {
+	case cmn.ExtTar:
+		extractCreator = extract.NewTarExtractCreator(m.ctx.t)
+	case cmn.ExtTarTgz, cmn.ExtTgz:
+		extractCreator = extract.NewTargzExtractCreator(m.ctx.t)
+	case cmn.ExtZip:
+		extractCreator = extract.NewZipExtractCreator(m.ctx.t)
+	case cmn.ExtTFRecord:
+		extractCreator = extract.NewTFRecordExtractCreator(m.ctx.t)
+	case cmn.ExtRecordIO:
+		extractCreator = extract.NewRecordIOExtractCreator(m.ctx.t)
+	default:
+		cmn.Assertf(false, "unknown extension %s", ext)
+	}
+	return
+}
+
 // updateFinishedAck marks daemonID as finished. If all daemons ack then the
 // finalCleanup is dispatched in separate goroutine.
 func (m *Manager) updateFinishedAck(daemonID string) {
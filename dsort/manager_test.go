@@ -63,6 +63,15 @@ var _ = Describe("Init", func() {
 		Expect(m.init(sr)).NotTo(HaveOccurred())
 		Expect(m.extractCreator.UsingCompression()).To(BeTrue())
 	})
+	It("should init with zip extension and tar output extension", func() {
+		m := &Manager{ctx: dsortContext{t: cluster.NewTargetMock(nil)}}
+		m.lock()
+		defer m.unlock()
+		sr := &ParsedRequestSpec{Extension: cmn.ExtZip, OutputExtension: cmn.ExtTar, Algorithm: &SortAlgorithm{Kind: SortKindNone}, MaxMemUsage: cmn.ParsedQuantity{Type: cmn.QuantityPercent, Value: 0}, DSorterType: DSorterGeneralType}
+		Expect(m.init(sr)).NotTo(HaveOccurred())
+		Expect(m.extractCreator.UsingCompression()).To(BeTrue())
+		Expect(m.extractCreator.SupportsOffset()).To(BeFalse())
+	})
 })
 
 func BenchmarkRecordsMarshal(b *testing.B) {
@@ -134,6 +134,9 @@ type LocalExtraction struct {
 	ExtractedSize int64 `json:"extracted_size,string"`
 	// ExtractedRecordCnt describes number of records extracted from all shards.
 	ExtractedRecordCnt int64 `json:"extracted_record_count,string"`
+	// FilteredRecordCnt describes number of records dropped by the filter
+	// (set once the extraction finishes).
+	FilteredRecordCnt int64 `json:"filtered_record_count,string"`
 	// ExtractedToDiskCnt describes number of shards extracted to the disk. To
 	// compute the number shards extracted to memory just subtract it from
 	// ExtractedCnt.
@@ -194,6 +197,9 @@ type Metrics struct {
 
 	// Description of the job.
 	Description string `json:"description,omitempty"`
+	// ResumedPhase is the last phase which had been completed before the job
+	// was resumed, empty if the job has not been resumed.
+	ResumedPhase string `json:"resumed_phase,omitempty"`
 
 	// Warnings which were produced during the job.
 	Warnings []string `json:"warnings,omitempty"`
@@ -298,7 +304,8 @@ type JobInfo struct {
 	Aborted  bool `json:"aborted"`
 	Archived bool `json:"archived"`
 
-	Description string `json:"description"`
+	Description  string `json:"description"`
+	ResumedPhase string `json:"resumed_phase,omitempty"`
 }
 
 func (m *Metrics) ToJobInfo(id string) JobInfo {
@@ -312,9 +319,10 @@ func (m *Metrics) ToJobInfo(id string) JobInfo {
 		SortingDuration:   m.Sorting.Elapsed,
 		CreationDuration:  m.Creation.Elapsed,
 
-		Aborted:     m.Aborted.Load(),
-		Archived:    m.Archived.Load(),
-		Description: m.Description,
+		Aborted:      m.Aborted.Load(),
+		Archived:     m.Archived.Load(),
+		Description:  m.Description,
+		ResumedPhase: m.ResumedPhase,
 	}
 }
 
@@ -328,6 +336,9 @@ func (j *JobInfo) Aggregate(other *JobInfo) {
 
 	j.Aborted = j.Aborted || other.Aborted
 	j.Archived = j.Archived && other.Archived
+	if j.ResumedPhase == "" {
+		j.ResumedPhase = other.ResumedPhase
+	}
 }
 
 func (j *JobInfo) IsRunning() bool {
@@ -12,6 +12,7 @@ import (
 	"fmt"
 	"math"
 	"net/url"
+	"regexp"
 	"strconv"
 	"strings"
 
@@ -26,7 +27,7 @@ const (
 
 var (
 	errMissingBucket            = errors.New("missing field 'bucket'")
-	errInvalidExtension         = errors.New("extension must be one of '.tar', '.tar.gz', or '.tgz'")
+	errInvalidExtension         = fmt.Errorf("extension must be one of: %+v", supportedExtensions)
 	errNegOutputShardSize       = errors.New("output shard size must be >= 0")
 	errEmptyOutputShardSize     = errors.New("output shard size must be set (cannot be 0)")
 	errNegativeConcurrencyLimit = fmt.Errorf("concurrency max limit must be 0 (limits will be calculated) or > 0")
@@ -39,10 +40,15 @@ var (
 	errInvalidAlgorithmKind      = fmt.Errorf("invalid algorithm kind, should be one of: %+v", supportedAlgorithms)
 	errInvalidSeed               = errors.New("invalid seed provided, should be int")
 	errInvalidAlgorithmExtension = errors.New("invalid extension provided, should be in format: .ext")
+
+	errInvalidFilterExtension   = errors.New("invalid filter extension provided, should be in format: .ext")
+	errInvalidFilterSize        = errors.New("invalid filter size provided, max size must be 0 (unlimited) or >= min size")
+	errInvalidFilterKeysFile    = errors.New("could not parse filter keys file, required URL")
+	errInvalidFilterSampleRatio = errors.New("invalid sample ratio provided, should be in range (0, 1]")
 )
 
 // supportedExtensions is a list of supported extensions by dSort
-var supportedExtensions = []string{cmn.ExtTar, cmn.ExtTgz, cmn.ExtTarTgz, cmn.ExtZip}
+var supportedExtensions = []string{cmn.ExtTar, cmn.ExtTgz, cmn.ExtTarTgz, cmn.ExtZip, cmn.ExtTFRecord, cmn.ExtRecordIO}
 
 // TODO: maybe this struct should be composed of `type` and `template` where
 // template is interface and each template has it's own struct. Then we could
@@ -80,8 +86,12 @@ type RequestSpec struct {
 	Description string `json:"description" yaml:"description"`
 	// Default: same as `bucket` field
 	OutputBucket string `json:"output_bucket" yaml:"output_bucket"`
+	// Default: same as `extension` field
+	OutputExtension string `json:"output_extension" yaml:"output_extension"`
 	// Default: alphanumeric, increasing
 	Algorithm SortAlgorithm `json:"algorithm" yaml:"algorithm"`
+	// Default: no filtering
+	Filter RecordFilter `json:"filter" yaml:"filter"`
 	// Default: ""
 	OrderFileURL string `json:"order_file" yaml:"order_file"`
 	// Default: "\t"
@@ -100,6 +110,8 @@ type RequestSpec struct {
 	StreamMultiplier int `json:"stream_multiplier" yaml:"stream_multiplier"`
 	// Default: false
 	ExtendedMetrics bool `json:"extended_metrics" yaml:"extended_metrics"`
+	// Default: false
+	Resumable bool `json:"resumable" yaml:"resumable"`
 
 	// debug
 	DSorterType string `json:"dsorter_type"`
@@ -116,10 +128,12 @@ type ParsedRequestSpec struct {
 	Provider            string                `json:"provider"`
 	OutputProvider      string                `json:"output_provider"`
 	Extension           string                `json:"extension"`
+	OutputExtension     string                `json:"output_extension"`
 	OutputShardSize     int64                 `json:"output_shard_size,string"`
 	InputFormat         *parsedInputTemplate  `json:"input_format"`
 	OutputFormat        *parsedOutputTemplate `json:"output_format"`
 	Algorithm           *SortAlgorithm        `json:"algorithm"`
+	Filter              *RecordFilter         `json:"filter"` // nil - no filtering
 	OrderFileURL        string                `json:"order_file"`
 	OrderFileSep        string                `json:"order_file_sep"`
 	MaxMemUsage         cmn.ParsedQuantity    `json:"max_mem_usage"`
@@ -128,6 +142,7 @@ type ParsedRequestSpec struct {
 	CreateConcMaxLimit  int                   `json:"create_concurrency_max_limit"`
 	StreamMultiplier    int                   `json:"stream_multiplier"` // TODO: should be removed
 	ExtendedMetrics     bool                  `json:"extended_metrics"`
+	Resumable           bool                  `json:"resumable"`
 
 	// debug
 	DSorterType string `json:"dsorter_type"`
@@ -146,10 +161,30 @@ type SortAlgorithm struct {
 	Seed string `json:"seed"` // seed provided to random generator
 
 	// Kind: content
-	Extension  string `json:"extension"`
+	Extension string `json:"extension"`
+
+	// Kind: feature
+	Feature string `json:"feature"` // name of the feature embedded in TFRecord or RecordIO record
+
+	// Kind: content, feature
 	FormatType string `json:"format_type"`
 }
 
+// RecordFilter determines which records are extracted from the input shards
+// (see `extract.RecordFilter`).
+type RecordFilter struct {
+	NameRegex   string   `json:"name_regex" yaml:"name_regex"`
+	Extensions  []string `json:"extensions" yaml:"extensions"`
+	MinSize     string   `json:"min_size" yaml:"min_size"`
+	MaxSize     string   `json:"max_size" yaml:"max_size"`
+	KeysFileURL string   `json:"keys_file" yaml:"keys_file"` // URL of the file with record names, one per line
+
+	// Deterministic sampling
+	SampleRatio      float64 `json:"sample_ratio" yaml:"sample_ratio"`
+	SampleSeed       string  `json:"sample_seed" yaml:"sample_seed"`
+	SampleComplement bool    `json:"sample_complement" yaml:"sample_complement"`
+}
+
 // Parse returns a non-nil error if a RequestSpec is invalid. When RequestSpec
 // is valid it parses all the fields, sets the values and returns ParsedRequestSpec.
 func (rs *RequestSpec) Parse() (*ParsedRequestSpec, error) {
@@ -186,6 +221,13 @@ func (rs *RequestSpec) Parse() (*ParsedRequestSpec, error) {
 		return nil, errInvalidExtension
 	}
 	parsedRS.Extension = rs.Extension
+	parsedRS.OutputExtension = rs.Extension
+	if rs.OutputExtension != "" {
+		if !validateExtension(rs.OutputExtension) {
+			return nil, errInvalidExtension
+		}
+		parsedRS.OutputExtension = rs.OutputExtension
+	}
 
 	parsedRS.OutputShardSize, err = cmn.S2B(rs.OutputShardSize)
 	if err != nil {
@@ -199,6 +241,15 @@ func (rs *RequestSpec) Parse() (*ParsedRequestSpec, error) {
 	if err != nil {
 		return nil, errInvalidAlgorithm
 	}
+	if parsedRS.Algorithm.Kind == SortKindFeature {
+		if err := extract.ValidateFeature(parsedRS.Algorithm.Feature, parsedRS.Extension); err != nil {
+			return nil, err
+		}
+	}
+
+	if parsedRS.Filter, err = parseRecordFilter(rs.Filter); err != nil {
+		return nil, err
+	}
 
 	if empty, valid := validateOrderFileURL(rs.OrderFileURL); !valid {
 		return nil, errInvalidOrderParam
@@ -246,6 +297,7 @@ func (rs *RequestSpec) Parse() (*ParsedRequestSpec, error) {
 	parsedRS.CreateConcMaxLimit = rs.CreateConcMaxLimit
 	parsedRS.StreamMultiplier = rs.StreamMultiplier
 	parsedRS.ExtendedMetrics = rs.ExtendedMetrics
+	parsedRS.Resumable = rs.Resumable
 	parsedRS.DSorterType = rs.DSorterType
 	parsedRS.DryRun = rs.DryRun
 
@@ -331,6 +383,11 @@ func parseAlgorithm(algo SortAlgorithm) (parsedAlgo *SortAlgorithm, err error) {
 			return nil, errInvalidAlgorithmExtension
 		}
 
+		if err := extract.ValidateAlgorithmFormatType(algo.FormatType); err != nil {
+			return nil, err
+		}
+	} else if algo.Kind == SortKindFeature {
+		algo.Feature = strings.TrimSpace(algo.Feature)
 		if err := extract.ValidateAlgorithmFormatType(algo.FormatType); err != nil {
 			return nil, err
 		}
@@ -341,6 +398,73 @@ func parseAlgorithm(algo SortAlgorithm) (parsedAlgo *SortAlgorithm, err error) {
 	return &algo, nil
 }
 
+// parseRecordFilter validates the filter and returns nil if there is nothing
+// to filter.
+func parseRecordFilter(filter RecordFilter) (*RecordFilter, error) {
+	if filter.NameRegex == "" && len(filter.Extensions) == 0 && filter.MinSize == "" && filter.MaxSize == "" &&
+		filter.KeysFileURL == "" && filter.SampleRatio == 0 {
+		return nil, nil
+	}
+
+	if _, err := regexp.Compile(filter.NameRegex); err != nil {
+		return nil, err
+	}
+	for _, ext := range filter.Extensions {
+		if ext == "" || ext[0] != '.' {
+			return nil, errInvalidFilterExtension
+		}
+	}
+	minSize, maxSize, err := filter.sizes()
+	if err != nil {
+		return nil, err
+	}
+	if minSize < 0 || maxSize < 0 || (maxSize > 0 && maxSize < minSize) {
+		return nil, errInvalidFilterSize
+	}
+	if empty, valid := validateOrderFileURL(filter.KeysFileURL); !empty && !valid {
+		return nil, errInvalidFilterKeysFile
+	}
+	if filter.SampleRatio < 0 || filter.SampleRatio > 1 {
+		return nil, errInvalidFilterSampleRatio
+	}
+	if filter.SampleSeed != "" {
+		if value, err := strconv.ParseInt(filter.SampleSeed, 10, 64); value < 0 || err != nil {
+			return nil, errInvalidSeed
+		}
+	}
+	return &filter, nil
+}
+
+func (f *RecordFilter) sizes() (minSize, maxSize int64, err error) {
+	if f.MinSize != "" {
+		if minSize, err = cmn.S2B(f.MinSize); err != nil {
+			return
+		}
+	}
+	if f.MaxSize != "" {
+		maxSize, err = cmn.S2B(f.MaxSize)
+	}
+	return
+}
+
+// newExtractFilter returns the filter that is applied by the record manager.
+// NOTE: the keys (if any) are loaded once the extraction phase starts.
+func (f *RecordFilter) newExtractFilter() *extract.RecordFilter {
+	filter := &extract.RecordFilter{SampleRatio: f.SampleRatio, SampleComplement: f.SampleComplement}
+	if f.NameRegex != "" {
+		filter.NameRegex = regexp.MustCompile(f.NameRegex)
+	}
+	if len(f.Extensions) > 0 {
+		filter.Extensions = cmn.NewStringSet(f.Extensions...)
+	}
+	filter.MinSize, filter.MaxSize, _ = f.sizes()
+	if f.SampleSeed != "" {
+		seed, _ := strconv.ParseInt(f.SampleSeed, 10, 64)
+		filter.SampleSeed = uint64(seed)
+	}
+	return filter
+}
+
 func validateOrderFileURL(orderURL string) (empty, valid bool) {
 	if orderURL == "" {
 		return true, true
@@ -11,6 +11,7 @@ import (
 	"math"
 
 	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/dsort/extract"
 	"github.com/NVIDIA/aistore/fs"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -44,6 +45,7 @@ var _ = Describe("RequestSpec", func() {
 			Expect(parsed.Provider).To(Equal(cmn.ProviderAIS))
 			Expect(parsed.OutputProvider).To(Equal(cmn.ProviderAIS))
 			Expect(parsed.Extension).To(Equal(cmn.ExtTar))
+			Expect(parsed.OutputExtension).To(Equal(cmn.ExtTar))
 
 			Expect(parsed.InputFormat.Template).To(Equal(cmn.ParsedTemplate{
 				Prefix: "prefix-",
@@ -157,6 +159,72 @@ var _ = Describe("RequestSpec", func() {
 			Expect(parsed.Extension).To(Equal(cmn.ExtZip))
 		})
 
+		It("should parse spec with .tfrecord extension and feature algorithm", func() {
+			rs := RequestSpec{
+				Bucket:          "test",
+				Extension:       cmn.ExtTFRecord,
+				InputFormat:     "prefix-{0010..0111}-suffix",
+				OutputFormat:    "prefix-{0010..0111}-suffix",
+				OutputShardSize: "10KB",
+				Algorithm:       SortAlgorithm{Kind: SortKindFeature, Feature: "label", FormatType: extract.FormatTypeInt},
+			}
+			parsed, err := rs.Parse()
+			Expect(err).ShouldNot(HaveOccurred())
+
+			Expect(parsed.Extension).To(Equal(cmn.ExtTFRecord))
+			Expect(parsed.Algorithm.Feature).To(Equal("label"))
+			Expect(parsed.Algorithm.FormatType).To(Equal(extract.FormatTypeInt))
+		})
+
+		It("should parse spec with different output extension", func() {
+			rs := RequestSpec{
+				Bucket:          "test",
+				Extension:       cmn.ExtZip,
+				OutputExtension: cmn.ExtTarTgz,
+				InputFormat:     "prefix-{0010..0111}-suffix",
+				OutputFormat:    "prefix-{0010..0111}-suffix",
+				OutputShardSize: "10KB",
+				Algorithm:       SortAlgorithm{Kind: SortKindNone},
+			}
+			parsed, err := rs.Parse()
+			Expect(err).ShouldNot(HaveOccurred())
+
+			Expect(parsed.Extension).To(Equal(cmn.ExtZip))
+			Expect(parsed.OutputExtension).To(Equal(cmn.ExtTarTgz))
+		})
+
+		It("should parse spec with record filter", func() {
+			rs := RequestSpec{
+				Bucket:          "test",
+				Extension:       cmn.ExtTar,
+				InputFormat:     "prefix-{0010..0111}-suffix",
+				OutputFormat:    "prefix-{0010..0111}-suffix",
+				OutputShardSize: "10KB",
+				Algorithm:       SortAlgorithm{Kind: SortKindNone},
+				Filter: RecordFilter{
+					NameRegex:   "^train/",
+					Extensions:  []string{".jpg", ".cls"},
+					MaxSize:     "1MB",
+					SampleRatio: 0.1,
+					SampleSeed:  "42",
+				},
+			}
+			parsed, err := rs.Parse()
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(parsed.Filter).NotTo(BeNil())
+
+			filter := parsed.Filter.newExtractFilter()
+			Expect(filter.NameRegex.String()).To(Equal("^train/"))
+			Expect(filter.Extensions).To(Equal(cmn.NewStringSet(".jpg", ".cls")))
+			Expect(filter.MaxSize).To(Equal(int64(cmn.MiB)))
+			Expect(filter.SampleSeed).To(Equal(uint64(42)))
+
+			rs.Filter = RecordFilter{}
+			parsed, err = rs.Parse()
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(parsed.Filter).To(BeNil())
+		})
+
 		It("should parse spec with %06d syntax", func() {
 			rs := RequestSpec{
 				Bucket:          "test",
@@ -360,6 +428,59 @@ var _ = Describe("RequestSpec", func() {
 			Expect(err).To(Equal(errInvalidExtension))
 		})
 
+		It("should fail due to invalid output extension", func() {
+			rs := RequestSpec{
+				Bucket:          "test",
+				Extension:       cmn.ExtTar,
+				OutputExtension: ".jpg",
+				InputFormat:     "prefix-{0010..0111}-suffix",
+				OutputFormat:    "prefix-{0010..0111}-suffix",
+				OutputShardSize: "10KB",
+				Algorithm:       SortAlgorithm{Kind: SortKindNone},
+			}
+			_, err := rs.Parse()
+			Expect(err).Should(HaveOccurred())
+			Expect(err).To(Equal(errInvalidExtension))
+		})
+
+		It("should fail due to invalid record filter", func() {
+			for _, test := range []struct {
+				filter RecordFilter
+				err    error
+			}{
+				{filter: RecordFilter{Extensions: []string{"jpg"}}, err: errInvalidFilterExtension},
+				{filter: RecordFilter{MinSize: "2MB", MaxSize: "1MB"}, err: errInvalidFilterSize},
+				{filter: RecordFilter{KeysFileURL: "keys.txt"}, err: errInvalidFilterKeysFile},
+				{filter: RecordFilter{SampleRatio: 1.5}, err: errInvalidFilterSampleRatio},
+				{filter: RecordFilter{SampleRatio: 0.5, SampleSeed: "seed"}, err: errInvalidSeed},
+			} {
+				rs := RequestSpec{
+					Bucket:          "test",
+					Extension:       cmn.ExtTar,
+					InputFormat:     "prefix-{0010..0111}-suffix",
+					OutputFormat:    "prefix-{0010..0111}-suffix",
+					OutputShardSize: "10KB",
+					Algorithm:       SortAlgorithm{Kind: SortKindNone},
+					Filter:          test.filter,
+				}
+				_, err := rs.Parse()
+				Expect(err).To(Equal(test.err))
+			}
+		})
+
+		It("should fail due to invalid RecordIO feature", func() {
+			rs := RequestSpec{
+				Bucket:          "test",
+				Extension:       cmn.ExtRecordIO,
+				InputFormat:     "prefix-{0010..0111}-suffix",
+				OutputFormat:    "prefix-{0010..0111}-suffix",
+				OutputShardSize: "10KB",
+				Algorithm:       SortAlgorithm{Kind: SortKindFeature, Feature: "class", FormatType: extract.FormatTypeInt},
+			}
+			_, err := rs.Parse()
+			Expect(err).Should(HaveOccurred())
+		})
+
 		It("should fail due to invalid mem usage specification", func() {
 			rs := RequestSpec{
 				Bucket:          "test",
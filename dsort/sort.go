@@ -21,9 +21,12 @@ const (
 	SortKindMD5          = "md5"
 	SortKindShuffle      = "shuffle" // shuffle randomly, can be used with seed to get reproducible results
 	SortKindContent      = "content" // sort by content of given file
+	SortKindFeature      = "feature" // sort by feature embedded in the record (TFRecord and RecordIO)
 )
 
-var supportedAlgorithms = []string{sortKindEmpty, SortKindAlphanumeric, SortKindMD5, SortKindShuffle, SortKindContent, SortKindNone}
+var supportedAlgorithms = []string{
+	sortKindEmpty, SortKindAlphanumeric, SortKindMD5, SortKindShuffle, SortKindContent, SortKindFeature, SortKindNone,
+}
 
 type (
 	alphaByKey struct {
@@ -145,10 +145,11 @@ type (
 		ErrCh    chan error   // for final EC result
 		Callback cluster.OnFinishObj
 
-		putTime time.Time // time when the object is put into main queue
-		tm      time.Time // to measure different steps
-		IsCopy  bool      // replicate or use erasure coding
-		rebuild bool      // true - internal request to reencode, e.g., from ec-encode xaction
+		putTime  time.Time // time when the object is put into main queue
+		tm       time.Time // to measure different steps
+		IsCopy   bool      // replicate or use erasure coding
+		rebuild  bool      // true - internal request to reencode, e.g., from ec-encode xaction
+		relayout bool      // true - re-encoding by ec-relayout xaction
 	}
 
 	RequestsControlMsg struct {
@@ -191,6 +192,7 @@ type (
 		metadata *Metadata          // object's metadata
 		isSlice  bool               // is it slice or replica
 		reqType  intraReqType       // request's type, slice/meta request/response
+		relayout bool               // sent by ec-relayout xaction
 	}
 )
 
@@ -236,6 +238,7 @@ var (
 	ErrorNoMetafile          = errors.New("no metafile")
 	ErrorNotFound            = errors.New("not found")
 	ErrorInsufficientTargets = errors.New("insufficient targets")
+	ErrorVerifyTimeout       = errors.New("verification timed out")
 )
 
 func Init(t cluster.Target) {
@@ -248,6 +251,8 @@ func Init(t cluster.Target) {
 	xreg.RegisterBucketXact(&xactPutProvider{})
 	xreg.RegisterBucketXact(&xactRespondProvider{})
 	xreg.RegisterBucketXact(&xactBckEncodeProvider{})
+	xreg.RegisterBucketXact(&xactBckScrubProvider{})
+	xreg.RegisterBucketXact(&xactBckRelayoutProvider{})
 
 	if err := initManager(t); err != nil {
 		cmn.ExitLogf("Failed to init manager: %v", err)
@@ -318,10 +323,15 @@ func freeSlices(slices []*slice) {
 }
 
 // requestECMeta returns an EC metadata found on a remote target.
-func requestECMeta(bck cmn.Bck, objName string, si *cluster.Snode, client *http.Client) (md *Metadata, err error) {
+// If `verify` is set, the target verifies the slice (replica) first - see `VerifyCT`.
+func requestECMeta(bck cmn.Bck, objName string, si *cluster.Snode, client *http.Client,
+	verify bool) (md *Metadata, err error) {
 	path := cmn.JoinWords(cmn.Version, cmn.EC, URLMeta, bck.Name, objName)
 	query := url.Values{}
 	query = cmn.AddBckToQuery(query, bck)
+	if verify {
+		query.Set(cmn.URLParamECVerify, "true")
+	}
 	url := si.URL(cmn.NetworkIntraData) + path
 	rq, err := http.NewRequest(http.MethodGet, url, nil)
 	if err != nil {
@@ -335,6 +345,8 @@ func requestECMeta(bck cmn.Bck, objName string, si *cluster.Snode, client *http.
 	defer cmn.Close(resp.Body)
 	if resp.StatusCode == http.StatusNotFound {
 		return nil, fmt.Errorf("%s/%s not found on %s", bck, objName, si.ID())
+	} else if resp.StatusCode == http.StatusServiceUnavailable && verify {
+		return nil, fmt.Errorf("%s/%s on %s: %w", bck, objName, si.ID(), ErrorVerifyTimeout)
 	} else if resp.StatusCode != http.StatusOK {
 		return nil, fmt.Errorf("failed to read %s GET request: %v", objName, err)
 	}
@@ -419,6 +431,8 @@ func LomFromHeader(t cluster.Target, hdr transport.ObjHdr) (*cluster.LOM, error)
 	if hdr.ObjAttrs.CksumType != cmn.ChecksumNone && hdr.ObjAttrs.CksumValue != "" {
 		lom.SetCksum(cmn.NewCksum(hdr.ObjAttrs.CksumType, hdr.ObjAttrs.CksumValue))
 	}
+	lom.SetUserMD(hdr.ObjAttrs.UserMD)
+	lom.SetTags(hdr.ObjAttrs.Tags)
 	return lom, nil
 }
 
@@ -0,0 +1,17 @@
+// Package ec provides erasure coding (EC) based data protection for AIStore.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package ec
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestEC(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "EC Suite")
+}
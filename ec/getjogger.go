@@ -221,6 +221,8 @@ func (c *getJogger) restoreReplicatedFromMemory(req *Request, meta *Metadata, no
 	}
 
 	req.LOM.SetSize(writer.Size())
+	req.LOM.SetUserMD(meta.UserMD)
+	req.LOM.SetTags(meta.Tags)
 	args := &WriteArgs{
 		Reader:     memsys.NewReader(writer),
 		MD:         cmn.MustMarshal(meta),
@@ -284,6 +286,8 @@ func (c *getJogger) restoreReplicatedFromDisk(req *Request, meta *Metadata, node
 		return err
 	}
 
+	req.LOM.SetUserMD(meta.UserMD)
+	req.LOM.SetTags(meta.Tags)
 	if err := req.LOM.Persist(); err != nil {
 		return err
 	}
@@ -591,6 +595,8 @@ func (c *getJogger) restoreMainObj(req *Request, meta *Metadata, slices []*slice
 		req.LOM.SetVersion(version)
 	}
 	req.LOM.SetSize(meta.Size)
+	req.LOM.SetUserMD(meta.UserMD)
+	req.LOM.SetTags(meta.Tags)
 	mainMeta := *meta
 	mainMeta.SliceID = 0
 	args := &WriteArgs{
@@ -825,7 +831,7 @@ func (c *getJogger) requestMeta(req *Request) (meta *Metadata, nodes map[string]
 		wg.Add(1)
 		go func(si *cluster.Snode) {
 			defer wg.Done()
-			md, err := requestECMeta(req.LOM.Bck().Bck, req.LOM.ObjName, si, c.client)
+			md, err := requestECMeta(req.LOM.Bck().Bck, req.LOM.ObjName, si, c.client, false /*verify*/)
 			if err != nil {
 				if glog.FastV(4, glog.SmoduleEC) {
 					glog.Infof("No EC meta %s from %s: %v", req.LOM.ObjName, si, err)
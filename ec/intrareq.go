@@ -47,6 +47,9 @@ type (
 		isSlice bool
 		// bucket ID
 		bid uint64
+		// The data is sent by EC re-layout: the destination may remove its
+		// replica/slice that the new layout does not need anymore
+		relayout bool
 	}
 )
 
@@ -58,11 +61,11 @@ var (
 
 func (r *intraReq) PackedSize() int {
 	if r.meta == nil {
-		// int8(type)+sender(string)+int8+int8+ptr_marker
-		return cmn.SizeofLen + len(r.sender) + 4 + cmn.SizeofI64
+		// int8(type)+sender(string)+int8+int8+ptr_marker+int8
+		return cmn.SizeofLen + len(r.sender) + 5 + cmn.SizeofI64
 	}
-	// int8(type)+sender(string)+int8+int8+ptr_marker+sizeof(meta)
-	return cmn.SizeofLen + len(r.sender) + r.meta.PackedSize() + 4 + cmn.SizeofI64
+	// int8(type)+sender(string)+int8+int8+ptr_marker+sizeof(meta)+int8
+	return cmn.SizeofLen + len(r.sender) + r.meta.PackedSize() + 5 + cmn.SizeofI64
 }
 
 func (r *intraReq) Pack(packer *cmn.BytePack) {
@@ -77,6 +80,8 @@ func (r *intraReq) Pack(packer *cmn.BytePack) {
 		packer.WriteByte(1)
 		packer.WriteAny(r.meta)
 	}
+	// NOTE: goes last for compatibility with older nodes
+	packer.WriteBool(r.relayout)
 }
 
 func (r *intraReq) Unpack(unpacker *cmn.ByteUnpack) error {
@@ -105,10 +110,16 @@ func (r *intraReq) Unpack(unpacker *cmn.ByteUnpack) error {
 	}
 	if i == 0 {
 		r.meta = nil
-		return nil
+	} else {
+		r.meta = &Metadata{}
+		if err = unpacker.ReadAny(r.meta); err != nil {
+			return err
+		}
+	}
+	if r.relayout, err = unpacker.ReadBool(); err == cmn.ErrorBufferUnderrun {
+		err = nil // packed by an older node
 	}
-	r.meta = &Metadata{}
-	return unpacker.ReadAny(r.meta)
+	return err
 }
 
 func (r *intraReq) NewPack(mm *memsys.MMSA) []byte {
@@ -285,6 +285,10 @@ func (mgr *Manager) recvResponse(w http.ResponseWriter, hdr transport.ObjHdr, ob
 //   - intra - if true, it is internal request and has low priority
 //   - cb - optional callback that is called after the object is encoded
 func (mgr *Manager) EncodeObject(lom *cluster.LOM, cb ...cluster.OnFinishObj) error {
+	return mgr.encodeObject(lom, false /*relayout*/, cb...)
+}
+
+func (mgr *Manager) encodeObject(lom *cluster.LOM, relayout bool, cb ...cluster.OnFinishObj) error {
 	if !lom.Bprops().EC.Enabled {
 		return ErrorECDisabled
 	}
@@ -311,10 +315,11 @@ func (mgr *Manager) EncodeObject(lom *cluster.LOM, cb ...cluster.OnFinishObj) er
 	}
 
 	req := &Request{
-		Action:  ActSplit,
-		IsCopy:  IsECCopy(lom.Size(), &lom.Bprops().EC),
-		LOM:     lom,
-		rebuild: len(cb) != 0,
+		Action:   ActSplit,
+		IsCopy:   IsECCopy(lom.Size(), &lom.Bprops().EC),
+		LOM:      lom,
+		rebuild:  len(cb) != 0,
+		relayout: relayout,
 	}
 	if len(cb) != 0 {
 		req.Callback = cb[0]
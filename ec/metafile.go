@@ -15,17 +15,24 @@ import (
 
 // Metadata - EC information stored in metafiles for every encoded object
 type Metadata struct {
-	Size       int64  `json:"size"`                      // obj size (after EC'ing sum size of slices differs from the original)
-	ObjCksum   string `json:"obj_chk"`                   // checksum of the original object
-	ObjVersion string `json:"obj_version,omitempty"`     // object version
-	CksumType  string `json:"slice_ck_type,omitempty"`   // slice checksum type
-	CksumValue string `json:"slice_chk_value,omitempty"` // slice checksum of the slice if EC is used
-	Data       int    `json:"data"`                      // the number of data slices
-	Parity     int    `json:"parity"`                    // the number of parity slices
-	SliceID    int    `json:"sliceid,omitempty"`         // 0 for full replica, 1 to N for slices
-	IsCopy     bool   `json:"copy"`                      // object is replicated(true) or encoded(false)
+	Size       int64         `json:"size"`                      // obj size (after EC'ing sum size of slices differs from the original)
+	ObjCksum   string        `json:"obj_chk"`                   // checksum of the original object
+	ObjVersion string        `json:"obj_version,omitempty"`     // object version
+	CksumType  string        `json:"slice_ck_type,omitempty"`   // slice checksum type
+	CksumValue string        `json:"slice_chk_value,omitempty"` // slice checksum of the slice if EC is used
+	Data       int           `json:"data"`                      // the number of data slices
+	Parity     int           `json:"parity"`                    // the number of parity slices
+	SliceID    int           `json:"sliceid,omitempty"`         // 0 for full replica, 1 to N for slices
+	IsCopy     bool          `json:"copy"`                      // object is replicated(true) or encoded(false)
+	UserMD     cmn.SimpleKVs `json:"user_md,omitempty"`         // user-defined metadata of the original object
+	Tags       cmn.SimpleKVs `json:"tags,omitempty"`            // tags of the original object
 }
 
+// Version of the packed metadata extension that follows the slice checksum.
+// Metadata packed by older nodes ends right after the checksum value, while
+// older nodes stop reading there and ignore the extension.
+const metaPackVersion = 1
+
 // interface guard
 var (
 	_ cmn.Unpacker = (*Metadata)(nil)
@@ -111,7 +118,42 @@ func (md *Metadata) Unpack(unpacker *cmn.ByteUnpack) (err error) {
 	if md.CksumType, err = unpacker.ReadString(); err != nil {
 		return
 	}
-	md.CksumValue, err = unpacker.ReadString()
+	if md.CksumValue, err = unpacker.ReadString(); err != nil {
+		return
+	}
+	var version byte
+	if version, err = unpacker.ReadByte(); err != nil {
+		if err == cmn.ErrorBufferUnderrun {
+			err = nil // packed by an older node - no extension
+		}
+		return
+	}
+	if version != metaPackVersion {
+		return fmt.Errorf("unsupported EC metadata version %d (expecting %d)", version, metaPackVersion)
+	}
+	if md.UserMD, err = unpackKVs(unpacker); err != nil {
+		return
+	}
+	md.Tags, err = unpackKVs(unpacker)
+	return
+}
+
+func unpackKVs(unpacker *cmn.ByteUnpack) (kvs cmn.SimpleKVs, err error) {
+	var cnt uint16
+	if cnt, err = unpacker.ReadUint16(); err != nil || cnt == 0 {
+		return
+	}
+	kvs = make(cmn.SimpleKVs, cnt)
+	for ; cnt > 0; cnt-- {
+		var k, v string
+		if k, err = unpacker.ReadString(); err != nil {
+			return
+		}
+		if v, err = unpacker.ReadString(); err != nil {
+			return
+		}
+		kvs[k] = v
+	}
 	return
 }
 
@@ -125,11 +167,31 @@ func (md *Metadata) Pack(packer *cmn.BytePack) {
 	packer.WriteString(md.ObjVersion)
 	packer.WriteString(md.CksumType)
 	packer.WriteString(md.CksumValue)
+	packer.WriteByte(metaPackVersion)
+	packKVs(packer, md.UserMD)
+	packKVs(packer, md.Tags)
+}
+
+func packKVs(packer *cmn.BytePack, kvs cmn.SimpleKVs) {
+	packer.WriteUint16(uint16(len(kvs)))
+	for k, v := range kvs {
+		packer.WriteString(k)
+		packer.WriteString(v)
+	}
 }
 
-// int16 is sufficient to keep Data,Parity, and SliceID, so:
-//    int64 + 3*int16 + bool + 4 strings
+// int16 is sufficient to keep Data,Parity, SliceID, and the number of
+// user-defined metadata entries and tags, so:
+//    int64 + 5*int16 + bool + 4 strings + version + user-defined metadata + tags
 func (md *Metadata) PackedSize() int {
-	return cmn.SizeofI64 + cmn.SizeofI16*3 + 1 + cmn.SizeofLen*4 +
+	size := cmn.SizeofI64 + cmn.SizeofI16*5 + 1 + cmn.SizeofLen*4 + 1 +
 		len(md.ObjCksum) + len(md.ObjVersion) + len(md.CksumType) + len(md.CksumValue)
+	return size + kvsPackedSize(md.UserMD) + kvsPackedSize(md.Tags)
+}
+
+func kvsPackedSize(kvs cmn.SimpleKVs) (size int) {
+	for k, v := range kvs {
+		size += cmn.SizeofLen*2 + len(k) + len(v)
+	}
+	return
 }
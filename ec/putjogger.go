@@ -166,6 +166,8 @@ func (c *putJogger) encode(req *Request) error {
 		IsCopy:    req.IsCopy,
 		ObjCksum:  cksumValue,
 		CksumType: cksumType,
+		UserMD:    req.LOM.UserMD(),
+		Tags:      req.LOM.Tags(),
 	}
 
 	// calculate the number of targets required to encode the object
@@ -272,6 +274,7 @@ func (c *putJogger) createCopies(req *Request, metadata *Metadata) error {
 		size:     req.LOM.Size(),
 		metadata: metadata,
 		reqType:  reqPut,
+		relayout: req.relayout,
 	}
 	err = c.parent.writeRemote(nodes, req.LOM, src, cb)
 
@@ -566,6 +569,7 @@ func (c *putJogger) sendSlices(req *Request, meta *Metadata) ([]*slice, error) {
 			metadata: mcopy,
 			isSlice:  true,
 			reqType:  reqPut,
+			relayout: req.relayout,
 		}
 
 		// Put in lom actual object's checksum. It will be stored in slice's xattrs on dest target
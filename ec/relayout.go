@@ -0,0 +1,239 @@
+// Package ec provides erasure coding (EC) based data protection for AIStore.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package ec
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"unsafe"
+
+	"github.com/NVIDIA/aistore/3rdparty/atomic"
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/fs/mpather"
+	"github.com/NVIDIA/aistore/transport"
+	"github.com/NVIDIA/aistore/xaction"
+	"github.com/NVIDIA/aistore/xaction/xreg"
+	"github.com/OneOfOne/xxhash"
+)
+
+// EC re-layout re-encodes the objects of the bucket after its data and/or
+// parity slice counts (or the size limit for replicated objects) have changed.
+// Each object is re-encoded by its main target, one object per mountpath at a
+// time. The objects that are already encoded with the current layout are
+// skipped, which makes it possible to resume interrupted re-layout: the
+// per-bucket marker is removed only when the re-layout completes and all the
+// objects get re-encoded - otherwise, the re-layout fails and is resumed (and
+// retries the failed objects) upon the next startup.
+// Slices (replicas) that are not needed with the new layout are removed.
+
+type (
+	// Implements `xreg.BucketEntryProvider` and `xreg.BucketEntry` interface.
+	xactBckRelayoutProvider struct {
+		xreg.BaseBckEntry
+		xact *XactBckRelayout
+
+		t    cluster.Target
+		uuid string
+	}
+
+	XactBckRelayout struct {
+		xaction.XactBase
+		t      cluster.Target
+		bck    cmn.Bck
+		smap   *cluster.Smap
+		failed atomic.Int64 // objects that failed to get re-encoded
+	}
+)
+
+// interface guard
+var _ cluster.Xact = (*XactBckRelayout)(nil)
+
+func (*xactBckRelayoutProvider) New(args xreg.XactArgs) xreg.BucketEntry {
+	return &xactBckRelayoutProvider{t: args.T, uuid: args.UUID}
+}
+
+func (p *xactBckRelayoutProvider) Start(bck cmn.Bck) error {
+	p.xact = NewXactBckRelayout(bck, p.t, p.uuid)
+	return nil
+}
+func (*xactBckRelayoutProvider) Kind() string        { return cmn.ActECRelayout }
+func (p *xactBckRelayoutProvider) Get() cluster.Xact { return p.xact }
+func (p *xactBckRelayoutProvider) PreRenewHook(previousEntry xreg.BucketEntry) (keep bool, err error) {
+	prev := previousEntry.(*xactBckRelayoutProvider)
+	err = fmt.Errorf("%s is already running", prev.xact)
+	return
+}
+
+func NewXactBckRelayout(bck cmn.Bck, t cluster.Target, uuid string) *XactBckRelayout {
+	return &XactBckRelayout{
+		XactBase: *xaction.NewXactBaseBck(uuid, cmn.ActECRelayout, bck),
+		t:        t,
+		bck:      bck,
+		smap:     t.Sowner().Get(),
+	}
+}
+
+// RelayoutInterrupted returns true if the re-layout of the bucket has not
+// completed (e.g., because the target was restarted).
+func RelayoutInterrupted(bck cmn.Bck) bool { return fs.MarkerExists(relayoutMarker(bck)) }
+
+// RemoveRelayoutMarker is called when a bucket stops being erasure coded.
+func RemoveRelayoutMarker(bck cmn.Bck) { fs.RemoveMarker(relayoutMarker(bck)) }
+
+func relayoutMarker(bck cmn.Bck) string {
+	digest := xxhash.ChecksumString64S(bck.String(), cmn.MLCG32)
+	return fs.ECRelayoutMarker + "." + strconv.FormatUint(digest, 16)
+}
+
+func (r *XactBckRelayout) Run() (err error) {
+	bck := cluster.NewBckEmbed(r.bck)
+	if err := bck.Init(r.t.Bowner(), r.t.Snode()); err != nil {
+		r.Finish(err)
+		return err
+	}
+	if !bck.Props.EC.Enabled {
+		err = fmt.Errorf("bucket %q does not have EC enabled", r.bck.Name)
+		r.Finish(err)
+		return err
+	}
+	if err = fs.PersistMarker(relayoutMarker(r.bck)); err != nil {
+		r.Finish(err)
+		return err
+	}
+
+	jg := mpather.NewJoggerGroup(&mpather.JoggerGroupOpts{
+		T:        r.t,
+		Bck:      r.bck,
+		CTs:      []string{MetaType},
+		VisitCT:  r.visitCT,
+		Throttle: true,
+	})
+	jg.Run()
+
+	select {
+	case <-r.ChanAbort():
+		jg.Stop()
+		err = fmt.Errorf("%s aborted, exiting", r)
+	case <-jg.ListenFinished():
+		err = jg.Stop()
+	}
+	if err == nil {
+		if failed := r.failed.Load(); failed > 0 {
+			err = fmt.Errorf("%s: failed to re-encode %d object(s)", r, failed)
+		} else {
+			fs.RemoveMarker(relayoutMarker(r.bck))
+		}
+	}
+
+	r.Finish(err)
+	return
+}
+
+// returns the number of targets that store the object: the main one plus
+// a target per slice (replica)
+func layoutTargetCnt(isCopy bool, data, parity int) int {
+	if isCopy {
+		return parity + 1
+	}
+	return data + parity + 1
+}
+
+// Re-encodes the objects whose main replica is stored on this target.
+func (r *XactBckRelayout) visitCT(ct *cluster.CT, _ []byte) error {
+	md, err := LoadMetadata(ct.FQN())
+	if err != nil {
+		glog.Warningf("%s: %v", r, err)
+		return nil
+	}
+	if md.SliceID != 0 {
+		return nil
+	}
+	si, err := cluster.HrwTarget(ct.Bck().MakeUname(ct.ObjName()), r.smap)
+	if err != nil {
+		r.failed.Inc()
+		glog.Errorf("%s: %s", r, err)
+		return nil
+	}
+	if si.ID() != r.t.Snode().ID() {
+		return nil
+	}
+
+	lom := &cluster.LOM{ObjName: ct.ObjName()}
+	if err := lom.Init(r.bck); err != nil {
+		r.failed.Inc()
+		glog.Errorf("%s: %v", r, err)
+		return nil
+	}
+	if err := lom.Load(false); err != nil {
+		// missing main replica is EC scrub's responsibility
+		glog.Warningf("%s: %s: %v", r, lom, err)
+		return nil
+	}
+	var (
+		ecConf = &lom.Bprops().EC
+		isCopy = IsECCopy(lom.Size(), ecConf)
+	)
+	if md.IsCopy == isCopy && md.Parity == ecConf.ParitySlices && (isCopy || md.Data == ecConf.DataSlices) {
+		return nil // already re-encoded
+	}
+
+	// wait for the object to get re-encoded - the self-throttling
+	errCh := make(chan error, 1)
+	cb := func(_ *cluster.LOM, err error) { errCh <- err }
+	if err := ECM.encodeObject(lom, true /*relayout*/, cb); err != nil {
+		return fmt.Errorf("%s: failed to re-encode %s: %v", r, lom, err)
+	}
+	select {
+	case err = <-errCh:
+	case <-r.ChanAbort():
+		return fmt.Errorf("%s aborted", r)
+	}
+	if err != nil {
+		r.failed.Inc()
+		glog.Errorf("%s: failed to re-encode %s: %v", r, lom, err)
+		return nil
+	}
+
+	oldCnt := layoutTargetCnt(md.IsCopy, md.Data, md.Parity)
+	newCnt := layoutTargetCnt(isCopy, ecConf.DataSlices, ecConf.ParitySlices)
+	if oldCnt > newCnt {
+		if err := r.cleanupStale(lom, newCnt, oldCnt); err != nil {
+			glog.Errorf("%s: failed to cleanup %s: %v", r, lom, err)
+		}
+	}
+	r.ObjectsInc()
+	r.BytesAdd(lom.Size())
+	return nil
+}
+
+// Removes the slices (replicas) from the targets that do not belong to the
+// new layout. HRW target list of the new layout is a prefix of the old one,
+// so these targets do not receive anything while re-encoding.
+func (r *XactBckRelayout) cleanupStale(lom *cluster.LOM, newCnt, oldCnt int) error {
+	targets, err := cluster.HrwTargetList(lom.Uname(), r.smap, cmn.Min(oldCnt, r.smap.CountActiveTargets()))
+	if err != nil {
+		return err
+	}
+	if len(targets) <= newCnt {
+		return nil
+	}
+	var (
+		mm      = r.t.SmallMMSA()
+		request = ECM.RestoreBckPutXact(lom.Bck()).newIntraReq(reqDel, nil, lom.Bck()).NewPack(mm)
+		o       = transport.AllocSend()
+	)
+	o.Hdr = transport.ObjHdr{Bck: lom.Bck().Bck, ObjName: lom.ObjName, Opaque: request}
+	o.Callback = func(hdr transport.ObjHdr, _ io.ReadCloser, _ unsafe.Pointer, err error) {
+		mm.Free(hdr.Opaque)
+		if err != nil {
+			glog.Errorf("failed to send cleanup request for %s/%s: %v", hdr.Bck, hdr.ObjName, err)
+		}
+	}
+	return ECM.req().Send(o, nil, targets[newCnt:]...)
+}
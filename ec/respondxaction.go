@@ -118,6 +118,47 @@ func (r *XactRespond) removeObjAndMeta(bck *cluster.Bck, objName string) error {
 	return nil
 }
 
+// The object can be re-encoded after its bucket EC layout changes, so that
+// a target that used to store a replica now gets a slice (or vice versa).
+// Removes the local content of the other type that is no longer needed.
+// Called only for the data sent by ec-relayout; skipped while rebalance or
+// resilver is running (or interrupted) since HRW may point elsewhere.
+func (r *XactRespond) removeStaleCT(hdr transport.ObjHdr, isSlice bool) {
+	if reb := xreg.GetRebMarked(); reb.Xact != nil || reb.Interrupted {
+		return
+	}
+	if resilver := xreg.GetResilverMarked(); resilver.Xact != nil || resilver.Interrupted {
+		return
+	}
+	lom := &cluster.LOM{ObjName: hdr.ObjName}
+	if err := lom.Init(hdr.Bck); err != nil {
+		return
+	}
+	lom.Lock(true)
+	defer lom.Unlock(true)
+
+	ctType := SliceType
+	if isSlice {
+		// never remove the main replica
+		si, err := cluster.HrwTarget(lom.Uname(), r.t.Sowner().Get())
+		if err != nil || si.ID() == r.t.Snode().ID() {
+			return
+		}
+		ctType = fs.ObjectType
+	}
+	fqn, _, err := cluster.HrwFQN(lom.Bck(), ctType, hdr.ObjName)
+	if err != nil {
+		return
+	}
+	if err := cmn.RemoveFile(fqn); err != nil {
+		glog.Errorf("%s failed to remove stale %s %q: %v", r.t.Snode(), ctType, fqn, err)
+		return
+	}
+	if ctType == fs.ObjectType {
+		lom.Uncache()
+	}
+}
+
 // DispatchReq is responsible for handling request from other targets
 func (r *XactRespond) DispatchReq(iReq intraReq, bck *cluster.Bck, objName string) {
 	daemonID := iReq.sender
@@ -208,6 +249,9 @@ func (r *XactRespond) DispatchResp(iReq intraReq, hdr transport.ObjHdr, object i
 			glog.Error(err)
 			return
 		}
+		if iReq.relayout {
+			r.removeStaleCT(hdr, iReq.isSlice)
+		}
 		r.ObjectsInc()
 		r.BytesAdd(hdr.ObjAttrs.Size)
 	default:
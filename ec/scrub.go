@@ -0,0 +1,479 @@
+// Package ec provides erasure coding (EC) based data protection for AIStore.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package ec
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/atomic"
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/fs/mpather"
+	"github.com/NVIDIA/aistore/memsys"
+	"github.com/NVIDIA/aistore/xaction"
+	"github.com/NVIDIA/aistore/xaction/xreg"
+)
+
+// EC scrub walks the metafiles of the bucket and, for each object that the
+// target owns (the "main" target), makes sure that:
+// 1. The full replica exists locally and matches its checksum.
+// 2. All slices (or replicas) exist on the other targets. The targets are
+//    requested to verify the checksum of their slice (replica) before
+//    responding with the metadata - see `VerifyCT`. A slice (replica) whose
+//    verification times out is considered in place but is not verified.
+// Damaged slices and replicas are removed, except the main replica which is
+// kept until restored. Missing ones are then rebuilt:
+// * if the main replica is missing or damaged, the object is restored from the
+//   slices (which also rebuilds the missing slices) - the `getJogger` path,
+// * otherwise, the object is re-encoded - the `putJogger` path.
+
+type (
+	// Implements `xreg.BucketEntryProvider` and `xreg.BucketEntry` interface.
+	xactBckScrubProvider struct {
+		xreg.BaseBckEntry
+		xact *XactBckScrub
+
+		t    cluster.Target
+		uuid string
+	}
+
+	XactBckScrub struct {
+		xaction.XactBase
+		t      cluster.Target
+		bck    cmn.Bck
+		smap   *cluster.Smap
+		client *http.Client
+		wg     *sync.WaitGroup // to wait for EC finishes re-encoding all objects
+		// stats
+		healthy    atomic.Int64
+		repaired   atomic.Int64
+		degraded   atomic.Int64
+		lost       atomic.Int64
+		missing    atomic.Int64
+		unverified atomic.Int64
+	}
+
+	ScrubStatsExt struct {
+		Healthy    int64 `json:"healthy,string"`    // all slices (replicas) are in place
+		Repaired   int64 `json:"repaired,string"`   // missing or damaged slices (replicas) rebuilt
+		Degraded   int64 `json:"degraded,string"`   // failed to rebuild, but the object is still readable
+		Lost       int64 `json:"lost,string"`       // failed to restore the object (too many slices lost)
+		Missing    int64 `json:"missing,string"`    // total number of missing or damaged slices (replicas)
+		Unverified int64 `json:"unverified,string"` // slices (replicas) whose verification timed out
+	}
+
+	// fails reading once the deadline passes
+	deadlineReader struct {
+		r        io.Reader
+		deadline time.Time
+	}
+)
+
+// interface guard
+var _ cluster.Xact = (*XactBckScrub)(nil)
+
+func (*xactBckScrubProvider) New(args xreg.XactArgs) xreg.BucketEntry {
+	return &xactBckScrubProvider{t: args.T, uuid: args.UUID}
+}
+
+func (p *xactBckScrubProvider) Start(bck cmn.Bck) error {
+	p.xact = NewXactBckScrub(bck, p.t, p.uuid)
+	return nil
+}
+func (*xactBckScrubProvider) Kind() string        { return cmn.ActECScrub }
+func (p *xactBckScrubProvider) Get() cluster.Xact { return p.xact }
+func (p *xactBckScrubProvider) PreRenewHook(previousEntry xreg.BucketEntry) (keep bool, err error) {
+	prev := previousEntry.(*xactBckScrubProvider)
+	err = fmt.Errorf("%s is already running", prev.xact)
+	return
+}
+
+func NewXactBckScrub(bck cmn.Bck, t cluster.Target, uuid string) *XactBckScrub {
+	config := cmn.GCO.Get()
+	return &XactBckScrub{
+		XactBase: *xaction.NewXactBaseBck(uuid, cmn.ActECScrub, bck),
+		t:        t,
+		bck:      bck,
+		smap:     t.Sowner().Get(),
+		wg:       &sync.WaitGroup{},
+		client: cmn.NewClient(cmn.TransportArgs{
+			Timeout:    config.Client.TimeoutLong, // must exceed the verification timeout (see `VerifyCT`)
+			UseHTTPS:   config.Net.HTTP.UseHTTPS,
+			SkipVerify: config.Net.HTTP.SkipVerify,
+		}),
+	}
+}
+
+func (r *XactBckScrub) Run() (err error) {
+	bck := cluster.NewBckEmbed(r.bck)
+	if err := bck.Init(r.t.Bowner(), r.t.Snode()); err != nil {
+		r.Finish(err)
+		return err
+	}
+	if !bck.Props.EC.Enabled {
+		err = fmt.Errorf("bucket %q does not have EC enabled", r.bck.Name)
+		r.Finish(err)
+		return err
+	}
+	slab, err := r.t.MMSA().GetSlab(memsys.MaxPageSlabSize)
+	cmn.AssertNoErr(err)
+
+	jg := mpather.NewJoggerGroup(&mpather.JoggerGroupOpts{
+		T:        r.t,
+		Bck:      r.bck,
+		CTs:      []string{MetaType},
+		VisitCT:  r.visitCT,
+		Slab:     slab,
+		Throttle: true,
+	})
+	jg.Run()
+
+	select {
+	case <-r.ChanAbort():
+		jg.Stop()
+		err = fmt.Errorf("%s aborted, exiting", r)
+	case <-jg.ListenFinished():
+		err = jg.Stop()
+	}
+	r.wg.Wait() // wait for all objects to get re-encoded
+
+	r.Finish(err)
+	return
+}
+
+func (r *XactBckScrub) Stats() cluster.XactStats {
+	baseStats := r.XactBase.Stats().(*xaction.BaseXactStats)
+	return &xaction.BaseXactStatsExt{
+		BaseXactStats: *baseStats,
+		Ext: &ScrubStatsExt{
+			Healthy:    r.healthy.Load(),
+			Repaired:   r.repaired.Load(),
+			Degraded:   r.degraded.Load(),
+			Lost:       r.lost.Load(),
+			Missing:    r.missing.Load(),
+			Unverified: r.unverified.Load(),
+		},
+	}
+}
+
+// Scrubs the objects whose main replica is stored on this target. The slices
+// and replicas are verified upon the requests of the main target.
+func (r *XactBckScrub) visitCT(ct *cluster.CT, buf []byte) error {
+	md, err := LoadMetadata(ct.FQN())
+	if err != nil {
+		glog.Warningf("%s: %v", r, err)
+		return nil
+	}
+	if md.SliceID != 0 {
+		return nil
+	}
+	si, err := cluster.HrwTarget(ct.Bck().MakeUname(ct.ObjName()), r.smap)
+	if err != nil {
+		glog.Errorf("%s: %s", r, err)
+		return nil
+	}
+	if si.ID() != r.t.Snode().ID() {
+		return nil
+	}
+	r.scrub(ct.ObjName(), md, buf)
+	return nil
+}
+
+func (r *XactBckScrub) scrub(objName string, md *Metadata, buf []byte) {
+	lom := &cluster.LOM{ObjName: objName}
+	if err := lom.Init(r.bck); err != nil {
+		glog.Errorf("%s: %v", r, err)
+		return
+	}
+	r.ObjectsInc()
+	errMain := verifyCT(lom, md, buf, time.Time{} /*no deadline*/)
+	required := md.Parity
+	if !md.IsCopy {
+		required += md.Data
+	}
+	found, unverified := r.countCTs(lom, md)
+	if unverified > 0 {
+		r.unverified.Add(int64(unverified))
+	}
+	if found < required {
+		r.missing.Add(int64(required - found))
+	}
+
+	switch {
+	case errMain == nil && found >= required:
+		r.healthy.Inc()
+		r.BytesAdd(lom.Size())
+	case errMain != nil:
+		r.missing.Inc()
+		glog.Warningf("%s: %s: %v - restoring", r, lom, errMain)
+		if err := r.restore(lom, md, errMain, buf); err != nil {
+			r.lost.Inc()
+			glog.Errorf("%s: failed to restore %s: %v", r, lom, err)
+			return
+		}
+		r.repaired.Inc()
+		r.BytesAdd(lom.Size())
+	default:
+		glog.Warningf("%s: %s: found %d out of %d slices - re-encoding", r, lom, found, required)
+		// wg.Add increases a counter, and callback afterRebuild decreases it.
+		r.wg.Add(1)
+		if err := ECM.EncodeObject(lom, r.afterRebuild); err != nil {
+			r.wg.Done()
+			r.degraded.Inc()
+			glog.Errorf("%s: failed to re-encode %s: %v", r, lom, err)
+		}
+	}
+}
+
+// Restores the main replica from the slices (replicas). The damaged replica
+// is moved to the workfile for the duration and replaced only after the
+// restored content matches the checksum - otherwise, it gets moved back.
+func (r *XactBckScrub) restore(lom *cluster.LOM, md *Metadata, errMain error, buf []byte) (err error) {
+	if !errors.Is(errMain, &cmn.BadCksumError{}) {
+		return ECM.RestoreObject(lom) // missing
+	}
+	var (
+		cksum   = cmn.NewCksum(md.CksumType, md.ObjCksum)
+		workFQN = fs.CSM.GenContentFQN(lom.FQN, fs.WorkfileType, fs.WorkfileScrub)
+		copies  []string
+	)
+	lom.Lock(true)
+	if err = lom.Load(false); err != nil || !lom.Cksum().Equal(cksum) {
+		lom.Unlock(true)
+		return // removed or overwritten in the meantime
+	}
+	for fqn := range lom.GetCopies() {
+		if fqn != lom.FQN {
+			copies = append(copies, fqn)
+		}
+	}
+	if err = cmn.Rename(lom.FQN, workFQN); err != nil {
+		lom.Unlock(true)
+		return
+	}
+	lom.Uncache()
+	lom.Unlock(true)
+
+	var (
+		restored bool
+		elom     = &cluster.LOM{ObjName: lom.ObjName}
+	)
+	if err = elom.Init(lom.Bck().Bck); err == nil {
+		if err = ECM.RestoreObject(elom); err == nil {
+			restored = true
+			var rcksum *cmn.Cksum
+			if rcksum, err = checksumFile(lom.FQN, cksum.Type(), buf, time.Time{}); err == nil && !rcksum.Equal(cksum) {
+				err = cmn.NewBadDataCksumError(cksum, rcksum, lom.String()+" (restored)")
+			}
+		}
+	}
+
+	lom.Lock(true)
+	defer lom.Unlock(true)
+	if err != nil {
+		if _, errStat := os.Stat(lom.FQN); restored || os.IsNotExist(errStat) {
+			if errRn := cmn.Rename(workFQN, lom.FQN); errRn != nil {
+				glog.Errorf("nested error: %v => (rename %s => err: %v)", err, workFQN, errRn)
+			}
+			lom.Uncache()
+			return
+		}
+		err = nil // overwritten in the meantime
+	}
+	if errRm := cmn.RemoveFile(workFQN); errRm != nil {
+		glog.Errorf("%s: failed to remove %s: %v", r, workFQN, errRm)
+	}
+	// the restored object has no copies - remove the former ones
+	for _, fqn := range copies {
+		if errRm := cmn.RemoveFile(fqn); errRm != nil {
+			glog.Errorf("%s: failed to remove %s: %v", r, fqn, errRm)
+		}
+	}
+	return lom.Load(false)
+}
+
+func (r *XactBckScrub) afterRebuild(lom *cluster.LOM, err error) {
+	if err == nil {
+		r.repaired.Inc()
+		r.BytesAdd(lom.Size())
+	} else {
+		r.degraded.Inc()
+		glog.Errorf("%s: failed to re-encode %s: %v", r, lom, err)
+	}
+	r.wg.Done()
+}
+
+// Requests (verified) metadata from all other targets and returns the number
+// of the slices (or replicas) that are in place, including those whose
+// verification timed out - the latter are also counted separately.
+func (r *XactBckScrub) countCTs(lom *cluster.LOM, md *Metadata) (cnt, unverified int) {
+	var (
+		wg    = &sync.WaitGroup{}
+		mtx   = &sync.Mutex{}
+		found = make(map[string]int, len(r.smap.Tmap))
+		nover atomic.Int32
+	)
+	for _, si := range r.smap.Tmap {
+		if si.ID() == r.t.Snode().ID() {
+			continue
+		}
+		wg.Add(1)
+		go func(si *cluster.Snode) {
+			defer wg.Done()
+			rmd, err := requestECMeta(lom.Bck().Bck, lom.ObjName, si, r.client, true /*verify*/)
+			if errors.Is(err, ErrorVerifyTimeout) {
+				glog.Warningf("%s: %v", r, err)
+				nover.Inc()
+				// the slice (replica) exists; retrieve its metadata without verifying
+				rmd, err = requestECMeta(lom.Bck().Bck, lom.ObjName, si, r.client, false /*verify*/)
+			}
+			if err != nil {
+				if glog.FastV(4, glog.SmoduleEC) {
+					glog.Infof("%s: no EC meta %s from %s: %v", r, lom, si, err)
+				}
+				return
+			}
+			// obsolete slice (replica) is going to be overwritten
+			if rmd.ObjCksum != md.ObjCksum {
+				return
+			}
+			mtx.Lock()
+			found[si.ID()] = rmd.SliceID
+			mtx.Unlock()
+		}(si)
+	}
+	wg.Wait()
+
+	unverified = int(nover.Load())
+	if md.IsCopy {
+		return len(found), unverified
+	}
+	ids := make(map[int]struct{}, len(found))
+	for _, id := range found {
+		if id != 0 {
+			ids[id] = struct{}{}
+		}
+	}
+	return len(ids), unverified
+}
+
+// VerifyCT checks that the replica (or slice) described by the metadata
+// exists locally and matches its checksum. Missing or damaged replica (slice)
+// gets removed along with its metafile. The verification is bounded by the
+// client timeout and fails with `ErrorVerifyTimeout` when the latter expires.
+func VerifyCT(bck *cluster.Bck, objName string, md *Metadata) error {
+	lom := &cluster.LOM{ObjName: objName}
+	if err := lom.Init(bck.Bck); err != nil {
+		return err
+	}
+	var (
+		buf, slab = mm.Alloc()
+		deadline  = time.Now().Add(cmn.GCO.Get().Client.Timeout)
+		err       = verifyCT(lom, md, buf, deadline)
+	)
+	slab.Free(buf)
+	if err == nil || errors.Is(err, ErrorVerifyTimeout) {
+		return err
+	}
+	if md.SliceID == 0 && errors.Is(err, &cmn.BadCksumError{}) {
+		removeReplica(lom, md)
+	}
+	if fqn, _, errFQN := cluster.HrwFQN(lom.Bck(), MetaType, objName); errFQN == nil {
+		if errRm := cmn.RemoveFile(fqn); errRm != nil {
+			glog.Errorf("nested error: verify %s -> remove metafile: %v", lom, errRm)
+		}
+	}
+	return err
+}
+
+// Removes the damaged slice but keeps its metafile; the damaged replica is
+// left to the caller. The replica is checksummed under read lock; zero
+// deadline means no deadline.
+func verifyCT(lom *cluster.LOM, md *Metadata, buf []byte, deadline time.Time) (err error) {
+	if md.SliceID == 0 {
+		return verifyReplica(lom, buf, deadline)
+	}
+
+	fqn := lom.MpathInfo.MakePathFQN(lom.Bck().Bck, SliceType, lom.ObjName)
+	if md.CksumType == "" || md.CksumType == cmn.ChecksumNone || md.CksumValue == "" {
+		_, err = os.Stat(fqn)
+		return
+	}
+	cksum, err := checksumFile(fqn, md.CksumType, buf, deadline)
+	if err != nil {
+		return
+	}
+	if expected := cmn.NewCksum(md.CksumType, md.CksumValue); !cksum.Equal(expected) {
+		err = cmn.NewBadDataCksumError(expected, cksum, fmt.Sprintf("%s, slice %d", lom, md.SliceID))
+		if errRm := cmn.RemoveFile(fqn); errRm != nil {
+			glog.Errorf("nested error: verify %s -> remove slice: %v", lom, errRm)
+		}
+	}
+	return
+}
+
+func verifyReplica(lom *cluster.LOM, buf []byte, deadline time.Time) (err error) {
+	lom.Lock(false)
+	if err = lom.Load(false); err != nil {
+		lom.Unlock(false)
+		return
+	}
+	stored := lom.Cksum()
+	if stored.IsEmpty() {
+		lom.Unlock(false)
+		return
+	}
+	cksum, err := checksumFile(lom.FQN, stored.Type(), buf, deadline)
+	lom.Unlock(false)
+	if err == nil && !cksum.Equal(stored) {
+		err = cmn.NewBadDataCksumError(stored, cksum, lom.String())
+	}
+	return
+}
+
+// Removes the damaged replica unless it has been overwritten in the meantime.
+func removeReplica(lom *cluster.LOM, md *Metadata) {
+	lom.Lock(true)
+	defer lom.Unlock(true)
+	if err := lom.Load(false); err != nil || !lom.Cksum().Equal(cmn.NewCksum(md.CksumType, md.ObjCksum)) {
+		return
+	}
+	if err := lom.Remove(); err != nil {
+		glog.Errorf("nested error: verify %s -> remove replica: %v", lom, err)
+	}
+}
+
+func checksumFile(fqn, cksumType string, buf []byte, deadline time.Time) (*cmn.Cksum, error) {
+	file, err := os.Open(fqn)
+	if err != nil {
+		return nil, err
+	}
+	var r io.Reader = file
+	if !deadline.IsZero() {
+		r = &deadlineReader{r: file, deadline: deadline}
+	}
+	_, cksum, err := cmn.CopyAndChecksum(ioutil.Discard, r, buf, cksumType)
+	cmn.Close(file)
+	if err != nil {
+		return nil, err
+	}
+	return &cksum.Cksum, nil
+}
+
+func (dr *deadlineReader) Read(p []byte) (int, error) {
+	if time.Now().After(dr.deadline) {
+		return 0, ErrorVerifyTimeout
+	}
+	return dr.r.Read(p)
+}
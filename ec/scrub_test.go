@@ -0,0 +1,265 @@
+// Package ec provides erasure coding (EC) based data protection for AIStore.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package ec
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/xaction"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// target mock that knows its own node
+type scrubTargetMock struct {
+	*cluster.TargetMock
+	si *cluster.Snode
+}
+
+func (t *scrubTargetMock) Snode() *cluster.Snode { return t.si }
+
+var _ = Describe("EC scrub", func() {
+	const (
+		testDir        = "/tmp/ec-scrub-test/"
+		mpath          = testDir + "mpath"
+		testBucketName = "TEST_EC_SCRUB_BUCKET"
+		testObjectName = "ecscrubobj.ext"
+	)
+
+	_ = cmn.CreateDir(mpath)
+	fs.Init()
+	fs.DisableFsIDCheck()
+	_, _ = fs.Add(mpath, "daeID")
+	_ = fs.CSM.RegisterContentType(fs.ObjectType, &fs.ObjectContentResolver{})
+	_ = fs.CSM.RegisterContentType(fs.WorkfileType, &fs.WorkfileContentResolver{})
+	_ = fs.CSM.RegisterContentType(SliceType, &SliceSpec{})
+	_ = fs.CSM.RegisterContentType(MetaType, &MetaSpec{})
+
+	var (
+		props = &cmn.BucketProps{
+			Cksum: cmn.CksumConf{Type: cmn.ChecksumXXHash},
+			EC:    cmn.ECConf{Enabled: true, DataSlices: 2, ParitySlices: 1},
+		}
+		bck     = cmn.Bck{Name: testBucketName, Provider: cmn.ProviderAIS, Ns: cmn.NsGlobal, Props: props}
+		bmdMock = cluster.NewBaseBownerMock(&cluster.Bck{Bck: bck})
+		mi      = fs.MountpathInfo{Path: mpath}
+		objFQN  = mi.MakePathFQN(bck, fs.ObjectType, testObjectName)
+		sliFQN  = mi.MakePathFQN(bck, SliceType, testObjectName)
+		content = []byte(strings.Repeat("0123456789abcdef", 1024))
+
+		buf = make([]byte, 32*cmn.KiB)
+		lom *cluster.LOM
+	)
+
+	newLom := func() *cluster.LOM {
+		lom := &cluster.LOM{ObjName: testObjectName}
+		Expect(lom.Init(bck)).NotTo(HaveOccurred())
+		lom.Uncache()
+		return lom
+	}
+	cksumOf := func(fqn string) *cmn.Cksum {
+		cksum, err := checksumFile(fqn, cmn.ChecksumXXHash, buf, time.Time{})
+		Expect(err).NotTo(HaveOccurred())
+		return cksum
+	}
+
+	BeforeEach(func() {
+		_ = cmn.CreateDir(mpath)
+		cluster.NewTargetMock(bmdMock) // initializes LOM cache and BMD owner
+
+		Expect(cmn.CreateDir(filepath.Dir(objFQN))).NotTo(HaveOccurred())
+		Expect(ioutil.WriteFile(objFQN, content, 0o644)).NotTo(HaveOccurred())
+		lom = newLom()
+		lom.SetSize(int64(len(content)))
+		lom.SetCksum(cksumOf(objFQN))
+		Expect(lom.Persist()).NotTo(HaveOccurred())
+		lom.Uncache()
+	})
+
+	AfterEach(func() {
+		_ = os.RemoveAll(testDir)
+	})
+
+	Describe("verifyCT", func() {
+		var md *Metadata
+
+		BeforeEach(func() {
+			_, cksumValue := lom.Cksum().Get()
+			md = &Metadata{ObjCksum: cksumValue, CksumType: cmn.ChecksumXXHash, Data: 2, Parity: 1}
+		})
+
+		It("should verify intact replica", func() {
+			Expect(verifyCT(newLom(), md, buf, time.Time{})).NotTo(HaveOccurred())
+		})
+
+		It("should report damaged replica but keep it", func() {
+			corruptFile(objFQN)
+
+			err := verifyCT(newLom(), md, buf, time.Time{})
+			Expect(errors.Is(err, &cmn.BadCksumError{})).To(BeTrue())
+			Expect(objFQN).To(BeARegularFile())
+		})
+
+		It("should report missing replica", func() {
+			Expect(os.Remove(objFQN)).NotTo(HaveOccurred())
+			Expect(verifyCT(newLom(), md, buf, time.Time{})).To(HaveOccurred())
+		})
+
+		It("should time out", func() {
+			err := verifyCT(newLom(), md, buf, time.Now().Add(-time.Second))
+			Expect(errors.Is(err, ErrorVerifyTimeout)).To(BeTrue())
+		})
+
+		Context("slice", func() {
+			BeforeEach(func() {
+				Expect(cmn.CreateDir(filepath.Dir(sliFQN))).NotTo(HaveOccurred())
+				Expect(ioutil.WriteFile(sliFQN, content[:len(content)/2], 0o644)).NotTo(HaveOccurred())
+				md.SliceID = 1
+				_, md.CksumValue = cksumOf(sliFQN).Get()
+			})
+
+			It("should verify intact slice", func() {
+				Expect(verifyCT(newLom(), md, buf, time.Time{})).NotTo(HaveOccurred())
+				Expect(sliFQN).To(BeARegularFile())
+			})
+
+			It("should remove damaged slice", func() {
+				corruptFile(sliFQN)
+
+				err := verifyCT(newLom(), md, buf, time.Time{})
+				Expect(errors.Is(err, &cmn.BadCksumError{})).To(BeTrue())
+				Expect(sliFQN).NotTo(BeAnExistingFile())
+			})
+
+			It("should only check existence when checksum is missing", func() {
+				md.CksumType, md.CksumValue = "", ""
+				corruptFile(sliFQN)
+				Expect(verifyCT(newLom(), md, buf, time.Time{})).NotTo(HaveOccurred())
+
+				Expect(os.Remove(sliFQN)).NotTo(HaveOccurred())
+				Expect(verifyCT(newLom(), md, buf, time.Time{})).To(HaveOccurred())
+			})
+		})
+	})
+
+	Describe("countCTs", func() {
+		const objCksum = "obj-cksum"
+
+		type remoteCT struct {
+			md       *Metadata // nil - no slice (replica)
+			overtime bool      // verification times out
+		}
+		var (
+			servers []*httptest.Server
+			md      = &Metadata{ObjCksum: objCksum, Data: 2, Parity: 1}
+		)
+
+		newXact := func(cts ...remoteCT) *XactBckScrub {
+			self := &cluster.Snode{DaemonID: "self"}
+			smap := &cluster.Smap{Tmap: cluster.NodeMap{self.ID(): self}}
+			for i, ct := range cts {
+				ct := ct
+				srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					switch {
+					case ct.md == nil:
+						w.WriteHeader(http.StatusNotFound)
+					case ct.overtime && cmn.IsParseBool(r.URL.Query().Get(cmn.URLParamECVerify)):
+						w.WriteHeader(http.StatusServiceUnavailable)
+					default:
+						w.Write(cmn.MustMarshal(ct.md))
+					}
+				}))
+				servers = append(servers, srv)
+				si := &cluster.Snode{DaemonID: "t" + string(rune('0'+i))}
+				si.IntraDataNet.DirectURL = srv.URL
+				smap.Tmap[si.ID()] = si
+			}
+			return &XactBckScrub{
+				XactBase: *xaction.NewXactBaseBck("scrub-id", cmn.ActECScrub, bck),
+				t:        &scrubTargetMock{TargetMock: cluster.NewTargetMock(bmdMock), si: self},
+				bck:      bck,
+				smap:     smap,
+				client:   &http.Client{},
+				wg:       &sync.WaitGroup{},
+			}
+		}
+		slice := func(id int, cksum string) *Metadata {
+			return &Metadata{ObjCksum: cksum, SliceID: id, Data: 2, Parity: 1}
+		}
+
+		AfterEach(func() {
+			for _, srv := range servers {
+				srv.Close()
+			}
+			servers = servers[:0]
+		})
+
+		It("should count distinct slices", func() {
+			r := newXact(
+				remoteCT{md: slice(1, objCksum)},
+				remoteCT{md: slice(2, objCksum)},
+				remoteCT{md: slice(2, objCksum)}, // duplicate
+				remoteCT{md: slice(0, objCksum)}, // replica
+			)
+			cnt, unverified := r.countCTs(lom, md)
+			Expect(cnt).To(Equal(2))
+			Expect(unverified).To(BeZero())
+		})
+
+		It("should skip missing and obsolete slices", func() {
+			r := newXact(
+				remoteCT{md: slice(1, objCksum)},
+				remoteCT{md: slice(2, "obsolete")},
+				remoteCT{},
+			)
+			cnt, _ := r.countCTs(lom, md)
+			Expect(cnt).To(Equal(1))
+		})
+
+		It("should count unverified slices as found", func() {
+			r := newXact(
+				remoteCT{md: slice(1, objCksum)},
+				remoteCT{md: slice(2, objCksum), overtime: true},
+				remoteCT{md: slice(3, objCksum)},
+			)
+			cnt, unverified := r.countCTs(lom, md)
+			Expect(cnt).To(Equal(3))
+			Expect(unverified).To(Equal(1))
+		})
+
+		It("should count replicas", func() {
+			rmd := &Metadata{ObjCksum: objCksum, IsCopy: true, Parity: 2}
+			r := newXact(remoteCT{md: rmd}, remoteCT{md: rmd}, remoteCT{})
+			cnt, _ := r.countCTs(lom, &Metadata{ObjCksum: objCksum, IsCopy: true, Parity: 2})
+			Expect(cnt).To(Equal(2))
+		})
+	})
+})
+
+// flips a few bytes in the middle of the file, keeping its size
+func corruptFile(fqn string) {
+	file, err := os.OpenFile(fqn, os.O_RDWR, 0)
+	Expect(err).ShouldNot(HaveOccurred())
+	b := make([]byte, 16)
+	_, err = file.ReadAt(b, 100)
+	Expect(err).ShouldNot(HaveOccurred())
+	for i := range b {
+		b[i] ^= 0xff
+	}
+	_, err = file.WriteAt(b, 100)
+	Expect(err).ShouldNot(HaveOccurred())
+	Expect(file.Close()).ShouldNot(HaveOccurred())
+}
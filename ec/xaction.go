@@ -143,6 +143,8 @@ func (r *xactECBase) newSliceResponse(md *Metadata, attrs *transport.ObjectAttrs
 	attrs.Version = md.ObjVersion
 	attrs.CksumType = md.CksumType
 	attrs.CksumValue = md.CksumValue
+	attrs.UserMD = md.UserMD
+	attrs.Tags = md.Tags
 
 	stat, err := os.Stat(fqn)
 	if err != nil {
@@ -179,6 +181,8 @@ func (r *xactECBase) newReplicaResponse(attrs *transport.ObjectAttrs, bck *clust
 	attrs.Size = lom.Size()
 	attrs.Version = lom.Version()
 	attrs.Atime = lom.AtimeUnix()
+	attrs.UserMD = lom.UserMD()
+	attrs.Tags = lom.Tags()
 	if lom.Cksum() != nil {
 		attrs.CksumType, attrs.CksumValue = lom.Cksum().Get()
 	}
@@ -357,6 +361,7 @@ func (r *xactECBase) writeRemote(daemonIDs []string, lom *cluster.LOM, src *data
 	}
 	req := r.newIntraReq(src.reqType, src.metadata, lom.Bck())
 	req.isSlice = src.isSlice
+	req.relayout = src.relayout
 
 	mm := r.t.SmallMMSA()
 	putData := req.NewPack(mm)
@@ -364,6 +369,8 @@ func (r *xactECBase) writeRemote(daemonIDs []string, lom *cluster.LOM, src *data
 		Size:    src.size,
 		Version: lom.Version(),
 		Atime:   lom.AtimeUnix(),
+		UserMD:  lom.UserMD(),
+		Tags:    lom.Tags(),
 	}
 	if src.metadata != nil && src.metadata.SliceID != 0 {
 		// for a slice read everything from slice's metadata
@@ -18,6 +18,7 @@ type (
 		Spec        []byte           `json:"spec"`
 		CommType    string           `json:"communication_type"`
 		WaitTimeout cmn.DurationJSON `json:"wait_timeout"`
+		Cache       bool             `json:"cache"` // cache transformed objects (see `cacheComm`)
 	}
 
 	BuildMsg struct {
@@ -26,6 +27,14 @@ type (
 		Deps        []byte           `json:"dependencies"`
 		Runtime     string           `json:"runtime"`
 		WaitTimeout cmn.DurationJSON `json:"wait_timeout"`
+		Cache       bool             `json:"cache"`
+	}
+
+	// PipelineMsg defines the pipeline of the initialized ETLs (stages) run
+	// in sequence.
+	PipelineMsg struct {
+		ID     string   `json:"id"`
+		Stages []string `json:"stages"` // IDs of the ETLs
 	}
 
 	Info struct {
@@ -67,6 +76,18 @@ func (m BuildMsg) Validate() error {
 	return nil
 }
 
+func (m PipelineMsg) Validate() error {
+	if len(m.Stages) < 2 {
+		return fmt.Errorf("pipeline requires at least 2 stages, got %d", len(m.Stages))
+	}
+	for _, id := range m.Stages {
+		if id == "" {
+			return ErrMissingUUID
+		}
+	}
+	return nil
+}
+
 func (p PodsLogsMsg) Len() int           { return len(p) }
 func (p PodsLogsMsg) Less(i, j int) bool { return p[i].TargetID < p[j].TargetID }
 func (p PodsLogsMsg) Swap(i, j int)      { p[i], p[j] = p[j], p[i] }
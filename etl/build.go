@@ -14,6 +14,11 @@ import (
 )
 
 func Build(t cluster.Target, msg BuildMsg) error {
+	// Runtimes run the code in K8s pods.
+	if err := k8s.Detect(); err != nil {
+		return err
+	}
+
 	// Initialize runtime.
 	r, exists := runtime.Runtimes[msg.Runtime]
 	cmn.Assert(exists) // Runtime should be checked in proxy during validation.
@@ -33,6 +38,7 @@ func Build(t cluster.Target, msg BuildMsg) error {
 		Spec:        []byte(podSpec),
 		CommType:    PushCommType,
 		WaitTimeout: msg.WaitTimeout,
+		Cache:       msg.Cache,
 	}, StartOpts{Env: map[string]string{
 		r.CodeEnvName(): string(msg.Code),
 		r.DepsEnvName(): string(msg.Deps),
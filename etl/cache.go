@@ -0,0 +1,273 @@
+// Package etl provides utilities to initialize and use transformation pods.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package etl
+
+import (
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/memsys"
+)
+
+// ETL cache stores the results of the transformations on the target, next to
+// the source objects, as `fs.ETLCacheType` content (one file per object and
+// ETL). Each cached result is tagged with the checksum (or, if not available,
+// the version) of the source object it was produced from - the result is used
+// only as long as the tag matches the source object, otherwise it is replaced.
+// When the ETL is stopped all its cached results are removed. In the meantime,
+// cached results are subject to LRU eviction.
+//
+// Cache is optional and enabled on ETL init (see `InitMsg.Cache`).
+
+// The tag of the source object is stored as an extended attribute of the result.
+const cacheXattr = "user.ais.etl"
+
+type (
+	cacheComm struct {
+		Communicator
+		t  cluster.Target
+		id string
+	}
+
+	// cacheWriter saves the transformation result as it is read.
+	cacheWriter struct {
+		r       io.ReadCloser
+		lom     *cluster.LOM
+		tag     string
+		fqn     string // cached result
+		workFQN string
+		fh      *os.File
+	}
+)
+
+// interface guard
+var _ Communicator = (*cacheComm)(nil)
+
+func newCacheComm(t cluster.Target, id string, c Communicator) *cacheComm {
+	return &cacheComm{Communicator: c, t: t, id: id}
+}
+
+// cacheTag returns the tag identifying the content of the (loaded) object,
+// empty if the content cannot be identified.
+func cacheTag(lom *cluster.LOM) string {
+	if cksum := lom.Cksum(); cksum != nil && cksum.Type() != cmn.ChecksumNone && cksum.Value() != "" {
+		return cksum.Type() + ":" + cksum.Value()
+	}
+	if lom.Version() != "" {
+		return "v:" + lom.Version()
+	}
+	return ""
+}
+
+func (cc *cacheComm) Do(w http.ResponseWriter, _ *http.Request, bck *cluster.Bck, objName string) error {
+	r, size, err := cc.Get(bck, objName)
+	if err != nil {
+		return err
+	}
+	return writeTransformed(w, cc.t.MMSA(), r, size)
+}
+
+func (cc *cacheComm) Get(bck *cluster.Bck, objName string) (io.ReadCloser, int64, error) {
+	lom := &cluster.LOM{ObjName: objName}
+	if err := lom.Init(bck.Bck); err != nil {
+		return nil, 0, err
+	}
+	lom.Lock(false)
+	if err := lom.Load(); err != nil {
+		lom.Unlock(false)
+		// Not present (yet) - nothing to look up.
+		return cc.Communicator.Get(bck, objName)
+	}
+	tag := cacheTag(lom)
+	lom.Unlock(false)
+	if tag == "" {
+		return cc.Communicator.Get(bck, objName)
+	}
+
+	fqn := fs.CSM.GenContentParsedFQN(lom.ParsedFQN(), fs.ETLCacheType, cc.id)
+	if fh, size, ok := cc.lookup(fqn, tag); ok {
+		return fh, size, nil
+	}
+	r, size, err := cc.Communicator.Get(bck, objName)
+	if err != nil {
+		return nil, 0, err
+	}
+	return newCacheWriter(r, lom, tag, fqn), size, nil
+}
+
+// lookup opens the cached result if it was produced from the current content
+// of the object.
+func (cc *cacheComm) lookup(fqn, tag string) (*os.File, int64, bool) {
+	b, err := fs.GetXattr(fqn, cacheXattr)
+	if err != nil || string(b) != tag {
+		return nil, 0, false
+	}
+	fh, err := os.Open(fqn)
+	if err != nil {
+		return nil, 0, false
+	}
+	finfo, err := fh.Stat()
+	if err != nil {
+		cmn.Close(fh)
+		return nil, 0, false
+	}
+	// LRU evicts the least recently used results first.
+	now := time.Now()
+	if err := os.Chtimes(fqn, now, now); err != nil {
+		glog.Warningf("%s: failed to update access time of %q: %v", cc, fqn, err)
+	}
+	return fh, finfo.Size(), true
+}
+
+// evict removes all cached results of the ETL.
+func (cc *cacheComm) evict() {
+	var (
+		evicted           int
+		availablePaths, _ = fs.Get()
+	)
+	for _, mpathInfo := range availablePaths {
+		for _, provider := range cmn.Providers.Keys() {
+			opts := &fs.Options{
+				Mpath: mpathInfo,
+				Bck:   cmn.Bck{Provider: provider, Ns: cmn.NsGlobal},
+			}
+			bcks, err := fs.AllMpathBcks(opts)
+			if err != nil {
+				continue
+			}
+			for _, bck := range bcks {
+				opts := &fs.Options{
+					Mpath: mpathInfo,
+					Bck:   bck,
+					CTs:   []string{fs.ETLCacheType},
+					Callback: func(fqn string, de fs.DirEntry) error {
+						if de.IsDir() {
+							return nil
+						}
+						if _, id, ok := fs.ParseETLCache(filepath.Base(fqn)); !ok || id != cc.id {
+							return nil
+						}
+						if err := cmn.RemoveFile(fqn); err != nil {
+							glog.Error(err)
+						} else {
+							evicted++
+						}
+						return nil
+					},
+				}
+				if err := fs.Walk(opts); err != nil && !os.IsNotExist(err) {
+					glog.Errorf("%s: failed to remove cached results from %s: %v", cc, mpathInfo, err)
+				}
+			}
+		}
+	}
+	if evicted > 0 {
+		glog.Infof("%s: removed %d cached result(s)", cc, evicted)
+	}
+}
+
+/////////////////
+// cacheWriter //
+/////////////////
+
+func newCacheWriter(r io.ReadCloser, lom *cluster.LOM, tag, fqn string) *cacheWriter {
+	cw := &cacheWriter{r: r, lom: lom, tag: tag, fqn: fqn}
+	cw.workFQN = fs.CSM.GenContentParsedFQN(lom.ParsedFQN(), fs.WorkfileType, fs.WorkfileETL)
+	fh, err := cmn.CreateFile(cw.workFQN)
+	if err != nil {
+		glog.Errorf("failed to cache transformed %s: %v", lom, err)
+		return cw
+	}
+	cw.fh = fh
+	return cw
+}
+
+func (cw *cacheWriter) Read(b []byte) (n int, err error) {
+	n, err = cw.r.Read(b)
+	if cw.fh == nil {
+		return
+	}
+	if n > 0 {
+		if _, errW := cw.fh.Write(b[:n]); errW != nil {
+			cw.discard(errW)
+			return
+		}
+	}
+	if err == io.EOF {
+		cw.finalize()
+	} else if err != nil {
+		cw.discard(err)
+	}
+	return
+}
+
+func (cw *cacheWriter) Close() error {
+	if cw.fh != nil {
+		// Not read till the end - nothing to cache.
+		cw.discard(nil)
+	}
+	return cw.r.Close()
+}
+
+func (cw *cacheWriter) discard(err error) {
+	if err != nil {
+		glog.Errorf("failed to cache transformed %s: %v", cw.lom, err)
+	}
+	if cw.fh != nil {
+		cmn.Close(cw.fh)
+		cw.fh = nil
+	}
+	if err := cmn.RemoveFile(cw.workFQN); err != nil {
+		glog.Error(err)
+	}
+}
+
+// finalize makes the result visible, unless the object has changed in the
+// meantime (in which case the result could be produced from either content).
+func (cw *cacheWriter) finalize() {
+	err := cw.fh.Close()
+	cw.fh = nil
+	if err != nil {
+		cw.discard(err)
+		return
+	}
+	if err := fs.SetXattr(cw.workFQN, cacheXattr, []byte(cw.tag)); err != nil {
+		cw.discard(err)
+		return
+	}
+	cw.lom.Lock(false)
+	defer cw.lom.Unlock(false)
+	if err := cw.lom.Load(false); err != nil || cacheTag(cw.lom) != cw.tag {
+		cw.discard(nil)
+		return
+	}
+	if err := cmn.Rename(cw.workFQN, cw.fqn); err != nil {
+		cw.discard(err)
+	}
+}
+
+// writeTransformed writes the result of the transformation to the response.
+func writeTransformed(w http.ResponseWriter, mm *memsys.MMSA, r io.ReadCloser, size int64) error {
+	if size >= 0 {
+		w.Header().Set(cmn.HeaderContentLength, strconv.FormatInt(size, 10))
+	} else {
+		size = memsys.DefaultBufSize
+	}
+	buf, slab := mm.Alloc(size)
+	_, err := io.CopyBuffer(w, r, buf)
+	slab.Free(buf)
+	if errClose := r.Close(); err == nil {
+		err = errClose
+	}
+	return err
+}
@@ -7,12 +7,15 @@ package etl
 import (
 	"crypto/rand"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"time"
 
+	"github.com/NVIDIA/aistore/3rdparty/atomic"
 	"github.com/NVIDIA/aistore/cluster"
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/fs"
@@ -32,6 +35,7 @@ var _ = Describe("CommunicatorTest", func() {
 
 		dataSize      = int64(cmn.MiB * 50)
 		transformData = make([]byte, dataSize)
+		transformCnt  atomic.Int32
 
 		bck        = cmn.Bck{Name: "commBck", Provider: cmn.ProviderAIS, Ns: cmn.NsGlobal}
 		objName    = "commObj"
@@ -73,7 +77,9 @@ var _ = Describe("CommunicatorTest", func() {
 		Expect(err).NotTo(HaveOccurred())
 
 		// Initialize the HTTP servers.
+		transformCnt.Store(0)
 		transformerServer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			transformCnt.Inc()
 			_, err := w.Write(transformData)
 			Expect(err).NotTo(HaveOccurred())
 		}))
@@ -120,8 +126,296 @@ var _ = Describe("CommunicatorTest", func() {
 			Expect(b).To(Equal(transformData))
 		})
 	}
+
+	Context("io://", func() {
+		makeIOCommunicator := func(spec *ProcessSpec) {
+			spec.Kind, spec.Name = ProcessKind, "somename"
+			proc := newProcess(spec, "somename-target", processLoopbackHost, nil)
+			proc.prepare()
+			comm = makeCommunicator(commArgs{t: tMock, proc: proc, commType: IOCommType})
+		}
+
+		readObject := func() []byte {
+			lom := &cluster.LOM{ObjName: objName}
+			Expect(lom.Init(clusterBck.Bck)).NotTo(HaveOccurred())
+			b, err := ioutil.ReadFile(lom.GetFQN())
+			Expect(err).NotTo(HaveOccurred())
+			return b
+		}
+
+		It("should perform transformation", func() {
+			makeIOCommunicator(&ProcessSpec{Command: []string{"cat"}})
+			resp, err := http.Get(proxyServer.URL)
+			Expect(err).NotTo(HaveOccurred())
+			defer resp.Body.Close()
+
+			b, err := ioutil.ReadAll(resp.Body)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(b).To(Equal(readObject()))
+		})
+
+		It("should perform offline transformation", func() {
+			makeIOCommunicator(&ProcessSpec{Command: []string{"cat"}, Workers: 1})
+			r, size, err := comm.Get(clusterBck, objName)
+			Expect(err).NotTo(HaveOccurred())
+			defer r.Close()
+
+			Expect(size).To(Equal(dataSize))
+			b, err := ioutil.ReadAll(r)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(b).To(Equal(readObject()))
+		})
+
+		It("should not send partial result when transformer fails", func() {
+			makeIOCommunicator(&ProcessSpec{Command: []string{"sh", "-c", "cat; echo failure >&2; exit 1"}})
+			w := httptest.NewRecorder()
+			err := comm.Do(w, nil, clusterBck, objName)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("failure"))
+			Expect(w.Body.Len()).To(BeZero())
+		})
+
+		It("should fail when transformer fails", func() {
+			makeIOCommunicator(&ProcessSpec{Command: []string{"sh", "-c", "echo failure >&2; exit 1"}})
+			_, _, err := comm.Get(clusterBck, objName)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("failure"))
+		})
+
+		It("should run pipeline", func() {
+			makeIOCommunicator(&ProcessSpec{Command: []string{"cat"}})
+			Expect(reg.put("stage-1", comm)).NotTo(HaveOccurred())
+			defer reg.removeByUUID("stage-1")
+			makeIOCommunicator(&ProcessSpec{Command: []string{"cat"}})
+			Expect(reg.put("stage-2", comm)).NotTo(HaveOccurred())
+			defer reg.removeByUUID("stage-2")
+
+			comm = &pipelineComm{t: tMock, id: "pipeline", stages: []string{"stage-1", "stage-2"}}
+			resp, err := http.Get(proxyServer.URL)
+			Expect(err).NotTo(HaveOccurred())
+			defer resp.Body.Close()
+
+			b, err := ioutil.ReadAll(resp.Body)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(b).To(Equal(readObject()))
+		})
+
+		It("should run pipeline with push stage", func() {
+			makeIOCommunicator(&ProcessSpec{Command: []string{"cat"}})
+			Expect(reg.put("stage-1", comm)).NotTo(HaveOccurred())
+			defer reg.removeByUUID("stage-1")
+			pod := &corev1.Pod{}
+			pod.SetName("somename")
+			comm = makeCommunicator(commArgs{t: tMock, pod: pod, commType: PushCommType, transformerURL: transformerServer.URL})
+			Expect(reg.put("stage-2", comm)).NotTo(HaveOccurred())
+			defer reg.removeByUUID("stage-2")
+
+			comm = &pipelineComm{t: tMock, id: "pipeline", stages: []string{"stage-1", "stage-2"}}
+			r, _, err := comm.Get(clusterBck, objName)
+			Expect(err).NotTo(HaveOccurred())
+			b, err := ioutil.ReadAll(r)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(b).To(Equal(transformData))
+			Expect(r.Close()).NotTo(HaveOccurred())
+		})
+
+		It("should fail pipeline when push stage fails", func() {
+			failServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				http.Error(w, "failure", http.StatusInternalServerError)
+			}))
+			defer failServer.Close()
+			makeIOCommunicator(&ProcessSpec{Command: []string{"cat"}})
+			Expect(reg.put("stage-1", comm)).NotTo(HaveOccurred())
+			defer reg.removeByUUID("stage-1")
+			pod := &corev1.Pod{}
+			pod.SetName("somename")
+			comm = makeCommunicator(commArgs{t: tMock, pod: pod, commType: PushCommType, transformerURL: failServer.URL})
+			Expect(reg.put("stage-2", comm)).NotTo(HaveOccurred())
+			defer reg.removeByUUID("stage-2")
+
+			comm = &pipelineComm{t: tMock, id: "pipeline", stages: []string{"stage-1", "stage-2"}}
+			r, _, err := comm.Get(clusterBck, objName)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("failure"))
+			Expect(err.Error()).To(ContainSubstring("stage=2"))
+			Expect(r).To(BeNil())
+		})
+
+		It("should attribute error to the stage of pipeline", func() {
+			makeIOCommunicator(&ProcessSpec{Command: []string{"cat"}})
+			Expect(reg.put("stage-1", comm)).NotTo(HaveOccurred())
+			defer reg.removeByUUID("stage-1")
+			makeIOCommunicator(&ProcessSpec{Command: []string{"sh", "-c", "cat >/dev/null; echo failure >&2; exit 1"}})
+			Expect(reg.put("stage-2", comm)).NotTo(HaveOccurred())
+			defer reg.removeByUUID("stage-2")
+
+			comm = &pipelineComm{t: tMock, id: "pipeline", stages: []string{"stage-1", "stage-2", "stage-3"}}
+			r, _, err := comm.Get(clusterBck, objName)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring(`uuid="stage-3"`))
+			Expect(err.Error()).To(ContainSubstring("stage=3"))
+			Expect(r).To(BeNil())
+
+			comm = &pipelineComm{t: tMock, id: "pipeline", stages: []string{"stage-1", "stage-2"}}
+			r, _, err = comm.Get(clusterBck, objName)
+			Expect(err).NotTo(HaveOccurred())
+			_, err = ioutil.ReadAll(r)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("failure"))
+			Expect(err.Error()).To(ContainSubstring(`uuid="stage-2"`))
+			Expect(err.Error()).To(ContainSubstring("stage=2"))
+			Expect(r.Close()).NotTo(HaveOccurred())
+		})
+
+		It("should fail when transformation times out", func() {
+			makeIOCommunicator(&ProcessSpec{Command: []string{"sleep", "10"}, ObjectTimeout: "100ms"})
+			_, _, err := comm.Get(clusterBck, objName)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("timed out"))
+		})
+	})
+
+	Context("cache", func() {
+		var cc *cacheComm
+
+		BeforeEach(func() {
+			_ = fs.CSM.RegisterContentType(fs.ObjectType, &fs.ObjectContentResolver{})
+			_ = fs.CSM.RegisterContentType(fs.WorkfileType, &fs.WorkfileContentResolver{})
+			_ = fs.CSM.RegisterContentType(fs.ETLCacheType, &fs.ETLCacheContentResolver{})
+
+			pod := &corev1.Pod{}
+			pod.SetName("somename")
+			cc = newCacheComm(tMock, "etl-id", makeCommunicator(commArgs{
+				t:              tMock,
+				pod:            pod,
+				commType:       PushCommType,
+				transformerURL: transformerServer.URL,
+			}))
+			comm = cc
+			setCksum("1")
+		})
+
+		get := func() []byte {
+			r, size, err := comm.Get(clusterBck, objName)
+			Expect(err).NotTo(HaveOccurred())
+			b, err := ioutil.ReadAll(r)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(r.Close()).NotTo(HaveOccurred())
+			if size >= 0 {
+				Expect(int64(len(b))).To(Equal(size))
+			}
+			return b
+		}
+
+		cacheFQN := func() string {
+			lom := &cluster.LOM{ObjName: objName}
+			Expect(lom.Init(clusterBck.Bck)).NotTo(HaveOccurred())
+			return fs.CSM.GenContentParsedFQN(lom.ParsedFQN(), fs.ETLCacheType, cc.id)
+		}
+
+		It("should transform object once", func() {
+			Expect(get()).To(Equal(transformData))
+			Expect(get()).To(Equal(transformData))
+			Expect(transformCnt.Load()).To(BeEquivalentTo(1))
+
+			resp, err := http.Get(proxyServer.URL)
+			Expect(err).NotTo(HaveOccurred())
+			defer resp.Body.Close()
+			b, err := ioutil.ReadAll(resp.Body)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(b).To(Equal(transformData))
+			Expect(transformCnt.Load()).To(BeEquivalentTo(1))
+		})
+
+		It("should transform object again when it changes", func() {
+			Expect(get()).To(Equal(transformData))
+			setCksum("2")
+			Expect(get()).To(Equal(transformData))
+			Expect(get()).To(Equal(transformData))
+			Expect(transformCnt.Load()).To(BeEquivalentTo(2))
+		})
+
+		It("should not cache partially read result", func() {
+			spec := &ProcessSpec{Kind: ProcessKind, Name: "somename", Command: []string{"cat"}}
+			proc := newProcess(spec, "somename-target", processLoopbackHost, nil)
+			proc.prepare()
+			cc.Communicator = makeCommunicator(commArgs{t: tMock, proc: proc, commType: IOCommType})
+
+			r, _, err := comm.Get(clusterBck, objName)
+			Expect(err).NotTo(HaveOccurred())
+			_, err = r.Read(make([]byte, cmn.KiB))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(r.Close()).NotTo(HaveOccurred())
+			Expect(cacheFQN()).NotTo(BeAnExistingFile())
+
+			get()
+			Expect(cacheFQN()).To(BeAnExistingFile())
+		})
+
+		It("should not cache failed transformation", func() {
+			failServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				http.Error(w, "failure", http.StatusInternalServerError)
+			}))
+			defer failServer.Close()
+			pod := &corev1.Pod{}
+			pod.SetName("somename")
+			cc.Communicator = makeCommunicator(commArgs{
+				t:              tMock,
+				pod:            pod,
+				commType:       PushCommType,
+				transformerURL: failServer.URL,
+			})
+
+			r, _, err := comm.Get(clusterBck, objName)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("failure"))
+			Expect(r).To(BeNil())
+			Expect(cacheFQN()).NotTo(BeAnExistingFile())
+		})
+
+		It("should remove cached results", func() {
+			Expect(get()).To(Equal(transformData))
+			Expect(cacheFQN()).To(BeAnExistingFile())
+			cc.evict()
+			Expect(cacheFQN()).NotTo(BeAnExistingFile())
+		})
+	})
+})
+
+var _ = Describe("guardedReader", func() {
+	It("should close without waiting for the read in flight", func() {
+		pr, pw := io.Pipe()
+		g := &guardedReader{r: pr}
+		readErr := make(chan error, 1)
+		go func() {
+			_, err := g.Read(make([]byte, 16))
+			readErr <- err
+		}()
+		time.Sleep(50 * time.Millisecond) // (the read gets blocked on the input)
+
+		closed := make(chan struct{})
+		go func() {
+			g.Close()
+			close(closed)
+		}()
+		Eventually(closed).Should(BeClosed())
+		_, err := g.Read(make([]byte, 16))
+		Expect(err).To(Equal(io.ErrClosedPipe))
+
+		Expect(pw.Close()).NotTo(HaveOccurred()) // closing the input unblocks the read
+		Eventually(readErr).Should(Receive(Equal(io.EOF)))
+	})
 })
 
+func setCksum(value string) {
+	lom := &cluster.LOM{ObjName: "commObj"}
+	Expect(lom.Init(cmn.Bck{Name: "commBck", Provider: cmn.ProviderAIS, Ns: cmn.NsGlobal})).NotTo(HaveOccurred())
+	Expect(lom.Load()).NotTo(HaveOccurred())
+	lom.SetCksum(cmn.NewCksum(cmn.ChecksumXXHash, value))
+	Expect(lom.Persist()).NotTo(HaveOccurred())
+	lom.ReCache()
+}
+
 // Creates a file with random content.
 func createRandomFile(fileName string, size int64) error {
 	b := make([]byte, size)
@@ -8,10 +8,14 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"net/http"
 	"net/http/httputil"
 	"net/url"
+	"os/exec"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/NVIDIA/aistore/3rdparty/glog"
 	"github.com/NVIDIA/aistore/cluster"
@@ -21,6 +25,9 @@ import (
 	corev1 "k8s.io/api/core/v1"
 )
 
+// max length of the error message returned by the transformer
+const errMsgMaxLen = 512
+
 type (
 	// Communicator is responsible for managing communications with local ETL container.
 	// Do() gets executed as part of (each) GET bucket/object by the user.
@@ -33,6 +40,9 @@ type (
 		PodName() string
 		SvcName() string
 
+		// process returns the local transformer process, nil if ETL runs in K8s pod.
+		process() *process
+
 		// Do() uses one of the two ETL container endpoints:
 		// - Method "PUT", Path "/"
 		// - Method "GET", Path "/bucket/object"
@@ -49,6 +59,7 @@ type (
 		listener       cluster.Slistener
 		t              cluster.Target
 		pod            *corev1.Pod
+		proc           *process // either `pod` or `proc` is set
 		name           string
 		commType       string
 		transformerURL string
@@ -60,6 +71,7 @@ type (
 
 		name    string
 		podName string
+		proc    *process
 
 		transformerURL string
 	}
@@ -75,6 +87,16 @@ type (
 		baseComm
 		rp *httputil.ReverseProxy
 	}
+	ioComm struct {
+		baseComm
+		workers chan struct{} // limits the number of concurrent transformations
+		timeout time.Duration
+	}
+
+	// sglReadCloser frees the SGL upon closing.
+	sglReadCloser struct {
+		*memsys.SGL
+	}
 )
 
 // interface guard
@@ -82,6 +104,7 @@ var (
 	_ Communicator = (*pushComm)(nil)
 	_ Communicator = (*redirectComm)(nil)
 	_ Communicator = (*revProxyComm)(nil)
+	_ Communicator = (*ioComm)(nil)
 )
 
 //////////////
@@ -93,9 +116,14 @@ func makeCommunicator(args commArgs) Communicator {
 		Slistener:      args.listener,
 		t:              args.t,
 		name:           args.name,
-		podName:        args.pod.GetName(),
+		proc:           args.proc,
 		transformerURL: args.transformerURL,
 	}
+	if args.proc != nil {
+		baseComm.podName = args.proc.name
+	} else {
+		baseComm.podName = args.pod.GetName()
+	}
 
 	switch args.commType {
 	case PushCommType:
@@ -118,15 +146,25 @@ func makeCommunicator(args commArgs) Communicator {
 			},
 		}
 		return &revProxyComm{baseComm: baseComm, rp: rp}
+	case IOCommType:
+		cmn.Assert(args.proc != nil)
+		timeout, err := args.proc.spec.objectTimeout()
+		cmn.AssertNoErr(err) // validated by proxy
+		return &ioComm{
+			baseComm: baseComm,
+			workers:  make(chan struct{}, args.proc.spec.workers()),
+			timeout:  timeout,
+		}
 	default:
 		cmn.AssertMsg(false, args.commType)
 	}
 	return nil
 }
 
-func (c baseComm) Name() string    { return c.name }
-func (c baseComm) PodName() string { return c.podName }
-func (c baseComm) SvcName() string { return c.podName /*pod name is same as service name*/ }
+func (c baseComm) Name() string      { return c.name }
+func (c baseComm) PodName() string   { return c.podName }
+func (c baseComm) SvcName() string   { return c.podName /*pod name is same as service name*/ }
+func (c baseComm) process() *process { return c.proc }
 
 //////////////
 // pushComm //
@@ -238,6 +276,97 @@ func (pc *revProxyComm) Get(bck *cluster.Bck, objName string) (io.ReadCloser, in
 	return handleResp(resp, err)
 }
 
+////////////
+// ioComm //
+////////////
+
+// Do buffers the whole result (see `Get`): the transformer may fail after
+// producing some output, and the failure must not be reported as a truncated
+// object with the success status.
+func (ic *ioComm) Do(w http.ResponseWriter, _ *http.Request, bck *cluster.Bck, objName string) error {
+	r, size, err := ic.Get(bck, objName)
+	if err != nil {
+		return err
+	}
+	w.Header().Set(cmn.HeaderContentType, cmn.ContentBinary)
+	return writeTransformed(w, ic.t.MMSA(), r, size)
+}
+
+// Get buffers the whole result so that the size of the transformed object is known.
+func (ic *ioComm) Get(bck *cluster.Bck, objName string) (io.ReadCloser, int64, error) {
+	sgl := ic.t.MMSA().NewSGL(0)
+	if err := ic.transform(bck, objName, sgl); err != nil {
+		sgl.Free()
+		return nil, 0, err
+	}
+	return sglReadCloser{sgl}, sgl.Size(), nil
+}
+
+// transform runs the transformer with the object streamed into its stdin.
+func (ic *ioComm) transform(bck *cluster.Bck, objName string, w io.Writer) error {
+	fh, err := ic.openObject(bck, objName)
+	if err != nil {
+		return err
+	}
+	defer cmn.Close(fh)
+	return ic.run(context.Background(), fh, w, strconv.Quote(objName))
+}
+
+// run runs the transformer with `r` streamed into its stdin and its stdout
+// written to `w`.
+func (ic *ioComm) run(ctx context.Context, r io.Reader, w io.Writer, what string) error {
+	ic.workers <- struct{}{}
+	defer func() { <-ic.workers }()
+
+	ctx, cancel := context.WithTimeout(ctx, ic.timeout)
+	defer cancel()
+	var (
+		stderr = &processLogs{}
+		args   = ic.proc.args
+		cmd    = exec.CommandContext(ctx, args[0], args[1:]...)
+	)
+	cmd.Env = ic.proc.envs
+	cmd.Stdin, cmd.Stdout = r, w
+	cmd.Stderr = io.MultiWriter(stderr, ic.proc.logs)
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return fmt.Errorf("transformation of %s timed out after %v", what, ic.timeout)
+		}
+		return fmt.Errorf("transformation of %s failed, err: %v, stderr: %q", what, err, stderr.Bytes())
+	}
+	return nil
+}
+
+func (ic *ioComm) openObject(bck *cluster.Bck, objName string) (*cmn.FileHandle, error) {
+	lom := &cluster.LOM{ObjName: objName}
+	if err := lom.Init(bck.Bck); err != nil {
+		return nil, err
+	}
+	fh, err := ic.tryOpenObject(lom)
+	if err != nil && cmn.IsObjNotExist(err) && bck.IsRemote() {
+		if _, err = ic.t.GetCold(context.Background(), lom, cluster.PrefetchWait); err != nil {
+			return nil, err
+		}
+		fh, err = ic.tryOpenObject(lom)
+	}
+	return fh, err
+}
+
+func (ic *ioComm) tryOpenObject(lom *cluster.LOM) (*cmn.FileHandle, error) {
+	lom.Lock(false)
+	defer lom.Unlock(false)
+	if err := lom.Load(); err != nil {
+		return nil, err
+	}
+	// Once opened, the file can be safely read without holding the lock.
+	return cmn.NewFileHandle(lom.GetFQN())
+}
+
+func (r sglReadCloser) Close() error {
+	r.SGL.Free()
+	return nil
+}
+
 // prune query (received from AIS proxy) prior to reverse-proxying the request to/from container -
 // not removing cmn.URLParamUUID, for instance, would cause infinite loop.
 func pruneQuery(rawQuery string) string {
@@ -260,5 +389,23 @@ func handleResp(resp *http.Response, err error) (io.ReadCloser, int64, error) {
 	if err != nil {
 		return nil, 0, err
 	}
+	if err := checkResp(resp); err != nil {
+		return nil, 0, err
+	}
 	return resp.Body, resp.ContentLength, nil
 }
+
+// checkResp returns an error (and closes the body) if the transformer
+// has failed to transform the object.
+func checkResp(resp *http.Response) error {
+	if resp.StatusCode >= http.StatusOK && resp.StatusCode < http.StatusMultipleChoices {
+		return nil
+	}
+	b, _ := ioutil.ReadAll(io.LimitReader(resp.Body, errMsgMaxLen))
+	cmn.Close(resp.Body)
+	msg := strings.TrimSpace(string(b))
+	if msg == "" {
+		msg = http.StatusText(resp.StatusCode)
+	}
+	return &cmn.HTTPError{Status: resp.StatusCode, Message: "transformer: " + msg}
+}
@@ -37,6 +37,8 @@ func (om *objMeta) Cksum() *cmn.Cksum    { return om.cksum }
 func (om *objMeta) Version() string      { return om.version }
 func (om *objMeta) AtimeUnix() int64     { return om.atime }
 func (*objMeta) CustomMD() cmn.SimpleKVs { return nil }
+func (*objMeta) UserMD() cmn.SimpleKVs   { return nil }
+func (*objMeta) Tags() cmn.SimpleKVs     { return nil }
 
 func NewOfflineDataProvider(msg *cmn.Bck2BckMsg) (*OfflineDataProvider, error) {
 	comm, err := GetCommunicator(msg.ID)
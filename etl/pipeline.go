@@ -0,0 +1,266 @@
+// Package etl provides utilities to initialize and use transformation pods.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package etl
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/NVIDIA/aistore/3rdparty/atomic"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+)
+
+// Pipeline chains already initialized ETLs (stages): the output of each stage
+// is streamed into the next one, without writing any intermediate objects.
+// Pipeline is registered as an ETL on its own, so that it can be used in place
+// of any ETL ID - both for inline (GET) and offline (bucket) transformation.
+//
+// The first stage can use any communication type, while the following stages
+// must be able to transform an arbitrary stream (see `streamer`), ie. use
+// either `PushCommType` or `IOCommType`. Stages are resolved on each
+// transformation, so stopping any of them makes the pipeline fail (with the
+// error pointing to the stage) until it is stopped as well.
+
+type (
+	// streamer is implemented by the communicators which can transform any
+	// stream of bytes, not only an object stored by the target.
+	streamer interface {
+		transformStream(r io.Reader, size int64) (io.ReadCloser, int64, error)
+	}
+
+	pipelineComm struct {
+		t      cluster.Target
+		tid    string
+		id     string
+		name   string
+		stages []string
+	}
+
+	// pushStream is the response of the transformer to the request with the
+	// `body`. Closing it stops reading the body (even if the request is still
+	// being sent): the following reads fail, while the read in flight (if any)
+	// is unblocked by closing the input of the stage (see `stageReader`).
+	pushStream struct {
+		io.ReadCloser
+		body *guardedReader
+	}
+	guardedReader struct {
+		r      io.Reader
+		closed atomic.Bool
+	}
+
+	// ioStream is the output of the transformer run in the background. Closing
+	// it terminates the transformer (if still running).
+	ioStream struct {
+		*io.PipeReader
+		cancel context.CancelFunc
+		done   chan struct{}
+	}
+
+	// stageReader attributes the errors encountered while reading the output
+	// of the stage and closes the input of the stage when closed.
+	stageReader struct {
+		io.ReadCloser
+		input  io.Closer
+		errCtx *cmn.ETLErrorContext
+	}
+)
+
+// interface guard
+var (
+	_ Communicator = (*pipelineComm)(nil)
+	_ streamer     = (*pushComm)(nil)
+	_ streamer     = (*ioComm)(nil)
+)
+
+// StartPipeline registers the pipeline of the ETLs which have been already
+// initialized on the target.
+func StartPipeline(t cluster.Target, msg PipelineMsg) error {
+	names := make([]string, 0, len(msg.Stages))
+	for idx, id := range msg.Stages {
+		errCtx := &cmn.ETLErrorContext{TID: t.Snode().DaemonID, UUID: id, Pipeline: msg.ID, Stage: idx + 1}
+		c, err := GetCommunicator(id)
+		if err != nil {
+			return cmn.NewETLError(errCtx, "%v", err)
+		}
+		errCtx.ETLName = c.Name()
+		if _, ok := c.(*pipelineComm); ok {
+			return cmn.NewETLError(errCtx, "pipeline cannot be used as a stage")
+		}
+		if _, ok := asStreamer(c); !ok && idx > 0 {
+			return cmn.NewETLError(errCtx, "only the first stage can use communication type other than %q or %q",
+				PushCommType, IOCommType)
+		}
+		names = append(names, c.Name())
+	}
+	c := &pipelineComm{
+		t:      t,
+		tid:    t.Snode().DaemonID,
+		id:     msg.ID,
+		name:   strings.Join(names, pipelineNameSep),
+		stages: msg.Stages,
+	}
+	return reg.put(msg.ID, c)
+}
+
+//////////////////
+// pipelineComm //
+//////////////////
+
+// Pipeline does not listen to the smap changes - its stages do.
+func (pc *pipelineComm) String() string     { return "etl-pipeline-" + pc.id }
+func (pc *pipelineComm) ListenSmapChanged() {}
+
+func (pc *pipelineComm) Name() string      { return pc.name }
+func (pc *pipelineComm) PodName() string   { return "" }
+func (pc *pipelineComm) SvcName() string   { return "" }
+func (pc *pipelineComm) process() *process { return nil }
+
+func (pc *pipelineComm) Do(w http.ResponseWriter, _ *http.Request, bck *cluster.Bck, objName string) error {
+	r, size, err := pc.Get(bck, objName)
+	if err != nil {
+		return err
+	}
+	return writeTransformed(w, pc.t.MMSA(), r, size)
+}
+
+func (pc *pipelineComm) Get(bck *cluster.Bck, objName string) (io.ReadCloser, int64, error) {
+	var (
+		r    io.ReadCloser
+		size int64
+	)
+	for idx, id := range pc.stages {
+		errCtx := &cmn.ETLErrorContext{TID: pc.tid, UUID: id, Pipeline: pc.id, Stage: idx + 1}
+		c, err := GetCommunicator(id)
+		if err != nil {
+			return nil, 0, pc.abort(r, cmn.NewETLError(errCtx, "%v", err))
+		}
+		errCtx.ETLName, errCtx.PodName = c.Name(), c.PodName()
+
+		var output io.ReadCloser
+		if idx == 0 {
+			output, size, err = c.Get(bck, objName)
+		} else if s, ok := asStreamer(c); ok {
+			output, size, err = s.transformStream(r, size)
+		} else {
+			err = fmt.Errorf("communication type does not support streaming")
+		}
+		if err != nil {
+			return nil, 0, pc.abort(r, cmn.NewETLError(errCtx, "%v", err))
+		}
+		r = &stageReader{ReadCloser: output, input: r, errCtx: errCtx}
+	}
+	return r, size, nil
+}
+
+// asStreamer returns the streamer of the stage - arbitrary streams are never cached.
+func asStreamer(c Communicator) (s streamer, ok bool) {
+	if cc, cached := c.(*cacheComm); cached {
+		c = cc.Communicator
+	}
+	s, ok = c.(streamer)
+	return
+}
+
+func (pc *pipelineComm) abort(r io.Closer, err error) error {
+	if r != nil {
+		cmn.Close(r)
+	}
+	return err
+}
+
+/////////////////
+// stageReader //
+/////////////////
+
+func (sr *stageReader) Read(b []byte) (int, error) {
+	n, err := sr.ReadCloser.Read(b)
+	if err != nil && err != io.EOF {
+		if _, ok := err.(*cmn.ETLError); !ok {
+			err = cmn.NewETLError(sr.errCtx, "%v", err)
+		}
+	}
+	return n, err
+}
+
+func (sr *stageReader) Close() error {
+	err := sr.ReadCloser.Close()
+	if sr.input != nil {
+		if errInput := sr.input.Close(); err == nil {
+			err = errInput
+		}
+	}
+	return err
+}
+
+///////////////
+// streamers //
+///////////////
+
+func (pc *pushComm) transformStream(r io.Reader, size int64) (io.ReadCloser, int64, error) {
+	// NOTE: `r` is closed by the pipeline, not by the request.
+	body := &guardedReader{r: r}
+	req, err := http.NewRequest(http.MethodPut, pc.transformerURL, ioutil.NopCloser(body))
+	if err != nil {
+		return nil, 0, err
+	}
+	req.ContentLength = size
+	req.Header.Set(cmn.HeaderContentType, cmn.ContentBinary)
+	resp, err := pc.t.DataClient().Do(req)
+	if err == nil {
+		err = checkResp(resp)
+	}
+	if err != nil {
+		body.Close()
+		return nil, 0, err
+	}
+	return &pushStream{ReadCloser: resp.Body, body: body}, resp.ContentLength, nil
+}
+
+func (s *pushStream) Close() error {
+	err := s.ReadCloser.Close()
+	s.body.Close()
+	return err
+}
+
+func (g *guardedReader) Read(b []byte) (int, error) {
+	if g.closed.Load() {
+		return 0, io.ErrClosedPipe
+	}
+	return g.r.Read(b)
+}
+
+// NOTE: does not wait for the read in flight - the latter may be blocked
+// until the input gets closed.
+func (g *guardedReader) Close() { g.closed.Store(true) }
+
+// transformStream runs the transformer in the background, its output is
+// streamed as it is produced. The error (if any) is returned by the reader.
+func (ic *ioComm) transformStream(r io.Reader, _ int64) (io.ReadCloser, int64, error) {
+	var (
+		pr, pw      = io.Pipe()
+		ctx, cancel = context.WithCancel(context.Background())
+		s           = &ioStream{PipeReader: pr, cancel: cancel, done: make(chan struct{})}
+	)
+	go func() {
+		pw.CloseWithError(ic.run(ctx, r, pw, "stream"))
+		close(s.done)
+	}()
+	return s, -1, nil
+}
+
+// Close waits for the transformer to terminate so that its input can be
+// safely released.
+func (s *ioStream) Close() error {
+	err := s.PipeReader.Close()
+	s.cancel()
+	<-s.done
+	return err
+}
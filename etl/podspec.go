@@ -6,6 +6,7 @@ package etl
 
 import (
 	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/NVIDIA/aistore/cmn"
@@ -19,6 +20,7 @@ const (
 
 	commTypeAnnotation    = "communication_type"
 	waitTimeoutAnnotation = "wait_timeout"
+	cacheAnnotation       = "cache"
 )
 
 // Currently we need the `default` port (on which the application runs) to be same as the
@@ -47,11 +49,14 @@ func podTransformCommType(errCtx *cmn.ETLErrorContext, pod *corev1.Pod) (string,
 	if err := validateCommType(commType); err != nil {
 		return "", cmn.NewETLError(errCtx, err.Error()).WithPodName(pod.Name)
 	}
+	if commType == IOCommType {
+		return "", cmn.NewETLError(errCtx, "communication type %q is supported only by process-based ETL", commType).WithPodName(pod.Name)
+	}
 	return commType, nil
 }
 
 func validateCommType(commType string) error {
-	if !cmn.StringInSlice(commType, []string{PushCommType, RedirectCommType, RevProxyCommType}) {
+	if !cmn.StringInSlice(commType, []string{PushCommType, RedirectCommType, RevProxyCommType, IOCommType}) {
 		return fmt.Errorf("unknown communication type: %q", commType)
 	}
 	return nil
@@ -69,7 +74,22 @@ func podTransformTimeout(errCtx *cmn.ETLErrorContext, pod *corev1.Pod) (cmn.Dura
 	return cmn.DurationJSON(v), nil
 }
 
+func podTransformCache(errCtx *cmn.ETLErrorContext, pod *corev1.Pod) (bool, error) {
+	if pod.Annotations == nil || pod.Annotations[cacheAnnotation] == "" {
+		return false, nil
+	}
+
+	v, err := strconv.ParseBool(pod.Annotations[cacheAnnotation])
+	if err != nil {
+		return false, cmn.NewETLError(errCtx, "%v", err).WithPodName(pod.Name)
+	}
+	return v, nil
+}
+
 func ValidateSpec(spec []byte) (msg InitMsg, err error) {
+	if isProcessSpec(spec) {
+		return validateProcessSpec(spec)
+	}
 	errCtx := &cmn.ETLErrorContext{}
 	msg.Spec = spec
 	pod, err := ParsePodSpec(errCtx, msg.Spec)
@@ -114,5 +134,8 @@ func ValidateSpec(spec []byte) (msg InitMsg, err error) {
 	if msg.WaitTimeout, err = podTransformTimeout(errCtx, pod); err != nil {
 		return msg, err
 	}
+	if msg.Cache, err = podTransformCache(errCtx, pod); err != nil {
+		return msg, err
+	}
 	return msg, nil
 }
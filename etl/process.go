@@ -0,0 +1,458 @@
+// Package etl provides utilities to initialize and use transformation pods.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package etl
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"plugin"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/sys"
+	"gopkg.in/yaml.v2"
+)
+
+// Process-based ETL runs the transformer as a local process on the same host
+// as the target (instead of K8s pod), so that ETL can be used in deployments
+// without Kubernetes. The transformer is either:
+//  * an executable - spawned and supervised (restarted when it exits) by the
+//    target; it must start a web server listening on `AIS_ETL_HOST:AIS_ETL_PORT`,
+//  * a Go plugin - loaded into the target which serves the exported `Handler`
+//    (`http.Handler` or `func(http.ResponseWriter, *http.Request)`).
+// In both cases, the target communicates with the transformer using any of the
+// supported communication mechanisms (see `Communicator`).
+
+const (
+	// ProcessKind is the kind of the ETL spec describing process-based ETL.
+	ProcessKind = "Process"
+
+	// Name of the symbol exported by the Go plugin.
+	pluginHandlerSymbol = "Handler"
+
+	// Environment variables set for the transformer executable.
+	processHostEnv = "AIS_ETL_HOST"
+	processPortEnv = "AIS_ETL_PORT"
+
+	processLoopbackHost    = "127.0.0.1"
+	processWaitTimeout     = 30 * time.Second
+	processProbeInterval   = 100 * time.Millisecond
+	processStopTimeout     = 10 * time.Second
+	processRestartDelay    = time.Second
+	processObjectTimeout   = 5 * time.Minute
+	processMaxRestarts     = 3
+	processMaxLogsSize     = cmn.MiB
+	processLogsTruncatedAt = processMaxLogsSize / 2
+)
+
+type (
+	// ProcessSpec describes the transformer run by each target as a local process.
+	ProcessSpec struct {
+		Kind        string            `yaml:"kind"`
+		Name        string            `yaml:"name"`
+		Command     []string          `yaml:"command,omitempty"` // executable and its arguments
+		Plugin      string            `yaml:"plugin,omitempty"`  // path to the Go plugin
+		Env         map[string]string `yaml:"env,omitempty"`     // additional environment (executable only)
+		CommType    string            `yaml:"communication_type,omitempty"`
+		WaitTimeout string            `yaml:"wait_timeout,omitempty"`
+		Cache       bool              `yaml:"cache,omitempty"` // cache transformed objects
+
+		// Used only with `IOCommType`.
+		Workers       int    `yaml:"workers,omitempty"`        // max number of concurrent transformations
+		ObjectTimeout string `yaml:"object_timeout,omitempty"` // max duration of a single transformation
+	}
+
+	// process spawns and supervises the transformer.
+	process struct {
+		spec *ProcessSpec
+		name string
+		addr string // host:port the transformer listens on
+		env  map[string]string
+		args []string // command with expanded environment variables
+		envs []string // environment of the command
+		logs *processLogs
+
+		mtx      sync.Mutex
+		cmd      *exec.Cmd
+		done     chan struct{} // closed when `cmd` exits
+		srv      *http.Server  // serves the Go plugin
+		restarts int
+		stopped  bool
+	}
+
+	// processLogs keeps the most recent output of the transformer.
+	processLogs struct {
+		mtx sync.Mutex
+		b   []byte
+	}
+)
+
+func isProcessSpec(spec []byte) bool {
+	var header struct {
+		Kind string `yaml:"kind"`
+	}
+	return yaml.Unmarshal(spec, &header) == nil && header.Kind == ProcessKind
+}
+
+func ParseProcessSpec(errCtx *cmn.ETLErrorContext, spec []byte) (*ProcessSpec, error) {
+	ps := &ProcessSpec{}
+	if err := yaml.UnmarshalStrict(spec, ps); err != nil {
+		return nil, cmn.NewETLError(errCtx, "failed to parse process spec: %v", err)
+	}
+	errCtx.ETLName = ps.Name
+	if ps.Name == "" {
+		return nil, cmn.NewETLError(errCtx, "name of the process is required")
+	}
+	if (len(ps.Command) == 0) == (ps.Plugin == "") {
+		return nil, cmn.NewETLError(errCtx, "exactly one of the command or plugin must be specified")
+	}
+	return ps, nil
+}
+
+func validateProcessSpec(spec []byte) (msg InitMsg, err error) {
+	errCtx := &cmn.ETLErrorContext{}
+	if err := checkProcessAllowed(); err != nil {
+		return msg, cmn.NewETLError(errCtx, "%v", err)
+	}
+	msg.Spec = spec
+	ps, err := ParseProcessSpec(errCtx, msg.Spec)
+	if err != nil {
+		return msg, err
+	}
+
+	msg.CommType = PushCommType
+	if ps.CommType != "" {
+		if err := validateCommType(ps.CommType); err != nil {
+			return msg, cmn.NewETLError(errCtx, "%v", err)
+		}
+		msg.CommType = ps.CommType
+	}
+	if ps.WaitTimeout != "" {
+		v, err := time.ParseDuration(ps.WaitTimeout)
+		if err != nil {
+			return msg, cmn.NewETLError(errCtx, "%v", err)
+		}
+		msg.WaitTimeout = cmn.DurationJSON(v)
+	}
+	msg.Cache = ps.Cache
+	if msg.CommType == IOCommType && ps.Plugin != "" {
+		return msg, cmn.NewETLError(errCtx, "communication type %q requires command", IOCommType)
+	}
+	if ps.Workers < 0 {
+		return msg, cmn.NewETLError(errCtx, "invalid number of workers: %d", ps.Workers)
+	}
+	if _, err := ps.objectTimeout(); err != nil {
+		return msg, cmn.NewETLError(errCtx, "%v", err)
+	}
+	return msg, nil
+}
+
+func (ps *ProcessSpec) workers() int {
+	if ps.Workers == 0 {
+		cpus, _ := sys.NumCPU()
+		return cpus
+	}
+	return ps.Workers
+}
+
+func (ps *ProcessSpec) objectTimeout() (time.Duration, error) {
+	if ps.ObjectTimeout == "" {
+		return processObjectTimeout, nil
+	}
+	return time.ParseDuration(ps.ObjectTimeout)
+}
+
+// checkProcessAllowed returns an error unless process-based ETL is enabled
+// in the configuration: the transformer runs with the privileges of the target.
+func checkProcessAllowed() error {
+	if !cmn.GCO.Get().ETL.AllowProcess {
+		return errors.New("process-based ETL is disabled (see `etl.allow_process` config)")
+	}
+	return nil
+}
+
+func startProcess(t cluster.Target, msg InitMsg, opts ...StartOpts) error {
+	errCtx := &cmn.ETLErrorContext{
+		TID:  t.Snode().DaemonID,
+		UUID: msg.ID,
+	}
+	if err := checkProcessAllowed(); err != nil {
+		return cmn.NewETLError(errCtx, "%v", err)
+	}
+	spec, err := ParseProcessSpec(errCtx, msg.Spec)
+	if err != nil {
+		return err
+	}
+
+	// The client must be able to reach the transformer when redirected.
+	host := processLoopbackHost
+	if msg.CommType == RedirectCommType {
+		host = t.Snode().PublicNet.NodeIPAddr
+	}
+	env := map[string]string{
+		"AIS_TARGET_URL": t.Snode().URL(cmn.NetworkPublic) + cmn.JoinWords(cmn.Version, cmn.ETL, cmn.ETLObject, reqSecret),
+	}
+	for k, v := range spec.Env {
+		env[k] = v
+	}
+	if len(opts) > 0 {
+		for k, v := range opts[0].Env {
+			env[k] = v
+		}
+	}
+
+	proc := newProcess(spec, spec.Name+"-"+t.Snode().ID(), host, env)
+	errCtx.PodName = proc.name
+	transformerURL := ""
+	if msg.CommType == IOCommType {
+		// The transformer is run separately for each object.
+		proc.prepare()
+	} else {
+		if err := proc.start(); err != nil {
+			return cmn.NewETLError(errCtx, "%v", err)
+		}
+		waitTimeout := time.Duration(msg.WaitTimeout)
+		if waitTimeout == 0 {
+			waitTimeout = processWaitTimeout
+		}
+		if err := proc.waitReady(waitTimeout); err != nil {
+			if errStop := proc.stop(); errStop != nil {
+				glog.Error(errStop)
+			}
+			return cmn.NewETLError(errCtx, "%v", err)
+		}
+		transformerURL = "http://" + proc.addr
+	}
+
+	c := makeCommunicator(commArgs{
+		listener:       newAborter(t, msg.ID),
+		t:              t,
+		proc:           proc,
+		name:           spec.Name,
+		commType:       msg.CommType,
+		transformerURL: transformerURL,
+	})
+	if msg.Cache {
+		c = newCacheComm(t, msg.ID, c)
+	}
+	// NOTE: communicator is put to registry only if the process is ready.
+	if err := reg.put(msg.ID, c); err != nil {
+		if errStop := proc.stop(); errStop != nil {
+			glog.Error(errStop)
+		}
+		return err
+	}
+	t.Sowner().Listeners().Reg(c)
+	return nil
+}
+
+/////////////
+// process //
+/////////////
+
+func newProcess(spec *ProcessSpec, name, host string, env map[string]string) *process {
+	if env == nil {
+		env = make(map[string]string, 2)
+	}
+	return &process{
+		spec: spec,
+		name: name,
+		addr: host,
+		env:  env,
+		logs: &processLogs{},
+	}
+}
+
+func (p *process) String() string { return fmt.Sprintf("ETL process %q", p.name) }
+
+// start allocates the port and starts the transformer.
+func (p *process) start() error {
+	ln, err := net.Listen("tcp", net.JoinHostPort(p.addr, "0"))
+	if err != nil {
+		return err
+	}
+	p.addr = ln.Addr().String()
+
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	if p.spec.Plugin != "" {
+		return p.servePlugin(ln)
+	}
+
+	// The port is released so that the transformer can listen on it.
+	cmn.Close(ln)
+	p.env[processHostEnv], p.env[processPortEnv], _ = net.SplitHostPort(p.addr)
+	p.prepare()
+	return p.exec()
+}
+
+// prepare expands the environment variables in the command.
+func (p *process) prepare() {
+	p.envs = os.Environ()
+	for k, v := range p.env {
+		p.envs = append(p.envs, k+"="+v)
+	}
+	p.args = make([]string, 0, len(p.spec.Command))
+	for _, arg := range p.spec.Command {
+		p.args = append(p.args, os.Expand(arg, p.getenv))
+	}
+}
+
+func (p *process) getenv(key string) string {
+	if v, ok := p.env[key]; ok {
+		return v
+	}
+	return os.Getenv(key)
+}
+
+// PRECONDITION: `p.mtx` must be locked.
+func (p *process) exec() error {
+	cmd := exec.Command(p.args[0], p.args[1:]...)
+	cmd.Env = p.envs
+	cmd.Stdout, cmd.Stderr = p.logs, p.logs
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	done := make(chan struct{})
+	p.cmd, p.done = cmd, done
+	go p.supervise(cmd, done)
+	return nil
+}
+
+// supervise waits for the transformer to exit and restarts it unless it has
+// been stopped or has been restarted too many times.
+func (p *process) supervise(cmd *exec.Cmd, done chan struct{}) {
+	err := cmd.Wait()
+	close(done)
+
+	time.Sleep(processRestartDelay)
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	if p.stopped {
+		return
+	}
+	if p.restarts >= processMaxRestarts {
+		glog.Errorf("%s exited (err: %v), giving up after %d restarts", p, err, p.restarts)
+		return
+	}
+	p.restarts++
+	glog.Warningf("%s exited (err: %v), restarting (%d/%d)", p, err, p.restarts, processMaxRestarts)
+	if err := p.exec(); err != nil {
+		glog.Errorf("failed to restart %s, err: %v", p, err)
+	}
+}
+
+// PRECONDITION: `p.mtx` must be locked.
+func (p *process) servePlugin(ln net.Listener) error {
+	var handler http.Handler
+	plug, err := plugin.Open(p.spec.Plugin)
+	if err == nil {
+		var sym plugin.Symbol
+		if sym, err = plug.Lookup(pluginHandlerSymbol); err == nil {
+			switch h := sym.(type) {
+			case *http.Handler:
+				handler = *h
+			case func(http.ResponseWriter, *http.Request):
+				handler = http.HandlerFunc(h)
+			default:
+				err = fmt.Errorf("plugin symbol %q has unsupported type %T", pluginHandlerSymbol, sym)
+			}
+		}
+	}
+	if err != nil {
+		cmn.Close(ln)
+		return err
+	}
+
+	p.srv = &http.Server{Handler: handler}
+	go func(srv *http.Server) {
+		if err := srv.Serve(ln); err != nil && err != http.ErrServerClosed {
+			glog.Errorf("%s failed to serve, err: %v", p, err)
+		}
+	}(p.srv)
+	return nil
+}
+
+// waitReady waits until the transformer accepts connections.
+func (p *process) waitReady(timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for {
+		conn, err := net.DialTimeout("tcp", p.addr, processProbeInterval)
+		if err == nil {
+			cmn.Close(conn)
+			return nil
+		}
+		p.mtx.Lock()
+		done := p.done
+		p.mtx.Unlock()
+		if done != nil {
+			select {
+			case <-done:
+				return fmt.Errorf("%s exited before becoming ready, logs: %q", p, p.logs.Bytes())
+			default:
+			}
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("%s failed to become ready in %v, err: %v", p, timeout, err)
+		}
+		time.Sleep(processProbeInterval)
+	}
+}
+
+// stop terminates the transformer: first gracefully and then, upon timeout, forcefully.
+func (p *process) stop() error {
+	p.mtx.Lock()
+	p.stopped = true
+	cmd, done, srv := p.cmd, p.done, p.srv
+	p.mtx.Unlock()
+
+	if srv != nil {
+		return srv.Close()
+	}
+	if cmd == nil {
+		return nil
+	}
+	// Signal fails only when the process has already exited.
+	_ = cmd.Process.Signal(syscall.SIGTERM)
+	select {
+	case <-done:
+	case <-time.After(processStopTimeout):
+		glog.Warningf("%s did not terminate in %v, killing", p, processStopTimeout)
+		if err := cmd.Process.Kill(); err != nil {
+			return err
+		}
+		<-done
+	}
+	return nil
+}
+
+/////////////////
+// processLogs //
+/////////////////
+
+func (l *processLogs) Write(b []byte) (int, error) {
+	l.mtx.Lock()
+	l.b = append(l.b, b...)
+	if len(l.b) > processMaxLogsSize {
+		l.b = append(l.b[:0], l.b[len(l.b)-processLogsTruncatedAt:]...)
+	}
+	l.mtx.Unlock()
+	return len(b), nil
+}
+
+func (l *processLogs) Bytes() []byte {
+	l.mtx.Lock()
+	b := append([]byte(nil), l.b...)
+	l.mtx.Unlock()
+	return b
+}
@@ -0,0 +1,157 @@
+// Package etl provides utilities to initialize and use transformation pods.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package etl
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+// When set, the test binary acts as the transformer executable.
+const testTransformerEnv = "AIS_ETL_TEST_TRANSFORMER"
+
+func init() {
+	if os.Getenv(testTransformerEnv) == "" {
+		return
+	}
+	addr := net.JoinHostPort(os.Getenv(processHostEnv), os.Getenv(processPortEnv))
+	err := http.ListenAndServe(addr, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := ioutil.ReadAll(r.Body)
+		w.Write(bytes.ToUpper(b))
+	}))
+	os.Stderr.WriteString(err.Error())
+	os.Exit(1)
+}
+
+var _ = Describe("ProcessTest", func() {
+	allowProcess := func(allow bool) {
+		config := cmn.GCO.BeginUpdate()
+		config.ETL.AllowProcess = allow
+		cmn.GCO.CommitUpdate(config)
+	}
+
+	Context("spec", func() {
+		BeforeEach(func() { allowProcess(true) })
+		AfterEach(func() { allowProcess(false) })
+
+		It("should validate process spec", func() {
+			spec := []byte(`
+kind: Process
+name: transformer
+command: ["/bin/transformer", "--port", "${AIS_ETL_PORT}"]
+communication_type: hrev://
+wait_timeout: 10s
+`)
+			Expect(isProcessSpec(spec)).To(BeTrue())
+			msg, err := ValidateSpec(spec)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(msg.CommType).To(Equal(RevProxyCommType))
+			Expect(time.Duration(msg.WaitTimeout)).To(Equal(10 * time.Second))
+		})
+
+		It("should reject process spec unless allowed", func() {
+			allowProcess(false)
+			_, err := ValidateSpec([]byte("kind: Process\nname: transformer\ncommand: [\"/bin/transformer\"]\n"))
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("etl.allow_process"))
+		})
+
+		It("should not treat pod spec as process spec", func() {
+			Expect(isProcessSpec([]byte("apiVersion: v1\nkind: Pod\n"))).To(BeFalse())
+		})
+
+		DescribeTable("should fail to validate invalid process spec",
+			func(spec string) {
+				_, err := ValidateSpec([]byte("kind: Process\n" + spec))
+				Expect(err).To(HaveOccurred())
+			},
+			Entry("missing name", `command: ["/bin/transformer"]`),
+			Entry("missing command and plugin", `name: transformer`),
+			Entry("both command and plugin", "name: transformer\ncommand: [\"/bin/transformer\"]\nplugin: transformer.so"),
+			Entry("invalid communication type", "name: transformer\ncommand: [\"/bin/transformer\"]\ncommunication_type: tcp://"),
+			Entry("invalid wait timeout", "name: transformer\ncommand: [\"/bin/transformer\"]\nwait_timeout: 10"),
+			Entry("unknown field", "name: transformer\ncommand: [\"/bin/transformer\"]\nimage: transformer"),
+			Entry("plugin with io communication", "name: transformer\nplugin: transformer.so\ncommunication_type: io://"),
+			Entry("invalid number of workers", "name: transformer\ncommand: [\"/bin/transformer\"]\nworkers: -1"),
+			Entry("invalid object timeout", "name: transformer\ncommand: [\"/bin/transformer\"]\nobject_timeout: 1"),
+		)
+	})
+
+	Context("executable", func() {
+		var proc *process
+
+		BeforeEach(func() {
+			spec := &ProcessSpec{Kind: ProcessKind, Name: "transformer", Command: []string{os.Args[0]}}
+			proc = newProcess(spec, "transformer-target", processLoopbackHost, map[string]string{testTransformerEnv: "1"})
+			Expect(proc.start()).NotTo(HaveOccurred())
+			Expect(proc.waitReady(10 * time.Second)).NotTo(HaveOccurred())
+		})
+
+		AfterEach(func() {
+			Expect(proc.stop()).NotTo(HaveOccurred())
+		})
+
+		transform := func(data string) string {
+			resp, err := http.Post("http://"+proc.addr, "text/plain", strings.NewReader(data))
+			Expect(err).NotTo(HaveOccurred())
+			defer resp.Body.Close()
+			b, err := ioutil.ReadAll(resp.Body)
+			Expect(err).NotTo(HaveOccurred())
+			return string(b)
+		}
+
+		It("should transform data", func() {
+			Expect(transform("data")).To(Equal("DATA"))
+		})
+
+		It("should restart transformer when it exits", func() {
+			proc.mtx.Lock()
+			err := proc.cmd.Process.Kill()
+			done := proc.done
+			proc.mtx.Unlock()
+			Expect(err).NotTo(HaveOccurred())
+			Eventually(done).Should(BeClosed())
+
+			Eventually(func() int {
+				proc.mtx.Lock()
+				defer proc.mtx.Unlock()
+				return proc.restarts
+			}, 5*time.Second).Should(Equal(1))
+			Expect(proc.waitReady(10 * time.Second)).NotTo(HaveOccurred())
+			Expect(transform("data")).To(Equal("DATA"))
+		})
+
+		It("should not restart stopped transformer", func() {
+			proc.mtx.Lock()
+			done := proc.done
+			proc.mtx.Unlock()
+			Expect(proc.stop()).NotTo(HaveOccurred())
+			Expect(done).To(BeClosed())
+
+			time.Sleep(2 * processRestartDelay)
+			proc.mtx.Lock()
+			Expect(proc.restarts).To(BeZero())
+			proc.mtx.Unlock()
+		})
+	})
+
+	It("should fail when transformer exits before becoming ready", func() {
+		spec := &ProcessSpec{Kind: ProcessKind, Name: "transformer", Command: []string{"false"}}
+		proc := newProcess(spec, "transformer-target", processLoopbackHost, nil)
+		Expect(proc.start()).NotTo(HaveOccurred())
+		Expect(proc.waitReady(10 * time.Second)).To(HaveOccurred())
+		Expect(proc.stop()).NotTo(HaveOccurred())
+	})
+})
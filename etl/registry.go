@@ -22,8 +22,15 @@ const (
 	RedirectCommType = "hpull://"
 	// Similar to redirection strategy but with usage of reverse proxy.
 	RevProxyCommType = "hrev://"
+	// Target runs the transformer (process-based ETL only) for each object:
+	// the object is streamed into its stdin and the result is read from its
+	// stdout. No web server is required.
+	IOCommType = "io://"
 )
 
+// Separates the names of the stages in the name of the pipeline.
+const pipelineNameSep = " -> "
+
 type (
 	registry struct {
 		mtx    sync.RWMutex
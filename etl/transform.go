@@ -144,6 +144,9 @@ func (e *Aborter) ListenSmapChanged() {
 }
 
 func Start(t cluster.Target, msg InitMsg, opts ...StartOpts) (err error) {
+	if isProcessSpec(msg.Spec) {
+		return startProcess(t, msg, opts...)
+	}
 	errCtx, podName, svcName, err := tryStart(t, msg, opts...)
 	if err != nil {
 		glog.Warning(cmn.NewETLError(errCtx, "Performing cleanup after unsuccessful Start"))
@@ -198,7 +201,10 @@ func tryStart(t cluster.Target, msg InitMsg, opts ...StartOpts) (errCtx *cmn.ETL
 		UUID: msg.ID,
 	}
 
-	cmn.Assert(k8s.NodeName != "") // Corresponding 'if' done at the beginning of the request.
+	if err = k8s.Detect(); err != nil {
+		err = cmn.NewETLError(errCtx, "%v", err)
+		return
+	}
 
 	// TODO: Move below code into `createPodSpec`.
 
@@ -278,6 +284,9 @@ func tryStart(t cluster.Target, msg InitMsg, opts ...StartOpts) (errCtx *cmn.ETL
 		commType:       msg.CommType,
 		transformerURL: "http://" + etlSocketAddr,
 	})
+	if msg.Cache {
+		c = newCacheComm(t, msg.ID, c)
+	}
 	// NOTE: communicator is put to registry only if the whole tryStart was successful.
 	if err = reg.put(msg.ID, c); err != nil {
 		return
@@ -332,8 +341,8 @@ func createServiceSpec(pod *corev1.Pod) *corev1.Service {
 	return svc
 }
 
-// Stop deletes all occupied by the ETL resources, including Pods and Services.
-// It unregisters ETL smap listener.
+// Stop deletes all occupied by the ETL resources, including Pods and Services
+// (or terminates local process). It unregisters ETL smap listener.
 func Stop(t cluster.Target, id string) error {
 	errCtx := &cmn.ETLErrorContext{
 		TID:  t.Snode().DaemonID,
@@ -347,27 +356,35 @@ func Stop(t cluster.Target, id string) error {
 	if err != nil {
 		return cmn.NewETLError(errCtx, err.Error())
 	}
+	if _, ok := c.(*pipelineComm); ok {
+		// Stages of the pipeline are stopped separately.
+		reg.removeByUUID(id)
+		return nil
+	}
 	errCtx.PodName = c.PodName()
-	errCtx.SvcName = c.SvcName()
-
-	if err := cleanupEntities(errCtx, c.PodName(), c.SvcName()); err != nil {
-		return err
+	if proc := c.process(); proc != nil {
+		if err := proc.stop(); err != nil {
+			return cmn.NewETLError(errCtx, "%v", err)
+		}
+	} else {
+		errCtx.SvcName = c.SvcName()
+		if err := cleanupEntities(errCtx, c.PodName(), c.SvcName()); err != nil {
+			return err
+		}
 	}
 
 	if c := reg.removeByUUID(id); c != nil {
 		t.Sowner().Listeners().Unreg(c)
 	}
+	if cc, ok := c.(*cacheComm); ok {
+		go cc.evict()
+	}
 
 	return nil
 }
 
 // StopAll deletes all running ETLs.
 func StopAll(t cluster.Target) {
-	// Skip if K8s isn't even available.
-	if k8s.Detect() != nil {
-		return
-	}
-
 	for _, e := range List() {
 		if err := Stop(t, e.ID); err != nil {
 			glog.Error(err)
@@ -390,6 +407,15 @@ func PodLogs(t cluster.Target, transformID string) (logs PodLogsMsg, err error)
 	if err != nil {
 		return logs, err
 	}
+	if _, ok := c.(*pipelineComm); ok {
+		return logs, fmt.Errorf("ETL %q is a pipeline, retrieve logs of its stages instead", transformID)
+	}
+	if proc := c.process(); proc != nil {
+		return PodLogsMsg{
+			TargetID: t.Snode().ID(),
+			Logs:     proc.logs.Bytes(),
+		}, nil
+	}
 	client, err := k8s.GetClient()
 	if err != nil {
 		return logs, err
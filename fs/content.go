@@ -5,6 +5,7 @@
 package fs
 
 import (
+	"encoding/hex"
 	"fmt"
 	"path/filepath"
 	"strconv"
@@ -33,6 +34,9 @@ const (
 	contentTypeLen = 2
 	ObjectType     = "ob"
 	WorkfileType   = "wk"
+	MultipartType  = "mp" // S3 multipart upload parts
+	ObjVersionType = "ov" // prior versions of objects and delete markers
+	ETLCacheType   = "et" // cached results of ETL transformations
 )
 
 type (
@@ -158,8 +162,11 @@ func (f *ContentSpecMgr) PermToProcess(fqn string) (ok bool) {
 // FIXME: This should be probably placed somewhere else \/
 
 type (
-	ObjectContentResolver   struct{}
-	WorkfileContentResolver struct{}
+	ObjectContentResolver     struct{}
+	WorkfileContentResolver   struct{}
+	MultipartContentResolver  struct{}
+	ObjVersionContentResolver struct{}
+	ETLCacheContentResolver   struct{}
 )
 
 func (wf *ObjectContentResolver) PermToMove() bool    { return true }
@@ -211,3 +218,95 @@ func (wf *WorkfileContentResolver) ParseUniqueFQN(base string) (orig string, old
 
 	return base[:tieIndex], filePID != pid, true
 }
+
+// NOTE: in-progress uploads do not survive the target's restart - parts of the
+// uploads started by a previous process are "old" and get removed by LRU.
+func (mp *MultipartContentResolver) PermToMove() bool    { return false }
+func (mp *MultipartContentResolver) PermToEvict() bool   { return true }
+func (mp *MultipartContentResolver) PermToProcess() bool { return false }
+
+// prefix is expected to be "<upload ID>.<part number>"
+func (mp *MultipartContentResolver) GenUniqueFQN(base, prefix string) string {
+	return base + "." + prefix + "." + spid
+}
+
+func (mp *MultipartContentResolver) ParseUniqueFQN(base string) (orig string, old, ok bool) {
+	pidIndex := strings.LastIndex(base, ".") // pid
+	if pidIndex < 0 {
+		return "", false, false
+	}
+	partIndex := strings.LastIndex(base[:pidIndex], ".") // part number
+	if partIndex < 0 {
+		return "", false, false
+	}
+	uploadIndex := strings.LastIndex(base[:partIndex], ".") // upload ID
+	if uploadIndex < 0 {
+		return "", false, false
+	}
+	filePID, err := strconv.ParseInt(base[pidIndex+1:], 16, 64)
+	if err != nil {
+		return "", false, false
+	}
+	return base[:uploadIndex], filePID != pid, true
+}
+
+// NOTE: prior versions are not rebalanced (or resilvered) - they stay with the
+// mountpath of the object at the time the version was created.
+func (ov *ObjVersionContentResolver) PermToMove() bool    { return false }
+func (ov *ObjVersionContentResolver) PermToEvict() bool   { return false }
+func (ov *ObjVersionContentResolver) PermToProcess() bool { return false }
+
+// prefix is expected to be the version ID
+func (ov *ObjVersionContentResolver) GenUniqueFQN(base, prefix string) string {
+	return base + "." + prefix
+}
+
+func (ov *ObjVersionContentResolver) ParseUniqueFQN(base string) (orig string, old, ok bool) {
+	orig, _, ok = ParseObjVersion(base)
+	return
+}
+
+// ParseObjVersion splits the base name of the ObjVersionType content
+// into the (base) name of the object and the version ID.
+func ParseObjVersion(base string) (orig, version string, ok bool) {
+	return splitSuffix(base)
+}
+
+// Cached results are derived from the objects, hence they can be always
+// evicted (and recomputed) but never moved.
+func (ec *ETLCacheContentResolver) PermToMove() bool    { return false }
+func (ec *ETLCacheContentResolver) PermToEvict() bool   { return true }
+func (ec *ETLCacheContentResolver) PermToProcess() bool { return false }
+
+// prefix is expected to be the ETL ID; the ID is hex-encoded as it may
+// contain any characters, including ".".
+func (ec *ETLCacheContentResolver) GenUniqueFQN(base, prefix string) string {
+	return base + "." + hex.EncodeToString([]byte(prefix))
+}
+
+func (ec *ETLCacheContentResolver) ParseUniqueFQN(base string) (orig string, old, ok bool) {
+	orig, _, ok = ParseETLCache(base)
+	return
+}
+
+// ParseETLCache splits the base name of the ETLCacheType content into the
+// (base) name of the source object and the ID of the ETL.
+func ParseETLCache(base string) (orig, etlID string, ok bool) {
+	var suffix string
+	if orig, suffix, ok = splitSuffix(base); !ok {
+		return
+	}
+	id, err := hex.DecodeString(suffix)
+	if err != nil {
+		return "", "", false
+	}
+	return orig, string(id), true
+}
+
+func splitSuffix(base string) (orig, suffix string, ok bool) {
+	idx := strings.LastIndex(base, ".")
+	if idx <= 0 || idx == len(base)-1 || strings.ContainsRune(base[idx:], '/') {
+		return "", "", false
+	}
+	return base[:idx], base[idx+1:], true
+}
@@ -0,0 +1,164 @@
+// Package fs_test provides tests for fs package
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package fs_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/devtools/tutils/tassert"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/ios"
+)
+
+func TestMultipartContentFQN(t *testing.T) {
+	const (
+		mpath   = "/tmp/path"
+		objName = "object/name.tar"
+		prefix  = "Xu3HVgSz5.12"
+	)
+	var (
+		mios = ios.NewIOStaterMock()
+		bck  = cmn.Bck{Name: "bucket", Provider: cmn.ProviderAIS, Ns: cmn.NsGlobal}
+	)
+	fs.Init(mios)
+	fs.DisableFsIDCheck()
+	if _, err := os.Stat(mpath); os.IsNotExist(err) {
+		cmn.CreateDir(mpath)
+		defer os.RemoveAll(mpath)
+	}
+	_, err := fs.Add(mpath, "daeID")
+	tassert.CheckFatal(t, err)
+
+	fs.CSM.RegisterContentType(fs.ObjectType, &fs.ObjectContentResolver{})
+	fs.CSM.RegisterContentType(fs.MultipartType, &fs.MultipartContentResolver{})
+
+	mpaths, _ := fs.Get()
+	fqn := mpaths[mpath].MakePathFQN(bck, fs.ObjectType, objName)
+	partFQN := fs.CSM.GenContentFQN(fqn, fs.MultipartType, prefix)
+
+	spec, info := fs.CSM.FileSpec(partFQN)
+	if spec == nil {
+		t.Fatalf("failed to parse %q", partFQN)
+	}
+	if info.Type != fs.MultipartType {
+		t.Errorf("content type %q, expected %q", info.Type, fs.MultipartType)
+	}
+	if info.Base != "name.tar" {
+		t.Errorf("base %q, expected %q", info.Base, "name.tar")
+	}
+	if info.Old {
+		t.Errorf("part of the current process must not be old")
+	}
+	if spec.PermToMove() {
+		t.Errorf("multipart content must not be moved")
+	}
+
+	// part of the upload started by a previous process
+	oldFQN := partFQN[:strings.LastIndex(partFQN, ".")] + ".0"
+	if _, info = fs.CSM.FileSpec(oldFQN); info == nil || !info.Old {
+		t.Errorf("part %q must be old", oldFQN)
+	}
+}
+
+func TestObjVersionContentFQN(t *testing.T) {
+	const (
+		mpath   = "/tmp/path"
+		objName = "object/name.v2.tar"
+		version = "12"
+	)
+	var (
+		mios = ios.NewIOStaterMock()
+		bck  = cmn.Bck{Name: "bucket", Provider: cmn.ProviderAIS, Ns: cmn.NsGlobal}
+	)
+	fs.Init(mios)
+	fs.DisableFsIDCheck()
+	if _, err := os.Stat(mpath); os.IsNotExist(err) {
+		cmn.CreateDir(mpath)
+		defer os.RemoveAll(mpath)
+	}
+	_, err := fs.Add(mpath, "daeID")
+	tassert.CheckFatal(t, err)
+
+	fs.CSM.RegisterContentType(fs.ObjectType, &fs.ObjectContentResolver{})
+	fs.CSM.RegisterContentType(fs.ObjVersionType, &fs.ObjVersionContentResolver{})
+
+	mpaths, _ := fs.Get()
+	fqn := mpaths[mpath].MakePathFQN(bck, fs.ObjectType, objName)
+	verFQN := fs.CSM.GenContentFQN(fqn, fs.ObjVersionType, version)
+
+	spec, info := fs.CSM.FileSpec(verFQN)
+	if spec == nil {
+		t.Fatalf("failed to parse %q", verFQN)
+	}
+	if info.Type != fs.ObjVersionType {
+		t.Errorf("content type %q, expected %q", info.Type, fs.ObjVersionType)
+	}
+	if info.Base != "name.v2.tar" {
+		t.Errorf("base %q, expected %q", info.Base, "name.v2.tar")
+	}
+	if spec.PermToEvict() || spec.PermToMove() || spec.PermToProcess() {
+		t.Errorf("prior versions must not be evicted, moved, or processed")
+	}
+	for _, base := range []string{"name", "name.", ".12", "name.1/2"} {
+		if _, _, ok := fs.ParseObjVersion(base); ok {
+			t.Errorf("%q must not be parsed as a version", base)
+		}
+	}
+}
+
+func TestETLCacheContentFQN(t *testing.T) {
+	const (
+		mpath   = "/tmp/path"
+		objName = "object/name.v2.tar"
+		etlID   = "f2cf92f1-8bd3"
+	)
+	var (
+		mios = ios.NewIOStaterMock()
+		bck  = cmn.Bck{Name: "bucket", Provider: cmn.ProviderAIS, Ns: cmn.NsGlobal}
+	)
+	fs.Init(mios)
+	fs.DisableFsIDCheck()
+	if _, err := os.Stat(mpath); os.IsNotExist(err) {
+		cmn.CreateDir(mpath)
+		defer os.RemoveAll(mpath)
+	}
+	_, err := fs.Add(mpath, "daeID")
+	tassert.CheckFatal(t, err)
+
+	fs.CSM.RegisterContentType(fs.ObjectType, &fs.ObjectContentResolver{})
+	fs.CSM.RegisterContentType(fs.ETLCacheType, &fs.ETLCacheContentResolver{})
+
+	mpaths, _ := fs.Get()
+	fqn := mpaths[mpath].MakePathFQN(bck, fs.ObjectType, objName)
+	cacheFQN := fs.CSM.GenContentFQN(fqn, fs.ETLCacheType, etlID)
+
+	spec, info := fs.CSM.FileSpec(cacheFQN)
+	if spec == nil {
+		t.Fatalf("failed to parse %q", cacheFQN)
+	}
+	if info.Type != fs.ETLCacheType {
+		t.Errorf("content type %q, expected %q", info.Type, fs.ETLCacheType)
+	}
+	if info.Base != "name.v2.tar" {
+		t.Errorf("base %q, expected %q", info.Base, "name.v2.tar")
+	}
+	if !spec.PermToEvict() || spec.PermToMove() || spec.PermToProcess() {
+		t.Errorf("cached results must be evictable but not moved or processed")
+	}
+	_, base := filepath.Split(cacheFQN)
+	if _, id, ok := fs.ParseETLCache(base); !ok || id != etlID {
+		t.Errorf("ETL ID %q, expected %q", id, etlID)
+	}
+
+	// ETL ID may contain dots
+	_, base = filepath.Split(fs.CSM.GenContentFQN(fqn, fs.ETLCacheType, "etl.v1.2"))
+	if orig, id, ok := fs.ParseETLCache(base); !ok || id != "etl.v1.2" || orig != "name.v2.tar" {
+		t.Errorf("ETL ID %q (object %q), expected %q (%q)", id, orig, "etl.v1.2", "name.v2.tar")
+	}
+}
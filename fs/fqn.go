@@ -25,7 +25,9 @@ const (
 	WorkfileColdget = "cold"   // object GET: coldget
 	WorkfilePut     = "put"    // object PUT
 	WorkfileAppend  = "append" // object APPEND
+	WorkfileScrub   = "scrub"  // object restored by scrub
 	WorkfileFSHC    = "fshc"   // FSHC test file
+	WorkfileETL     = "etl"    // ETL: caching transformed object
 )
 
 type ParsedFQN struct {
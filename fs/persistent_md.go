@@ -18,8 +18,11 @@ const (
 	NodeRestartedMarker = "node_restarted"
 	RebalanceMarker     = "rebalance"
 	ResilverMarker      = "resilver"
+	ECRelayoutMarker    = "ec_relayout" // + "." + bucket digest (see ec.RelayoutInterrupted)
 	markersDirName      = ".ais.markers"
 
+	DSortCheckpointDirName = ".ais.dsort" // checkpoints of resumable dSort jobs
+
 	BmdPersistedFileName = ".ais.bmd"
 	BmdPersistedPrevious = BmdPersistedFileName + ".prev" // previous version
 
@@ -29,6 +32,7 @@ const (
 // List of AIS metadata files and directories (basenames only)
 var mdFilesDirs = []string{
 	markersDirName,
+	DSortCheckpointDirName,
 
 	BmdPersistedFileName,
 	BmdPersistedPrevious,
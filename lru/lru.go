@@ -8,6 +8,7 @@ package lru
 import (
 	"container/heap"
 	"fmt"
+	"math"
 	"os"
 	"path/filepath"
 	"sort"
@@ -41,13 +42,36 @@ import (
 //   - runLRU - to initiate a new LRU extended action on the local target
 // All other methods are private to this module and are used only internally.
 
-// TODO: extend LRU to remove CTs beyond just []string{fs.WorkfileType, fs.ObjectType}
+// In addition, LRU enforces retention policy of the ais buckets that keep prior
+// versions of objects (see `versioning.max_versions` and `versioning.max_age`):
+// prior versions beyond the configured limits are removed regardless of the
+// used capacity.
+
+// Cached results of ETL transformations (fs.ETLCacheType) can be always recomputed,
+// and so they are evicted first (least recently used first) - before any objects.
+
+// The order in which the objects of a given bucket get evicted is determined by
+// the bucket's eviction policy (`lru.policy`):
+//   - "lru"  - least recently accessed first (default)
+//   - "lfu"  - least frequently accessed first; the frequency is the number of
+//              accesses (see cluster.LOM.AccessCnt) that halves every `freqHalfLife`
+//              since the last access, so that formerly popular objects eventually age out
+//   - "gdsf" - the same frequency divided by the object size (greedy-dual-size-frequency),
+//              to retain more small popular objects at the expense of large ones
+//
+// In addition, a bucket can be given a capacity quota (`lru.quota`) that is split
+// evenly between the target's mountpaths. Once exceeded, the bucket gets evicted down
+// to its quota times LowWM/HighWM - regardless of the used capacity and without
+// touching any other buckets.
+
+// TODO: extend LRU to remove CTs beyond just []string{fs.WorkfileType, fs.MultipartType, fs.ObjectType, fs.ObjVersionType, fs.ETLCacheType}
 
 // LRU defaults/tunables
 const (
 	minEvictThresh = 10 * cmn.MiB
 	capCheckThresh = 256 * cmn.MiB // capacity checking threshold, when exceeded may result in lru throttling
 
+	freqHalfLife = 24 * time.Hour // see "lfu" and "gdsf" policies (above)
 	xactIdleTime = 30 * time.Second
 )
 
@@ -62,9 +86,24 @@ type (
 		GetFSStats          func(path string) (blocks, bavail uint64, bsize int64, err error)
 	}
 
-	// minHeap keeps fileInfo sorted by access time with oldest
-	// on top of the heap.
-	minHeap []*cluster.LOM
+	// minHeap keeps objects sorted by eviction priority with the lowest
+	// (to be evicted first) on top of the heap.
+	minHeap []*heapItem
+
+	heapItem struct {
+		lom  *cluster.LOM
+		prio float64
+	}
+
+	// computes eviction priority of the object: the lower the sooner it gets evicted
+	policy func(lom *cluster.LOM, now int64) float64
+
+	// cached result of ETL transformation
+	etlCacheFile struct {
+		fqn   string
+		size  int64
+		atime int64
+	}
 
 	// parent - contains mpath joggers
 	lruP struct {
@@ -79,9 +118,12 @@ type (
 		// runtime
 		curSize   int64
 		totalSize int64 // difference between lowWM size and used size
-		newest    int64
+		maxPrio   float64
 		heap      *minHeap
+		policy    string // eviction policy of the current bucket
+		quota     bool   // evicting the current bucket because of its quota
 		oldWork   []string
+		etlCache  []etlCacheFile
 		misplaced []*cluster.LOM
 		bck       cmn.Bck
 		now       int64
@@ -108,9 +150,29 @@ type (
 		xaction.XactDemandBase
 		Renewed           chan struct{}
 		OkRemoveMisplaced func() bool
+		// stats
+		mtx     sync.Mutex
+		evicted map[string]EvictStats // by eviction policy
+		quota   EvictStats
+	}
+
+	EvictStats struct {
+		Objects int64 `json:"objects,string"`
+		Bytes   int64 `json:"bytes,string"`
+	}
+
+	StatsExt struct {
+		Policies map[string]EvictStats `json:"policies"` // evicted by each eviction policy
+		Quota    EvictStats            `json:"quota"`    // evicted because of bucket quotas (included in the above)
 	}
 )
 
+var policies = map[string]policy{
+	cmn.LRUPolicyLRU:  prioLRU,
+	cmn.LRUPolicyLFU:  prioLFU,
+	cmn.LRUPolicyGDSF: prioGDSF,
+}
+
 // interface guard
 var _ xaction.XactDemand = (*Xaction)(nil)
 
@@ -157,6 +219,7 @@ func Run(ini *InitLRU) {
 		joggers[mpath] = &lruJ{
 			heap:      &h,
 			oldWork:   make([]string, 0, 64),
+			etlCache:  make([]etlCacheFile, 0, 64),
 			misplaced: make([]*cluster.LOM, 0, 64),
 			stopCh:    make(chan struct{}, 1),
 			mpathInfo: mpathInfo,
@@ -180,6 +243,14 @@ repeat:
 			if err = j.removeTrash(); err != nil {
 				goto ex
 			}
+			if err = j.trimVersions(); err != nil {
+				goto ex
+			}
+			if len(j.ini.Buckets) == 0 {
+				if err = j.jogQuotas(); err != nil {
+					goto ex
+				}
+			}
 			// compute the size (bytes) to free up (and do it after removing the $trash)
 			if err = j.evictSize(); err != nil {
 				goto ex
@@ -234,6 +305,34 @@ repeat:
 func (r *Xaction) Run() error { cmn.Assert(false); return nil }
 func (r *Xaction) Renew()     { r.Renewed <- struct{}{} }
 
+func (r *Xaction) Stats() cluster.XactStats {
+	baseStats := r.XactDemandBase.Stats().(*xaction.BaseXactStats)
+	ext := &StatsExt{Policies: make(map[string]EvictStats, len(policies))}
+	r.mtx.Lock()
+	for name, es := range r.evicted {
+		ext.Policies[name] = es
+	}
+	ext.Quota = r.quota
+	r.mtx.Unlock()
+	return &xaction.BaseXactStatsExt{BaseXactStats: *baseStats, Ext: ext}
+}
+
+func (r *Xaction) addEvicted(policy string, objs, bytes int64, quota bool) {
+	r.mtx.Lock()
+	if r.evicted == nil {
+		r.evicted = make(map[string]EvictStats, len(policies))
+	}
+	es := r.evicted[policy]
+	es.Objects += objs
+	es.Bytes += bytes
+	r.evicted[policy] = es
+	if quota {
+		r.quota.Objects += objs
+		r.quota.Bytes += bytes
+	}
+	r.mtx.Unlock()
+}
+
 //////////
 // lruJ //
 //////////
@@ -299,6 +398,55 @@ func (j *lruJ) jogBcks(bcks []cmn.Bck, force bool) (err error) {
 	return
 }
 
+// evict the buckets that exceed their share of the capacity quota on this mountpath
+func (j *lruJ) jogQuotas() (err error) {
+	var (
+		bcks []cmn.Bck
+		num  = int64(len(j.joggers))
+		bmd  = j.ini.T.Bowner().Get()
+	)
+	bmd.Range(nil, nil, func(bck *cluster.Bck) bool {
+		if bck.Props.LRU.Enabled && bck.Props.LRU.Quota > 0 {
+			bcks = append(bcks, bck.Bck)
+		}
+		return false
+	})
+	for _, bck := range bcks {
+		var (
+			size  uint64
+			props *cmn.BucketProps
+			path  = j.mpathInfo.MakePathCT(bck, fs.ObjectType)
+		)
+		if size, err = ios.GetDirSize(path); err != nil {
+			err = nil
+			continue // nothing stored on this mountpath
+		}
+		j.bck = bck
+		if props, err = j.bprops(); err != nil {
+			err = nil
+			continue
+		}
+		share := props.LRU.Quota / num
+		if int64(size) <= share {
+			continue
+		}
+		if j.allowDelObj, err = j.allow(); err != nil || !j.allowDelObj {
+			err = nil
+			continue
+		}
+		j.totalSize = int64(size) - share*j.config.LRU.LowWM/j.config.LRU.HighWM
+		glog.Infof("%s: %s exceeds its quota, freeing-up %s", j, bck, cmn.B2S(j.totalSize, 2))
+		j.quota = true
+		_, err = j.jogBck()
+		j.quota = false
+		if err != nil {
+			return
+		}
+	}
+	j.totalSize = 0
+	return
+}
+
 func (j *lruJ) removeTrash() (err error) {
 	trashDir := j.mpathInfo.MakePathTrash()
 	err = fs.Scanner(trashDir, func(fqn string, de fs.DirEntry) error {
@@ -324,18 +472,112 @@ func (j *lruJ) removeTrash() (err error) {
 	return
 }
 
+// remove prior versions of the objects beyond the limits configured for the bucket
+func (j *lruJ) trimVersions() (err error) {
+	var (
+		bcks []cmn.Bck
+		opts = fs.Options{
+			Mpath: j.mpathInfo,
+			Bck:   cmn.Bck{Provider: cmn.ProviderAIS, Ns: cmn.NsGlobal},
+		}
+	)
+	if bcks, err = fs.AllMpathBcks(&opts); err != nil {
+		return
+	}
+	for _, bck := range bcks {
+		b := cluster.NewBckEmbed(bck)
+		if err := b.Init(j.ini.T.Bowner(), j.ini.T.Snode()); err != nil {
+			continue
+		}
+		if !b.Props.Versioning.KeepVersions() {
+			continue
+		}
+		if err = j.trimBckVersions(b.Bck); err != nil {
+			return
+		}
+	}
+	return
+}
+
+func (j *lruJ) trimBckVersions(bck cmn.Bck) (err error) {
+	var (
+		fevicted, bevicted int64
+		objFQNs            = make(cmn.StringSet, 64)
+		xlru               = j.ini.Xaction
+	)
+	// 1. collect (ObjectType FQNs of) the objects that have prior versions
+	opts := &fs.Options{
+		Mpath: j.mpathInfo,
+		Bck:   bck,
+		CTs:   []string{fs.ObjVersionType},
+		Callback: func(fqn string, de fs.DirEntry) error {
+			if de.IsDir() {
+				return nil
+			}
+			if err := j.yieldTerm(); err != nil {
+				return err
+			}
+			parsedFQN, err := fs.ParseFQN(fqn)
+			if err != nil {
+				return nil
+			}
+			dir, base := filepath.Split(parsedFQN.ObjName)
+			if orig, _, ok := fs.ParseObjVersion(base); ok {
+				objFQNs.Add(fs.CSM.FQN(j.mpathInfo, bck, fs.ObjectType, dir+orig))
+			}
+			return nil
+		},
+	}
+	if err = fs.Walk(opts); err != nil {
+		return
+	}
+	// 2. trim
+	for fqn := range objFQNs {
+		lom := &cluster.LOM{FQN: fqn}
+		if err := lom.Init(bck); err != nil {
+			continue
+		}
+		lom.Lock(true)
+		removed, size, err := lom.TrimVersions()
+		lom.Unlock(true)
+		if err != nil {
+			glog.Errorf("%s: failed to trim prior versions of %s: %v", j, lom, err)
+		}
+		fevicted += int64(removed)
+		bevicted += size
+		if err = j.yieldTerm(); err != nil {
+			return err
+		}
+	}
+	if fevicted > 0 {
+		glog.Infof("%s: removed %d prior version(s) (%s) of the objects in %s", j, fevicted, cmn.B2S(bevicted, 2), bck)
+	}
+	j.ini.StatsT.Add(stats.LruEvictSize, bevicted)
+	j.ini.StatsT.Add(stats.LruEvictCount, fevicted)
+	xlru.ObjectsAdd(fevicted)
+	xlru.BytesAdd(bevicted)
+	return
+}
+
 func (j *lruJ) jogBck() (size int64, err error) {
 	// 1. init per-bucket min-heap (and reuse the slice)
 	h := (*j.heap)[:0]
 	j.heap = &h
 	heap.Init(j.heap)
+	j.curSize, j.maxPrio = 0, 0
+	j.policy = cmn.LRUPolicyLRU
+	if props, err := j.bprops(); err == nil {
+		if _, ok := policies[props.LRU.Policy]; ok {
+			j.policy = props.LRU.Policy
+		}
+	}
 
 	// 2. collect
 	// TODO: LRU other CTs besides WorkfileType
 	opts := &fs.Options{
 		Mpath:    j.mpathInfo,
 		Bck:      j.bck,
-		CTs:      []string{fs.WorkfileType, fs.ObjectType},
+		CTs:      []string{fs.WorkfileType, fs.MultipartType, fs.ObjectType, fs.ETLCacheType},
 		Callback: j.walk,
 		Sorted:   false,
 	}
@@ -370,6 +612,20 @@ func (j *lruJ) walk(fqn string, de fs.DirEntry) error {
 		}
 		return nil
 	}
+	// parts of the multipart uploads that did not survive the restart
+	if parsedFQN.ContentType == fs.MultipartType {
+		if _, info := fs.CSM.FileSpec(fqn); info != nil && info.Old {
+			j.oldWork = append(j.oldWork, fqn)
+		}
+		return nil
+	}
+	// cached ETL results: collect all (to evict the least recently used first)
+	if parsedFQN.ContentType == fs.ETLCacheType {
+		if finfo, err := os.Stat(fqn); err == nil {
+			j.etlCache = append(j.etlCache, etlCacheFile{fqn: fqn, size: finfo.Size(), atime: finfo.ModTime().UnixNano()})
+		}
+		return nil
+	}
 	lom := &cluster.LOM{ObjName: parsedFQN.ObjName, MpathInfo: parsedFQN.MpathInfo, Digest: parsedFQN.Digest}
 	err = lom.Init(j.bck)
 	if err != nil {
@@ -388,20 +644,24 @@ func (j *lruJ) walk(fqn string, de fs.DirEntry) error {
 	if lom.HasCopies() && lom.IsCopy() {
 		return nil
 	}
+	if lom.IsDirty() { // not yet written to the Cloud
+		return nil
+	}
 	if !lom.IsHRW() {
 		j.misplaced = append(j.misplaced, lom)
 		return nil
 	}
 
 	// do nothing if the heap's curSize >= totalSize and
-	// the file is more recent then the the heap's newest.
-	if j.curSize >= j.totalSize && lom.AtimeUnix() > j.newest {
+	// the object is less evictable than any object in the heap
+	prio := policies[j.policy](lom, j.now)
+	if j.curSize >= j.totalSize && prio > j.maxPrio {
 		return nil
 	}
-	heap.Push(h, lom)
+	heap.Push(h, &heapItem{lom: lom, prio: prio})
 	j.curSize += lom.Size()
-	if lom.AtimeUnix() > j.newest {
-		j.newest = lom.AtimeUnix()
+	if prio > j.maxPrio || h.Len() == 1 {
+		j.maxPrio = prio
 	}
 	return nil
 }
@@ -426,6 +686,8 @@ func (j *lruJ) evict() (size int64, err error) {
 	}
 	j.oldWork = j.oldWork[:0]
 	// 2.
+	size += j.evictETLCache()
+	// 3.
 	f := j.ini.Xaction.OkRemoveMisplaced
 	if f == nil || f() {
 		for _, lom := range j.misplaced {
@@ -452,9 +714,9 @@ func (j *lruJ) evict() (size int64, err error) {
 		}
 	}
 	j.misplaced = j.misplaced[:0]
-	// 3.
+	// 4.
 	for h.Len() > 0 && j.totalSize > 0 {
-		lom := heap.Pop(h).(*cluster.LOM)
+		lom := heap.Pop(h).(*heapItem).lom
 		if j.evictObj(lom) {
 			bevicted += lom.Size()
 			size += lom.Size()
@@ -466,8 +728,33 @@ func (j *lruJ) evict() (size int64, err error) {
 	}
 	j.ini.StatsT.Add(stats.LruEvictSize, bevicted)
 	j.ini.StatsT.Add(stats.LruEvictCount, fevicted)
+	if j.quota {
+		j.ini.StatsT.Add(stats.LruEvictQuotaSize, bevicted)
+		j.ini.StatsT.Add(stats.LruEvictQuotaCount, fevicted)
+	}
 	xlru.ObjectsAdd(fevicted)
 	xlru.BytesAdd(bevicted)
+	if fevicted > 0 {
+		xlru.addEvicted(j.policy, fevicted, bevicted, j.quota)
+	}
+	return
+}
+
+// evictETLCache removes the least recently used cached ETL results (see `etl.cacheComm`).
+func (j *lruJ) evictETLCache() (size int64) {
+	sort.Slice(j.etlCache, func(i, k int) bool { return j.etlCache[i].atime < j.etlCache[k].atime })
+	for _, cf := range j.etlCache {
+		if j.totalSize <= 0 {
+			break
+		}
+		if err := cmn.RemoveFile(cf.fqn); err != nil {
+			glog.Warningf("%s: failed to remove cached ETL result %q: %v", j, cf.fqn, err)
+			continue
+		}
+		j.totalSize -= cf.size
+		size += cf.size
+	}
+	j.etlCache = j.etlCache[:0]
 	return
 }
 
@@ -589,14 +876,45 @@ func (j *lruJ) allow() (ok bool, err error) {
 	return
 }
 
+func (j *lruJ) bprops() (*cmn.BucketProps, error) {
+	b := cluster.NewBckEmbed(j.bck)
+	if err := b.Init(j.ini.T.Bowner(), j.ini.T.Snode()); err != nil {
+		return nil, err
+	}
+	return b.Props, nil
+}
+
+//////////////
+// policies //
+//////////////
+
+func prioLRU(lom *cluster.LOM, _ int64) float64   { return float64(lom.AtimeUnix()) }
+func prioLFU(lom *cluster.LOM, now int64) float64 { return freq(lom, now) }
+
+func prioGDSF(lom *cluster.LOM, now int64) float64 {
+	return freq(lom, now) / float64(cmn.MaxI64(lom.Size(), 1))
+}
+
+// number of accesses (counting the initial write) that halves every
+// `freqHalfLife` since the last access
+func freq(lom *cluster.LOM, now int64) float64 {
+	age := float64(cmn.MaxI64(now-lom.AtimeUnix(), 0))
+	return float64(lom.AccessCnt()+1) * math.Exp2(-age/float64(freqHalfLife))
+}
+
 //////////////
 // min-heap //
 //////////////
 
-func (h minHeap) Len() int            { return len(h) }
-func (h minHeap) Less(i, j int) bool  { return h[i].Atime().Before(h[j].Atime()) }
+func (h minHeap) Len() int { return len(h) }
+func (h minHeap) Less(i, j int) bool {
+	if h[i].prio != h[j].prio {
+		return h[i].prio < h[j].prio
+	}
+	return h[i].lom.AtimeUnix() < h[j].lom.AtimeUnix()
+}
 func (h minHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
-func (h *minHeap) Push(x interface{}) { *h = append(*h, x.(*cluster.LOM)) }
+func (h *minHeap) Push(x interface{}) { *h = append(*h, x.(*heapItem)) }
 func (h *minHeap) Pop() interface{} {
 	old := *h
 	n := len(old)
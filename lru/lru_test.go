@@ -128,6 +128,7 @@ func createAndAddMountpath(path string) {
 
 	fs.CSM.RegisterContentType(fs.ObjectType, &fs.ObjectContentResolver{})
 	fs.CSM.RegisterContentType(fs.WorkfileType, &fs.WorkfileContentResolver{})
+	fs.CSM.RegisterContentType(fs.MultipartType, &fs.MultipartContentResolver{})
 }
 
 func getRandomFileName(fileCounter int) string {
@@ -297,6 +298,126 @@ var _ = Describe("LRU tests", func() {
 			})
 		})
 
+		Describe("eviction policies", func() {
+			setLRUConf := func(name string, f func(conf *cmn.LRUConf)) {
+				props, ok := t.Bowner().Get().Get(cluster.NewBck(name, cmn.ProviderAIS, cmn.NsGlobal, nil))
+				Expect(ok).To(BeTrue())
+				f(&props.LRU)
+			}
+			statsExt := func() *lru.StatsExt {
+				return ini.Xaction.Stats().(*xaction.BaseXactStatsExt).Ext.(*lru.StatsExt)
+			}
+			access := func(filesPath string, files []fileMetadata, cnt int) {
+				for _, file := range files {
+					lom := &cluster.LOM{FQN: path.Join(filesPath, file.name)}
+					Expect(lom.Init(cmn.Bck{})).NotTo(HaveOccurred())
+					Expect(lom.Load(false)).NotTo(HaveOccurred())
+					for i := 0; i < cnt; i++ {
+						lom.IncAccessCnt()
+					}
+					Expect(lom.Persist()).NotTo(HaveOccurred())
+					lom.Uncache()
+				}
+			}
+
+			It("should evict the least frequently used files", func() {
+				const numberOfFiles = 6
+				setLRUConf(bucketName, func(conf *cmn.LRUConf) { conf.Policy = cmn.LRUPolicyLFU })
+				ini.GetFSStats = getMockGetFSStats(numberOfFiles)
+
+				// older but popular
+				popularFiles := []fileMetadata{
+					{getRandomFileName(0), fileSize},
+					{getRandomFileName(1), fileSize},
+					{getRandomFileName(2), fileSize},
+				}
+				saveRandomFilesWithMetadata(filesPath, popularFiles)
+				access(filesPath, popularFiles, 5)
+				time.Sleep(1 * time.Second)
+				saveRandomFiles(filesPath, 3)
+
+				lru.Run(ini)
+
+				files, err := ioutil.ReadDir(filesPath)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(len(files)).To(Equal(3))
+
+				popularNames := namesFromFilesMetadatas(popularFiles)
+				for _, name := range files {
+					Expect(cmn.StringInSlice(name.Name(), popularNames)).To(BeTrue())
+				}
+				Expect(statsExt().Policies[cmn.LRUPolicyLFU].Objects).To(BeEquivalentTo(3))
+				Expect(statsExt().Policies[cmn.LRUPolicyLRU].Objects).To(BeZero())
+			})
+
+			It("should evict large files first", func() {
+				const totalSize = 32 * cmn.MiB
+				setLRUConf(bucketName, func(conf *cmn.LRUConf) { conf.Policy = cmn.LRUPolicyGDSF })
+				ini.GetFSStats = func(string) (blocks, bavail uint64, bsize int64, err error) {
+					bsize = blockSize
+					btaken := uint64(totalSize / blockSize)
+					blocks = uint64(float64(btaken) / initialDiskUsagePct)
+					bavail = blocks - btaken
+					return
+				}
+
+				files := []fileMetadata{
+					{getRandomFileName(0), int64(4 * cmn.MiB)},
+					{getRandomFileName(1), int64(16 * cmn.MiB)},
+					{getRandomFileName(2), int64(4 * cmn.MiB)},
+					{getRandomFileName(3), int64(8 * cmn.MiB)},
+				}
+				saveRandomFilesWithMetadata(filesPath, files)
+
+				// evicting the largest file is enough to go under lwm
+				lru.Run(ini)
+
+				filesLeft, err := ioutil.ReadDir(filesPath)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(len(filesLeft)).To(Equal(3))
+				for _, name := range filesLeft {
+					Expect(name.Name()).NotTo(Equal(files[1].name))
+				}
+				Expect(statsExt().Policies[cmn.LRUPolicyGDSF].Bytes).To(BeEquivalentTo(16 * cmn.MiB))
+			})
+
+			It("should evict only the bucket that exceeds its quota", func() {
+				const (
+					numberOfFiles = 10
+					smallFileSize = cmn.MiB
+					quota         = 6 * cmn.MiB
+				)
+				setLRUConf(bucketName, func(conf *cmn.LRUConf) { conf.Quota = quota })
+				setLRUConf(bucketNameAnother, func(conf *cmn.LRUConf) { conf.Enabled = true })
+				// used capacity is well below hwm
+				ini.GetFSStats = func(string) (blocks, bavail uint64, bsize int64, err error) {
+					bsize = blockSize
+					btaken := uint64(2 * numberOfFiles * smallFileSize / blockSize)
+					blocks = btaken * 10
+					bavail = blocks - btaken
+					return
+				}
+				for i := 0; i < numberOfFiles; i++ {
+					saveRandomFile(path.Join(filesPath, getRandomFileName(i)), smallFileSize)
+					saveRandomFile(path.Join(fpAnother, getRandomFileName(i)), smallFileSize)
+				}
+
+				lru.Run(ini)
+
+				files, err := ioutil.ReadDir(filesPath)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(len(files)).To(BeNumerically(">", 0))
+				Expect(len(files) * smallFileSize).To(BeNumerically("<=", quota*lwm/hwm))
+				filesAnother, err := ioutil.ReadDir(fpAnother)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(len(filesAnother)).To(Equal(numberOfFiles))
+
+				evicted := int64(numberOfFiles - len(files))
+				Expect(statsExt().Quota.Objects).To(Equal(evicted))
+				Expect(statsExt().Policies[cmn.LRUPolicyLRU].Objects).To(Equal(evicted))
+			})
+		})
+
 		Describe("not evict files", func() {
 			It("should do nothing when disk usage is below hwm", func() {
 				const numberOfFiles = 4
@@ -333,6 +454,26 @@ var _ = Describe("LRU tests", func() {
 				Expect(len(files)).To(Equal(numberOfFiles))
 			})
 
+			It("should not evict dirty objects", func() {
+				saveRandomFiles(filesPath, numberOfCreatedFiles)
+				files, err := ioutil.ReadDir(filesPath)
+				Expect(err).NotTo(HaveOccurred())
+				for _, file := range files {
+					lom := &cluster.LOM{FQN: path.Join(filesPath, file.Name())}
+					Expect(lom.Init(cmn.Bck{})).NotTo(HaveOccurred())
+					Expect(lom.Load(false)).NotTo(HaveOccurred())
+					lom.SetCustomMD(cmn.SimpleKVs{cluster.DirtyObjMD: cmn.GenTie()})
+					Expect(lom.Persist()).NotTo(HaveOccurred())
+					lom.Uncache()
+				}
+
+				lru.Run(ini)
+
+				files, err = ioutil.ReadDir(filesPath)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(len(files)).To(Equal(numberOfCreatedFiles))
+			})
+
 			It("should not evict if LRU disabled and force is false", func() {
 				saveRandomFiles(fpAnother, numberOfCreatedFiles)
 
@@ -347,6 +488,28 @@ var _ = Describe("LRU tests", func() {
 			})
 		})
 
+		Describe("evict multipart uploads", func() {
+			It("should remove parts of the uploads of the previous process", func() {
+				var (
+					mpaths, _ = fs.Get()
+					bck       = cmn.Bck{Name: bucketName, Provider: cmn.ProviderAIS, Ns: cmn.NsGlobal}
+					objFQN    = mpaths[basePath].MakePathFQN(bck, fs.ObjectType, "mpt-obj")
+					curFQN    = fs.CSM.GenContentFQN(objFQN, fs.MultipartType, "upload-id.1")
+					oldFQN    = mpaths[basePath].MakePathFQN(bck, fs.MultipartType, "mpt-obj.upload-id.2.0")
+				)
+				saveRandomFiles(filesPath, numberOfCreatedFiles)
+				for _, fqn := range []string{curFQN, oldFQN} {
+					Expect(cmn.CreateDir(path.Dir(fqn))).NotTo(HaveOccurred())
+					Expect(ioutil.WriteFile(fqn, []byte("part"), 0o644)).NotTo(HaveOccurred())
+				}
+
+				lru.Run(ini)
+
+				Expect(curFQN).To(BeARegularFile())
+				Expect(oldFQN).NotTo(BeAnExistingFile())
+			})
+		})
+
 		Describe("evict trash directory", func() {
 			It("should totally evict trash directory", func() {
 				var (
@@ -163,6 +163,7 @@ func (r *Xact) Run() error {
 			// Copy only the values that can change between calls
 			debug.Assert(r.msg.UseCache == msg.UseCache)
 			debug.Assert(r.msg.Prefix == msg.Prefix)
+			debug.Assert(r.msg.Delimiter == msg.Delimiter)
 			debug.Assert(r.msg.Flags == msg.Flags)
 			r.msg.ContinuationToken = msg.ContinuationToken
 			r.msg.PageSize = msg.PageSize
@@ -93,6 +93,8 @@ func (w *Walk) CloudObjPage() (*cmn.BucketList, error) {
 		needCksum   = w.msg.WantProp(cmn.GetPropsChecksum)
 		needVersion = w.msg.WantProp(cmn.GetPropsVersion)
 		needCopies  = w.msg.WantProp(cmn.GetPropsCopies)
+		needUserMD  = w.msg.WantProp(cmn.GetPropsUserMD)
+		needTags    = w.msg.WantProp(cmn.GetPropsTags)
 	)
 
 	for _, e := range objList.Entries {
@@ -134,6 +136,12 @@ func (w *Walk) CloudObjPage() (*cmn.BucketList, error) {
 		if needCopies {
 			e.Copies = int16(lom.NumCopies())
 		}
+		if needUserMD {
+			e.UserMD = lom.UserMD()
+		}
+		if needTags {
+			e.Tags = lom.Tags()
+		}
 
 		if postCallback != nil {
 			postCallback(lom)
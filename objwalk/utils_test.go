@@ -69,3 +69,54 @@ func TestConcatObjLists(t *testing.T) {
 		})
 	}
 }
+
+func TestConcatObjListsVersions(t *testing.T) {
+	var (
+		prev = func(name, ver string) *cmn.BucketEntry {
+			return &cmn.BucketEntry{Name: name, Version: ver, Flags: cmn.EntryIsPrevVer}
+		}
+		lists = []*cmn.BucketList{
+			{Entries: []*cmn.BucketEntry{
+				{Name: "a", Version: "11"}, prev("a", "9"), prev("a", "10"), {Name: "c", Version: "1"},
+			}},
+			{Entries: []*cmn.BucketEntry{
+				prev("b", "2"), {Name: "b", Version: "3"}, prev("a", "10"), {Name: "a", Version: "11"},
+			}},
+		}
+		expected = []string{"a/11", "a/10", "a/9", "b/3", "b/2"}
+	)
+	objs := cmn.ConcatObjLists(lists, 2)
+	tassert.Fatalf(t, len(objs.Entries) == len(expected), "expected %d entries, got %d", len(expected), len(objs.Entries))
+	for i, e := range objs.Entries {
+		tassert.Errorf(t, e.Name+"/"+e.Version == expected[i], "entry %d: expected %q, got %q", i, expected[i], e.Name+"/"+e.Version)
+		tassert.Errorf(t, e.IsPrevVersion() == (i != 0 && objs.Entries[i-1].Name == e.Name), "entry %d: unexpected flags", i)
+	}
+	tassert.Errorf(t, objs.ContinuationToken == "b", "expected continuation token %q, got %q", "b", objs.ContinuationToken)
+}
+
+func TestDirEntryName(t *testing.T) {
+	tests := []struct {
+		prefix    string
+		delimiter string
+		objName   string
+		dir       string
+	}{
+		{prefix: "", delimiter: "", objName: "a/b/c", dir: ""},
+		{prefix: "", delimiter: "/", objName: "obj", dir: ""},
+		{prefix: "", delimiter: "/", objName: "a/b/c", dir: "a/"},
+		{prefix: "a/", delimiter: "/", objName: "a/b/c", dir: "a/b/"},
+		{prefix: "a/", delimiter: "/", objName: "a/c", dir: ""},
+		{prefix: "a/b", delimiter: "/", objName: "a/bc/d", dir: "a/bc/"},
+		{prefix: "b/", delimiter: "/", objName: "a/b/c", dir: ""},
+		{prefix: "", delimiter: "--", objName: "x--y--z", dir: "x--"},
+		{prefix: "x--", delimiter: "--", objName: "x--y--z", dir: "x--y--"},
+	}
+	for _, test := range tests {
+		dir := cmn.DirEntryName(test.prefix, test.delimiter, test.objName)
+		tassert.Errorf(
+			t, dir == test.dir,
+			"prefix=%q, delimiter=%q, name=%q: expected %q, got %q",
+			test.prefix, test.delimiter, test.objName, test.dir, dir,
+		)
+	}
+}
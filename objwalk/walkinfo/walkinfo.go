@@ -8,6 +8,7 @@ import (
 	"context"
 	"path/filepath"
 	"strings"
+	"sync"
 
 	"github.com/NVIDIA/aistore/cluster"
 	"github.com/NVIDIA/aistore/cmn"
@@ -25,10 +26,13 @@ type (
 		objectFilter cluster.ObjectFilter
 		propNeeded   map[string]bool
 		prefix       string
+		delimiter    string
+		dirs         sync.Map // directories (common prefixes) listed so far
 		Marker       string
 		markerDir    string
 		msg          *cmn.SelectMsg
 		timeFormat   string
+		deleted      map[string]struct{} // deleted objects listed so far (SelectVersions)
 	}
 
 	PostCallbackFunc func(lom *cluster.LOM)
@@ -45,6 +49,8 @@ var wiProps = []string{
 	cmn.GetPropsVersion,
 	cmn.GetPropsStatus,
 	cmn.GetPropsCopies,
+	cmn.GetPropsUserMD,
+	cmn.GetPropsTags,
 	cmn.GetTargetURL,
 }
 
@@ -73,6 +79,7 @@ func NewWalkInfo(ctx context.Context, t cluster.Target, msg *cmn.SelectMsg) *Wal
 		smap:         t.Sowner().Get(),
 		postCallback: postCallback,
 		prefix:       msg.Prefix,
+		delimiter:    msg.Delimiter,
 		Marker:       msg.ContinuationToken,
 		markerDir:    markerDir,
 		msg:          msg,
@@ -87,6 +94,8 @@ func (wi *WalkInfo) needCksum() bool     { return wi.propNeeded[cmn.GetPropsChec
 func (wi *WalkInfo) needVersion() bool   { return wi.propNeeded[cmn.GetPropsVersion] }
 func (wi *WalkInfo) needStatus() bool    { return wi.propNeeded[cmn.GetPropsStatus] } //nolint:unused // left for consistency
 func (wi *WalkInfo) needCopies() bool    { return wi.propNeeded[cmn.GetPropsCopies] }
+func (wi *WalkInfo) needUserMD() bool    { return wi.propNeeded[cmn.GetPropsUserMD] }
+func (wi *WalkInfo) needTags() bool      { return wi.propNeeded[cmn.GetPropsTags] }
 func (wi *WalkInfo) needTargetURL() bool { return wi.propNeeded[cmn.GetTargetURL] }
 
 // Checks if the directory should be processed by cache list call
@@ -114,6 +123,18 @@ func (wi *WalkInfo) ProcessDir(fqn string) error {
 		return filepath.SkipDir
 	}
 
+	// With delimiter, all the objects in the directory may roll up into a single
+	// entry: skip the directory if the entry has been already listed.
+	if wi.delimiter != "" {
+		if dir := cmn.DirEntryName(wi.prefix, wi.delimiter, ct.ObjName()+"/"); dir != "" {
+			if _, ok := wi.dirs.Load(dir); ok {
+				return filepath.SkipDir
+			}
+			if wi.Marker != "" && cmn.TokenIncludesObject(wi.Marker, dir) {
+				return filepath.SkipDir
+			}
+		}
+	}
 	return nil
 }
 
@@ -125,15 +146,19 @@ func (wi *WalkInfo) SetObjectFilter(f cluster.ObjectFilter) {
 //  - its name starts with prefix (if prefix is set)
 //  - it has not been already returned by previous page request
 //  - this target responses getobj request for the object
+// With delimiter, the object may instead roll up into a directory entry.
 func (wi *WalkInfo) lsObject(lom *cluster.LOM, objStatus uint16) *cmn.BucketEntry {
 	objName := lom.ObjName
 	if wi.prefix != "" && !strings.HasPrefix(objName, wi.prefix) {
 		return nil
 	}
-	if wi.Marker != "" && cmn.TokenIncludesObject(wi.Marker, objName) {
+	if wi.objectFilter != nil && !wi.objectFilter(lom) {
 		return nil
 	}
-	if wi.objectFilter != nil && !wi.objectFilter(lom) {
+	if dir := cmn.DirEntryName(wi.prefix, wi.delimiter, objName); dir != "" {
+		return wi.lsDir(dir)
+	}
+	if wi.Marker != "" && cmn.TokenIncludesObject(wi.Marker, objName) {
 		return nil
 	}
 
@@ -155,6 +180,12 @@ func (wi *WalkInfo) lsObject(lom *cluster.LOM, objStatus uint16) *cmn.BucketEntr
 	if wi.needCopies() {
 		fileInfo.Copies = int16(lom.NumCopies())
 	}
+	if wi.needUserMD() {
+		fileInfo.UserMD = lom.UserMD()
+	}
+	if wi.needTags() {
+		fileInfo.Tags = lom.Tags()
+	}
 	if wi.needTargetURL() {
 		fileInfo.TargetURL = wi.t.Snode().URL(cmn.NetworkPublic)
 	}
@@ -167,6 +198,18 @@ func (wi *WalkInfo) lsObject(lom *cluster.LOM, objStatus uint16) *cmn.BucketEntr
 	return fileInfo
 }
 
+// Adds a directory (common prefix) entry to the list unless it has been
+// already listed by this or previous page request.
+func (wi *WalkInfo) lsDir(dir string) *cmn.BucketEntry {
+	if wi.Marker != "" && cmn.TokenIncludesObject(wi.Marker, dir) {
+		return nil
+	}
+	if _, loaded := wi.dirs.LoadOrStore(dir, struct{}{}); loaded {
+		return nil
+	}
+	return &cmn.BucketEntry{Name: dir, Flags: cmn.EntryIsDir}
+}
+
 // Since objwalk returns only "accessible" objects by default, it always needs
 // LOM to check if an object is misplaced etc. On the other hand, skipping LOM
 // loading and checking increases bucket list performance. So, when we need
@@ -175,38 +218,151 @@ func (wi *WalkInfo) lsObject(lom *cluster.LOM, objStatus uint16) *cmn.BucketEntr
 // It may be useful only for huge buckets: reading list of a  bucket with 5
 // million objects takes about a minutes, and skipping LOM saves ~5s.
 func (wi *WalkInfo) Callback(fqn string, de fs.DirEntry) (*cmn.BucketEntry, error) {
+	entry, _, err := wi.callback(fqn, de)
+	return entry, err
+}
+
+func (wi *WalkInfo) callback(fqn string, de fs.DirEntry) (*cmn.BucketEntry, *cluster.LOM, error) {
 	if de.IsDir() {
-		return nil, nil
+		return nil, nil, nil
 	}
 
 	var objStatus uint16 = cmn.ObjStatusOK
 	lom := &cluster.LOM{FQN: fqn}
 	if err := lom.Init(cmn.Bck{}); err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	if err := lom.Load(); err != nil {
 		if cmn.IsErrObjNought(err) {
-			return nil, nil
+			return nil, nil, nil
 		}
-		return nil, err
+		return nil, nil, err
 	}
 	if lom.IsCopy() {
-		return nil, nil
+		return nil, nil, nil
 	}
 	if !lom.IsHRW() {
 		objStatus = cmn.ObjStatusMoved
 	} else {
 		si, err := cluster.HrwTarget(lom.Uname(), wi.smap)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 		if wi.t.Snode().ID() != si.ID() {
 			objStatus = cmn.ObjStatusMoved
 		}
 	}
 	if objStatus == cmn.ObjStatusMoved && !wi.msg.IsFlagSet(cmn.SelectMisplaced) {
+		return nil, nil, nil
+	}
+	return wi.lsObject(lom, objStatus), lom, nil
+}
+
+// CallbackVersions is Callback that, in addition, lists prior versions of the
+// object and delete markers (see cmn.SelectVersions) - right after the object.
+// The versions of deleted objects (that have no current version) are listed
+// when walking fs.ObjVersionType content.
+// NOTE: prior versions are kept by the target that owns the object (HRW) and
+// are not listed when the object is misplaced.
+func (wi *WalkInfo) CallbackVersions(fqn string, de fs.DirEntry) ([]*cmn.BucketEntry, error) {
+	if de.IsDir() {
+		return nil, nil
+	}
+	parsedFQN, err := fs.ParseFQN(fqn)
+	if err != nil {
+		return nil, nil
+	}
+	if parsedFQN.ContentType == fs.ObjVersionType {
+		return wi.lsDeleted(parsedFQN)
+	}
+	entry, lom, err := wi.callback(fqn, de)
+	if entry == nil || err != nil {
+		return nil, err
+	}
+	entries := []*cmn.BucketEntry{entry}
+	if entry.IsDir() || !entry.IsStatusOK() {
+		return entries, nil
+	}
+	entry.Version = lom.Version()
+	return wi.lsVersions(lom, entries)
+}
+
+func (wi *WalkInfo) lsVersions(lom *cluster.LOM, entries []*cmn.BucketEntry) ([]*cmn.BucketEntry, error) {
+	vers, err := lom.ListVersions()
+	if err != nil {
+		return nil, err
+	}
+	for _, ver := range vers {
+		entry := &cmn.BucketEntry{Name: lom.ObjName, Version: ver.Version, Flags: cmn.EntryIsPrevVer}
+		if ver.DelMarker {
+			entry.Flags |= cmn.EntryIsDelMark
+		}
+		if wi.needAtime() {
+			entry.Atime = cmn.FormatUnixNano(ver.Mtime, wi.timeFormat)
+		}
+		if wi.needCksum() && ver.Cksum != nil {
+			_, entry.Checksum = ver.Cksum.Get()
+		}
+		if wi.needTargetURL() {
+			entry.TargetURL = wi.t.Snode().URL(cmn.NetworkPublic)
+		}
+		if wi.needSize() {
+			entry.Size = ver.Size
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// lists prior versions of a deleted object (once per object)
+func (wi *WalkInfo) lsDeleted(parsedFQN fs.ParsedFQN) ([]*cmn.BucketEntry, error) {
+	objName, _, ok := fs.ParseObjVersion(parsedFQN.ObjName)
+	if !ok {
+		return nil, nil
+	}
+	if _, ok := wi.deleted[objName]; ok {
+		return nil, nil
+	}
+	if wi.prefix != "" && !strings.HasPrefix(objName, wi.prefix) {
+		return nil, nil
+	}
+	if wi.objectFilter != nil {
+		return nil, nil // filters require the object's metadata
+	}
+	lom := &cluster.LOM{ObjName: objName}
+	if err := lom.Init(parsedFQN.Bck); err != nil {
+		return nil, err
+	}
+	if lom.MpathInfo.Path != parsedFQN.MpathInfo.Path {
+		return nil, nil
+	}
+	si, err := cluster.HrwTarget(lom.Uname(), wi.smap)
+	if err != nil {
+		return nil, err
+	}
+	if wi.t.Snode().ID() != si.ID() {
+		return nil, nil
+	}
+	if err := lom.Load(); err == nil || !cmn.IsObjNotExist(err) {
+		return nil, nil // listed along with the object
+	}
+	if wi.deleted == nil {
+		wi.deleted = make(map[string]struct{}, 16)
+	}
+	wi.deleted[objName] = struct{}{}
+	if dir := cmn.DirEntryName(wi.prefix, wi.delimiter, objName); dir != "" {
+		// (the versions index outlives the versions - see LOM.saveVersIdx)
+		if vers, err := lom.ListVersions(); err != nil || len(vers) == 0 {
+			return nil, err
+		}
+		if entry := wi.lsDir(dir); entry != nil {
+			return []*cmn.BucketEntry{entry}, nil
+		}
+		return nil, nil
+	}
+	if wi.Marker != "" && cmn.TokenIncludesObject(wi.Marker, objName) {
 		return nil, nil
 	}
-	return wi.lsObject(lom, objStatus), nil
+	return wi.lsVersions(lom, nil)
 }
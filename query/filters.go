@@ -39,6 +39,9 @@ const (
 	VersionGeF = "version_ge"
 
 	ExtF = "ext"
+
+	TagF    = "tag"     // object has the tag with the given key and value
+	TagKeyF = "tag_key" // object has the tag with the given key (any value)
 )
 
 var functionMeta = map[string]filterMeta{
@@ -55,6 +58,9 @@ var functionMeta = map[string]filterMeta{
 	VersionGeF: {1, intArg},
 
 	ExtF: {1, stringArg},
+
+	TagF:    {2, stringArg},
+	TagKeyF: {1, stringArg},
 }
 
 func NewFilter(fname string, args []string) *FilterMsg {
@@ -136,6 +142,10 @@ func functionFilterMsgToObjectFilter(filterMsg *FilterMsg) (cluster.ObjectFilter
 		switch filterMsg.FName {
 		case ExtF:
 			return ExtFilter(filterMsg.Args[0]), nil
+		case TagF:
+			return TagFilter(filterMsg.Args[0], filterMsg.Args[1]), nil
+		case TagKeyF:
+			return TagKeyFilter(filterMsg.Args[0]), nil
 		default:
 			cmn.Assert(false)
 			return nil, nil
@@ -299,6 +309,36 @@ func ExtFilter(ext string) cluster.ObjectFilter {
 	}
 }
 
+func TagFilter(key, value string) cluster.ObjectFilter {
+	return func(lom *cluster.LOM) bool {
+		v, ok := lom.Tags()[key]
+		return ok && v == value
+	}
+}
+
+func TagFilterMsg(key, value string) *FilterMsg {
+	return &FilterMsg{
+		Type:  FUNCTION,
+		FName: TagF,
+		Args:  []string{key, value},
+	}
+}
+
+func TagKeyFilter(key string) cluster.ObjectFilter {
+	return func(lom *cluster.LOM) bool {
+		_, ok := lom.Tags()[key]
+		return ok
+	}
+}
+
+func TagKeyFilterMsg(key string) *FilterMsg {
+	return &FilterMsg{
+		Type:  FUNCTION,
+		FName: TagKeyF,
+		Args:  []string{key},
+	}
+}
+
 func And(filters ...cluster.ObjectFilter) cluster.ObjectFilter {
 	return func(lom *cluster.LOM) bool {
 		for _, f := range filters {
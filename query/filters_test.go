@@ -0,0 +1,51 @@
+// Package query provides interface to iterate over objects with additional filtering
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package query
+
+import (
+	"testing"
+
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/devtools/tutils/tassert"
+)
+
+func TestTagFilter(t *testing.T) {
+	lom := &cluster.LOM{ObjName: "obj"}
+	lom.SetTags(cmn.SimpleKVs{"split": "train", "empty": ""})
+
+	tests := []struct {
+		name   string
+		filter *FilterMsg
+		match  bool
+	}{
+		{"tag", TagFilterMsg("split", "train"), true},
+		{"tag empty value", TagFilterMsg("empty", ""), true},
+		{"tag other value", TagFilterMsg("split", "test"), false},
+		{"tag missing", TagFilterMsg("class", "train"), false},
+		{"tag key", TagKeyFilterMsg("split"), true},
+		{"tag key missing", TagKeyFilterMsg("class"), false},
+		{"and", NewAndFilter(TagKeyFilterMsg("empty"), TagFilterMsg("split", "train")), true},
+		{"or", NewOrFilter(TagKeyFilterMsg("class"), TagFilterMsg("split", "test")), false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			filter, err := ObjFilterFromMsg(test.filter)
+			tassert.CheckFatal(t, err)
+			tassert.Errorf(t, filter(lom) == test.match, "expected match=%t", test.match)
+		})
+	}
+
+	t.Run("untagged", func(t *testing.T) {
+		untagged := &cluster.LOM{ObjName: "obj"}
+		tassert.Errorf(t, !TagKeyFilter("split")(untagged), "untagged object must not match")
+		tassert.Errorf(t, !TagFilter("split", "")(untagged), "untagged object must not match")
+	})
+
+	t.Run("invalid args", func(t *testing.T) {
+		_, err := ObjFilterFromMsg(NewFilter(TagF, []string{"split"}))
+		tassert.Errorf(t, err != nil, "expected error for missing tag value")
+	})
+}
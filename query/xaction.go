@@ -208,10 +208,28 @@ func (r *ObjectsListingXact) startFromBck() {
 		return nil
 	}
 
+	cts := []string{fs.ObjectType}
+	if r.msg.IsFlagSet(cmn.SelectVersions) && bck.IsAIS() {
+		cts = append(cts, fs.ObjVersionType)
+		cb = func(fqn string, de fs.DirEntry) error {
+			entries, err := wi.CallbackVersions(fqn, de)
+			if err != nil {
+				r.putResult(&Result{err: err})
+				return cmn.NewAbortedError(r.t.Snode().DaemonID + " ResultSetXact")
+			}
+			for _, entry := range entries {
+				if r.putResult(&Result{entry: entry}) {
+					return cmn.NewAbortedError(r.t.Snode().DaemonID + " ResultSetXact")
+				}
+			}
+			return nil
+		}
+	}
+
 	opts := &fs.WalkBckOptions{
 		Options: fs.Options{
 			Bck:      bck.Bck,
-			CTs:      []string{fs.ObjectType},
+			CTs:      cts,
 			Callback: cb,
 			Sorted:   true,
 		},
@@ -253,6 +271,24 @@ func (r *ObjectsListingXact) peekN(n uint) (result []*cmn.BucketEntry, err error
 	if size == 0 {
 		size = len(r.buff)
 	}
+	// never separate prior versions from the object (see cmn.SelectVersions)
+	for err == nil && n != 0 && size > 0 && r.msg.IsFlagSet(cmn.SelectVersions) {
+		if size == len(r.buff) {
+			res, ok := <-r.resultCh
+			if !ok {
+				break
+			}
+			if res.err != nil {
+				err = res.err
+				break
+			}
+			r.buff = append(r.buff, res.entry)
+		}
+		if !r.buff[size].IsPrevVersion() || r.buff[size].Name != r.buff[size-1].Name {
+			break
+		}
+		size++
+	}
 	return r.buff[:size], err
 }
 
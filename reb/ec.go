@@ -338,6 +338,8 @@ func (reb *Manager) sendFromDisk(ct *rebCT, target *cluster.Snode) error {
 	if lom != nil {
 		o.Hdr.ObjAttrs.Atime = lom.AtimeUnix()
 		o.Hdr.ObjAttrs.Version = lom.Version()
+		o.Hdr.ObjAttrs.UserMD = lom.UserMD()
+		o.Hdr.ObjAttrs.Tags = lom.Tags()
 		if cksum := lom.Cksum(); cksum != nil {
 			o.Hdr.ObjAttrs.CksumType, o.Hdr.ObjAttrs.CksumValue = cksum.Get()
 		}
@@ -1815,6 +1817,8 @@ func (reb *Manager) rebuildAndSend(obj *rebObject) error {
 				CksumType:  cksumType,
 				CksumValue: cksumValue,
 				Version:    s.meta.ObjVersion,
+				UserMD:     s.meta.UserMD,
+				Tags:       s.meta.Tags,
 			},
 		}
 		reb.saveCTToDisk(memsys.NewReader(s.sgl), req, hdr)
@@ -690,6 +690,8 @@ func (rj *rebalanceJogger) send(lom *cluster.LOM, tsi *cluster.Snode, addAck boo
 			CksumType:  cksumType,
 			CksumValue: cksumValue,
 			Version:    lom.Version(),
+			UserMD:     lom.UserMD(),
+			Tags:       lom.Tags(),
 		},
 	}
 	o.Callback, o.CmplPtr = rj.objSentCallback, unsafe.Pointer(lom)
@@ -249,6 +249,8 @@ func (reb *Manager) recvObjRegular(hdr transport.ObjHdr, smap *cluster.Smap, unp
 	}
 	lom.SetAtimeUnix(hdr.ObjAttrs.Atime)
 	lom.SetVersion(hdr.ObjAttrs.Version)
+	lom.SetUserMD(hdr.ObjAttrs.UserMD)
+	lom.SetTags(hdr.ObjAttrs.Tags)
 
 	params := cluster.PutObjectParams{
 		Tag:          fs.WorkfilePut,
@@ -0,0 +1,532 @@
+// Package scrub provides periodic verification of the stored objects to detect
+// and repair silent data corruption (aka bit rot).
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package scrub
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/atomic"
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/ec"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/fs/mpather"
+	"github.com/NVIDIA/aistore/memsys"
+	"github.com/NVIDIA/aistore/stats"
+	"github.com/NVIDIA/aistore/xaction"
+	"github.com/NVIDIA/aistore/xaction/xreg"
+)
+
+// Checksums are validated on warm GET (if configured) and when objects move,
+// which leaves the data that is never read unverified. Scrub reads every
+// object stored on the target, along with its mirror copies, and compares the
+// content against the checksum stored in the object's metadata.
+//
+// A corrupted replica gets repaired:
+// * from an intact mirror copy, if any,
+// * otherwise, from the EC slices (erasure coded buckets),
+// * otherwise, from the remote backend (Cloud buckets and buckets with backend).
+// Missing mirror copies are re-created from the intact replica. Objects that
+// cannot be repaired are kept as is and reported (see `StatsExt`).
+//
+// Objects are checksummed without holding the object lock, so as not to block
+// PUT and DELETE for the duration of the read. The findings are then re-checked
+// under the write lock before repairing (the object may have been overwritten
+// or deleted in the meantime).
+//
+// Scrub runs periodically (config.Scrub.Interval) or upon user request. In order
+// to reduce its impact on the live workload, scrub reads at most config.Scrub.Rate
+// bytes per second from each mountpath, and throttles itself in accordance with
+// the current storage-target's utilization.
+//
+// Only the objects that belong to the target (HRW-wise) are scrubbed: EC slices
+// and replicas are verified by EC scrub, misplaced objects are left to rebalance.
+
+const maxUnrepaired = 100 // max number of unrepaired objects listed in the stats
+
+var (
+	errNoReplica = errors.New("no intact replica")
+	errDirty     = errors.New("not yet written to the remote backend")
+)
+
+type (
+	XactProvider struct {
+		xreg.BaseGlobalEntry
+		xact *Xaction
+
+		t      cluster.Target
+		statsT stats.Tracker
+		id     string
+	}
+
+	Xaction struct {
+		xaction.XactBase
+		t      cluster.Target
+		statsT stats.Tracker
+		pacers map[string]*pacer // mountpath => pacer
+		// stats
+		corrupted  atomic.Int64
+		missing    atomic.Int64
+		repaired   atomic.Int64
+		failed     atomic.Int64
+		mtx        sync.Mutex
+		unrepaired []string
+	}
+
+	StatsExt struct {
+		Corrupted  int64    `json:"corrupted,string"` // objects with at least one corrupted replica
+		Missing    int64    `json:"missing,string"`   // objects with at least one missing mirror copy
+		Repaired   int64    `json:"repaired,string"`  // corrupted (or missing copies) objects that got repaired
+		Failed     int64    `json:"failed,string"`    // corrupted (or missing copies) objects that failed to repair
+		Unrepaired []string `json:"unrepaired"`       // names of the objects that failed to repair (up to 100)
+	}
+
+	// limits the number of bytes read from a mountpath per second
+	pacer struct {
+		started time.Time
+		rate    int64
+		bytes   atomic.Int64
+	}
+)
+
+// interface guard
+var _ cluster.Xact = (*Xaction)(nil)
+
+func init() {
+	xreg.RegisterGlobalXact(&XactProvider{})
+}
+
+func (*XactProvider) New(args xreg.XactArgs) xreg.GlobalEntry {
+	return &XactProvider{t: args.T, statsT: args.Custom.(stats.Tracker), id: args.UUID}
+}
+
+func (p *XactProvider) Start(_ cmn.Bck) error {
+	p.xact = newXaction(p.t, p.statsT, p.id)
+	return nil
+}
+func (*XactProvider) Kind() string        { return cmn.ActScrub }
+func (p *XactProvider) Get() cluster.Xact { return p.xact }
+
+// keep the scrub that is already running
+func (*XactProvider) PreRenewHook(_ xreg.GlobalEntry) bool { return true }
+
+func newXaction(t cluster.Target, statsT stats.Tracker, id string) *Xaction {
+	return &Xaction{
+		XactBase: *xaction.NewXactBase(xaction.XactBaseID(id), cmn.ActScrub),
+		t:        t,
+		statsT:   statsT,
+		pacers:   make(map[string]*pacer),
+	}
+}
+
+func (r *Xaction) Run() (err error) {
+	config := cmn.GCO.Get()
+	glog.Infof("%s: %s started: rate %s/s per mountpath", r.t.Snode(), r, cmn.B2S(config.Scrub.Rate, 0))
+
+	availablePaths, _ := fs.Get()
+	for path := range availablePaths {
+		r.pacers[path] = newPacer(config.Scrub.Rate)
+	}
+	slab, err := r.t.MMSA().GetSlab(memsys.MaxPageSlabSize)
+	cmn.AssertNoErr(err)
+
+	jg := mpather.NewJoggerGroup(&mpather.JoggerGroupOpts{
+		T:                     r.t,
+		CTs:                   []string{fs.ObjectType},
+		VisitObj:              r.visitObj,
+		Slab:                  slab,
+		SkipGloballyMisplaced: true,
+		Throttle:              true,
+	})
+	jg.Run()
+
+	select {
+	case <-r.ChanAbort():
+		jg.Stop()
+		err = fmt.Errorf("%s aborted, exiting", r)
+	case <-jg.ListenFinished():
+		err = jg.Stop()
+	}
+	glog.Infof("%s: %s finished: %d objects (%s), corrupted %d, missing copies %d, repaired %d",
+		r.t.Snode(), r, r.ObjCount(), cmn.B2S(r.BytesCount(), 2), r.corrupted.Load(), r.missing.Load(),
+		r.repaired.Load())
+
+	r.Finish(err)
+	return
+}
+
+func (r *Xaction) Stats() cluster.XactStats {
+	baseStats := r.XactBase.Stats().(*xaction.BaseXactStats)
+	r.mtx.Lock()
+	unrepaired := make([]string, len(r.unrepaired))
+	copy(unrepaired, r.unrepaired)
+	r.mtx.Unlock()
+	return &xaction.BaseXactStatsExt{
+		BaseXactStats: *baseStats,
+		Ext: &StatsExt{
+			Corrupted:  r.corrupted.Load(),
+			Missing:    r.missing.Load(),
+			Repaired:   r.repaired.Load(),
+			Failed:     r.failed.Load(),
+			Unrepaired: unrepaired,
+		},
+	}
+}
+
+func (r *Xaction) visitObj(lom *cluster.LOM, buf []byte) error {
+	r.scrubObj(lom, buf)
+	if p, ok := r.pacers[lom.MpathInfo.Path]; ok {
+		p.pace(r.ChanAbort())
+	}
+	return nil
+}
+
+// Verifies the object and its copies and repairs the corrupted (and missing) ones.
+func (r *Xaction) scrubObj(lom *cluster.LOM, buf []byte) {
+	lom.Lock(false)
+	if err := lom.Load(false); err != nil {
+		lom.Unlock(false)
+		return // removed in the meantime (or, metadata error which is FSHC's job)
+	}
+	if lom.IsCopy() {
+		lom.Unlock(false)
+		return // verified along with its main replica
+	}
+	var (
+		size   = lom.Size()
+		cksum  = lom.Cksum()
+		copies = make(map[string]*fs.MountpathInfo, lom.NumCopies())
+	)
+	if cksum != nil {
+		cksum = cksum.Clone()
+	}
+	for fqn, mpi := range lom.GetCopies() {
+		copies[fqn] = mpi
+	}
+	lom.Unlock(false)
+
+	r.ObjectsInc()
+	r.BytesAdd(size)
+	bad, missing := r.verify(lom.FQN, lom.MpathInfo, cksum, copies, buf)
+	if len(bad) == 0 && len(missing) == 0 {
+		return
+	}
+	if cmn.StringInSlice(lom.FQN, missing) {
+		return // removed in the meantime
+	}
+	repaired, err := r.repair(lom, buf)
+	if err != nil {
+		glog.Errorf("%s: failed to repair %s: %v", r, lom, err)
+		r.failed.Inc()
+		r.mtx.Lock()
+		if len(r.unrepaired) < maxUnrepaired {
+			r.unrepaired = append(r.unrepaired, lom.Bck().String()+"/"+lom.ObjName)
+		}
+		r.mtx.Unlock()
+		return
+	}
+	if repaired {
+		r.repaired.Inc()
+		r.statsT.Add(stats.ScrubRepairCount, 1)
+	}
+}
+
+// Returns FQNs of the replicas whose content does not match the checksum
+// stored in the object's metadata - the main replica (if corrupted) goes first -
+// and FQNs of the mirror copies that do not exist.
+// NOTE: caller is responsible for locking.
+func (r *Xaction) findCorrupted(lom *cluster.LOM, buf []byte) (bad, missing []string) {
+	return r.verify(lom.FQN, lom.MpathInfo, lom.Cksum(), lom.GetCopies(), buf)
+}
+
+func (r *Xaction) verify(objFQN string, objMpi *fs.MountpathInfo, cksum *cmn.Cksum,
+	copies map[string]*fs.MountpathInfo, buf []byte) (bad, missing []string) {
+	if cksum == nil || cksum.Type() == cmn.ChecksumNone {
+		return // nothing to compare with
+	}
+	if err := r.verifyFile(objFQN, objMpi, cksum, buf); err != nil {
+		if os.IsNotExist(err) {
+			missing = append(missing, objFQN)
+		} else {
+			bad = append(bad, objFQN)
+		}
+	}
+	for fqn, mpi := range copies {
+		if fqn == objFQN {
+			continue
+		}
+		if err := r.verifyFile(fqn, mpi, cksum, buf); err != nil {
+			if os.IsNotExist(err) {
+				missing = append(missing, fqn)
+			} else {
+				bad = append(bad, fqn)
+			}
+		}
+	}
+	return
+}
+
+func (r *Xaction) verifyFile(fqn string, mpi *fs.MountpathInfo, expected *cmn.Cksum, buf []byte) error {
+	file, err := os.Open(fqn)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			glog.Errorf("%s: failed to open %s: %v", r, fqn, err)
+			r.t.FSHC(err, fqn)
+		}
+		return err
+	}
+	n, cksum, err := cmn.CopyAndChecksum(ioutil.Discard, file, buf, expected.Type())
+	cmn.Close(file)
+	if p, ok := r.pacers[mpi.Path]; ok {
+		p.add(n)
+	}
+	if err != nil {
+		glog.Errorf("%s: failed to read %s: %v", r, fqn, err)
+		r.t.FSHC(err, fqn)
+		return err
+	}
+	if !cksum.Equal(expected) {
+		err = cmn.NewBadDataCksumError(expected, &cksum.Cksum, fqn)
+		glog.Errorf("%s: %v", r, err)
+		return err
+	}
+	return nil
+}
+
+// Re-checks the object under the write lock and repairs the corrupted replicas
+// and the missing copies. Returns false if there was nothing to repair (the
+// object was overwritten or deleted in the meantime).
+func (r *Xaction) repair(lom *cluster.LOM, buf []byte) (repaired bool, err error) {
+	lom.Lock(true)
+	if err = lom.Load(false); err != nil {
+		lom.Unlock(true)
+		return false, nil // removed in the meantime
+	}
+	bad, missing := r.findCorrupted(lom, buf)
+	if len(bad) == 0 && len(missing) == 0 {
+		lom.Unlock(true)
+		return false, nil
+	}
+	if len(bad) > 0 {
+		glog.Warningf("%s: %s: %d corrupted replica(s) - repairing", r, lom, len(bad))
+		r.corrupted.Inc()
+		r.statsT.Add(stats.ScrubCorruptCount, 1)
+	}
+	if len(missing) > 0 {
+		glog.Warningf("%s: %s: %d missing copies - restoring", r, lom, len(missing))
+		r.missing.Inc()
+	}
+	bad = append(bad, missing...) // (the main replica, if corrupted, still goes first)
+	for fqn := range lom.GetCopies() {
+		if !cmn.StringInSlice(fqn, bad) {
+			err = restoreFromCopy(lom, fqn, bad, buf)
+			lom.Unlock(true)
+			return err == nil, err
+		}
+	}
+	err = r.restoreFromRemote(lom, bad, buf)
+	return err == nil, err
+}
+
+// Overwrites the corrupted replicas with the intact one.
+// NOTE: caller is responsible for locking.
+func restoreFromCopy(lom *cluster.LOM, goodFQN string, bad []string, buf []byte) error {
+	if bad[0] == lom.FQN {
+		src := lom.Clone(goodFQN)
+		if err := src.Init(lom.Bck().Bck); err != nil {
+			return err
+		}
+		if err := src.Load(false); err != nil {
+			return err
+		}
+		if _, err := src.CopyObject(lom.FQN, buf); err != nil {
+			return err
+		}
+		bad = bad[1:]
+	}
+	return recopy(lom, bad, buf)
+}
+
+// Re-creates the mirror copies from the (intact) main replica.
+// NOTE: caller is responsible for locking.
+func recopy(lom *cluster.LOM, copies []string, buf []byte) error {
+	for _, fqn := range copies {
+		if _, ok := lom.GetCopies()[fqn]; ok {
+			if err := lom.DelCopies(fqn); err != nil {
+				return err
+			}
+		}
+		if !lom.MirrorConf().Enabled {
+			continue
+		}
+		if _, err := lom.CopyObject(fqn, buf); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Restores the object from the EC slices or from the remote backend. The
+// corrupted replicas are replaced only after the restored content matches the
+// checksum stored in the object's metadata, so that a failed restore (missing
+// slices, remote object deleted or updated in the meantime) keeps the object
+// as is. Dirty objects (write-back, write-never) are skipped: the remote
+// backend does not have their latest content.
+// NOTE: called with the object write-locked; returns with the object unlocked.
+func (r *Xaction) restoreFromRemote(lom *cluster.LOM, bad []string, buf []byte) (err error) {
+	var (
+		cksum   = lom.Cksum().Clone()
+		workFQN = fs.CSM.GenContentFQN(lom.FQN, fs.WorkfileType, fs.WorkfileScrub)
+		fromEC  = lom.Bprops().EC.Enabled
+	)
+	if lom.IsDirty() {
+		lom.Unlock(true)
+		return errDirty
+	}
+	if fromEC {
+		_, errMeta := ec.ObjectMetadata(lom.Bck(), lom.ObjName)
+		fromEC = errMeta == nil // encoded
+	}
+	if !fromEC && !lom.Bck().IsRemote() {
+		lom.Unlock(true)
+		return errNoReplica
+	}
+	if fromEC {
+		return r.restoreFromEC(lom, cksum, workFQN, bad, buf)
+	}
+	lom.Unlock(true)
+
+	if err = r.download(lom, cksum, workFQN, buf); err != nil {
+		if errRm := cmn.RemoveFile(workFQN); errRm != nil {
+			glog.Errorf("nested error: %v => (remove %s => err: %v)", err, workFQN, errRm)
+		}
+		return
+	}
+	lom.Lock(true)
+	defer lom.Unlock(true)
+	if err = lom.Load(false); err != nil || !lom.Cksum().Equal(cksum) {
+		return cmn.RemoveFile(workFQN) // removed or overwritten in the meantime
+	}
+	if err = cmn.Rename(workFQN, lom.FQN); err != nil {
+		return
+	}
+	if err = lom.Persist(); err != nil {
+		return
+	}
+	lom.ReCache()
+	return recopy(lom, bad[1:], buf)
+}
+
+// Reads the object from the remote backend into the workfile.
+func (r *Xaction) download(lom *cluster.LOM, expected *cmn.Cksum, workFQN string, buf []byte) error {
+	reader, _, _, err := r.t.Cloud(lom.Bck()).GetObjReader(context.Background(), lom)
+	if err != nil {
+		return err
+	}
+	file, err := lom.CreateFile(workFQN)
+	if err != nil {
+		cmn.Close(reader)
+		return err
+	}
+	_, cksum, err := cmn.CopyAndChecksum(file, reader, buf, expected.Type())
+	cmn.Close(reader)
+	if errClose := file.Close(); err == nil {
+		err = errClose
+	}
+	if err != nil {
+		return err
+	}
+	if !cksum.Equal(expected) {
+		return cmn.NewBadDataCksumError(expected, &cksum.Cksum, lom.String()+" (remote)")
+	}
+	return nil
+}
+
+// EC restores the object in place - the corrupted main replica is moved
+// to the workfile for the duration and gets moved back if the restore fails.
+// NOTE: called with the object write-locked; returns with the object unlocked.
+func (r *Xaction) restoreFromEC(lom *cluster.LOM, cksum *cmn.Cksum, workFQN string, bad []string, buf []byte) (err error) {
+	if err = cmn.Rename(lom.FQN, workFQN); err != nil {
+		lom.Unlock(true)
+		return
+	}
+	lom.Uncache()
+	lom.Unlock(true)
+
+	var (
+		restored bool
+		elom     = &cluster.LOM{ObjName: lom.ObjName}
+	)
+	if err = elom.Init(lom.Bck().Bck); err == nil {
+		if err = ec.ECM.RestoreObject(elom); err == nil {
+			restored = true
+			if r.verifyFile(lom.FQN, lom.MpathInfo, cksum, buf) != nil {
+				err = fmt.Errorf("restored %s does not match the checksum %s", lom, cksum)
+			}
+		}
+	}
+
+	lom.Lock(true)
+	defer lom.Unlock(true)
+	if err != nil {
+		if _, errStat := os.Stat(lom.FQN); restored || os.IsNotExist(errStat) {
+			if errRn := cmn.Rename(workFQN, lom.FQN); errRn != nil {
+				glog.Errorf("nested error: %v => (rename %s => err: %v)", err, workFQN, errRn)
+			}
+			lom.Uncache()
+			return
+		}
+		err = nil // overwritten in the meantime
+	}
+	if errRm := cmn.RemoveFile(workFQN); errRm != nil {
+		glog.Errorf("%s: failed to remove %s: %v", r, workFQN, errRm)
+	}
+	if err = lom.Load(false); err != nil {
+		return
+	}
+	// the restored object has no copies - remove the corrupted ones
+	for _, fqn := range bad[1:] {
+		if _, ok := lom.GetCopies()[fqn]; !ok {
+			if errRm := cmn.RemoveFile(fqn); errRm != nil {
+				glog.Errorf("%s: failed to remove %s: %v", r, fqn, errRm)
+			}
+		}
+	}
+	return recopy(lom, bad[1:], buf)
+}
+
+///////////
+// pacer //
+///////////
+
+func newPacer(rate int64) *pacer { return &pacer{started: time.Now(), rate: rate} }
+
+func (p *pacer) add(n int64) { p.bytes.Add(n) }
+
+// sleeps, if need be, to keep reading at no more than the configured rate
+func (p *pacer) pace(abortCh <-chan struct{}) {
+	if p.rate <= 0 {
+		return
+	}
+	var (
+		expected = time.Duration(float64(p.bytes.Load()) / float64(p.rate) * float64(time.Second))
+		ahead    = expected - time.Since(p.started)
+	)
+	if ahead <= 0 {
+		return
+	}
+	select {
+	case <-time.After(ahead):
+	case <-abortCh:
+	}
+}
@@ -0,0 +1,18 @@
+// Package scrub provides periodic verification of the stored objects to detect
+// and repair silent data corruption (aka bit rot).
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package scrub
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestScrub(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Scrub Suite")
+}
@@ -0,0 +1,246 @@
+// Package scrub provides periodic verification of the stored objects to detect
+// and repair silent data corruption (aka bit rot).
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package scrub
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/devtools/tutils/readers"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/stats"
+	"github.com/NVIDIA/aistore/xaction"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Scrub", func() {
+	const (
+		testDir = "/tmp/scrub-test_q/"
+
+		testBucketName = "TEST_LOCAL_SCRUB_BUCKET"
+		mpath          = testDir + "scrubtest_mpath/2"
+		mpath2         = testDir + "scrubtest_mpath/1"
+
+		testObjectName = "scrubtestobj.ext"
+		testObjectSize = 12345
+	)
+
+	_ = cmn.CreateDir(mpath)
+	_ = cmn.CreateDir(mpath2)
+
+	config := cmn.GCO.BeginUpdate()
+	config.TestFSP.Count = 1
+	cmn.GCO.CommitUpdate(config)
+
+	fs.Init()
+	fs.DisableFsIDCheck()
+	_, _ = fs.Add(mpath, "daeID")
+	_, _ = fs.Add(mpath2, "daeID")
+	_ = fs.CSM.RegisterContentType(fs.ObjectType, &fs.ObjectContentResolver{})
+	_ = fs.CSM.RegisterContentType(fs.WorkfileType, &fs.WorkfileContentResolver{})
+
+	var (
+		props = &cmn.BucketProps{
+			Cksum:  cmn.CksumConf{Type: cmn.ChecksumXXHash},
+			Mirror: cmn.MirrorConf{Enabled: true, Copies: 2},
+		}
+		bck     = cmn.Bck{Name: testBucketName, Provider: cmn.ProviderAIS, Ns: cmn.NsGlobal, Props: props}
+		bmdMock = cluster.NewBaseBownerMock(&cluster.Bck{Bck: bck})
+		mi      = fs.MountpathInfo{Path: mpath}
+		mi2     = fs.MountpathInfo{Path: mpath2}
+		fqn     = mi.MakePathFQN(bck, fs.ObjectType, testObjectName)
+		fqn2    = mi2.MakePathFQN(bck, fs.ObjectType, testObjectName)
+
+		objFQN, copyFQN string // main replica (HRW mountpath) and its copy
+
+		buf   = make([]byte, 32*cmn.KiB)
+		tMock cluster.Target
+		xact  *Xaction
+	)
+
+	ext := func() *StatsExt {
+		return xact.Stats().(*xaction.BaseXactStatsExt).Ext.(*StatsExt)
+	}
+
+	BeforeEach(func() {
+		_ = cmn.CreateDir(mpath)
+		_ = cmn.CreateDir(mpath2)
+		tMock = cluster.NewTargetMock(bmdMock)
+		xact = newXaction(tMock, stats.NewTrackerMock(), "scrub-id")
+
+		// object with a mirror copy
+		hlom := &cluster.LOM{ObjName: testObjectName}
+		Expect(hlom.Init(bck)).NotTo(HaveOccurred())
+		objFQN, copyFQN = fqn, fqn2
+		if hlom.FQN != fqn {
+			objFQN, copyFQN = fqn2, fqn
+		}
+		createTestFile(filepath.Dir(objFQN), testObjectName, testObjectSize)
+		lom := newBasicLom(objFQN)
+		lom.SetSize(testObjectSize)
+		Expect(lom.Persist()).NotTo(HaveOccurred())
+		Expect(lom.ValidateContentChecksum()).NotTo(HaveOccurred())
+		_, err := lom.CopyObject(copyFQN, buf)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		_ = os.RemoveAll(testDir)
+	})
+
+	Describe("scrubObj", func() {
+		It("should not report intact object", func() {
+			xact.scrubObj(newBasicLom(objFQN), buf)
+
+			Expect(xact.ObjCount()).To(BeEquivalentTo(1))
+			Expect(xact.BytesCount()).To(BeEquivalentTo(testObjectSize))
+			Expect(ext().Corrupted).To(BeZero())
+		})
+
+		It("should skip copies", func() {
+			xact.scrubObj(newBasicLom(copyFQN), buf)
+			Expect(xact.ObjCount()).To(BeZero())
+		})
+
+		It("should repair corrupted copy", func() {
+			corruptFile(copyFQN)
+
+			xact.scrubObj(newBasicLom(objFQN), buf)
+
+			Expect(ext().Corrupted).To(BeEquivalentTo(1))
+			Expect(ext().Repaired).To(BeEquivalentTo(1))
+			lom := newBasicLom(objFQN)
+			Expect(lom.Load(false)).NotTo(HaveOccurred())
+			Expect(lom.GetCopies()).To(And(HaveKey(objFQN), HaveKey(copyFQN)))
+			Expect(xact.findCorrupted(lom, buf)).To(BeEmpty())
+		})
+
+		It("should repair corrupted main replica from copy", func() {
+			corruptFile(objFQN)
+
+			xact.scrubObj(newBasicLom(objFQN), buf)
+
+			Expect(ext().Corrupted).To(BeEquivalentTo(1))
+			Expect(ext().Repaired).To(BeEquivalentTo(1))
+			lom := newBasicLom(objFQN)
+			Expect(lom.Load(false)).NotTo(HaveOccurred())
+			Expect(lom.ValidateContentChecksum()).NotTo(HaveOccurred())
+			Expect(xact.findCorrupted(lom, buf)).To(BeEmpty())
+		})
+
+		It("should treat unreadable copy as corrupted", func() {
+			Expect(os.Remove(copyFQN)).NotTo(HaveOccurred())
+			Expect(cmn.CreateDir(copyFQN)).NotTo(HaveOccurred()) // (read fails with EISDIR)
+
+			xact.scrubObj(newBasicLom(objFQN), buf)
+
+			Expect(ext().Corrupted).To(BeEquivalentTo(1))
+			Expect(ext().Repaired).To(BeEquivalentTo(1))
+			Expect(copyFQN).To(BeARegularFile())
+		})
+
+		It("should count missing copy separately and restore it", func() {
+			Expect(os.Remove(copyFQN)).NotTo(HaveOccurred())
+
+			xact.scrubObj(newBasicLom(objFQN), buf)
+
+			Expect(ext().Corrupted).To(BeZero())
+			Expect(ext().Missing).To(BeEquivalentTo(1))
+			Expect(ext().Repaired).To(BeEquivalentTo(1))
+			Expect(copyFQN).To(BeARegularFile())
+		})
+
+		It("should not repair object that is intact upon re-check", func() {
+			// (e.g., overwritten after being checksummed without the lock)
+			repaired, err := xact.repair(newBasicLom(objFQN), buf)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(repaired).To(BeFalse())
+			Expect(ext().Corrupted).To(BeZero())
+			Expect(ext().Missing).To(BeZero())
+		})
+
+		It("should report object without intact replica", func() {
+			corruptFile(objFQN)
+			corruptFile(copyFQN)
+
+			xact.scrubObj(newBasicLom(objFQN), buf)
+
+			Expect(ext().Corrupted).To(BeEquivalentTo(1))
+			Expect(ext().Repaired).To(BeZero())
+			Expect(ext().Failed).To(BeEquivalentTo(1))
+			Expect(ext().Unrepaired).To(HaveLen(1))
+			Expect(ext().Unrepaired[0]).To(HaveSuffix(testObjectName))
+			// corrupted object is kept as is
+			Expect(objFQN).To(BeARegularFile())
+			Expect(copyFQN).To(BeARegularFile())
+		})
+	})
+
+	Describe("pacer", func() {
+		It("should limit the rate", func() {
+			p := newPacer(cmn.MiB)
+			p.add(cmn.MiB / 2)
+			started := time.Now()
+			p.pace(make(chan struct{}))
+			Expect(time.Since(started)).To(BeNumerically(">=", 400*time.Millisecond))
+		})
+
+		It("should not sleep when rate is not limited", func() {
+			p := newPacer(0)
+			p.add(cmn.GiB)
+			started := time.Now()
+			p.pace(make(chan struct{}))
+			Expect(time.Since(started)).To(BeNumerically("<", 100*time.Millisecond))
+		})
+
+		It("should stop sleeping when aborted", func() {
+			p := newPacer(cmn.KiB)
+			p.add(cmn.MiB)
+			abortCh := make(chan struct{})
+			close(abortCh)
+			started := time.Now()
+			p.pace(abortCh)
+			Expect(time.Since(started)).To(BeNumerically("<", 100*time.Millisecond))
+		})
+	})
+})
+
+func createTestFile(filePath, objName string, size int64) {
+	err := cmn.CreateDir(filePath)
+	Expect(err).ShouldNot(HaveOccurred())
+
+	r, err := readers.NewFileReader(filePath, objName, size, cmn.ChecksumNone)
+	Expect(err).ShouldNot(HaveOccurred())
+	Expect(r.Close()).ShouldNot(HaveOccurred())
+}
+
+// flips a few bytes in the middle of the file, keeping its size
+func corruptFile(fqn string) {
+	file, err := os.OpenFile(fqn, os.O_RDWR, 0)
+	Expect(err).ShouldNot(HaveOccurred())
+	b := make([]byte, 16)
+	_, err = file.ReadAt(b, 100)
+	Expect(err).ShouldNot(HaveOccurred())
+	for i := range b {
+		b[i] ^= 0xff
+	}
+	_, err = file.WriteAt(b, 100)
+	Expect(err).ShouldNot(HaveOccurred())
+	Expect(file.Close()).ShouldNot(HaveOccurred())
+}
+
+func newBasicLom(fqn string) *cluster.LOM {
+	lom := &cluster.LOM{FQN: fqn}
+	err := lom.Init(cmn.Bck{})
+	Expect(err).NotTo(HaveOccurred())
+	lom.Uncache()
+	return lom
+}
@@ -31,11 +31,17 @@ const (
 	LruEvictCount  = "lru.evict.n"
 	VerChangeCount = "vchange.n"
 	VerChangeSize  = "vchange.size"
+	// lru: evicted because of bucket capacity quotas (included in the above)
+	LruEvictQuotaSize  = "lru.evict.quota.size"
+	LruEvictQuotaCount = "lru.evict.quota.n"
 	// rebalance
 	RebTxCount = "reb.tx.n"
 	RebTxSize  = "reb.tx.size"
 	RebRxCount = "reb.rx.n"
 	RebRxSize  = "reb.rx.size"
+	// scrub
+	ScrubCorruptCount = "scrub.corrupt.n"
+	ScrubRepairCount  = "scrub.repair.n"
 	// errors
 	ErrCksumCount    = "err.cksum.n"
 	ErrCksumSize     = "err.cksum.size"
@@ -136,6 +142,8 @@ func (r *Trunner) RegisterAll() {
 	r.Register(GetThroughput, KindThroughput)
 	r.Register(LruEvictSize, KindCounter)
 	r.Register(LruEvictCount, KindCounter)
+	r.Register(LruEvictQuotaSize, KindCounter)
+	r.Register(LruEvictQuotaCount, KindCounter)
 	r.Register(VerChangeCount, KindCounter)
 	r.Register(VerChangeSize, KindCounter)
 	r.Register(GetRedirLatency, KindLatency)
@@ -153,6 +161,10 @@ func (r *Trunner) RegisterAll() {
 	r.Register(RebRxCount, KindCounter)
 	r.Register(RebRxSize, KindCounter)
 
+	// scrub
+	r.Register(ScrubCorruptCount, KindCounter)
+	r.Register(ScrubRepairCount, KindCounter)
+
 	// special
 	r.Register(RestartCount, KindCounter)
 
@@ -194,7 +206,7 @@ func (r *Trunner) log(uptime time.Duration) {
 	// 2. capacity
 	cs, updated, _ := fs.CapPeriodic(r.MPCap)
 	if updated {
-		if cs.Err != nil {
+		if cs.Err != nil || r.haveQuotas() {
 			go r.T.RunLRU("" /*uuid*/, false)
 		}
 		for mpath, fsCapacity := range r.MPCap {
@@ -212,6 +224,15 @@ func (r *Trunner) log(uptime time.Duration) {
 	}
 }
 
+// whether any of the buckets has LRU capacity quota (see cmn.LRUConf.Quota)
+func (r *Trunner) haveQuotas() (yes bool) {
+	r.T.Bowner().Get().Range(nil, nil, func(bck *cluster.Bck) bool {
+		yes = bck.Props.LRU.Enabled && bck.Props.LRU.Quota > 0
+		return yes
+	})
+	return
+}
+
 // NOTE the naming conventions (above)
 func (r *Trunner) doAdd(nv NamedVal64) {
 	var (
@@ -52,11 +52,13 @@ type (
 
 	// object attrs
 	ObjectAttrs struct {
-		Atime      int64  // access time - nanoseconds since UNIX epoch
-		Size       int64  // size of objects in bytes
-		CksumType  string // checksum type
-		CksumValue string // checksum of the object produced by given checksum type
-		Version    string // version of the object
+		Atime      int64         // access time - nanoseconds since UNIX epoch
+		Size       int64         // size of objects in bytes
+		CksumType  string        // checksum type
+		CksumValue string        // checksum of the object produced by given checksum type
+		Version    string        // version of the object
+		UserMD     cmn.SimpleKVs // user-defined metadata
+		Tags       cmn.SimpleKVs // object tags
 	}
 	// object header
 	ObjHdr struct {
@@ -140,8 +142,12 @@ func NewObjStream(client Client, toURL string, extra *Extra) (s *Stream) {
 //   network errors that may cause sudden and instant termination of the underlying
 //   stream(s).
 func (s *Stream) Send(obj *Obj) (err error) {
-	debug.Assert(len(obj.Hdr.Opaque) < len(s.maxheader)-int(unsafe.Sizeof(Obj{})))
-	if err = s.startSend(obj); err != nil {
+	if l := objHdrLen(&obj.Hdr); l > maxHeaderSize {
+		err = fmt.Errorf("%s: header of %s is too large (%d > %d)", s, obj, l, maxHeaderSize)
+	} else {
+		err = s.startSend(obj)
+	}
+	if err != nil {
 		if obj.Reader != nil {
 			cmn.Close(obj.Reader) // NOTE: always closing
 		}
@@ -25,8 +25,8 @@ import (
 
 // transport defaults
 const (
-	maxHeaderSize  = memsys.PageSize
-	burstNum       = 32 // default max num objects that can be posted for sending without any back-pressure
+	maxHeaderSize  = 4 * memsys.PageSize // NOTE: must fit max-size user-defined metadata and tags
+	burstNum       = 32                  // default max num objects that can be posted for sending without any back-pressure
 	defaultIdleOut = time.Second * 2
 	tickUnit       = time.Second
 )
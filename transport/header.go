@@ -54,6 +54,24 @@ func insObjHeader(hbuf []byte, hdr *ObjHdr, usePDU bool) (off int) {
 	return
 }
 
+// objHdrLen returns the size of the serialized object header
+// (see insObjHeader above)
+func objHdrLen(hdr *ObjHdr) (l int) {
+	l = sizeProtoHdr + cmn.SizeofI64*6 + len(hdr.Bck.Name) + len(hdr.ObjName) + len(hdr.Bck.Provider) +
+		len(hdr.Bck.Ns.Name) + len(hdr.Bck.Ns.UUID) + len(hdr.Opaque)
+	attr := &hdr.ObjAttrs
+	l += cmn.SizeofI64*5 + len(attr.CksumType) + len(attr.CksumValue) + len(attr.Version)
+	return l + kvsLen(attr.UserMD) + kvsLen(attr.Tags)
+}
+
+func kvsLen(kvs cmn.SimpleKVs) (l int) {
+	l = cmn.SizeofI64
+	for k, v := range kvs {
+		l += cmn.SizeofI64*2 + len(k) + len(v)
+	}
+	return
+}
+
 func insMsg(hbuf []byte, msg *Msg) (off int) {
 	off = sizeProtoHdr
 	off = insInt64(off, hbuf, msg.Flags)
@@ -105,6 +123,17 @@ func insAttrs(off int, to []byte, attr ObjectAttrs) int {
 	off = insString(off, to, attr.CksumType)
 	off = insString(off, to, attr.CksumValue)
 	off = insString(off, to, attr.Version)
+	off = insKVs(off, to, attr.UserMD)
+	off = insKVs(off, to, attr.Tags)
+	return off
+}
+
+func insKVs(off int, to []byte, kvs cmn.SimpleKVs) int {
+	off = insInt64(off, to, int64(len(kvs)))
+	for k, v := range kvs {
+		off = insString(off, to, k)
+		off = insString(off, to, v)
+	}
 	return off
 }
 
@@ -174,5 +203,22 @@ func extAttrs(off int, from []byte) (n int, attr ObjectAttrs) {
 	off, attr.CksumType = extString(off, from)
 	off, attr.CksumValue = extString(off, from)
 	off, attr.Version = extString(off, from)
+	off, attr.UserMD = extKVs(off, from)
+	off, attr.Tags = extKVs(off, from)
 	return off, attr
 }
+
+func extKVs(off int, from []byte) (int, cmn.SimpleKVs) {
+	off, cnt := extInt64(off, from)
+	if cnt == 0 {
+		return off, nil
+	}
+	kvs := make(cmn.SimpleKVs, cnt)
+	for i := int64(0); i < cnt; i++ {
+		var k, v string
+		off, k = extString(off, from)
+		off, v = extString(off, from)
+		kvs[k] = v
+	}
+	return off, kvs
+}
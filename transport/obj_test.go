@@ -30,6 +30,7 @@ import (
 	"path"
 	"reflect"
 	"strconv"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -128,8 +129,8 @@ func Example_headers() {
 	stream.Fin()
 
 	// Output:
-	// {Bck:aws://@uuid#namespace/abc ObjName:X ObjAttrs:{Atime:663346294 Size:231 CksumType:xxhash CksumValue:hash Version:2} Opaque:[]} (119)
-	// {Bck:ais://abracadabra ObjName:p/q/s ObjAttrs:{Atime:663346294 Size:213 CksumType:xxhash CksumValue:hash Version:2} Opaque:[49 50 51]} (121)
+	// {Bck:aws://@uuid#namespace/abc ObjName:X ObjAttrs:{Atime:663346294 Size:231 CksumType:xxhash CksumValue:hash Version:2 UserMD:map[] Tags:map[]} Opaque:[]} (135)
+	// {Bck:ais://abracadabra ObjName:p/q/s ObjAttrs:{Atime:663346294 Size:213 CksumType:xxhash CksumValue:hash Version:2 UserMD:map[color:red] Tags:map[]} Opaque:[49 50 51]} (161)
 }
 
 func sendText(stream *transport.Stream, txt1, txt2 string) {
@@ -174,6 +175,7 @@ func sendText(stream *transport.Stream, txt1, txt2 string) {
 			CksumType:  cmn.ChecksumXXHash,
 			CksumValue: "hash",
 			Version:    "2",
+			UserMD:     cmn.SimpleKVs{"color": "red"},
 		},
 		Opaque: []byte{'1', '2', '3'},
 	}
@@ -381,6 +383,15 @@ func Test_ObjAttrs(t *testing.T) {
 			CksumValue: "102412",
 			Version:    "",
 		},
+		{
+			Size:       1024,
+			Atime:      1024,
+			CksumType:  cmn.ChecksumXXHash,
+			CksumValue: "120421",
+			Version:    "1",
+			UserMD:     maxUserMD(),
+			Tags:       maxTags(),
+		},
 	}
 
 	ts := httptest.NewServer(objmux)
@@ -438,6 +449,51 @@ func Test_ObjAttrs(t *testing.T) {
 	}
 }
 
+// Send must reject (rather than panic on) a header that doesn't fit
+func Test_ObjHdrTooLarge(t *testing.T) {
+	ts := httptest.NewServer(objmux)
+	defer ts.Close()
+
+	trname := "hdrtoolarge"
+	err := transport.HandleObjStream(trname, func(http.ResponseWriter, transport.ObjHdr, io.Reader, error) {})
+	tassert.CheckFatal(t, err)
+	defer transport.Unhandle(trname)
+	stream := transport.NewObjStream(transport.NewIntraDataClient(), ts.URL+transport.ObjURLPath(trname), nil)
+
+	hdr := transport.ObjHdr{
+		Bck:      cmn.Bck{Provider: cmn.ProviderAIS},
+		ObjName:  strings.Repeat("a", 16*cmn.KiB),
+		ObjAttrs: transport.ObjectAttrs{UserMD: maxUserMD(), Tags: maxTags()},
+	}
+	if err := stream.Send(&transport.Obj{Hdr: hdr}); err == nil {
+		t.Fatal("expected an error sending oversized header")
+	}
+	stream.Fin()
+}
+
+// user-defined metadata and tags of the max allowed size
+func maxUserMD() cmn.SimpleKVs {
+	var (
+		md   = make(cmn.SimpleKVs, cmn.MaxUserMDEntries)
+		vlen = cmn.MaxUserMDSize/cmn.MaxUserMDEntries - 3
+	)
+	for i := 0; i < cmn.MaxUserMDEntries; i++ {
+		md[fmt.Sprintf("k%02d", i)] = strings.Repeat("v", vlen)
+	}
+	cmn.AssertNoErr(cmn.ValidateUserMD(md))
+	return md
+}
+
+func maxTags() cmn.SimpleKVs {
+	tags := make(cmn.SimpleKVs, cmn.MaxObjTags)
+	for i := 0; i < cmn.MaxObjTags; i++ {
+		k := fmt.Sprintf("%d", i)
+		tags[k+strings.Repeat("k", cmn.MaxTagKeyLen-len(k))] = strings.Repeat("v", cmn.MaxTagValueLen)
+	}
+	cmn.AssertNoErr(cmn.ValidateObjTags(tags))
+	return tags
+}
+
 func receive10G(w http.ResponseWriter, hdr transport.ObjHdr, objReader io.Reader, err error) {
 	cmn.Assert(err == nil || cmn.IsEOF(err))
 	written, _ := io.Copy(ioutil.Discard, objReader)
@@ -230,6 +230,7 @@ repeat:
 			return s.deactivate()
 		}
 		l := insObjHeader(s.maxheader, &obj.Hdr, s.usePDU())
+		debug.Assert(l == objHdrLen(&obj.Hdr))
 		s.header = s.maxheader[:l]
 		s.sendoff.ins = inHdr
 		return s.sendHdr(b)
@@ -432,6 +433,8 @@ func (hdr *ObjHdr) FromHdrProvider(meta cmn.ObjHeaderMetaProvider, objName strin
 		hdr.ObjAttrs.CksumType, hdr.ObjAttrs.CksumValue = meta.Cksum().Get()
 	}
 	hdr.ObjAttrs.Version = meta.Version()
+	hdr.ObjAttrs.UserMD = meta.UserMD()
+	hdr.ObjAttrs.Tags = meta.Tags()
 }
 
 ///////////
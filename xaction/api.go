@@ -63,6 +63,7 @@ var _ cluster.XactStats = (*BaseXactStats)(nil)
 var XactsDtor = map[string]XactDescriptor{
 	// bucket-less (aka "global") xactions with scope = (target | cluster)
 	cmn.ActLRU:       {Type: XactTypeGlobal, Startable: true, Mountpath: true},
+	cmn.ActScrub:     {Type: XactTypeGlobal, Startable: true, Mountpath: true},
 	cmn.ActElection:  {Type: XactTypeGlobal, Startable: false},
 	cmn.ActResilver:  {Type: XactTypeGlobal, Startable: true, Mountpath: true},
 	cmn.ActRebalance: {Type: XactTypeGlobal, Startable: true, Metasync: true, Owned: false, Mountpath: true},
@@ -78,10 +79,13 @@ var XactsDtor = map[string]XactDescriptor{
 	cmn.ActCopyBucket:    {Type: XactTypeBck, Startable: false, Metasync: true, Owned: false, RefreshCap: true, Mountpath: true},
 	cmn.ActETLBucket:     {Type: XactTypeBck, Startable: false, Metasync: true, Owned: false, RefreshCap: true, Mountpath: true},
 	cmn.ActECEncode:      {Type: XactTypeBck, Startable: true, Metasync: true, Owned: false, RefreshCap: true, Mountpath: true},
+	cmn.ActECScrub:       {Type: XactTypeBck, Startable: true, Mountpath: true},
+	cmn.ActECRelayout:    {Type: XactTypeBck, Startable: false, Metasync: true, Owned: false, RefreshCap: true, Mountpath: true},
 	cmn.ActEvictObjects:  {Type: XactTypeBck, Startable: false, Mountpath: true},
 	cmn.ActDelete:        {Type: XactTypeBck, Startable: false, Mountpath: true},
 	cmn.ActLoadLomCache:  {Type: XactTypeBck, Startable: true, Mountpath: true},
 	cmn.ActPrefetch:      {Type: XactTypeBck, Startable: true},
+	cmn.ActWriteBack:     {Type: XactTypeBck, Startable: true},
 	cmn.ActPromote:       {Type: XactTypeBck, Startable: false, RefreshCap: true},
 	cmn.ActQueryObjects:  {Type: XactTypeBck, Startable: false, Metasync: false, Owned: true},
 	cmn.ActListObjects:   {Type: XactTypeBck, Startable: false, Metasync: false, Owned: true},
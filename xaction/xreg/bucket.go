@@ -121,6 +121,22 @@ func (r *registry) renewECEncode(t cluster.Target, bck *cluster.Bck, uuid, phase
 	})
 }
 
+func RenewECScrub(t cluster.Target, bck *cluster.Bck, uuid string) (cluster.Xact, error) {
+	return defaultReg.renewECScrub(t, bck, uuid)
+}
+
+func (r *registry) renewECScrub(t cluster.Target, bck *cluster.Bck, uuid string) (cluster.Xact, error) {
+	return r.renewBucketXact(cmn.ActECScrub, bck, XactArgs{T: t, UUID: uuid})
+}
+
+func RenewECRelayout(t cluster.Target, bck *cluster.Bck, uuid string) (cluster.Xact, error) {
+	return defaultReg.renewECRelayout(t, bck, uuid)
+}
+
+func (r *registry) renewECRelayout(t cluster.Target, bck *cluster.Bck, uuid string) (cluster.Xact, error) {
+	return r.renewBucketXact(cmn.ActECRelayout, bck, XactArgs{T: t, UUID: uuid})
+}
+
 // TODO: Restart the EC (#531) in case of mountpath event.
 func RenewMakeNCopies(t cluster.Target, tag string) { defaultReg.renewMakeNCopies(t, tag) }
 
@@ -209,6 +225,18 @@ func (r *registry) renewPutMirror(t cluster.Target, lom *cluster.LOM) cluster.Xa
 	return xact
 }
 
+// NOTE: `scan` makes the xaction look up (and flush) all dirty objects of the
+// bucket, in addition to those that it is given via `Flush`
+func RenewWriteBack(t cluster.Target, bck *cluster.Bck, uuid string, scan bool) cluster.Xact {
+	return defaultReg.renewWriteBack(t, bck, uuid, scan)
+}
+
+func (r *registry) renewWriteBack(t cluster.Target, bck *cluster.Bck, uuid string, scan bool) cluster.Xact {
+	xact, err := r.renewBucketXact(cmn.ActWriteBack, bck, XactArgs{T: t, UUID: uuid, Custom: scan})
+	cmn.AssertNoErr(err)
+	return xact
+}
+
 func RenewTransferBck(t cluster.Target, bckFrom, bckTo *cluster.Bck, uuid, kind,
 	phase string, dm *bundle.DataMover, dp cluster.LomReaderProvider, meta *cmn.Bck2BckMsg) (cluster.Xact, error) {
 	return defaultReg.renewTransferBck(t, bckFrom, bckTo, uuid, kind, phase, dm, dp, meta)
@@ -101,6 +101,19 @@ func (r *registry) renewLRU(id string) cluster.Xact {
 	return res.entry.Get()
 }
 
+func RenewScrub(t cluster.Target, statsT stats.Tracker, id string) cluster.Xact {
+	return defaultReg.renewScrub(t, statsT, id)
+}
+
+func (r *registry) renewScrub(t cluster.Target, statsT stats.Tracker, id string) cluster.Xact {
+	e := r.globalXacts[cmn.ActScrub].New(XactArgs{T: t, UUID: id, Custom: statsT})
+	res := r.renewGlobalXaction(e)
+	if res.err != nil || !res.isNew { // previous scrub is still running
+		return nil
+	}
+	return res.entry.Get()
+}
+
 func RenewDownloader(t cluster.Target, statsT stats.Tracker) (cluster.Xact, error) {
 	return defaultReg.renewDownloader(t, statsT)
 }
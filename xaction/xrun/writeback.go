@@ -0,0 +1,336 @@
+// Package runners provides implementation for the AIStore extended actions.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package xrun
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/atomic"
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/fs/mpather"
+	"github.com/NVIDIA/aistore/xaction"
+	"github.com/NVIDIA/aistore/xaction/xreg"
+)
+
+// Write-back: PUT into a bucket with cmn.WritePolicyBack completes upon local
+// commit and leaves the object marked as dirty (cluster.DirtyObjMD). The mark
+// is persisted with the object's metadata, so that the objects that did not
+// make it to the Cloud prior to (target) restart get flushed when the xaction
+// scans the bucket.
+//
+// An object that fails to get flushed after wbMaxAttempts is flushed again
+// after a delay that grows with each failed round of attempts - the xaction
+// does not go idle while there are such objects.
+
+const (
+	wbQueueSize       = 256
+	wbMaxAttempts     = 3
+	wbRetryDelay      = time.Second // doubles with each failed attempt
+	wbRequeueDelay    = time.Minute // doubles with each failed round of attempts
+	wbMaxRequeueDelay = time.Hour
+)
+
+type (
+	writeBackProvider struct {
+		xreg.BaseBckEntry
+		xact *XactWriteBack
+
+		t    cluster.Target
+		uuid string
+		scan bool
+	}
+	XactWriteBack struct {
+		xaction.XactDemandBase
+		t       cluster.Target
+		workers *mpather.WorkerGroup
+		workCh  chan *cluster.LOM
+		mtx     sync.Mutex
+		rounds  map[string]int // uname => failed rounds of attempts
+		// stats
+		queued   atomic.Int64
+		failed   atomic.Int64
+		retried  atomic.Int64
+		deferred atomic.Int64
+		scanning atomic.Bool
+	}
+	WriteBackStatsExt struct {
+		Queued   int64 `json:"queued,string"`   // dirty objects waiting to be flushed
+		Failed   int64 `json:"failed,string"`   // failed rounds of attempts (the object remains dirty)
+		Retried  int64 `json:"retried,string"`  // failed attempts that were retried
+		Deferred int64 `json:"deferred,string"` // failed objects waiting to be flushed again
+		Scanning bool  `json:"scanning"`        // looking up dirty objects in the bucket
+	}
+)
+
+// interface guard
+var _ cluster.Xact = (*XactWriteBack)(nil)
+
+func (*writeBackProvider) New(args xreg.XactArgs) xreg.BucketEntry {
+	return &writeBackProvider{t: args.T, uuid: args.UUID, scan: args.Custom.(bool)}
+}
+
+func (p *writeBackProvider) Start(bck cmn.Bck) error {
+	p.xact = newXactWriteBack(p.uuid, bck, p.t)
+	go func() {
+		err := p.xact.Run()
+		p.xact.Finish(err)
+	}()
+	if p.scan {
+		p.xact.Scan()
+	}
+	return nil
+}
+func (*writeBackProvider) Kind() string        { return cmn.ActWriteBack }
+func (p *writeBackProvider) Get() cluster.Xact { return p.xact }
+func (p *writeBackProvider) PreRenewHook(previousEntry xreg.BucketEntry) (keep bool, err error) {
+	if keep, err = p.BaseBckEntry.PreRenewHook(previousEntry); keep && p.scan {
+		previousEntry.(*writeBackProvider).xact.Scan()
+	}
+	return
+}
+
+func newXactWriteBack(uuid string, bck cmn.Bck, t cluster.Target) (r *XactWriteBack) {
+	r = &XactWriteBack{t: t, workCh: make(chan *cluster.LOM, wbQueueSize), rounds: make(map[string]int)}
+	if uuid == "" {
+		r.XactDemandBase = *xaction.NewXactDemandBaseBck(cmn.ActWriteBack, bck)
+	} else {
+		r.XactDemandBase = *xaction.NewXactDemandBaseBckUUID(uuid, cmn.ActWriteBack, bck)
+	}
+	r.workers = mpather.NewWorkerGroup(&mpather.WorkerGroupOpts{
+		QueueSize: wbQueueSize,
+		Callback: func(lom *cluster.LOM, _ []byte) {
+			r.flush(lom)
+			r.queued.Dec()
+			r.DecPending() // to support action renewal on-demand
+		},
+	})
+	r.InitIdle()
+	return
+}
+
+func (r *XactWriteBack) Run() error {
+	glog.Infoln(r.String())
+
+	r.workers.Run()
+
+	for {
+		select {
+		case lom := <-r.workCh:
+			if ok := r.workers.Do(lom); !ok {
+				glog.Errorf("%s: failed to get post with path: %s", r, lom)
+				r.queued.Dec()
+				r.DecPending()
+			}
+		case <-r.IdleTimer():
+			return r.stop()
+		case <-r.ChanAbort():
+			if err := r.stop(); err != nil {
+				return cmn.NewAbortedError(err.Error())
+			}
+			return cmn.NewAbortedError(r.String())
+		}
+	}
+}
+
+// Flush queues a given (locally committed) dirty object to be written to the Cloud.
+func (r *XactWriteBack) Flush(lom *cluster.LOM) error {
+	if r.Finished() {
+		return xaction.NewErrXactExpired("Cannot flush: " + r.String())
+	}
+	r.queued.Inc()
+	r.IncPending() // ref-count via base to support on-demand action
+	r.workCh <- lom
+	return nil
+}
+
+// Scan looks up dirty objects in all mountpaths and queues them to be flushed.
+func (r *XactWriteBack) Scan() {
+	if !r.scanning.CAS(false, true) {
+		return
+	}
+	r.IncPending() // prevent the xaction from going idle while scanning
+	jg := mpather.NewJoggerGroup(&mpather.JoggerGroupOpts{
+		T:        r.t,
+		Bck:      r.Bck(),
+		CTs:      []string{fs.ObjectType},
+		VisitObj: r.visitObj,
+		DoLoad:   mpather.Load,
+		Throttle: true,
+	})
+	jg.Run()
+	go func() {
+		select {
+		case <-r.ChanAbort():
+			jg.Stop()
+		case <-jg.ListenFinished():
+			if err := jg.Stop(); err != nil {
+				glog.Errorf("%s: scan failed: %v", r, err)
+			}
+		}
+		r.scanning.Store(false)
+		r.DecPending()
+	}()
+}
+
+func (r *XactWriteBack) visitObj(lom *cluster.LOM, _ []byte) error {
+	if lom.IsDirty() {
+		return r.Flush(lom)
+	}
+	return nil
+}
+
+func (r *XactWriteBack) Stats() cluster.XactStats {
+	baseStats := r.XactDemandBase.Stats().(*xaction.BaseXactStats)
+	return &xaction.BaseXactStatsExt{
+		BaseXactStats: *baseStats,
+		Ext: &WriteBackStatsExt{
+			Queued:   r.queued.Load(),
+			Failed:   r.failed.Load(),
+			Retried:  r.retried.Load(),
+			Deferred: r.deferred.Load(),
+			Scanning: r.scanning.Load(),
+		},
+	}
+}
+
+func (r *XactWriteBack) flush(lom *cluster.LOM) {
+	delay := wbRetryDelay
+	for attempt := 1; ; attempt++ {
+		flushed, err := r.tryFlush(lom)
+		if err == nil {
+			if flushed {
+				r.ObjectsInc()
+				r.BytesAdd(lom.Size())
+			}
+			r.mtx.Lock()
+			delete(r.rounds, lom.Uname())
+			r.mtx.Unlock()
+			return
+		}
+		if attempt == wbMaxAttempts || r.AbortedAfter(delay) {
+			r.failed.Inc()
+			glog.Errorf("%s: failed to flush %s (attempts: %d): %v", r, lom, attempt, err)
+			r.requeue(lom)
+			return
+		}
+		r.retried.Inc()
+		delay *= 2
+	}
+}
+
+// requeue flushes the object again after a delay that grows with each failed
+// round of attempts.
+func (r *XactWriteBack) requeue(lom *cluster.LOM) {
+	if r.Aborted() {
+		return // to be flushed upon next scan
+	}
+	r.mtx.Lock()
+	r.rounds[lom.Uname()]++
+	delay := wbRequeueDelay << (r.rounds[lom.Uname()] - 1)
+	r.mtx.Unlock()
+	if delay <= 0 || delay > wbMaxRequeueDelay {
+		delay = wbMaxRequeueDelay
+	}
+	r.deferred.Inc()
+	r.IncPending() // do not go idle
+	time.AfterFunc(delay, func() {
+		r.deferred.Dec()
+		if !r.Aborted() {
+			if err := r.Flush(lom); err != nil {
+				glog.Errorf("%s: failed to requeue %s (to be flushed upon next scan): %v", r, lom, err)
+			}
+		}
+		r.DecPending()
+	})
+}
+
+// Writes the object to the Cloud and then clears the dirty mark - unless the
+// object has been overwritten (and marked again) in the meantime.
+// The object is opened under read lock and then written without holding the
+// lock: the opened file is a snapshot of the object as all writers (PUT,
+// APPEND, promote, etc.) replace the object's file via rename.
+func (r *XactWriteBack) tryFlush(lom *cluster.LOM) (flushed bool, err error) {
+	var (
+		cloud   = r.t.Cloud(lom.Bck())
+		version string
+	)
+	lom.Lock(false)
+	if err = lom.Load(false); err != nil {
+		lom.Unlock(false)
+		if cmn.IsObjNotExist(err) {
+			err = nil // deleted in the meantime
+		}
+		return
+	}
+	gen, dirty := lom.GetCustomMD(cluster.DirtyObjMD)
+	if !dirty {
+		lom.Unlock(false)
+		return
+	}
+	fh, err := os.Open(lom.FQN)
+	lom.Unlock(false)
+	if err != nil {
+		return false, fmt.Errorf("failed to open %s err: %w", lom.FQN, err)
+	}
+	version, _, err = cloud.PutObj(context.Background(), fh, lom)
+	cmn.Close(fh)
+	if err != nil {
+		return
+	}
+
+	lom.Lock(true)
+	defer lom.Unlock(true)
+	if err = lom.Load(false); err != nil {
+		if cmn.IsObjNotExist(err) {
+			// deleted while being written - delete from the Cloud as well
+			if _, err = cloud.DeleteObj(context.Background(), lom); err != nil {
+				err = fmt.Errorf("failed to delete %s (deleted while being flushed): %w", lom, err)
+			}
+		}
+		return true, err
+	}
+	if cur, ok := lom.GetCustomMD(cluster.DirtyObjMD); !ok || cur != gen {
+		return true, nil
+	}
+	customMD := cmn.SimpleKVs{cluster.SourceObjMD: cloud.Provider()}
+	if version != "" {
+		customMD[cluster.VersionObjMD] = version
+		if lom.VersionConf().Enabled {
+			lom.SetVersion(version)
+		}
+	}
+	lom.SetCustomMD(customMD)
+	if err = lom.PersistWithCopies(); err != nil {
+		return true, err
+	}
+	lom.ReCache()
+	return true, nil
+}
+
+func (r *XactWriteBack) stop() (err error) {
+	r.XactDemandBase.Stop()
+	n := r.workers.Stop()
+	for drained := false; !drained; {
+		select {
+		case <-r.workCh:
+			n++
+		default:
+			drained = true
+		}
+	}
+	if n > 0 {
+		r.queued.Sub(int64(n))
+		r.SubPending(n)
+		err = fmt.Errorf("%s: dropped %d object(s) - to be flushed upon next scan", r, n)
+	}
+	return err
+}